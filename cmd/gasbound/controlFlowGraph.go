@@ -0,0 +1,191 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/multiversx/mx-chain-vm-go/wasmbinary"
+)
+
+var errUnbalancedControl = errors.New("unbalanced block, loop, if, else and end instructions")
+
+// controlFrame is a block, loop, if or the function body itself, which branches can target.
+type controlFrame struct {
+	isLoop bool
+	start  int
+	end    int
+}
+
+// basicBlock is a sequence of instructions which is always executed from its first to its last instruction.
+type basicBlock struct {
+	first      int
+	last       int
+	offset     int
+	successors []int
+}
+
+// controlFlowGraph holds the basic blocks of a function; the block with index 0 is the entry point
+// and the blocks without successors leave the function.
+type controlFlowGraph struct {
+	instructions []*wasmbinary.Instruction
+	blocks       []*basicBlock
+}
+
+// buildControlFlowGraph links the instructions of a function body and groups them into basic blocks.
+// A branch to a block or an if leads to its end instruction, a branch to a loop to its first instruction.
+func buildControlFlowGraph(decoded []*wasmbinary.Instruction) (*controlFlowGraph, error) {
+	successors, err := linkInstructions(decoded)
+	if err != nil {
+		return nil, err
+	}
+
+	isLeader := make([]bool, len(decoded))
+	isLeader[0] = true
+	for i, instructionSuccessors := range successors {
+		fallsThrough := len(instructionSuccessors) == 1 && instructionSuccessors[0] == i+1
+		if fallsThrough {
+			continue
+		}
+		if i+1 < len(decoded) {
+			isLeader[i+1] = true
+		}
+		for _, successor := range instructionSuccessors {
+			isLeader[successor] = true
+		}
+	}
+
+	graph := &controlFlowGraph{
+		instructions: decoded,
+	}
+	instructionBlock := make([]int, len(decoded))
+	for i := range decoded {
+		if isLeader[i] {
+			graph.blocks = append(graph.blocks, &basicBlock{
+				first:  i,
+				offset: decoded[i].Offset,
+			})
+		}
+		block := graph.blocks[len(graph.blocks)-1]
+		block.last = i
+		instructionBlock[i] = len(graph.blocks) - 1
+	}
+
+	for _, block := range graph.blocks {
+		for _, successor := range successors[block.last] {
+			block.successors = append(block.successors, instructionBlock[successor])
+		}
+	}
+
+	return graph, nil
+}
+
+// linkInstructions returns the instructions which can follow each instruction; none means leaving the function.
+func linkInstructions(decoded []*wasmbinary.Instruction) ([][]int, error) {
+	if len(decoded) == 0 || decoded[len(decoded)-1].Spec.Name != "End" {
+		return nil, errUnbalancedControl
+	}
+
+	frames, framesOfBranches, elseOfIf, err := matchControlFrames(decoded)
+	if err != nil {
+		return nil, err
+	}
+
+	lastIndex := len(decoded) - 1
+	successors := make([][]int, len(decoded))
+	for i, instruction := range decoded {
+		switch instruction.Spec.Name {
+		case "Unreachable", "Return":
+			successors[i] = nil
+		case "Br", "BrTable":
+			successors[i] = branchTargets(framesOfBranches[i])
+		case "BrIf":
+			successors[i] = append(branchTargets(framesOfBranches[i]), i+1)
+		case "If":
+			falseBranch := frames[i].end
+			elseIndex, hasElse := elseOfIf[i]
+			if hasElse {
+				falseBranch = elseIndex + 1
+			}
+			successors[i] = []int{i + 1, falseBranch}
+		case "Else":
+			successors[i] = []int{frames[i].end}
+		default:
+			if i < lastIndex {
+				successors[i] = []int{i + 1}
+			}
+		}
+	}
+
+	return successors, nil
+}
+
+// matchControlFrames finds, for each block, loop and if, its end instruction and,
+// for each branch instruction, the frames which it targets.
+func matchControlFrames(decoded []*wasmbinary.Instruction) (map[int]*controlFrame, map[int][]*controlFrame, map[int]int, error) {
+	frames := make(map[int]*controlFrame)
+	framesOfBranches := make(map[int][]*controlFrame)
+	elseOfIf := make(map[int]int)
+
+	functionFrame := &controlFrame{start: -1, end: len(decoded) - 1}
+	stack := []*controlFrame{functionFrame}
+	for i, instruction := range decoded {
+		switch instruction.Spec.Name {
+		case "Block", "Loop", "If":
+			frame := &controlFrame{isLoop: instruction.Spec.Name == "Loop", start: i}
+			frames[i] = frame
+			stack = append(stack, frame)
+		case "Else":
+			top := stack[len(stack)-1]
+			if top == functionFrame || decoded[top.start].Spec.Name != "If" {
+				return nil, nil, nil, errUnbalancedControl
+			}
+			frames[i] = top
+			elseOfIf[top.start] = i
+		case "End":
+			stack[len(stack)-1].end = i
+			stack = stack[:len(stack)-1]
+			if len(stack) == 0 && i != len(decoded)-1 {
+				return nil, nil, nil, errUnbalancedControl
+			}
+		case "Br", "BrIf":
+			frame, err := frameAtDepth(stack, instruction.Index)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+			framesOfBranches[i] = []*controlFrame{frame}
+		case "BrTable":
+			for _, depth := range instruction.Targets {
+				frame, err := frameAtDepth(stack, depth)
+				if err != nil {
+					return nil, nil, nil, err
+				}
+				framesOfBranches[i] = append(framesOfBranches[i], frame)
+			}
+		}
+	}
+
+	if len(stack) != 0 {
+		return nil, nil, nil, errUnbalancedControl
+	}
+
+	return frames, framesOfBranches, elseOfIf, nil
+}
+
+func frameAtDepth(stack []*controlFrame, depth uint32) (*controlFrame, error) {
+	if int(depth) >= len(stack) {
+		return nil, fmt.Errorf("branch to label %d outside of the function", depth)
+	}
+	return stack[len(stack)-1-int(depth)], nil
+}
+
+func branchTargets(frames []*controlFrame) []int {
+	targets := make([]int, 0, len(frames))
+	for _, frame := range frames {
+		if frame.isLoop {
+			targets = append(targets, frame.start+1)
+		} else {
+			targets = append(targets, frame.end)
+		}
+	}
+	return targets
+}
@@ -0,0 +1,156 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"github.com/multiversx/mx-chain-vm-go/config"
+	gasSchedules "github.com/multiversx/mx-chain-vm-go/scenario/gasSchedules"
+	"github.com/multiversx/mx-chain-vm-go/wasmbinary"
+	cli "github.com/urfave/cli/v2"
+)
+
+var errMissingContract = errors.New("the path to the contract WASM file is required")
+
+func main() {
+	app := &cli.App{
+		Name:      "gasbound",
+		Usage:     "computes the worst-case gas of the endpoints of a contract, or reports the loops and recursions preventing it",
+		ArgsUsage: "<contract.wasm>",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "executor",
+				Value: executorWasmer2,
+				Usage: "executor whose opcode metering is used: wasmer1 or wasmer2",
+			},
+			&cli.StringFlag{
+				Name:  "gas-schedule",
+				Usage: "gas schedule TOML with the costs; defaults to the latest embedded gas schedule",
+			},
+			&cli.StringSliceFlag{
+				Name:  "endpoint",
+				Usage: "endpoint to analyze; defaults to all the exported functions",
+			},
+		},
+		Action: run,
+	}
+
+	err := app.Run(os.Args)
+	if err != nil {
+		_, _ = fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(cCtx *cli.Context) error {
+	if cCtx.NArg() != 1 {
+		return errMissingContract
+	}
+
+	code, err := os.ReadFile(cCtx.Args().First())
+	if err != nil {
+		return err
+	}
+
+	gasSchedule, err := loadGasSchedule(cCtx.String("gas-schedule"))
+	if err != nil {
+		return err
+	}
+
+	report, err := analyzeContract(code, cCtx.String("executor"), gasSchedule, cCtx.StringSlice("endpoint"))
+	if err != nil {
+		return err
+	}
+
+	return writeReport(os.Stdout, report)
+}
+
+func loadGasSchedule(path string) (config.GasScheduleMap, error) {
+	if len(path) == 0 {
+		return gasSchedules.LoadGasScheduleConfig(gasSchedules.GetV4())
+	}
+
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return gasSchedules.LoadGasScheduleConfig(string(contents))
+}
+
+// endpointBound is the worst-case gas of an exported function.
+type endpointBound struct {
+	Name string
+	*functionBound
+}
+
+// gasBoundReport holds the bounds of the analyzed endpoints, in alphabetical order.
+type gasBoundReport struct {
+	Executor      string
+	Endpoints     []*endpointBound
+	UnpricedHooks []string
+}
+
+// analyzeContract computes the worst-case gas of the given endpoints of a contract, or of all its exported functions.
+func analyzeContract(code []byte, executorName string, gasSchedule config.GasScheduleMap, endpoints []string) (*gasBoundReport, error) {
+	module, err := wasmbinary.ParseModule(code)
+	if err != nil {
+		return nil, err
+	}
+
+	analyzer, err := newGasBoundAnalyzer(module, executorName, gasSchedule)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(endpoints) == 0 {
+		for name := range module.Exports {
+			endpoints = append(endpoints, name)
+		}
+	}
+	sort.Strings(endpoints)
+
+	report := &gasBoundReport{
+		Executor: executorName,
+	}
+	for _, name := range endpoints {
+		funcIndex, ok := module.Exports[name]
+		if !ok {
+			return nil, fmt.Errorf("endpoint %s is not exported", name)
+		}
+
+		bound, err := analyzer.analyzeFunction(funcIndex)
+		if err != nil {
+			return nil, err
+		}
+		report.Endpoints = append(report.Endpoints, &endpointBound{Name: name, functionBound: bound})
+	}
+	report.UnpricedHooks = analyzer.sortedUnpricedHooks()
+
+	return report, nil
+}
+
+func writeReport(w io.Writer, report *gasBoundReport) error {
+	_, err := fmt.Fprintf(w, "Worst-case gas per endpoint, metered as by %s; VM hooks are counted with their fixed cost only.\n", report.Executor)
+	if err != nil {
+		return err
+	}
+
+	for _, endpoint := range report.Endpoints {
+		if endpoint.Unbounded {
+			_, err = fmt.Fprintf(w, "%s: unbounded, %s\n", endpoint.Name, endpoint.Reason)
+		} else {
+			_, err = fmt.Fprintf(w, "%s: %d\n", endpoint.Name, endpoint.Gas)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(report.UnpricedHooks) > 0 {
+		_, err = fmt.Fprintf(w, "VM hooks without a cost in the gas schedule, counted as 0: %v\n", report.UnpricedHooks)
+	}
+	return err
+}
@@ -0,0 +1,306 @@
+package main
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/multiversx/mx-chain-vm-go/config"
+	"github.com/multiversx/mx-chain-vm-go/executor"
+	"github.com/multiversx/mx-chain-vm-go/math"
+	"github.com/multiversx/mx-chain-vm-go/wasmbinary"
+	"github.com/multiversx/mx-chain-vm-go/wasmer2"
+)
+
+const (
+	executorWasmer1 = "wasmer1"
+	executorWasmer2 = "wasmer2"
+)
+
+const opcodeCostSection = "WASMOpcodeCost"
+
+// opcodePricer returns the cost of an instruction, as metered by an executor,
+// or false if the executor does not support the instruction.
+type opcodePricer interface {
+	price(spec *wasmbinary.InstructionSpec) (uint64, bool)
+}
+
+// fieldPricer prices the instructions with an opcode costs structure whose fields are named after the instructions:
+// the gas schedule given to wasmer, which copies each field to its opcode costs array, or the structure given to wasmer2.
+type fieldPricer struct {
+	opcodeCosts reflect.Value
+}
+
+func (pricer *fieldPricer) price(spec *wasmbinary.InstructionSpec) (uint64, bool) {
+	field := pricer.opcodeCosts.FieldByName(spec.Name)
+	if !field.IsValid() {
+		return 0, false
+	}
+	return field.Uint(), true
+}
+
+func newOpcodePricer(executorName string, opcodeCosts *executor.WASMOpcodeCost) (opcodePricer, error) {
+	switch executorName {
+	case executorWasmer1:
+		return &fieldPricer{opcodeCosts: reflect.ValueOf(opcodeCosts).Elem()}, nil
+	case executorWasmer2:
+		return &fieldPricer{opcodeCosts: reflect.ValueOf(wasmer2.NewOpcodeCost(opcodeCosts)).Elem()}, nil
+	default:
+		return nil, fmt.Errorf("unknown executor: %s", executorName)
+	}
+}
+
+// functionBound is the worst-case gas consumption of a function; if it cannot be bounded, reason explains why.
+type functionBound struct {
+	Gas       uint64
+	Unbounded bool
+	Reason    string
+}
+
+// gasBoundAnalyzer computes the worst-case gas of the functions of a module, caching the result of each function.
+type gasBoundAnalyzer struct {
+	module        *wasmbinary.Module
+	pricer        opcodePricer
+	executorName  string
+	opcodeCosts   *executor.WASMOpcodeCost
+	gasSchedule   config.GasScheduleMap
+	bounds        map[uint32]*functionBound
+	inProgress    map[uint32]bool
+	unpricedHooks map[string]struct{}
+}
+
+func newGasBoundAnalyzer(module *wasmbinary.Module, executorName string, gasSchedule config.GasScheduleMap) (*gasBoundAnalyzer, error) {
+	gasCost, err := config.CreateGasConfig(gasSchedule)
+	if err != nil {
+		return nil, err
+	}
+
+	pricer, err := newOpcodePricer(executorName, gasCost.WASMOpcodeCost)
+	if err != nil {
+		return nil, err
+	}
+
+	return &gasBoundAnalyzer{
+		module:        module,
+		pricer:        pricer,
+		executorName:  executorName,
+		opcodeCosts:   gasCost.WASMOpcodeCost,
+		gasSchedule:   gasSchedule,
+		bounds:        make(map[uint32]*functionBound),
+		inProgress:    make(map[uint32]bool),
+		unpricedHooks: make(map[string]struct{}),
+	}, nil
+}
+
+// analyzeFunction returns the worst-case gas of a function, including the functions and VM hooks it calls.
+func (analyzer *gasBoundAnalyzer) analyzeFunction(funcIndex uint32) (*functionBound, error) {
+	if funcIndex >= analyzer.module.NumFunctions() {
+		return nil, fmt.Errorf("function index %d out of range", funcIndex)
+	}
+	if analyzer.module.IsImported(funcIndex) {
+		return &functionBound{Gas: analyzer.hookCost(analyzer.module.Imports[funcIndex].Name)}, nil
+	}
+	bound, ok := analyzer.bounds[funcIndex]
+	if ok {
+		return bound, nil
+	}
+	if analyzer.inProgress[funcIndex] {
+		return &functionBound{
+			Unbounded: true,
+			Reason:    fmt.Sprintf("recursion through function %s", analyzer.module.FunctionName(funcIndex)),
+		}, nil
+	}
+
+	analyzer.inProgress[funcIndex] = true
+	bound, err := analyzer.analyzeFunctionBody(funcIndex)
+	delete(analyzer.inProgress, funcIndex)
+	if err != nil {
+		return nil, fmt.Errorf("function %s: %w", analyzer.module.FunctionName(funcIndex), err)
+	}
+
+	analyzer.bounds[funcIndex] = bound
+	return bound, nil
+}
+
+func (analyzer *gasBoundAnalyzer) analyzeFunctionBody(funcIndex uint32) (*functionBound, error) {
+	body := analyzer.module.Function(funcIndex)
+	decoded, err := wasmbinary.DecodeFunctionBody(body)
+	if err != nil {
+		return nil, err
+	}
+	graph, err := buildControlFlowGraph(decoded)
+	if err != nil {
+		return nil, err
+	}
+
+	loopBlock, reachable, hasLoop := findCycle(graph)
+	if hasLoop {
+		return &functionBound{
+			Unbounded: true,
+			Reason: fmt.Sprintf("loop at offset 0x%x in function %s",
+				graph.blocks[loopBlock].offset, analyzer.module.FunctionName(funcIndex)),
+		}, nil
+	}
+
+	blockCosts := make([]uint64, len(graph.blocks))
+	for i, block := range graph.blocks {
+		if !reachable[i] {
+			continue
+		}
+		blockBound, err := analyzer.analyzeBlock(graph, block)
+		if err != nil {
+			return nil, err
+		}
+		if blockBound.Unbounded {
+			return blockBound, nil
+		}
+		blockCosts[i] = blockBound.Gas
+	}
+
+	return &functionBound{
+		Gas: math.AddUint64(analyzer.localsCost(body.NumLocals), longestPath(graph, blockCosts)),
+	}, nil
+}
+
+// analyzeBlock prices the instructions of a basic block, adding the worst-case gas of the called functions.
+func (analyzer *gasBoundAnalyzer) analyzeBlock(graph *controlFlowGraph, block *basicBlock) (*functionBound, error) {
+	gas := uint64(0)
+	for _, instruction := range graph.instructions[block.first : block.last+1] {
+		cost, ok := analyzer.pricer.price(instruction.Spec)
+		if !ok {
+			return nil, fmt.Errorf("opcode %s at offset 0x%x is not supported by %s",
+				instruction.Spec.Name, instruction.Offset, analyzer.executorName)
+		}
+		gas = math.AddUint64(gas, cost)
+
+		switch instruction.Spec.Name {
+		case "CallIndirect":
+			return &functionBound{
+				Unbounded: true,
+				Reason:    fmt.Sprintf("indirect call at offset 0x%x", instruction.Offset),
+			}, nil
+		case "Call":
+			calleeBound, err := analyzer.analyzeFunction(instruction.Index)
+			if err != nil {
+				return nil, err
+			}
+			if calleeBound.Unbounded {
+				return calleeBound, nil
+			}
+			gas = math.AddUint64(gas, calleeBound.Gas)
+		}
+	}
+
+	return &functionBound{Gas: gas}, nil
+}
+
+// localsCost is the cost of allocating the locals of a function, the first LocalsUnmetered being free.
+func (analyzer *gasBoundAnalyzer) localsCost(numLocals uint64) uint64 {
+	unmeteredLocals := uint64(analyzer.opcodeCosts.LocalsUnmetered)
+	if numLocals <= unmeteredLocals {
+		return 0
+	}
+	return math.MulUint64(numLocals-unmeteredLocals, uint64(analyzer.opcodeCosts.LocalAllocate))
+}
+
+// hookCost returns the fixed cost of a VM hook, found in the gas schedule under its name with the initial capitalized.
+// The opcode section is excluded, since opcode names might coincide with hook names.
+func (analyzer *gasBoundAnalyzer) hookCost(hookName string) uint64 {
+	if len(hookName) > 0 {
+		key := strings.ToUpper(hookName[0:1]) + hookName[1:]
+		for _, section := range sortedSections(analyzer.gasSchedule) {
+			if section == opcodeCostSection {
+				continue
+			}
+			cost, ok := analyzer.gasSchedule[section][key]
+			if ok {
+				return cost
+			}
+		}
+	}
+
+	analyzer.unpricedHooks[hookName] = struct{}{}
+	return 0
+}
+
+// sortedUnpricedHooks returns the VM hooks called by the analyzed functions which have no fixed cost in the gas schedule.
+func (analyzer *gasBoundAnalyzer) sortedUnpricedHooks() []string {
+	hooks := make([]string, 0, len(analyzer.unpricedHooks))
+	for hook := range analyzer.unpricedHooks {
+		hooks = append(hooks, hook)
+	}
+	sort.Strings(hooks)
+	return hooks
+}
+
+// findCycle looks for a back edge among the blocks reachable from the entry block and returns the block it leads to.
+// When there is no cycle, it also returns which blocks are reachable.
+func findCycle(graph *controlFlowGraph) (int, []bool, bool) {
+	const (
+		unvisited = iota
+		onPath
+		done
+	)
+
+	state := make([]int, len(graph.blocks))
+	var visit func(blockIndex int) (int, bool)
+	visit = func(blockIndex int) (int, bool) {
+		state[blockIndex] = onPath
+		for _, successor := range graph.blocks[blockIndex].successors {
+			switch state[successor] {
+			case onPath:
+				return successor, true
+			case unvisited:
+				cycleBlock, found := visit(successor)
+				if found {
+					return cycleBlock, true
+				}
+			}
+		}
+		state[blockIndex] = done
+		return 0, false
+	}
+
+	cycleBlock, found := visit(0)
+	reachable := make([]bool, len(graph.blocks))
+	for i := range state {
+		reachable[i] = state[i] == done
+	}
+	return cycleBlock, reachable, found
+}
+
+// longestPath returns the most expensive path from the entry block to a block leaving the function; the graph must be acyclic.
+func longestPath(graph *controlFlowGraph, blockCosts []uint64) uint64 {
+	costFrom := make(map[int]uint64, len(graph.blocks))
+	var visit func(blockIndex int) uint64
+	visit = func(blockIndex int) uint64 {
+		cost, ok := costFrom[blockIndex]
+		if ok {
+			return cost
+		}
+
+		maxSuccessorCost := uint64(0)
+		for _, successor := range graph.blocks[blockIndex].successors {
+			successorCost := visit(successor)
+			if successorCost > maxSuccessorCost {
+				maxSuccessorCost = successorCost
+			}
+		}
+
+		cost = math.AddUint64(blockCosts[blockIndex], maxSuccessorCost)
+		costFrom[blockIndex] = cost
+		return cost
+	}
+
+	return visit(0)
+}
+
+func sortedSections(gasSchedule config.GasScheduleMap) []string {
+	sections := make([]string, 0, len(gasSchedule))
+	for section := range gasSchedule {
+		sections = append(sections, section)
+	}
+	sort.Strings(sections)
+	return sections
+}
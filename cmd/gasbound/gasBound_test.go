@@ -0,0 +1,202 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/multiversx/mx-chain-vm-go/config"
+	gasSchedules "github.com/multiversx/mx-chain-vm-go/scenario/gasSchedules"
+	"github.com/multiversx/mx-chain-vm-go/wasmbinary"
+	"github.com/stretchr/testify/require"
+)
+
+const getGasLeftCost = uint64(50)
+
+// testFunction is a function of a test module, with its code without the locals declarations.
+type testFunction struct {
+	export    string
+	numLocals uint32
+	code      []byte
+}
+
+// buildTestModule encodes a module in which all the functions have the type () -> ().
+func buildTestModule(imports []string, functions []testFunction) []byte {
+	builder := wasmbinary.NewModuleBuilder()
+	for _, name := range imports {
+		builder.AddImport(name, nil, nil)
+	}
+	for _, function := range functions {
+		locals := bytes.Repeat([]byte{wasmbinary.ValueTypeI32}, int(function.numLocals))
+		funcIndex := builder.AddFunction(nil, nil, locals, function.code)
+		if len(function.export) > 0 {
+			builder.ExportFunction(function.export, funcIndex)
+		}
+	}
+	return builder.Build()
+}
+
+func makeTestGasSchedule() config.GasScheduleMap {
+	gasSchedule := config.MakeGasMapForTests()
+	gasSchedule["BaseOpsAPICost"]["GetGasLeft"] = getGasLeftCost
+	return gasSchedule
+}
+
+func analyzeTestModule(t *testing.T, executorName string, imports []string, functions []testFunction) map[string]*endpointBound {
+	report, err := analyzeContract(buildTestModule(imports, functions), executorName, makeTestGasSchedule(), nil)
+	require.Nil(t, err)
+
+	bounds := make(map[string]*endpointBound)
+	for _, endpoint := range report.Endpoints {
+		bounds[endpoint.Name] = endpoint
+	}
+	return bounds
+}
+
+func TestGasBound_Branches(t *testing.T) {
+	bounds := analyzeTestModule(t, executorWasmer2, nil, []testFunction{
+		{
+			// i32.const 1; if; nop; nop; else; nop; end; end
+			export: "ifElse",
+			code:   []byte{0x41, 0x01, 0x04, 0x40, 0x01, 0x01, 0x05, 0x01, 0x0b, 0x0b},
+		},
+		{
+			// block; br 0; nop; end; end
+			export: "deadCode",
+			code:   []byte{0x02, 0x40, 0x0c, 0x00, 0x01, 0x0b, 0x0b},
+		},
+		{
+			// loop; nop; end; end
+			export: "loopWithoutBackEdge",
+			code:   []byte{0x03, 0x40, 0x01, 0x0b, 0x0b},
+		},
+	})
+
+	// the then branch is the longest: i32.const, if, nop, nop, else, end, end
+	require.Equal(t, uint64(7), bounds["ifElse"].Gas)
+	require.Equal(t, uint64(4), bounds["deadCode"].Gas)
+	require.Equal(t, uint64(4), bounds["loopWithoutBackEdge"].Gas)
+}
+
+func TestGasBound_CallsAndHooks(t *testing.T) {
+	bounds := analyzeTestModule(t, executorWasmer2, []string{"getGasLeft", "unknownHook"}, []testFunction{
+		{
+			// call getGasLeft; drop; call #3; call unknownHook; end
+			export: "endpoint",
+			code:   []byte{0x10, 0x00, 0x1a, 0x10, 0x03, 0x10, 0x01, 0x0b},
+		},
+		{
+			// nop; end
+			code: []byte{0x01, 0x0b},
+		},
+	})
+
+	require.False(t, bounds["endpoint"].Unbounded)
+	require.Equal(t, uint64(5)+getGasLeftCost+2, bounds["endpoint"].Gas)
+
+	report, err := analyzeContract(
+		buildTestModule([]string{"unknownHook"}, []testFunction{{export: "endpoint", code: []byte{0x10, 0x00, 0x0b}}}),
+		executorWasmer2, makeTestGasSchedule(), nil)
+	require.Nil(t, err)
+	require.Equal(t, []string{"unknownHook"}, report.UnpricedHooks)
+}
+
+func TestGasBound_Locals(t *testing.T) {
+	gasCost, err := config.CreateGasConfig(makeTestGasSchedule())
+	require.Nil(t, err)
+	unmeteredLocals := gasCost.WASMOpcodeCost.LocalsUnmetered
+
+	bounds := analyzeTestModule(t, executorWasmer2, nil, []testFunction{
+		{export: "unmetered", numLocals: unmeteredLocals, code: []byte{0x0b}},
+		{export: "metered", numLocals: unmeteredLocals + 3, code: []byte{0x0b}},
+	})
+
+	require.Equal(t, uint64(1), bounds["unmetered"].Gas)
+	require.Equal(t, uint64(1)+3*uint64(gasCost.WASMOpcodeCost.LocalAllocate), bounds["metered"].Gas)
+}
+
+func TestGasBound_Unbounded(t *testing.T) {
+	bounds := analyzeTestModule(t, executorWasmer2, nil, []testFunction{
+		{
+			// loop; br 0; end; end
+			export: "loop",
+			code:   []byte{0x03, 0x40, 0x0c, 0x00, 0x0b, 0x0b},
+		},
+		{
+			// call #1; end
+			export: "recursion",
+			code:   []byte{0x10, 0x01, 0x0b},
+		},
+		{
+			// i32.const 0; call_indirect 0 0; end
+			export: "indirect",
+			code:   []byte{0x41, 0x00, 0x11, 0x00, 0x00, 0x0b},
+		},
+		{
+			// call #0; end
+			export: "callsLoop",
+			code:   []byte{0x10, 0x00, 0x0b},
+		},
+	})
+
+	for _, name := range []string{"loop", "recursion", "indirect", "callsLoop"} {
+		require.True(t, bounds[name].Unbounded, name)
+	}
+	require.Contains(t, bounds["loop"].Reason, "loop at offset")
+	require.Equal(t, "recursion through function recursion", bounds["recursion"].Reason)
+	require.Contains(t, bounds["indirect"].Reason, "indirect call")
+	require.Equal(t, bounds["loop"].Reason, bounds["callsLoop"].Reason)
+}
+
+func TestGasBound_ExecutorSupport(t *testing.T) {
+	// f32.const 0; drop; end
+	module := buildTestModule(nil, []testFunction{
+		{export: "float", code: []byte{0x43, 0x00, 0x00, 0x00, 0x00, 0x1a, 0x0b}},
+	})
+
+	_, err := analyzeContract(module, executorWasmer2, makeTestGasSchedule(), nil)
+	require.ErrorContains(t, err, "F32Const")
+
+	report, err := analyzeContract(module, executorWasmer1, makeTestGasSchedule(), nil)
+	require.Nil(t, err)
+	require.Equal(t, uint64(3), report.Endpoints[0].Gas)
+}
+
+func TestGasBound_InvalidModule(t *testing.T) {
+	_, err := analyzeContract([]byte("not wasm"), executorWasmer2, makeTestGasSchedule(), nil)
+	require.Equal(t, wasmbinary.ErrNotWasmModule, err)
+
+	// block; end
+	module := buildTestModule(nil, []testFunction{{export: "unbalanced", code: []byte{0x02, 0x40, 0x0b}}})
+	_, err = analyzeContract(module, executorWasmer2, makeTestGasSchedule(), nil)
+	require.ErrorIs(t, err, errUnbalancedControl)
+
+	_, err = analyzeContract(buildTestModule(nil, nil), executorWasmer2, makeTestGasSchedule(), []string{"missing"})
+	require.ErrorContains(t, err, "missing")
+}
+
+func TestGasBound_ExecutorsPriceAlike(t *testing.T) {
+	gasSchedule, err := gasSchedules.LoadGasScheduleConfig(gasSchedules.GetV4())
+	require.Nil(t, err)
+	gasCost, err := config.CreateGasConfig(gasSchedule)
+	require.Nil(t, err)
+
+	wasmer1, err := newOpcodePricer(executorWasmer1, gasCost.WASMOpcodeCost)
+	require.Nil(t, err)
+	wasmer2, err := newOpcodePricer(executorWasmer2, gasCost.WASMOpcodeCost)
+	require.Nil(t, err)
+
+	checkPricesAlike(t, wasmbinary.Instructions, wasmer1, wasmer2)
+	checkPricesAlike(t, wasmbinary.MiscInstructions, wasmer1, wasmer2)
+}
+
+func checkPricesAlike(t *testing.T, specs map[byte]*wasmbinary.InstructionSpec, wasmer1 opcodePricer, wasmer2 opcodePricer) {
+	for _, spec := range specs {
+		wasmer1Cost, ok := wasmer1.price(spec)
+		require.True(t, ok)
+
+		wasmer2Cost, ok := wasmer2.price(spec)
+		if ok {
+			require.Equal(t, wasmer1Cost, wasmer2Cost, spec.Name)
+		}
+	}
+}
@@ -0,0 +1,353 @@
+package main
+
+import (
+	"strings"
+
+	eapigen "github.com/multiversx/mx-chain-vm-go/vmhost/vmhooks/generate"
+	"github.com/multiversx/mx-chain-vm-go/wasmbinary"
+)
+
+const benchmarkFunctionName = "bench"
+
+// opcodeRepetitions is the number of times an opcode is repeated in each loop iteration,
+// so that the cost of the loop itself is amortized
+const opcodeRepetitions = 16
+
+// the contracts create managedValuesCount values of each managed type in their prologue,
+// so that the handles passed to the benchmarked hooks are valid
+const managedValuesCount = 4
+
+const (
+	hookMemLength   = 32
+	hookMemPtrStart = 4096
+	hookMemPtrStep  = 1024
+	benchmarkPages  = 1
+)
+
+// the counter local holds the number of remaining iterations; the opcode contracts also declare,
+// for each value type, two operand locals and one result local, in the order of opcodeLocalTypes
+const counterLocal = 0
+
+var opcodeLocalTypes = []wasmValueType{wasmI32, wasmI64, wasmF32, wasmF64}
+
+const localsPerValueType = 3
+
+type opcodeSpec struct {
+	name    string
+	code    []byte
+	params  []wasmValueType
+	results []wasmValueType
+}
+
+// benchmarkedOpcodes lists the opcodes which can be benchmarked in isolation, named after the WASMOpcodeCost fields.
+// Control flow, calls, table, atomic and SIMD instructions are not covered.
+var benchmarkedOpcodes = buildBenchmarkedOpcodes()
+
+func buildBenchmarkedOpcodes() []opcodeSpec {
+	var specs []opcodeSpec
+	add := func(name string, code []byte, params []wasmValueType, results ...wasmValueType) {
+		specs = append(specs, opcodeSpec{name: name, code: code, params: params, results: results})
+	}
+	unary := func(name string, opcode byte, valueType wasmValueType) {
+		add(name, []byte{opcode}, []wasmValueType{valueType}, valueType)
+	}
+	binary := func(name string, opcode byte, valueType wasmValueType) {
+		add(name, []byte{opcode}, []wasmValueType{valueType, valueType}, valueType)
+	}
+	compare := func(name string, opcode byte, valueType wasmValueType) {
+		add(name, []byte{opcode}, []wasmValueType{valueType, valueType}, wasmI32)
+	}
+	convert := func(name string, code []byte, from wasmValueType, to wasmValueType) {
+		add(name, code, []wasmValueType{from}, to)
+	}
+	memArg := []byte{0x00, 0x00}
+	load := func(name string, opcode byte, valueType wasmValueType) {
+		add(name, append([]byte{opcode}, memArg...), []wasmValueType{wasmI32}, valueType)
+	}
+	store := func(name string, opcode byte, valueType wasmValueType) {
+		add(name, append([]byte{opcode}, memArg...), []wasmValueType{wasmI32, valueType})
+	}
+
+	add("I32Eqz", []byte{0x45}, []wasmValueType{wasmI32}, wasmI32)
+	add("I64Eqz", []byte{0x50}, []wasmValueType{wasmI64}, wasmI32)
+	for i, name := range []string{"Eq", "Ne", "LtS", "LtU", "GtS", "GtU", "LeS", "LeU", "GeS", "GeU"} {
+		compare("I32"+name, byte(0x46+i), wasmI32)
+		compare("I64"+name, byte(0x51+i), wasmI64)
+	}
+	for i, name := range []string{"Eq", "Ne", "Lt", "Gt", "Le", "Ge"} {
+		compare("F32"+name, byte(0x5b+i), wasmF32)
+		compare("F64"+name, byte(0x61+i), wasmF64)
+	}
+	for i, name := range []string{"Clz", "Ctz", "Popcnt"} {
+		unary("I32"+name, byte(0x67+i), wasmI32)
+		unary("I64"+name, byte(0x79+i), wasmI64)
+	}
+	for i, name := range []string{"Add", "Sub", "Mul", "DivS", "DivU", "RemS", "RemU", "And", "Or", "Xor", "Shl", "ShrS", "ShrU", "Rotl", "Rotr"} {
+		binary("I32"+name, byte(0x6a+i), wasmI32)
+		binary("I64"+name, byte(0x7c+i), wasmI64)
+	}
+	for i, name := range []string{"Abs", "Neg", "Ceil", "Floor", "Trunc", "Nearest", "Sqrt"} {
+		unary("F32"+name, byte(0x8b+i), wasmF32)
+		unary("F64"+name, byte(0x99+i), wasmF64)
+	}
+	for i, name := range []string{"Add", "Sub", "Mul", "Div", "Min", "Max", "Copysign"} {
+		binary("F32"+name, byte(0x92+i), wasmF32)
+		binary("F64"+name, byte(0xa0+i), wasmF64)
+	}
+
+	convert("I32WrapI64", []byte{0xa7}, wasmI64, wasmI32)
+	convert("I32TruncF32S", []byte{0xa8}, wasmF32, wasmI32)
+	convert("I32TruncF32U", []byte{0xa9}, wasmF32, wasmI32)
+	convert("I32TruncF64S", []byte{0xaa}, wasmF64, wasmI32)
+	convert("I32TruncF64U", []byte{0xab}, wasmF64, wasmI32)
+	convert("I64ExtendI32S", []byte{0xac}, wasmI32, wasmI64)
+	convert("I64ExtendI32U", []byte{0xad}, wasmI32, wasmI64)
+	convert("I64TruncF32S", []byte{0xae}, wasmF32, wasmI64)
+	convert("I64TruncF32U", []byte{0xaf}, wasmF32, wasmI64)
+	convert("I64TruncF64S", []byte{0xb0}, wasmF64, wasmI64)
+	convert("I64TruncF64U", []byte{0xb1}, wasmF64, wasmI64)
+	convert("F32ConvertI32S", []byte{0xb2}, wasmI32, wasmF32)
+	convert("F32ConvertI32U", []byte{0xb3}, wasmI32, wasmF32)
+	convert("F32ConvertI64S", []byte{0xb4}, wasmI64, wasmF32)
+	convert("F32ConvertI64U", []byte{0xb5}, wasmI64, wasmF32)
+	convert("F32DemoteF64", []byte{0xb6}, wasmF64, wasmF32)
+	convert("F64ConvertI32S", []byte{0xb7}, wasmI32, wasmF64)
+	convert("F64ConvertI32U", []byte{0xb8}, wasmI32, wasmF64)
+	convert("F64ConvertI64S", []byte{0xb9}, wasmI64, wasmF64)
+	convert("F64ConvertI64U", []byte{0xba}, wasmI64, wasmF64)
+	convert("F64PromoteF32", []byte{0xbb}, wasmF32, wasmF64)
+	convert("I32ReinterpretF32", []byte{0xbc}, wasmF32, wasmI32)
+	convert("I64ReinterpretF64", []byte{0xbd}, wasmF64, wasmI64)
+	convert("F32ReinterpretI32", []byte{0xbe}, wasmI32, wasmF32)
+	convert("F64ReinterpretI64", []byte{0xbf}, wasmI64, wasmF64)
+	convert("I32Extend8S", []byte{0xc0}, wasmI32, wasmI32)
+	convert("I32Extend16S", []byte{0xc1}, wasmI32, wasmI32)
+	convert("I64Extend8S", []byte{0xc2}, wasmI64, wasmI64)
+	convert("I64Extend16S", []byte{0xc3}, wasmI64, wasmI64)
+	convert("I64Extend32S", []byte{0xc4}, wasmI64, wasmI64)
+	for i, name := range []string{"I32TruncSatF32S", "I32TruncSatF32U", "I32TruncSatF64S", "I32TruncSatF64U", "I64TruncSatF32S", "I64TruncSatF32U", "I64TruncSatF64S", "I64TruncSatF64U"} {
+		from := wasmF32
+		if strings.Contains(name, "F64") {
+			from = wasmF64
+		}
+		to := wasmI32
+		if strings.HasPrefix(name, "I64") {
+			to = wasmI64
+		}
+		convert(name, []byte{0xfc, byte(i)}, from, to)
+	}
+
+	load("I32Load", 0x28, wasmI32)
+	load("I64Load", 0x29, wasmI64)
+	load("F32Load", 0x2a, wasmF32)
+	load("F64Load", 0x2b, wasmF64)
+	load("I32Load8S", 0x2c, wasmI32)
+	load("I32Load8U", 0x2d, wasmI32)
+	load("I32Load16S", 0x2e, wasmI32)
+	load("I32Load16U", 0x2f, wasmI32)
+	load("I64Load8S", 0x30, wasmI64)
+	load("I64Load8U", 0x31, wasmI64)
+	load("I64Load16S", 0x32, wasmI64)
+	load("I64Load16U", 0x33, wasmI64)
+	load("I64Load32S", 0x34, wasmI64)
+	load("I64Load32U", 0x35, wasmI64)
+	store("I32Store", 0x36, wasmI32)
+	store("I64Store", 0x37, wasmI64)
+	store("F32Store", 0x38, wasmF32)
+	store("F64Store", 0x39, wasmF64)
+	store("I32Store8", 0x3a, wasmI32)
+	store("I32Store16", 0x3b, wasmI32)
+	store("I64Store8", 0x3c, wasmI64)
+	store("I64Store16", 0x3d, wasmI64)
+	store("I64Store32", 0x3e, wasmI64)
+
+	return specs
+}
+
+// benchmarkLoop wraps the iteration code in a loop running as many times as the first call argument.
+func benchmarkLoop(module *wasmbinary.ModuleBuilder, prologue []byte, iteration []byte) []byte {
+	getArgument := module.AddImport("smallIntGetUnsignedArgument", []wasmValueType{wasmI32}, []wasmValueType{wasmI64})
+
+	body := append([]byte{}, prologue...)
+	body = append(body, i32Const(0)...)
+	body = append(body, call(getArgument)...)
+	body = append(body, localSet(counterLocal)...)
+	body = append(body, opBlock, blockTypeNo, opLoop, blockTypeNo)
+	body = append(body, localGet(counterLocal)...)
+	body = append(body, opI64Eqz, opBrIf, 1)
+	body = append(body, iteration...)
+	body = append(body, localGet(counterLocal)...)
+	body = append(body, i64Const(1)...)
+	body = append(body, opI64Sub)
+	body = append(body, localSet(counterLocal)...)
+	body = append(body, opBr, 0, opEnd, opEnd)
+	return body
+}
+
+func finishModule(module *wasmbinary.ModuleBuilder, locals []wasmValueType, body []byte) []byte {
+	funcIdx := module.AddFunction(nil, nil, locals, append(body, opEnd))
+	module.ExportFunction(benchmarkFunctionName, funcIdx)
+	module.ExportMemory("memory")
+	return module.Build()
+}
+
+// createBaselineContract generates a contract with an empty loop, measuring the cost of the loop itself.
+func createBaselineContract() []byte {
+	module := wasmbinary.NewModuleBuilder()
+	module.AddMemory(benchmarkPages)
+	body := benchmarkLoop(module, nil, nil)
+	return finishModule(module, []wasmValueType{wasmI64}, body)
+}
+
+// createHookContract generates a contract calling the given VM hook once per loop iteration,
+// with synthetic arguments: handles of existing managed values, distinct memory pointers and fixed lengths.
+func createHookContract(function *eapigen.EIFunction) []byte {
+	module := wasmbinary.NewModuleBuilder()
+	module.AddMemory(benchmarkPages)
+
+	bigIntNew := module.AddImport("bigIntNew", []wasmValueType{wasmI64}, []wasmValueType{wasmI32})
+	mBufferNew := module.AddImport("mBufferNew", nil, []wasmValueType{wasmI32})
+	mBufferSetBytes := module.AddImport("mBufferSetBytes", []wasmValueType{wasmI32, wasmI32, wasmI32}, []wasmValueType{wasmI32})
+	bigFloatNewFromParts := module.AddImport("bigFloatNewFromParts", []wasmValueType{wasmI32, wasmI32, wasmI32}, []wasmValueType{wasmI32})
+	decimalNew := module.AddImport("decimalNew", []wasmValueType{wasmI64, wasmI32}, []wasmValueType{wasmI32})
+	managedMapNew := module.AddImport("managedMapNew", nil, []wasmValueType{wasmI32})
+
+	var prologue []byte
+	for i := int32(0); i < managedValuesCount; i++ {
+		prologue = append(prologue, i64Const(int64(7+i))...)
+		prologue = append(prologue, call(bigIntNew)...)
+		prologue = append(prologue, opDrop)
+		prologue = append(prologue, call(mBufferNew)...)
+		prologue = append(prologue, i32Const(0)...)
+		prologue = append(prologue, i32Const(hookMemLength)...)
+		prologue = append(prologue, call(mBufferSetBytes)...)
+		prologue = append(prologue, opDrop)
+		prologue = append(prologue, i32Const(7+i)...)
+		prologue = append(prologue, i32Const(5)...)
+		prologue = append(prologue, i32Const(-1)...)
+		prologue = append(prologue, call(bigFloatNewFromParts)...)
+		prologue = append(prologue, opDrop)
+		prologue = append(prologue, i64Const(int64(7+i))...)
+		prologue = append(prologue, i32Const(2)...)
+		prologue = append(prologue, call(decimalNew)...)
+		prologue = append(prologue, opDrop)
+		prologue = append(prologue, call(managedMapNew)...)
+		prologue = append(prologue, opDrop)
+	}
+
+	params, results := hookSignature(function)
+	hook := module.AddImport(hookImportName(function), params, results)
+
+	var iteration []byte
+	numPointers := int32(0)
+	for i, arg := range function.Arguments {
+		switch arg.Type {
+		case eapigen.EITypeInt64:
+			iteration = append(iteration, i64Const(1)...)
+		case eapigen.EITypeMemPtr:
+			iteration = append(iteration, i32Const(hookMemPtrStart+numPointers*hookMemPtrStep)...)
+			numPointers++
+		case eapigen.EITypeMemLength:
+			iteration = append(iteration, i32Const(hookMemLength)...)
+		default:
+			iteration = append(iteration, i32Const(int32(1+i%(managedValuesCount-1)))...)
+		}
+	}
+	iteration = append(iteration, call(hook)...)
+	if len(results) > 0 {
+		iteration = append(iteration, opDrop)
+	}
+
+	body := benchmarkLoop(module, prologue, iteration)
+	return finishModule(module, []wasmValueType{wasmI64}, body)
+}
+
+// createOpcodeContract generates a contract executing the given opcode opcodeRepetitions times per loop iteration.
+// The operands are read from locals and the result is written to a separate local, so that values stay constant.
+func createOpcodeContract(spec opcodeSpec) []byte {
+	module := wasmbinary.NewModuleBuilder()
+	module.AddMemory(benchmarkPages)
+
+	locals := []wasmValueType{wasmI64}
+	var prologue []byte
+	for _, valueType := range opcodeLocalTypes {
+		for i := 0; i < localsPerValueType; i++ {
+			locals = append(locals, valueType)
+		}
+		prologue = append(prologue, typedConst(valueType, 7)...)
+		prologue = append(prologue, localSet(operandLocal(valueType, 0))...)
+		prologue = append(prologue, typedConst(valueType, 3)...)
+		prologue = append(prologue, localSet(operandLocal(valueType, 1))...)
+	}
+
+	var snippet []byte
+	for position, param := range spec.params {
+		snippet = append(snippet, localGet(operandLocal(param, position))...)
+	}
+	snippet = append(snippet, spec.code...)
+	for _, result := range spec.results {
+		snippet = append(snippet, localSet(resultLocal(result))...)
+	}
+
+	var iteration []byte
+	for i := 0; i < opcodeRepetitions; i++ {
+		iteration = append(iteration, snippet...)
+	}
+
+	body := benchmarkLoop(module, prologue, iteration)
+	return finishModule(module, locals, body)
+}
+
+func hookSignature(function *eapigen.EIFunction) ([]wasmValueType, []wasmValueType) {
+	params := make([]wasmValueType, 0, len(function.Arguments))
+	for _, arg := range function.Arguments {
+		params = append(params, eiTypeToWasm(arg.Type))
+	}
+	var results []wasmValueType
+	if function.Result != nil {
+		results = append(results, eiTypeToWasm(function.Result.Type))
+	}
+	return params, results
+}
+
+func eiTypeToWasm(eiType eapigen.EIType) wasmValueType {
+	if eiType == eapigen.EITypeInt64 {
+		return wasmI64
+	}
+	return wasmI32
+}
+
+// hookImportName returns the name under which the executors expose a VM hook to contracts.
+func hookImportName(function *eapigen.EIFunction) string {
+	return strings.ToLower(function.Name[0:1]) + function.Name[1:]
+}
+
+func typedConst(valueType wasmValueType, value int64) []byte {
+	switch valueType {
+	case wasmI64:
+		return i64Const(value)
+	case wasmF32:
+		return f32Const(float32(value) + 0.5)
+	case wasmF64:
+		return f64Const(float64(value) + 0.5)
+	default:
+		return i32Const(int32(value))
+	}
+}
+
+func valueTypeLocalBase(valueType wasmValueType) uint32 {
+	for i, localType := range opcodeLocalTypes {
+		if localType == valueType {
+			return 1 + uint32(i*localsPerValueType)
+		}
+	}
+	return 1
+}
+
+func operandLocal(valueType wasmValueType, position int) uint32 {
+	return valueTypeLocalBase(valueType) + uint32(position%2)
+}
+
+func resultLocal(valueType wasmValueType) uint32 {
+	return valueTypeLocalBase(valueType) + 2
+}
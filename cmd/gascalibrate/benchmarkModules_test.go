@@ -0,0 +1,23 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBenchmarkModules_OpcodeNames(t *testing.T) {
+	names := make(map[string]struct{})
+	for _, spec := range benchmarkedOpcodes {
+		_, duplicate := names[spec.name]
+		require.False(t, duplicate, spec.name)
+		names[spec.name] = struct{}{}
+	}
+
+	gasSchedule, err := loadGasSchedule("")
+	require.Nil(t, err)
+	for _, spec := range benchmarkedOpcodes {
+		_, ok := gasSchedule[opcodeCostSection][spec.name]
+		require.True(t, ok, spec.name)
+	}
+}
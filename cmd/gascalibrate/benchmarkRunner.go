@@ -0,0 +1,169 @@
+package main
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"math/big"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/multiversx/mx-chain-core-go/data/vm"
+	scenexec "github.com/multiversx/mx-chain-scenario-go/scenario/executor"
+	"github.com/multiversx/mx-chain-scenario-go/worldmock"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	"github.com/multiversx/mx-chain-vm-go/config"
+	"github.com/multiversx/mx-chain-vm-go/executor"
+	"github.com/multiversx/mx-chain-vm-go/scenario"
+	eapigen "github.com/multiversx/mx-chain-vm-go/vmhost/vmhooks/generate"
+)
+
+// the contracts run under a schedule where everything costs 1, so that gas never runs out while timing them
+const benchmarkGasValue = 1
+const benchmarkGasProvided = uint64(1) << 50
+const benchmarkTimeoutInMilliseconds = 10 * 60 * 1000
+
+var callerAddress = []byte("gascalibrate_caller_____________")
+
+// the hooks reading call arguments receive small indexes, which must point to existing arguments
+var extraArguments = [][]byte{
+	make([]byte, hookMemLength),
+	make([]byte, hookMemLength),
+	make([]byte, hookMemLength),
+}
+
+// benchmarkRunner times contract calls on a dedicated VM, under a single executor.
+type benchmarkRunner struct {
+	executorName string
+	vm           scenexec.VMInterface
+	world        *worldmock.MockWorld
+	iterations   uint64
+	repetitions  int
+	numContracts int
+}
+
+func newBenchmarkRunner(
+	executorName string,
+	executorFactory executor.ExecutorAbstractFactory,
+	iterations uint64,
+	repetitions int,
+) (*benchmarkRunner, error) {
+	if iterations == 0 || repetitions <= 0 {
+		return nil, fmt.Errorf("iterations and repetitions must be positive")
+	}
+
+	builder := scenario.NewScenarioVMHostBuilder()
+	builder.OverrideVMExecutor = executorFactory
+	builder.TimeOutForSCExecutionInMilliseconds = benchmarkTimeoutInMilliseconds
+
+	world := builder.NewMockWorld()
+	world.AcctMap.CreateAccount(callerAddress, world)
+
+	vm, err := builder.NewVM(world, config.MakeGasMap(benchmarkGasValue, benchmarkGasValue))
+	if err != nil {
+		return nil, err
+	}
+
+	return &benchmarkRunner{
+		executorName: executorName,
+		vm:           vm,
+		world:        world,
+		iterations:   iterations,
+		repetitions:  repetitions,
+	}, nil
+}
+
+// runAll measures every entry, relative to the empty loop. The entries are in the order produced by createEntries.
+func (runner *benchmarkRunner) runAll(entries []*calibrationEntry, eiMetadata *eapigen.EIMetadata) error {
+	baseline, err := runner.measure(createBaselineContract())
+	if err != nil {
+		return fmt.Errorf("%s: baseline benchmark failed: %w", runner.executorName, err)
+	}
+
+	for i, entry := range entries {
+		var code []byte
+		repetitions := 1.0
+		if i < len(eiMetadata.AllFunctions) {
+			code = createHookContract(eiMetadata.AllFunctions[i])
+		} else {
+			code = createOpcodeContract(benchmarkedOpcodes[i-len(eiMetadata.AllFunctions)])
+			repetitions = opcodeRepetitions
+		}
+
+		nanoseconds, err := runner.measure(code)
+		if err != nil {
+			entry.Failures[runner.executorName] = err.Error()
+			continue
+		}
+		entry.Nanoseconds[runner.executorName] = (nanoseconds - baseline) / repetitions
+		_, _ = fmt.Fprintf(os.Stderr, "%s %s %s: %.1f ns\n", runner.executorName, entry.Kind, entry.Name, entry.Nanoseconds[runner.executorName])
+	}
+
+	return nil
+}
+
+// measure returns the duration of one loop iteration of the given benchmark contract, in nanoseconds.
+// Each sample is the difference between a run with twice the iterations and a run with the configured
+// iterations, which cancels out the cost of compiling, instantiating and calling the contract.
+func (runner *benchmarkRunner) measure(code []byte) (float64, error) {
+	address := runner.deployContract(code)
+
+	_, err := runner.timeCall(address, 1)
+	if err != nil {
+		return 0, err
+	}
+
+	samples := make([]float64, 0, runner.repetitions)
+	for i := 0; i < runner.repetitions; i++ {
+		single, err := runner.timeCall(address, runner.iterations)
+		if err != nil {
+			return 0, err
+		}
+		double, err := runner.timeCall(address, 2*runner.iterations)
+		if err != nil {
+			return 0, err
+		}
+		samples = append(samples, float64(double-single)/float64(runner.iterations))
+	}
+
+	sort.Float64s(samples)
+	return samples[0], nil
+}
+
+func (runner *benchmarkRunner) deployContract(code []byte) []byte {
+	runner.numContracts++
+	address := make([]byte, 32)
+	copy(address[8:], scenario.DefaultVMType)
+	copy(address[10:], fmt.Sprintf("gascalibrate_%d", runner.numContracts))
+
+	codeHash := sha256.Sum256(code)
+	runner.world.AcctMap.CreateSmartContractAccountWithCodeHash(callerAddress, address, code, codeHash[:], runner.world)
+	return address
+}
+
+func (runner *benchmarkRunner) timeCall(address []byte, iterations uint64) (time.Duration, error) {
+	input := &vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			CallerAddr:  callerAddress,
+			Arguments:   append([][]byte{big.NewInt(0).SetUint64(iterations).Bytes()}, extraArguments...),
+			CallValue:   big.NewInt(0),
+			CallType:    vm.DirectCall,
+			GasPrice:    1,
+			GasProvided: benchmarkGasProvided,
+		},
+		RecipientAddr: address,
+		Function:      benchmarkFunctionName,
+	}
+
+	start := time.Now()
+	vmOutput, err := runner.vm.RunSmartContractCall(input)
+	elapsed := time.Since(start)
+	if err != nil {
+		return 0, err
+	}
+	if vmOutput.ReturnCode != vmcommon.Ok {
+		return 0, fmt.Errorf("%s: %s", vmOutput.ReturnCode, vmOutput.ReturnMessage)
+	}
+
+	return elapsed, nil
+}
@@ -0,0 +1,297 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/multiversx/mx-chain-vm-go/config"
+)
+
+const opcodeCostSection = "WASMOpcodeCost"
+
+const (
+	kindHook   = "hook"
+	kindOpcode = "opcode"
+)
+
+// calibrationEntry holds the measurements of one VM hook or WASM opcode, under each executor.
+type calibrationEntry struct {
+	Kind        string
+	Name        string
+	Section     string
+	Key         string
+	Nanoseconds map[string]float64
+	Failures    map[string]string
+}
+
+// calibrationResult is the outcome of fitting the measurements against a reference operation.
+type calibrationResult struct {
+	Reference        *calibrationEntry
+	GasPerNanosecond float64
+	MedianGasPerNs   float64
+	Proposed         config.GasScheduleMap
+	Fitted           []*fittedEntry
+	Outliers         []*fittedEntry
+}
+
+// fittedEntry compares the current and the proposed gas cost of a measured operation.
+type fittedEntry struct {
+	Entry       *calibrationEntry
+	Nanoseconds float64
+	CurrentGas  uint64
+	ProposedGas uint64
+	GasPerNs    float64
+	Deviation   float64
+}
+
+func newCalibrationEntry(kind string, name string) *calibrationEntry {
+	return &calibrationEntry{
+		Kind:        kind,
+		Name:        name,
+		Nanoseconds: make(map[string]float64),
+		Failures:    make(map[string]string),
+	}
+}
+
+// isMapped returns true if the entry corresponds to a key of the gas schedule.
+func (entry *calibrationEntry) isMapped() bool {
+	return len(entry.Section) > 0
+}
+
+// worstNanoseconds returns the slowest measurement across executors, or 0 if the entry was not measured successfully.
+func (entry *calibrationEntry) worstNanoseconds() float64 {
+	if len(entry.Failures) > 0 {
+		return 0
+	}
+	worst := 0.0
+	for _, nanoseconds := range entry.Nanoseconds {
+		worst = math.Max(worst, nanoseconds)
+	}
+	return worst
+}
+
+// mapHookToSchedule finds the gas schedule key of a VM hook, which is its name with the initial capitalized.
+// The opcode section is excluded, since opcode names might coincide with hook names.
+func mapHookToSchedule(entry *calibrationEntry, gasSchedule config.GasScheduleMap) {
+	key := strings.ToUpper(entry.Name[0:1]) + entry.Name[1:]
+	for _, section := range sortedSections(gasSchedule) {
+		if section == opcodeCostSection {
+			continue
+		}
+		if _, ok := gasSchedule[section][key]; ok {
+			entry.Section = section
+			entry.Key = key
+			return
+		}
+	}
+}
+
+// mapOpcodeToSchedule finds the gas schedule key of a WASM opcode, which has the same name.
+func mapOpcodeToSchedule(entry *calibrationEntry, gasSchedule config.GasScheduleMap) {
+	if _, ok := gasSchedule[opcodeCostSection][entry.Name]; ok {
+		entry.Section = opcodeCostSection
+		entry.Key = entry.Name
+	}
+}
+
+// fitCalibration scales all the measurements by the gas per nanosecond of the reference operation
+// and flags the operations whose current gas per nanosecond deviates from the median by more than outlierFactor.
+func fitCalibration(
+	entries []*calibrationEntry,
+	gasSchedule config.GasScheduleMap,
+	reference string,
+	outlierFactor float64,
+) (*calibrationResult, error) {
+	if outlierFactor < 1 {
+		return nil, fmt.Errorf("the outlier factor must be at least 1")
+	}
+
+	referenceEntry := findEntry(entries, reference)
+	if referenceEntry == nil || !referenceEntry.isMapped() {
+		return nil, fmt.Errorf("reference operation %s was not found in the gas schedule", reference)
+	}
+	referenceNs := referenceEntry.worstNanoseconds()
+	referenceGas := gasSchedule[referenceEntry.Section][referenceEntry.Key]
+	if referenceNs <= 0 || referenceGas == 0 {
+		return nil, fmt.Errorf("reference operation %s has no usable measurement or gas cost", reference)
+	}
+
+	result := &calibrationResult{
+		Reference:        referenceEntry,
+		GasPerNanosecond: float64(referenceGas) / referenceNs,
+		Proposed:         cloneGasSchedule(gasSchedule),
+	}
+
+	gasPerNsValues := make([]float64, 0, len(entries))
+	for _, entry := range entries {
+		nanoseconds := entry.worstNanoseconds()
+		if !entry.isMapped() || nanoseconds <= 0 {
+			continue
+		}
+
+		fitted := &fittedEntry{
+			Entry:       entry,
+			Nanoseconds: nanoseconds,
+			CurrentGas:  gasSchedule[entry.Section][entry.Key],
+			ProposedGas: uint64(math.Max(1, math.Round(nanoseconds*result.GasPerNanosecond))),
+		}
+		result.Proposed[entry.Section][entry.Key] = fitted.ProposedGas
+		result.Fitted = append(result.Fitted, fitted)
+
+		if fitted.CurrentGas > 0 {
+			fitted.GasPerNs = float64(fitted.CurrentGas) / nanoseconds
+			gasPerNsValues = append(gasPerNsValues, fitted.GasPerNs)
+		}
+	}
+
+	result.MedianGasPerNs = median(gasPerNsValues)
+	if result.MedianGasPerNs <= 0 {
+		return result, nil
+	}
+
+	for _, fitted := range result.Fitted {
+		if fitted.GasPerNs <= 0 {
+			continue
+		}
+		fitted.Deviation = fitted.GasPerNs / result.MedianGasPerNs
+		if fitted.Deviation > outlierFactor || fitted.Deviation < 1/outlierFactor {
+			result.Outliers = append(result.Outliers, fitted)
+		}
+	}
+	sort.SliceStable(result.Outliers, func(i, j int) bool {
+		return logDistance(result.Outliers[i].Deviation) > logDistance(result.Outliers[j].Deviation)
+	})
+
+	return result, nil
+}
+
+func findEntry(entries []*calibrationEntry, name string) *calibrationEntry {
+	for _, entry := range entries {
+		if strings.EqualFold(entry.Name, name) || strings.EqualFold(entry.Key, name) {
+			return entry
+		}
+	}
+	return nil
+}
+
+func median(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sorted := append([]float64{}, values...)
+	sort.Float64s(sorted)
+	middle := len(sorted) / 2
+	if len(sorted)%2 == 1 {
+		return sorted[middle]
+	}
+	return (sorted[middle-1] + sorted[middle]) / 2
+}
+
+func logDistance(deviation float64) float64 {
+	return math.Abs(math.Log(deviation))
+}
+
+func cloneGasSchedule(gasSchedule config.GasScheduleMap) config.GasScheduleMap {
+	clone := make(config.GasScheduleMap, len(gasSchedule))
+	for section, costs := range gasSchedule {
+		clone[section] = make(map[string]uint64, len(costs))
+		for key, value := range costs {
+			clone[section][key] = value
+		}
+	}
+	return clone
+}
+
+func sortedSections(gasSchedule config.GasScheduleMap) []string {
+	sections := make([]string, 0, len(gasSchedule))
+	for section := range gasSchedule {
+		sections = append(sections, section)
+	}
+	sort.Strings(sections)
+	return sections
+}
+
+func sortedCostKeys(costs map[string]uint64) []string {
+	keys := make([]string, 0, len(costs))
+	for key := range costs {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// writeGasScheduleTOML writes the gas schedule with sorted sections and keys, in the layout of the gas schedule files.
+func writeGasScheduleTOML(w io.Writer, gasSchedule config.GasScheduleMap) error {
+	for i, section := range sortedSections(gasSchedule) {
+		if i > 0 {
+			_, err := fmt.Fprintln(w)
+			if err != nil {
+				return err
+			}
+		}
+		_, err := fmt.Fprintf(w, "[%s]\n", section)
+		if err != nil {
+			return err
+		}
+		for _, key := range sortedCostKeys(gasSchedule[section]) {
+			_, err = fmt.Fprintf(w, "    %s = %d\n", key, gasSchedule[section][key])
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// writeReport renders the calibration results as plain text.
+func writeReport(w io.Writer, result *calibrationResult, entries []*calibrationEntry, executorNames []string, unsupportedOpcodes []string) error {
+	report := &strings.Builder{}
+
+	fmt.Fprintf(report, "Reference: %s.%s, %.2f gas/ns\n", result.Reference.Section, result.Reference.Key, result.GasPerNanosecond)
+	fmt.Fprintf(report, "Median current gas/ns: %.2f\n\n", result.MedianGasPerNs)
+
+	fmt.Fprintf(report, "Outliers (%d):\n", len(result.Outliers))
+	for _, fitted := range result.Outliers {
+		fmt.Fprintf(report, "    %-50s %10.1f ns  current %10d  proposed %10d  %8.2fx median\n",
+			fitted.Entry.Section+"."+fitted.Entry.Key, fitted.Nanoseconds, fitted.CurrentGas, fitted.ProposedGas, fitted.Deviation)
+	}
+
+	fmt.Fprintf(report, "\nMeasurements (%d):\n", len(result.Fitted))
+	for _, fitted := range result.Fitted {
+		fmt.Fprintf(report, "    %-50s", fitted.Entry.Section+"."+fitted.Entry.Key)
+		for _, executorName := range executorNames {
+			fmt.Fprintf(report, "  %s %10.1f ns", executorName, fitted.Entry.Nanoseconds[executorName])
+		}
+		fmt.Fprintf(report, "  current %10d  proposed %10d\n", fitted.CurrentGas, fitted.ProposedGas)
+	}
+
+	var failed, unmapped []string
+	for _, entry := range entries {
+		for _, executorName := range executorNames {
+			failure, ok := entry.Failures[executorName]
+			if ok {
+				failed = append(failed, fmt.Sprintf("%s %s on %s: %s", entry.Kind, entry.Name, executorName, failure))
+			}
+		}
+		if !entry.isMapped() {
+			unmapped = append(unmapped, entry.Name)
+		}
+	}
+
+	fmt.Fprintf(report, "\nNot benchmarkable with synthetic arguments (%d):\n", len(failed))
+	for _, line := range failed {
+		fmt.Fprintf(report, "    %s\n", line)
+	}
+	fmt.Fprintf(report, "\nNot found in the gas schedule (%d):\n", len(unmapped))
+	for _, name := range unmapped {
+		fmt.Fprintf(report, "    %s\n", name)
+	}
+	fmt.Fprintf(report, "\nOpcodes not covered by the generated contracts (%d):\n    %s\n",
+		len(unsupportedOpcodes), strings.Join(unsupportedOpcodes, " "))
+
+	_, err := io.WriteString(w, report.String())
+	return err
+}
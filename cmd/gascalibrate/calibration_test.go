@@ -0,0 +1,99 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/multiversx/mx-chain-vm-go/config"
+	"github.com/stretchr/testify/require"
+)
+
+func measuredEntry(kind string, name string, nanoseconds float64) *calibrationEntry {
+	entry := newCalibrationEntry(kind, name)
+	entry.Nanoseconds[executorWasmer1] = nanoseconds
+	entry.Nanoseconds[executorWasmer2] = nanoseconds / 2
+	return entry
+}
+
+func testGasSchedule() config.GasScheduleMap {
+	return config.GasScheduleMap{
+		"BigIntAPICost": {
+			"BigIntAdd": 2000,
+			"BigIntMul": 6000,
+			"BigIntPow": 10,
+		},
+		"ManagedBufferAPICost": {
+			"MBufferNew": 2000,
+		},
+		opcodeCostSection: {
+			"I32Add": 10,
+		},
+	}
+}
+
+func TestCalibration_MapToSchedule(t *testing.T) {
+	gasSchedule := testGasSchedule()
+
+	hook := newCalibrationEntry(kindHook, "mBufferNew")
+	mapHookToSchedule(hook, gasSchedule)
+	require.Equal(t, "ManagedBufferAPICost", hook.Section)
+	require.Equal(t, "MBufferNew", hook.Key)
+
+	unknown := newCalibrationEntry(kindHook, "getGasLeft")
+	mapHookToSchedule(unknown, gasSchedule)
+	require.False(t, unknown.isMapped())
+
+	opcode := newCalibrationEntry(kindOpcode, "I32Add")
+	mapOpcodeToSchedule(opcode, gasSchedule)
+	require.Equal(t, opcodeCostSection, opcode.Section)
+}
+
+func TestCalibration_Fit(t *testing.T) {
+	gasSchedule := testGasSchedule()
+	entries := []*calibrationEntry{
+		measuredEntry(kindHook, "bigIntAdd", 100),
+		measuredEntry(kindHook, "bigIntMul", 300),
+		measuredEntry(kindHook, "bigIntPow", 500),
+		measuredEntry(kindHook, "mBufferNew", 100),
+		measuredEntry(kindOpcode, "I32Add", 0.5),
+		measuredEntry(kindHook, "getGasLeft", 10),
+	}
+	entries[3].Failures[executorWasmer2] = "execution failed"
+	for _, entry := range entries {
+		if entry.Kind == kindHook {
+			mapHookToSchedule(entry, gasSchedule)
+		} else {
+			mapOpcodeToSchedule(entry, gasSchedule)
+		}
+	}
+
+	result, err := fitCalibration(entries, gasSchedule, "BigIntAdd", 3)
+	require.Nil(t, err)
+	require.Equal(t, 20.0, result.GasPerNanosecond)
+	require.Equal(t, uint64(2000), result.Proposed["BigIntAPICost"]["BigIntAdd"])
+	require.Equal(t, uint64(6000), result.Proposed["BigIntAPICost"]["BigIntMul"])
+	require.Equal(t, uint64(10000), result.Proposed["BigIntAPICost"]["BigIntPow"])
+	require.Equal(t, uint64(10), result.Proposed[opcodeCostSection]["I32Add"])
+	require.Equal(t, uint64(2000), result.Proposed["ManagedBufferAPICost"]["MBufferNew"])
+	require.Equal(t, uint64(10), gasSchedule["BigIntAPICost"]["BigIntPow"])
+	require.Len(t, result.Fitted, 4)
+
+	require.Equal(t, 20.0, result.MedianGasPerNs)
+	require.Len(t, result.Outliers, 1)
+	require.Equal(t, "BigIntPow", result.Outliers[0].Entry.Key)
+
+	_, err = fitCalibration(entries, gasSchedule, "getGasLeft", 3)
+	require.NotNil(t, err)
+	_, err = fitCalibration(entries, gasSchedule, "BigIntAdd", 0.5)
+	require.NotNil(t, err)
+}
+
+func TestCalibration_WriteGasScheduleTOML(t *testing.T) {
+	buffer := &bytes.Buffer{}
+	err := writeGasScheduleTOML(buffer, config.GasScheduleMap{
+		"B": {"Y": 2, "X": 1},
+		"A": {"Z": 3},
+	})
+	require.Nil(t, err)
+	require.Equal(t, "[A]\n    Z = 3\n\n[B]\n    X = 1\n    Y = 2\n", buffer.String())
+}
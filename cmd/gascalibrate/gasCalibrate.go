@@ -0,0 +1,202 @@
+package main
+
+import (
+	"fmt"
+	"go/token"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/multiversx/mx-chain-vm-go/config"
+	"github.com/multiversx/mx-chain-vm-go/executor"
+	gasSchedules "github.com/multiversx/mx-chain-vm-go/scenario/gasSchedules"
+	eapigen "github.com/multiversx/mx-chain-vm-go/vmhost/vmhooks/generate"
+	"github.com/multiversx/mx-chain-vm-go/wasmer"
+	"github.com/multiversx/mx-chain-vm-go/wasmer2"
+	cli "github.com/urfave/cli/v2"
+)
+
+const (
+	executorWasmer1 = "wasmer1"
+	executorWasmer2 = "wasmer2"
+	executorBoth    = "both"
+)
+
+func main() {
+	app := &cli.App{
+		Name:  "gascalibrate",
+		Usage: "benchmarks the VM hooks and WASM opcodes on this machine and proposes a gas schedule",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "executor",
+				Value: executorBoth,
+				Usage: "executor to benchmark: wasmer1, wasmer2 or both; with both, the slower measurement is used",
+			},
+			&cli.StringFlag{
+				Name:  "vmhooks-path",
+				Value: "vmhost/vmhooks",
+				Usage: "path to the VM hooks sources, from which the list of hooks is extracted",
+			},
+			&cli.StringFlag{
+				Name:  "gas-schedule",
+				Usage: "gas schedule TOML with the current costs; defaults to the latest embedded gas schedule",
+			},
+			&cli.StringFlag{
+				Name:  "reference",
+				Value: "BigIntAdd",
+				Usage: "operation whose current cost is kept, all other costs are fitted relative to it",
+			},
+			&cli.Uint64Flag{
+				Name:  "iterations",
+				Value: 10000,
+				Usage: "loop iterations per timed call",
+			},
+			&cli.IntFlag{
+				Name:  "repetitions",
+				Value: 5,
+				Usage: "timed samples per operation, the fastest one is kept",
+			},
+			&cli.Float64Flag{
+				Name:  "outlier-factor",
+				Value: 3,
+				Usage: "operations whose current gas per nanosecond differs from the median by more than this factor are reported",
+			},
+			&cli.StringFlag{
+				Name:  "output",
+				Value: "gasScheduleCalibrated.toml",
+				Usage: "file where the proposed gas schedule is written",
+			},
+			&cli.StringFlag{
+				Name:  "report",
+				Usage: "file where the report is written; defaults to the standard output",
+			},
+		},
+		Action: run,
+	}
+
+	err := app.Run(os.Args)
+	if err != nil {
+		_, _ = fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(cCtx *cli.Context) error {
+	executorFactories, executorNames, err := selectExecutors(cCtx.String("executor"))
+	if err != nil {
+		return err
+	}
+
+	gasSchedule, err := loadGasSchedule(cCtx.String("gas-schedule"))
+	if err != nil {
+		return err
+	}
+
+	eiMetadata := eapigen.NewEIMetadata()
+	err = eapigen.ReadAndParseEIMetadata(token.NewFileSet(), filepath.Clean(cCtx.String("vmhooks-path"))+"/", eiMetadata)
+	if err != nil {
+		return err
+	}
+
+	entries := createEntries(eiMetadata, gasSchedule)
+	for i, executorName := range executorNames {
+		runner, err := newBenchmarkRunner(executorName, executorFactories[i], cCtx.Uint64("iterations"), cCtx.Int("repetitions"))
+		if err != nil {
+			return err
+		}
+		err = runner.runAll(entries, eiMetadata)
+		if err != nil {
+			return err
+		}
+	}
+
+	result, err := fitCalibration(entries, gasSchedule, cCtx.String("reference"), cCtx.Float64("outlier-factor"))
+	if err != nil {
+		return err
+	}
+
+	err = writeProposedGasSchedule(cCtx.String("output"), result.Proposed)
+	if err != nil {
+		return err
+	}
+
+	reportWriter := io.Writer(os.Stdout)
+	if cCtx.IsSet("report") {
+		reportFile, err := os.Create(cCtx.String("report"))
+		if err != nil {
+			return err
+		}
+		defer reportFile.Close()
+		reportWriter = reportFile
+	}
+
+	return writeReport(reportWriter, result, entries, executorNames, unsupportedOpcodes(gasSchedule))
+}
+
+func selectExecutors(executorName string) ([]executor.ExecutorAbstractFactory, []string, error) {
+	switch executorName {
+	case executorWasmer1:
+		return []executor.ExecutorAbstractFactory{wasmer.ExecutorFactory()}, []string{executorWasmer1}, nil
+	case executorWasmer2:
+		return []executor.ExecutorAbstractFactory{wasmer2.ExecutorFactory()}, []string{executorWasmer2}, nil
+	case executorBoth:
+		return []executor.ExecutorAbstractFactory{wasmer.ExecutorFactory(), wasmer2.ExecutorFactory()},
+			[]string{executorWasmer1, executorWasmer2}, nil
+	default:
+		return nil, nil, fmt.Errorf("unknown executor: %s", executorName)
+	}
+}
+
+func loadGasSchedule(path string) (config.GasScheduleMap, error) {
+	if len(path) == 0 {
+		return gasSchedules.LoadGasScheduleConfig(gasSchedules.GetV4())
+	}
+
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return gasSchedules.LoadGasScheduleConfig(string(contents))
+}
+
+// createEntries lists all the VM hooks and the benchmarked opcodes, mapped to their gas schedule keys.
+func createEntries(eiMetadata *eapigen.EIMetadata, gasSchedule config.GasScheduleMap) []*calibrationEntry {
+	entries := make([]*calibrationEntry, 0, len(eiMetadata.AllFunctions)+len(benchmarkedOpcodes))
+	for _, function := range eiMetadata.AllFunctions {
+		entry := newCalibrationEntry(kindHook, hookImportName(function))
+		mapHookToSchedule(entry, gasSchedule)
+		entries = append(entries, entry)
+	}
+	for _, spec := range benchmarkedOpcodes {
+		entry := newCalibrationEntry(kindOpcode, spec.name)
+		mapOpcodeToSchedule(entry, gasSchedule)
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+func writeProposedGasSchedule(path string, gasSchedule config.GasScheduleMap) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	return writeGasScheduleTOML(file, gasSchedule)
+}
+
+// unsupportedOpcodes lists the opcodes of the gas schedule which have no generated benchmark contract.
+func unsupportedOpcodes(gasSchedule config.GasScheduleMap) []string {
+	benchmarked := make(map[string]struct{}, len(benchmarkedOpcodes))
+	for _, spec := range benchmarkedOpcodes {
+		benchmarked[spec.name] = struct{}{}
+	}
+
+	var unsupported []string
+	for _, name := range sortedCostKeys(gasSchedule[opcodeCostSection]) {
+		if _, ok := benchmarked[name]; !ok {
+			unsupported = append(unsupported, name)
+		}
+	}
+	return unsupported
+}
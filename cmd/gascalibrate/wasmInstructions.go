@@ -0,0 +1,69 @@
+package main
+
+import (
+	"encoding/binary"
+	"math"
+
+	"github.com/multiversx/mx-chain-vm-go/wasmbinary"
+)
+
+// wasmValueType is the binary encoding of a WebAssembly value type.
+type wasmValueType = byte
+
+const (
+	wasmI32 = wasmbinary.ValueTypeI32
+	wasmI64 = wasmbinary.ValueTypeI64
+	wasmF32 = wasmbinary.ValueTypeF32
+	wasmF64 = wasmbinary.ValueTypeF64
+)
+
+const (
+	opBlock     = 0x02
+	opLoop      = 0x03
+	opBr        = 0x0c
+	opBrIf      = 0x0d
+	opEnd       = 0x0b
+	opCall      = 0x10
+	opDrop      = 0x1a
+	opLocalGet  = 0x20
+	opLocalSet  = 0x21
+	opI32Const  = 0x41
+	opI64Const  = 0x42
+	opF32Const  = 0x43
+	opF64Const  = 0x44
+	opI64Eqz    = 0x50
+	opI64Sub    = 0x7d
+	blockTypeNo = 0x40
+)
+
+func i32Const(value int32) []byte {
+	return wasmbinary.AppendS64([]byte{opI32Const}, int64(value))
+}
+
+func i64Const(value int64) []byte {
+	return wasmbinary.AppendS64([]byte{opI64Const}, value)
+}
+
+func f32Const(value float32) []byte {
+	out := []byte{opF32Const, 0, 0, 0, 0}
+	binary.LittleEndian.PutUint32(out[1:], math.Float32bits(value))
+	return out
+}
+
+func f64Const(value float64) []byte {
+	out := []byte{opF64Const, 0, 0, 0, 0, 0, 0, 0, 0}
+	binary.LittleEndian.PutUint64(out[1:], math.Float64bits(value))
+	return out
+}
+
+func localGet(idx uint32) []byte {
+	return wasmbinary.AppendU32([]byte{opLocalGet}, idx)
+}
+
+func localSet(idx uint32) []byte {
+	return wasmbinary.AppendU32([]byte{opLocalSet}, idx)
+}
+
+func call(funcIdx uint32) []byte {
+	return wasmbinary.AppendU32([]byte{opCall}, funcIdx)
+}
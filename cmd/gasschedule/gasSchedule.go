@@ -0,0 +1,157 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/multiversx/mx-chain-vm-go/config"
+	"github.com/multiversx/mx-chain-vm-go/executor"
+	gasSchedules "github.com/multiversx/mx-chain-vm-go/scenario/gasSchedules"
+	"github.com/multiversx/mx-chain-vm-go/wasmer"
+	"github.com/multiversx/mx-chain-vm-go/wasmer2"
+	cli "github.com/urfave/cli/v2"
+)
+
+// the embedded gas schedules can be referenced by name instead of by path
+const (
+	embeddedV3 = "v3"
+	embeddedV4 = "v4"
+)
+
+func main() {
+	app := &cli.App{
+		Name:  "gasschedule",
+		Usage: "validates and compares gas schedules; a gas schedule is a TOML file, or v3/v4 for the embedded ones",
+		Commands: []*cli.Command{
+			{
+				Name:      "validate",
+				Usage:     "checks that the gas schedule is accepted by the VM, reporting all the problems found",
+				ArgsUsage: "<gas schedule>",
+				Action:    validate,
+			},
+			{
+				Name:      "diff",
+				Usage:     "lists the changed costs per category, with percentage changes",
+				ArgsUsage: "<old gas schedule> <new gas schedule>",
+				Action:    diff,
+			},
+			{
+				Name:      "rerun",
+				Usage:     "runs the scenarios of a folder under both gas schedules and reports the gas used by each transaction",
+				ArgsUsage: "<old gas schedule> <new gas schedule>",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "scenarios",
+						Aliases:  []string{"s"},
+						Required: true,
+						Usage:    "folder with the .scen.json files to run",
+					},
+					&cli.StringFlag{
+						Name:  "executor",
+						Usage: "wasmer1 or wasmer2; defaults to the executor of the VM",
+					},
+				},
+				Action: rerun,
+			},
+		},
+	}
+
+	err := app.Run(os.Args)
+	if err != nil {
+		_, _ = fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func validate(cCtx *cli.Context) error {
+	if cCtx.NArg() != 1 {
+		return fmt.Errorf("expected exactly one gas schedule")
+	}
+
+	gasSchedule, err := loadGasSchedule(cCtx.Args().Get(0))
+	if err != nil {
+		return err
+	}
+
+	issues := config.ValidateGasSchedule(gasSchedule)
+	numErrors := 0
+	for _, issue := range issues {
+		fmt.Println(issue.String())
+		if !issue.IsWarning {
+			numErrors++
+		}
+	}
+
+	if numErrors > 0 {
+		return fmt.Errorf("gas schedule is invalid: %d errors, %d warnings", numErrors, len(issues)-numErrors)
+	}
+	fmt.Printf("gas schedule is valid, %d warnings\n", len(issues))
+	return nil
+}
+
+func diff(cCtx *cli.Context) error {
+	oldSchedule, newSchedule, err := loadGasSchedulePair(cCtx)
+	if err != nil {
+		return err
+	}
+
+	return writeDiff(os.Stdout, diffGasSchedules(oldSchedule, newSchedule))
+}
+
+func rerun(cCtx *cli.Context) error {
+	oldSchedule, newSchedule, err := loadGasSchedulePair(cCtx)
+	if err != nil {
+		return err
+	}
+
+	var executorFactory executor.ExecutorAbstractFactory
+	switch cCtx.String("executor") {
+	case "":
+	case "wasmer1":
+		executorFactory = wasmer.ExecutorFactory()
+	case "wasmer2":
+		executorFactory = wasmer2.ExecutorFactory()
+	default:
+		return fmt.Errorf("unknown executor: %s", cCtx.String("executor"))
+	}
+
+	comparisons, err := rerunScenarios(cCtx.String("scenarios"), oldSchedule, newSchedule, executorFactory)
+	if err != nil {
+		return err
+	}
+
+	return writeScenarioComparisons(os.Stdout, comparisons)
+}
+
+func loadGasSchedulePair(cCtx *cli.Context) (config.GasScheduleMap, config.GasScheduleMap, error) {
+	if cCtx.NArg() != 2 {
+		return nil, nil, fmt.Errorf("expected exactly two gas schedules")
+	}
+
+	oldSchedule, err := loadGasSchedule(cCtx.Args().Get(0))
+	if err != nil {
+		return nil, nil, err
+	}
+	newSchedule, err := loadGasSchedule(cCtx.Args().Get(1))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return oldSchedule, newSchedule, nil
+}
+
+func loadGasSchedule(name string) (config.GasScheduleMap, error) {
+	switch strings.ToLower(name) {
+	case embeddedV3:
+		return gasSchedules.LoadGasScheduleConfig(gasSchedules.GetV3())
+	case embeddedV4:
+		return gasSchedules.LoadGasScheduleConfig(gasSchedules.GetV4())
+	}
+
+	contents, err := os.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+	return gasSchedules.LoadGasScheduleConfig(string(contents))
+}
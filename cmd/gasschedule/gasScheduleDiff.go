@@ -0,0 +1,143 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/multiversx/mx-chain-vm-go/config"
+)
+
+// costChange describes how the gas cost under one key differs between two gas schedules.
+type costChange struct {
+	Key      string
+	OldValue uint64
+	NewValue uint64
+	Added    bool
+	Removed  bool
+}
+
+// percentage returns the relative change of the cost, or 0 for added and removed keys.
+func (change *costChange) percentage() float64 {
+	if change.Added || change.Removed || change.OldValue == 0 {
+		return 0
+	}
+	return (float64(change.NewValue) - float64(change.OldValue)) * 100 / float64(change.OldValue)
+}
+
+// sectionDiff holds the changes of one gas schedule category.
+type sectionDiff struct {
+	Section string
+	Changes []*costChange
+}
+
+// diffGasSchedules compares two gas schedules key by key, grouped by category. Unchanged categories are omitted.
+func diffGasSchedules(oldSchedule config.GasScheduleMap, newSchedule config.GasScheduleMap) []*sectionDiff {
+	diffs := make([]*sectionDiff, 0)
+	for _, section := range unionOfKeys(oldSchedule, newSchedule) {
+		oldCosts := oldSchedule[section]
+		newCosts := newSchedule[section]
+
+		diff := &sectionDiff{Section: section}
+		for _, key := range unionOfCostKeys(oldCosts, newCosts) {
+			oldValue, inOld := oldCosts[key]
+			newValue, inNew := newCosts[key]
+			if inOld && inNew && oldValue == newValue {
+				continue
+			}
+			diff.Changes = append(diff.Changes, &costChange{
+				Key:      key,
+				OldValue: oldValue,
+				NewValue: newValue,
+				Added:    !inOld,
+				Removed:  !inNew,
+			})
+		}
+
+		if len(diff.Changes) > 0 {
+			diffs = append(diffs, diff)
+		}
+	}
+
+	return diffs
+}
+
+// writeDiff renders the differences between two gas schedules as text.
+func writeDiff(w io.Writer, diffs []*sectionDiff) error {
+	if len(diffs) == 0 {
+		_, err := fmt.Fprintln(w, "gas schedules are identical")
+		return err
+	}
+
+	for _, diff := range diffs {
+		increased, decreased, added, removed := 0, 0, 0, 0
+		for _, change := range diff.Changes {
+			switch {
+			case change.Added:
+				added++
+			case change.Removed:
+				removed++
+			case change.NewValue > change.OldValue:
+				increased++
+			default:
+				decreased++
+			}
+		}
+
+		_, err := fmt.Fprintf(w, "[%s] %d increased, %d decreased, %d added, %d removed\n",
+			diff.Section, increased, decreased, added, removed)
+		if err != nil {
+			return err
+		}
+
+		for _, change := range diff.Changes {
+			var line string
+			switch {
+			case change.Added:
+				line = fmt.Sprintf("    + %-40s %12d\n", change.Key, change.NewValue)
+			case change.Removed:
+				line = fmt.Sprintf("    - %-40s %12d\n", change.Key, change.OldValue)
+			default:
+				line = fmt.Sprintf("    ~ %-40s %12d -> %12d  (%+.2f%%)\n",
+					change.Key, change.OldValue, change.NewValue, change.percentage())
+			}
+			_, err = io.WriteString(w, line)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func unionOfKeys(first config.GasScheduleMap, second config.GasScheduleMap) []string {
+	keys := make(map[string]struct{})
+	for key := range first {
+		keys[key] = struct{}{}
+	}
+	for key := range second {
+		keys[key] = struct{}{}
+	}
+	return sortedSet(keys)
+}
+
+func unionOfCostKeys(first map[string]uint64, second map[string]uint64) []string {
+	keys := make(map[string]struct{})
+	for key := range first {
+		keys[key] = struct{}{}
+	}
+	for key := range second {
+		keys[key] = struct{}{}
+	}
+	return sortedSet(keys)
+}
+
+func sortedSet(set map[string]struct{}) []string {
+	sorted := make([]string, 0, len(set))
+	for key := range set {
+		sorted = append(sorted, key)
+	}
+	sort.Strings(sorted)
+	return sorted
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/multiversx/mx-chain-vm-go/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffGasSchedules(t *testing.T) {
+	oldSchedule := config.GasScheduleMap{
+		"BigIntAPICost": {"BigIntAdd": 2000, "BigIntSub": 2000, "BigIntPow": 100},
+		"Unchanged":     {"A": 1},
+		"Removed":       {"B": 2},
+	}
+	newSchedule := config.GasScheduleMap{
+		"BigIntAPICost": {"BigIntAdd": 2500, "BigIntSub": 2000, "BigIntGCD": 6000},
+		"Unchanged":     {"A": 1},
+	}
+
+	diffs := diffGasSchedules(oldSchedule, newSchedule)
+	require.Len(t, diffs, 2)
+	require.Equal(t, "BigIntAPICost", diffs[0].Section)
+	require.Equal(t, []*costChange{
+		{Key: "BigIntAdd", OldValue: 2000, NewValue: 2500},
+		{Key: "BigIntGCD", NewValue: 6000, Added: true},
+		{Key: "BigIntPow", OldValue: 100, Removed: true},
+	}, diffs[0].Changes)
+	require.Equal(t, 25.0, diffs[0].Changes[0].percentage())
+	require.Equal(t, "Removed", diffs[1].Section)
+
+	buffer := &bytes.Buffer{}
+	require.Nil(t, writeDiff(buffer, diffs))
+	require.Contains(t, buffer.String(), "[BigIntAPICost] 1 increased, 0 decreased, 1 added, 1 removed")
+	require.Contains(t, buffer.String(), "(+25.00%)")
+
+	buffer.Reset()
+	require.Nil(t, writeDiff(buffer, diffGasSchedules(oldSchedule, oldSchedule)))
+	require.Equal(t, "gas schedules are identical\n", buffer.String())
+}
+
+func TestFormatDelta(t *testing.T) {
+	require.Equal(t, "(+50, +50.00%)", formatDelta(100, 150))
+	require.Equal(t, "(-25, -25.00%)", formatDelta(100, 75))
+	require.Equal(t, "(+10)", formatDelta(0, 10))
+}
@@ -0,0 +1,224 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	scenexec "github.com/multiversx/mx-chain-scenario-go/scenario/executor"
+	fr "github.com/multiversx/mx-chain-scenario-go/scenario/expression/fileresolver"
+	scenio "github.com/multiversx/mx-chain-scenario-go/scenario/io"
+	scenmodel "github.com/multiversx/mx-chain-scenario-go/scenario/model"
+	"github.com/multiversx/mx-chain-scenario-go/worldmock"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	"github.com/multiversx/mx-chain-vm-go/config"
+	"github.com/multiversx/mx-chain-vm-go/executor"
+	vmscenario "github.com/multiversx/mx-chain-vm-go/scenario"
+)
+
+const scenarioFileSuffix = ".scen.json"
+
+// txGas is the gas used by one transaction executed by the VM.
+type txGas struct {
+	Function   string
+	GasUsed    uint64
+	ReturnCode vmcommon.ReturnCode
+}
+
+// scenarioGasComparison holds the gas used by the transactions of a scenario under two gas schedules.
+type scenarioGasComparison struct {
+	Path   string
+	Old    []*txGas
+	New    []*txGas
+	OldErr error
+	NewErr error
+}
+
+// recordingVMBuilder creates VMs which always use the given gas schedule, regardless of what the scenario
+// requests, and which record the gas used by every transaction.
+type recordingVMBuilder struct {
+	*vmscenario.ScenarioVMHostBuilder
+	gasSchedule config.GasScheduleMap
+	records     []*txGas
+}
+
+// GasScheduleMapFromScenarios ignores the gas schedule requested by the scenario.
+func (builder *recordingVMBuilder) GasScheduleMapFromScenarios(_ scenmodel.GasSchedule) (worldmock.GasScheduleMap, error) {
+	return builder.gasSchedule, nil
+}
+
+// NewVM wraps the VM, so that the gas used by each transaction is recorded.
+func (builder *recordingVMBuilder) NewVM(
+	world *worldmock.MockWorld,
+	gasSchedule map[string]map[string]uint64,
+) (scenexec.VMInterface, error) {
+	vm, err := builder.ScenarioVMHostBuilder.NewVM(world, gasSchedule)
+	if err != nil {
+		return nil, err
+	}
+
+	return &recordingVM{VMInterface: vm, builder: builder}, nil
+}
+
+type recordingVM struct {
+	scenexec.VMInterface
+	builder *recordingVMBuilder
+}
+
+// RunSmartContractCreate executes the deployment and records its gas.
+func (vm *recordingVM) RunSmartContractCreate(input *vmcommon.ContractCreateInput) (*vmcommon.VMOutput, error) {
+	vmOutput, err := vm.VMInterface.RunSmartContractCreate(input)
+	vm.record("<deploy>", &input.VMInput, vmOutput)
+	return vmOutput, err
+}
+
+// RunSmartContractCall executes the call and records its gas.
+func (vm *recordingVM) RunSmartContractCall(input *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+	vmOutput, err := vm.VMInterface.RunSmartContractCall(input)
+	vm.record(input.Function, &input.VMInput, vmOutput)
+	return vmOutput, err
+}
+
+func (vm *recordingVM) record(function string, input *vmcommon.VMInput, vmOutput *vmcommon.VMOutput) {
+	if vmOutput == nil {
+		return
+	}
+
+	gasUsed := uint64(0)
+	if input.GasProvided > vmOutput.GasRemaining {
+		gasUsed = input.GasProvided - vmOutput.GasRemaining
+	}
+	vm.builder.records = append(vm.builder.records, &txGas{
+		Function:   function,
+		GasUsed:    gasUsed,
+		ReturnCode: vmOutput.ReturnCode,
+	})
+}
+
+// gasAgnosticRunner runs scenarios without checking the expected gas, since it changes with the gas schedule.
+type gasAgnosticRunner struct {
+	*scenexec.ScenarioExecutor
+}
+
+// RunScenario disables the gas checks before running the scenario.
+func (runner *gasAgnosticRunner) RunScenario(scenario *scenmodel.Scenario, fileResolver fr.FileResolver) error {
+	scenario.CheckGas = false
+	return runner.ScenarioExecutor.RunScenario(scenario, fileResolver)
+}
+
+// rerunScenarios runs every scenario in the folder under both gas schedules.
+func rerunScenarios(
+	folder string,
+	oldSchedule config.GasScheduleMap,
+	newSchedule config.GasScheduleMap,
+	executorFactory executor.ExecutorAbstractFactory,
+) ([]*scenarioGasComparison, error) {
+	paths, err := findScenarioFiles(folder)
+	if err != nil {
+		return nil, err
+	}
+
+	comparisons := make([]*scenarioGasComparison, 0, len(paths))
+	for _, path := range paths {
+		comparison := &scenarioGasComparison{Path: path}
+		comparison.Old, comparison.OldErr = runScenarioRecordingGas(path, oldSchedule, executorFactory)
+		comparison.New, comparison.NewErr = runScenarioRecordingGas(path, newSchedule, executorFactory)
+		comparisons = append(comparisons, comparison)
+	}
+
+	return comparisons, nil
+}
+
+func runScenarioRecordingGas(
+	path string,
+	gasSchedule config.GasScheduleMap,
+	executorFactory executor.ExecutorAbstractFactory,
+) ([]*txGas, error) {
+	builder := &recordingVMBuilder{
+		ScenarioVMHostBuilder: vmscenario.NewScenarioVMHostBuilder(),
+		gasSchedule:           gasSchedule,
+	}
+	builder.OverrideVMExecutor = executorFactory
+
+	scenarioExecutor := scenexec.NewScenarioExecutor(builder)
+	defer scenarioExecutor.Close()
+
+	controller := scenio.NewScenarioController(
+		&gasAgnosticRunner{ScenarioExecutor: scenarioExecutor},
+		scenio.NewDefaultFileResolver(),
+		builder.GetVMType(),
+	)
+	err := controller.RunSingleJSONScenario(path, scenio.DefaultRunScenarioOptions())
+
+	return builder.records, err
+}
+
+func findScenarioFiles(folder string) ([]string, error) {
+	paths := make([]string, 0)
+	err := filepath.Walk(folder, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() && strings.HasSuffix(path, scenarioFileSuffix) {
+			paths = append(paths, path)
+		}
+		return nil
+	})
+	sort.Strings(paths)
+
+	return paths, err
+}
+
+// writeScenarioComparisons renders the per-transaction gas deltas of each scenario.
+func writeScenarioComparisons(w io.Writer, comparisons []*scenarioGasComparison) error {
+	var totalOld, totalNew uint64
+	for _, comparison := range comparisons {
+		_, err := fmt.Fprintf(w, "%s\n", comparison.Path)
+		if err != nil {
+			return err
+		}
+		if comparison.OldErr != nil {
+			_, _ = fmt.Fprintf(w, "    old schedule failed: %s\n", comparison.OldErr)
+		}
+		if comparison.NewErr != nil {
+			_, _ = fmt.Fprintf(w, "    new schedule failed: %s\n", comparison.NewErr)
+		}
+		if len(comparison.Old) != len(comparison.New) {
+			_, _ = fmt.Fprintf(w, "    transaction count differs: %d before, %d after\n", len(comparison.Old), len(comparison.New))
+		}
+
+		numTxs := len(comparison.Old)
+		if len(comparison.New) < numTxs {
+			numTxs = len(comparison.New)
+		}
+		for i := 0; i < numTxs; i++ {
+			oldTx, newTx := comparison.Old[i], comparison.New[i]
+			totalOld += oldTx.GasUsed
+			totalNew += newTx.GasUsed
+
+			line := fmt.Sprintf("    #%-4d %-40s %12d -> %12d  %s",
+				i, oldTx.Function, oldTx.GasUsed, newTx.GasUsed, formatDelta(oldTx.GasUsed, newTx.GasUsed))
+			if oldTx.ReturnCode != newTx.ReturnCode {
+				line += fmt.Sprintf("  return code %s -> %s", oldTx.ReturnCode, newTx.ReturnCode)
+			}
+			_, err = fmt.Fprintln(w, line)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	_, err := fmt.Fprintf(w, "total gas used: %d -> %d  %s\n", totalOld, totalNew, formatDelta(totalOld, totalNew))
+	return err
+}
+
+func formatDelta(oldValue uint64, newValue uint64) string {
+	delta := int64(newValue) - int64(oldValue)
+	if oldValue == 0 {
+		return fmt.Sprintf("(%+d)", delta)
+	}
+	return fmt.Sprintf("(%+d, %+.2f%%)", delta, float64(delta)*100/float64(oldValue))
+}
@@ -0,0 +1,180 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	scenio "github.com/multiversx/mx-chain-scenario-go/scenario/io"
+	scenmodel "github.com/multiversx/mx-chain-scenario-go/scenario/model"
+	worldmock "github.com/multiversx/mx-chain-scenario-go/worldmock"
+	"github.com/multiversx/mx-chain-scenario-go/worldmock/esdtconvert"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	"github.com/multiversx/mx-chain-vm-go/vmhost"
+	cli "github.com/urfave/cli/v2"
+)
+
+const (
+	formatText = "text"
+	formatJSON = "json"
+)
+
+func main() {
+	app := &cli.App{
+		Name:      "vmoutputdiff",
+		Usage:     "renders the changes of a VMOutput against a world state",
+		ArgsUsage: "<vmoutput.json>",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    "state",
+				Aliases: []string{"s"},
+				Usage:   "scenario file whose setState steps describe the world state before the transaction",
+			},
+			&cli.StringFlag{
+				Name:    "format",
+				Aliases: []string{"f"},
+				Value:   formatText,
+				Usage:   "output format, text or json",
+			},
+			&cli.BoolFlag{
+				Name:  "no-color",
+				Usage: "disables the terminal colors of the text output",
+			},
+			&cli.StringFlag{
+				Name:  "protected-prefix",
+				Value: core.ProtectedKeyPrefix,
+				Usage: "the protected key prefix of the chain",
+			},
+		},
+		Action: run,
+	}
+
+	err := app.Run(os.Args)
+	if err != nil {
+		_, _ = fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(cCtx *cli.Context) error {
+	if cCtx.NArg() != 1 {
+		return fmt.Errorf("expected exactly one VMOutput file")
+	}
+
+	vmOutput, err := loadVMOutput(cCtx.Args().First())
+	if err != nil {
+		return err
+	}
+
+	world := worldmock.NewMockWorld()
+	if cCtx.IsSet("state") {
+		err = loadWorldState(world, cCtx.String("state"))
+		if err != nil {
+			return err
+		}
+	}
+
+	differ, err := vmhost.NewVMOutputDiffer(world, []byte(cCtx.String("protected-prefix")))
+	if err != nil {
+		return err
+	}
+
+	diff, err := differ.Diff(vmOutput)
+	if err != nil {
+		return err
+	}
+
+	switch cCtx.String("format") {
+	case formatJSON:
+		jsonOutput, err := diff.ToJSON()
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(os.Stdout, string(jsonOutput))
+		return err
+	case formatText:
+		return diff.WriteText(os.Stdout, !cCtx.Bool("no-color"))
+	default:
+		return fmt.Errorf("unknown format: %s", cCtx.String("format"))
+	}
+}
+
+// loadVMOutput reads a JSON-encoded VMOutput. The map keys are rebuilt from the
+// addresses and storage offsets, since raw bytes do not survive as JSON object keys.
+func loadVMOutput(path string) (*vmcommon.VMOutput, error) {
+	jsonBytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	vmOutput := &vmcommon.VMOutput{}
+	err = json.Unmarshal(jsonBytes, vmOutput)
+	if err != nil {
+		return nil, err
+	}
+
+	outputAccounts := make(map[string]*vmcommon.OutputAccount, len(vmOutput.OutputAccounts))
+	for _, outputAccount := range vmOutput.OutputAccounts {
+		storageUpdates := make(map[string]*vmcommon.StorageUpdate, len(outputAccount.StorageUpdates))
+		for _, storageUpdate := range outputAccount.StorageUpdates {
+			storageUpdates[string(storageUpdate.Offset)] = storageUpdate
+		}
+		outputAccount.StorageUpdates = storageUpdates
+		outputAccounts[string(outputAccount.Address)] = outputAccount
+	}
+	vmOutput.OutputAccounts = outputAccounts
+
+	return vmOutput, nil
+}
+
+// loadWorldState applies the setState steps of a scenario file to the given world.
+func loadWorldState(world *worldmock.MockWorld, path string) error {
+	scenario, err := scenio.ParseScenariosScenarioDefaultParser(path)
+	if err != nil {
+		return err
+	}
+
+	for _, step := range scenario.Steps {
+		setStateStep, ok := step.(*scenmodel.SetStateStep)
+		if !ok {
+			continue
+		}
+
+		for _, scenAccount := range setStateStep.Accounts {
+			account, err := convertAccount(scenAccount, world)
+			if err != nil {
+				return err
+			}
+			world.AcctMap.PutAccount(account)
+		}
+	}
+
+	return nil
+}
+
+func convertAccount(scenAccount *scenmodel.Account, world *worldmock.MockWorld) (*worldmock.Account, error) {
+	storage := make(map[string][]byte)
+	for _, keyValuePair := range scenAccount.Storage {
+		storage[string(keyValuePair.Key.Value)] = keyValuePair.Value.Value
+	}
+
+	err := esdtconvert.WriteScenariosESDTToStorage(scenAccount.ESDTData, storage)
+	if err != nil {
+		return nil, err
+	}
+
+	return &worldmock.Account{
+		Address:         scenAccount.Address.Value,
+		Nonce:           scenAccount.Nonce.Value,
+		Balance:         big.NewInt(0).Set(scenAccount.Balance.Value),
+		BalanceDelta:    big.NewInt(0),
+		DeveloperReward: big.NewInt(0),
+		Storage:         storage,
+		Code:            scenAccount.Code.Value,
+		OwnerAddress:    scenAccount.Owner.Value,
+		IsSmartContract: len(scenAccount.Code.Value) > 0,
+		MockWorld:       world,
+	}, nil
+}
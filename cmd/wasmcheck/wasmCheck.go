@@ -0,0 +1,166 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-vm-go/config"
+	gasSchedules "github.com/multiversx/mx-chain-vm-go/scenario/gasSchedules"
+	cli "github.com/urfave/cli/v2"
+)
+
+var errMissingContract = errors.New("the path to the contract WASM file is required")
+
+// errInvalidContract makes the tool exit with a distinct code after the verdict was written
+var errInvalidContract = errors.New("the contract does not pass the deploy-time checks")
+
+const exitCodeInvalidContract = 2
+
+func main() {
+	app := &cli.App{
+		Name:      "wasmcheck",
+		Usage:     "runs the deploy-time checks of the VM against a contract and prints a JSON verdict",
+		ArgsUsage: "<contract.wasm>",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "executor",
+				Value: executorWasmer2,
+				Usage: "executor whose behavior is emulated: wasmer1 or wasmer2",
+			},
+			&cli.StringFlag{
+				Name:  "gas-schedule",
+				Usage: "gas schedule TOML with the costs; defaults to the latest embedded gas schedule",
+			},
+			&cli.StringSliceFlag{
+				Name:  "enable-flag",
+				Usage: "epoch flag considered active, such as CryptoOpcodesV2Flag; all the other flags are inactive",
+			},
+			&cli.Uint64Flag{
+				Name:  "gas-limit",
+				Usage: "gas provided to the deployment; the deploy gas is not checked when missing",
+			},
+		},
+		Action: run,
+	}
+
+	err := app.Run(os.Args)
+	if errors.Is(err, errInvalidContract) {
+		os.Exit(exitCodeInvalidContract)
+	}
+	if err != nil {
+		_, _ = fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(cCtx *cli.Context) error {
+	if cCtx.NArg() != 1 {
+		return errMissingContract
+	}
+
+	code, err := os.ReadFile(cCtx.Args().First())
+	if err != nil {
+		return err
+	}
+
+	gasSchedule, err := loadGasSchedule(cCtx.String("gas-schedule"))
+	if err != nil {
+		return err
+	}
+
+	options := &checkOptions{
+		executorName: cCtx.String("executor"),
+		gasSchedule:  gasSchedule,
+		enabledFlags: cCtx.StringSlice("enable-flag"),
+		gasLimit:     cCtx.Uint64("gas-limit"),
+	}
+	result, err := checkContract(code, options)
+	if err != nil {
+		return err
+	}
+
+	err = writeVerdict(os.Stdout, result)
+	if err != nil {
+		return err
+	}
+	if !result.Valid {
+		return errInvalidContract
+	}
+	return nil
+}
+
+func loadGasSchedule(path string) (config.GasScheduleMap, error) {
+	if len(path) == 0 {
+		return gasSchedules.LoadGasScheduleConfig(gasSchedules.GetV4())
+	}
+
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return gasSchedules.LoadGasScheduleConfig(string(contents))
+}
+
+// checkOptions describes the chain on which the contract would be deployed.
+type checkOptions struct {
+	executorName string
+	gasSchedule  config.GasScheduleMap
+	enabledFlags []string
+	gasLimit     uint64
+}
+
+func (options *checkOptions) isFlagEnabled(flag core.EnableEpochFlag) bool {
+	for _, name := range options.enabledFlags {
+		if name == string(flag) {
+			return true
+		}
+	}
+	return false
+}
+
+// problem is a check failed by the contract, identified by a stable rule name.
+type problem struct {
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+}
+
+// verdict is the machine-readable outcome of the checks.
+type verdict struct {
+	Valid        bool       `json:"valid"`
+	Executor     string     `json:"executor"`
+	EnabledFlags []string   `json:"enabledFlags"`
+	CodeSize     uint64     `json:"codeSize"`
+	DeployGas    uint64     `json:"deployGas"`
+	CallGas      uint64     `json:"callGas"`
+	Problems     []*problem `json:"problems"`
+}
+
+func (result *verdict) addProblem(rule string, format string, args ...interface{}) {
+	result.Problems = append(result.Problems, &problem{
+		Rule:    rule,
+		Message: fmt.Sprintf(format, args...),
+	})
+	result.Valid = false
+}
+
+func writeVerdict(w io.Writer, result *verdict) error {
+	encoded, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintln(w, string(encoded))
+	return err
+}
+
+func sortedFlags(flags []string) []string {
+	sorted := make([]string, len(flags))
+	copy(sorted, flags)
+	sort.Strings(sorted)
+	return sorted
+}
@@ -0,0 +1,214 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/multiversx/mx-chain-scenario-go/worldmock"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	"github.com/multiversx/mx-chain-vm-go/config"
+	"github.com/multiversx/mx-chain-vm-go/executor"
+	"github.com/multiversx/mx-chain-vm-go/math"
+	"github.com/multiversx/mx-chain-vm-go/vmhost/contexts"
+	"github.com/multiversx/mx-chain-vm-go/wasmbinary"
+	"github.com/multiversx/mx-chain-vm-go/wasmer2"
+)
+
+const (
+	executorWasmer1 = "wasmer1"
+	executorWasmer2 = "wasmer2"
+
+	vmHooksModule = "env"
+)
+
+// The rules reported in the verdict.
+const (
+	ruleMalformedModule  = "malformed-module"
+	ruleUnknownVMHook    = "unknown-vm-hook"
+	ruleForbiddenOpcode  = "forbidden-opcode"
+	ruleReservedFunction = "reserved-function"
+	ruleDeployCheck      = "deploy-check"
+	ruleDeployGas        = "deploy-gas"
+)
+
+// staticContractCode answers the questions of the deploy-time checks from the parsed module,
+// the same way the chosen executor answers them from an instance.
+type staticContractCode struct {
+	module       *wasmbinary.Module
+	executorName string
+}
+
+var _ contexts.ContractCode = (*staticContractCode)(nil)
+
+// HasMemory mirrors the executors: Wasmer 2 always provides a memory, Wasmer 1 requires an exported one.
+func (code *staticContractCode) HasMemory() bool {
+	if code.executorName == executorWasmer2 {
+		return true
+	}
+	return len(code.module.MemoryExports) > 0
+}
+
+// GetFunctionNames returns the names of the exported functions, in alphabetical order.
+func (code *staticContractCode) GetFunctionNames() []string {
+	names := make([]string, 0, len(code.module.Exports))
+	for name := range code.module.Exports {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ValidateFunctionArities checks that no exported function has parameters or results.
+func (code *staticContractCode) ValidateFunctionArities() error {
+	for _, name := range code.GetFunctionNames() {
+		functionType, err := code.module.FunctionType(code.module.Exports[name])
+		if err != nil {
+			return err
+		}
+		if len(functionType.Params) > 0 || len(functionType.Results) > 0 {
+			return fmt.Errorf("%w: %s", executor.ErrFunctionNonvoidSignature, name)
+		}
+	}
+	return nil
+}
+
+// IsFunctionImported mirrors the executors: only Wasmer 1 reports the imported VM hooks.
+func (code *staticContractCode) IsFunctionImported(name string) bool {
+	if code.executorName == executorWasmer2 {
+		return false
+	}
+	for _, imported := range code.module.Imports {
+		if imported.Module == vmHooksModule && imported.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// checkContract runs all the deploy-time checks of the VM against a contract and collects the failed ones.
+func checkContract(code []byte, options *checkOptions) (*verdict, error) {
+	if options.executorName != executorWasmer1 && options.executorName != executorWasmer2 {
+		return nil, fmt.Errorf("unknown executor %s", options.executorName)
+	}
+
+	gasCost, err := config.CreateGasConfig(options.gasSchedule)
+	if err != nil {
+		return nil, err
+	}
+
+	codeSize := uint64(len(code))
+	result := &verdict{
+		Valid:        true,
+		Executor:     options.executorName,
+		EnabledFlags: sortedFlags(options.enabledFlags),
+		CodeSize:     codeSize,
+		DeployGas:    math.AddUint64(gasCost.BaseOpsAPICost.CreateContract, math.MulUint64(codeSize, gasCost.BaseOperationCost.CompilePerByte)),
+		CallGas:      math.AddUint64(gasCost.BaseOperationCost.GetCode, math.MulUint64(codeSize, gasCost.BaseOperationCost.AoTPreparePerByte)),
+		Problems:     make([]*problem, 0),
+	}
+	if options.gasLimit > 0 && result.DeployGas > options.gasLimit {
+		result.addProblem(ruleDeployGas, "deploying %d bytes costs %d gas, more than the gas limit of %d", codeSize, result.DeployGas, options.gasLimit)
+	}
+
+	module, err := wasmbinary.ParseModule(code)
+	if err != nil {
+		result.addProblem(ruleMalformedModule, "%s", err)
+		return result, nil
+	}
+
+	vmHookNames := wasmer2.VMHookNames()
+	checkImports(result, module, vmHookNames)
+	checkOpcodes(result, module)
+
+	builtInFuncContainer, err := createBuiltInFunctionContainer(options)
+	if err != nil {
+		return nil, err
+	}
+	checkReservedFunctions(result, module, vmHookNames, builtInFuncContainer)
+
+	contractCode := &staticContractCode{
+		module:       module,
+		executorName: options.executorName,
+	}
+	enableEpochsHandler := &worldmock.EnableEpochsHandlerStub{
+		IsFlagEnabledCalled: options.isFlagEnabled,
+	}
+	err = contexts.VerifyContractCode(contractCode, vmHookNames, builtInFuncContainer, enableEpochsHandler)
+	if err != nil {
+		result.addProblem(ruleDeployCheck, "%s", err)
+	}
+
+	return result, nil
+}
+
+func createBuiltInFunctionContainer(options *checkOptions) (vmcommon.BuiltInFunctionContainer, error) {
+	world := worldmock.NewMockWorld()
+	world.EnableEpochsHandler = &worldmock.EnableEpochsHandlerStub{
+		IsFlagEnabledCalled: options.isFlagEnabled,
+	}
+
+	err := world.InitBuiltinFunctions(options.gasSchedule)
+	if err != nil {
+		return nil, err
+	}
+	return world.BuiltinFuncs.Container, nil
+}
+
+// checkImports reports the VM hooks that no executor provides; a contract importing them cannot be instantiated.
+func checkImports(result *verdict, module *wasmbinary.Module, vmHookNames vmcommon.FunctionNames) {
+	for _, imported := range module.Imports {
+		if imported.Module != vmHooksModule {
+			result.addProblem(ruleUnknownVMHook, "%s.%s is imported from a module other than %s", imported.Module, imported.Name, vmHooksModule)
+			continue
+		}
+		if _, ok := vmHookNames[imported.Name]; !ok {
+			result.addProblem(ruleUnknownVMHook, "%s is not a VM hook", imported.Name)
+		}
+	}
+}
+
+// checkOpcodes reports the floating point instructions, which are not deterministic,
+// and the instructions of the proposals that the executors do not support.
+func checkOpcodes(result *verdict, module *wasmbinary.Module) {
+	numImports := uint32(len(module.Imports))
+	for i, body := range module.Functions {
+		funcIndex := numImports + uint32(i)
+		instructions, err := wasmbinary.DecodeFunctionBody(body)
+		var unsupportedOpcode *wasmbinary.UnsupportedOpcodeError
+		if errors.As(err, &unsupportedOpcode) {
+			result.addProblem(ruleForbiddenOpcode, "function %s: %s", module.FunctionName(funcIndex), err)
+		} else if err != nil {
+			result.addProblem(ruleMalformedModule, "function %s: %s", module.FunctionName(funcIndex), err)
+		}
+
+		for _, instruction := range instructions {
+			if isFloatingPointInstruction(instruction.Spec) {
+				result.addProblem(ruleForbiddenOpcode, "function %s: floating point instruction %s at offset 0x%x",
+					module.FunctionName(funcIndex), instruction.Spec.Name, instruction.Offset)
+			}
+		}
+	}
+}
+
+func isFloatingPointInstruction(spec *wasmbinary.InstructionSpec) bool {
+	return strings.Contains(spec.Name, "F32") || strings.Contains(spec.Name, "F64")
+}
+
+// checkReservedFunctions reports every exported function clashing with a VM hook, a built-in function
+// or an endpoint handled by the protocol, while the deploy-time check only reports the first one.
+func checkReservedFunctions(
+	result *verdict,
+	module *wasmbinary.Module,
+	vmHookNames vmcommon.FunctionNames,
+	builtInFuncContainer vmcommon.BuiltInFunctionContainer,
+) {
+	reserved := contexts.NewReservedFunctions(vmHookNames, builtInFuncContainer)
+	code := &staticContractCode{module: module}
+	for _, name := range code.GetFunctionNames() {
+		if reserved.IsReserved(name) {
+			result.addProblem(ruleReservedFunction, "the exported function %s is reserved", name)
+		}
+	}
+}
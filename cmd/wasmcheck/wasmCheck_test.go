@@ -0,0 +1,226 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/multiversx/mx-chain-vm-go/config"
+	"github.com/multiversx/mx-chain-vm-go/vmhost"
+	"github.com/multiversx/mx-chain-vm-go/wasmbinary"
+	"github.com/stretchr/testify/require"
+)
+
+// endCode is the body of a function doing nothing.
+var endCode = []byte{0x0b}
+
+// testFunction is a function of a test module; functions with results have the type () -> i32, the others () -> ().
+type testFunction struct {
+	export     string
+	withResult bool
+	code       []byte
+}
+
+// testModule describes a module importing VM hooks of type () -> ().
+type testModule struct {
+	imports      []string
+	functions    []testFunction
+	exportMemory bool
+}
+
+func (testModule *testModule) build() []byte {
+	builder := wasmbinary.NewModuleBuilder()
+	for _, name := range testModule.imports {
+		builder.AddImport(name, nil, nil)
+	}
+	for _, function := range testModule.functions {
+		var results []byte
+		if function.withResult {
+			results = []byte{wasmbinary.ValueTypeI32}
+		}
+		funcIndex := builder.AddFunction(nil, results, nil, function.code)
+		if len(function.export) > 0 {
+			builder.ExportFunction(function.export, funcIndex)
+		}
+	}
+	if testModule.exportMemory {
+		builder.AddMemory(1)
+		builder.ExportMemory("memory")
+	}
+	return builder.Build()
+}
+
+func makeTestOptions(executorName string, enabledFlags ...string) *checkOptions {
+	return &checkOptions{
+		executorName: executorName,
+		gasSchedule:  config.MakeGasMapForTests(),
+		enabledFlags: enabledFlags,
+	}
+}
+
+func checkTestModule(t *testing.T, module *testModule, options *checkOptions) *verdict {
+	result, err := checkContract(module.build(), options)
+	require.Nil(t, err)
+	return result
+}
+
+func problemRules(result *verdict) []string {
+	rules := make([]string, 0, len(result.Problems))
+	for _, problem := range result.Problems {
+		rules = append(rules, problem.Rule)
+	}
+	return rules
+}
+
+func TestWasmCheck_ValidContract(t *testing.T) {
+	module := &testModule{
+		imports: []string{"getNumArguments", "signalError"},
+		functions: []testFunction{
+			{export: "init", code: endCode},
+			{export: "doSomething", code: endCode},
+			{code: endCode},
+		},
+		exportMemory: true,
+	}
+
+	for _, executorName := range []string{executorWasmer1, executorWasmer2} {
+		result := checkTestModule(t, module, makeTestOptions(executorName))
+		require.True(t, result.Valid)
+		require.Empty(t, result.Problems)
+		require.Equal(t, executorName, result.Executor)
+	}
+}
+
+func TestWasmCheck_UnknownExecutor(t *testing.T) {
+	_, err := checkContract((&testModule{}).build(), makeTestOptions("wasmer3"))
+	require.NotNil(t, err)
+}
+
+func TestWasmCheck_MalformedModule(t *testing.T) {
+	result, err := checkContract([]byte("not wasm"), makeTestOptions(executorWasmer2))
+	require.Nil(t, err)
+	require.False(t, result.Valid)
+	require.Equal(t, []string{ruleMalformedModule}, problemRules(result))
+}
+
+func TestWasmCheck_UnknownVMHook(t *testing.T) {
+	result := checkTestModule(t, &testModule{
+		imports:   []string{"getNumArguments", "mintMoney"},
+		functions: []testFunction{{export: "init", code: endCode}},
+	}, makeTestOptions(executorWasmer2))
+	require.False(t, result.Valid)
+	require.Equal(t, []string{ruleUnknownVMHook}, problemRules(result))
+	require.Contains(t, result.Problems[0].Message, "mintMoney")
+}
+
+func TestWasmCheck_ForbiddenOpcodes(t *testing.T) {
+	result := checkTestModule(t, &testModule{
+		functions: []testFunction{
+			// f32.const 1.0; drop; end
+			{export: "float", code: []byte{0x43, 0x00, 0x00, 0x80, 0x3f, 0x1a, 0x0b}},
+			// v128.const ...; end
+			{export: "simd", code: []byte{0xfd, 0x0c, 0x0b}},
+			{export: "integers", code: []byte{0x41, 0x01, 0x1a, 0x0b}},
+		},
+	}, makeTestOptions(executorWasmer2))
+	require.False(t, result.Valid)
+	require.Equal(t, []string{ruleForbiddenOpcode, ruleForbiddenOpcode}, problemRules(result))
+	require.Contains(t, result.Problems[0].Message, "F32Const")
+	require.Contains(t, result.Problems[1].Message, "simd")
+}
+
+func TestWasmCheck_ReservedFunctions(t *testing.T) {
+	result := checkTestModule(t, &testModule{
+		functions: []testFunction{
+			{export: "init", code: endCode},
+			{export: "getArgument", code: endCode},
+			{export: vmhost.UpgradeFunctionName, code: endCode},
+		},
+	}, makeTestOptions(executorWasmer2))
+	require.False(t, result.Valid)
+	require.Equal(t, []string{ruleReservedFunction, ruleReservedFunction, ruleDeployCheck}, problemRules(result))
+}
+
+func TestWasmCheck_NonvoidEndpoint(t *testing.T) {
+	result := checkTestModule(t, &testModule{
+		functions: []testFunction{
+			// i32.const 0; end
+			{export: "getValue", withResult: true, code: []byte{0x41, 0x00, 0x0b}},
+		},
+	}, makeTestOptions(executorWasmer2))
+	require.False(t, result.Valid)
+	require.Equal(t, []string{ruleDeployCheck}, problemRules(result))
+	require.Contains(t, result.Problems[0].Message, "getValue")
+}
+
+func TestWasmCheck_MemoryDependsOnExecutor(t *testing.T) {
+	module := &testModule{
+		functions: []testFunction{{export: "init", code: endCode}},
+	}
+
+	result := checkTestModule(t, module, makeTestOptions(executorWasmer2))
+	require.True(t, result.Valid)
+
+	result = checkTestModule(t, module, makeTestOptions(executorWasmer1))
+	require.False(t, result.Valid)
+	require.Equal(t, []string{ruleDeployCheck}, problemRules(result))
+}
+
+func TestWasmCheck_NewCryptoAPIDependsOnFlag(t *testing.T) {
+	module := &testModule{
+		imports:      []string{"managedVerifySecp256r1"},
+		functions:    []testFunction{{export: "init", code: endCode}},
+		exportMemory: true,
+	}
+
+	result := checkTestModule(t, module, makeTestOptions(executorWasmer1))
+	require.False(t, result.Valid)
+	require.Equal(t, []string{ruleDeployCheck}, problemRules(result))
+
+	result = checkTestModule(t, module, makeTestOptions(executorWasmer1, string(vmhost.CryptoOpcodesV2Flag)))
+	require.True(t, result.Valid)
+}
+
+func TestWasmCheck_DeployGas(t *testing.T) {
+	module := &testModule{
+		functions: []testFunction{{export: "init", code: endCode}},
+	}
+	code := module.build()
+	gasSchedule := config.MakeGasMapForTests()
+	expectedDeployGas := gasSchedule["BaseOpsAPICost"]["CreateContract"] + uint64(len(code))*gasSchedule["BaseOperationCost"]["CompilePerByte"]
+	expectedCallGas := gasSchedule["BaseOperationCost"]["GetCode"] + uint64(len(code))*gasSchedule["BaseOperationCost"]["AoTPreparePerByte"]
+
+	options := makeTestOptions(executorWasmer2)
+	options.gasLimit = expectedDeployGas
+	result, err := checkContract(code, options)
+	require.Nil(t, err)
+	require.True(t, result.Valid)
+	require.Equal(t, uint64(len(code)), result.CodeSize)
+	require.Equal(t, expectedDeployGas, result.DeployGas)
+	require.Equal(t, expectedCallGas, result.CallGas)
+
+	options.gasLimit = expectedDeployGas - 1
+	result, err = checkContract(code, options)
+	require.Nil(t, err)
+	require.False(t, result.Valid)
+	require.Equal(t, []string{ruleDeployGas}, problemRules(result))
+}
+
+func TestWasmCheck_VerdictIsJSON(t *testing.T) {
+	result := checkTestModule(t, &testModule{
+		imports:   []string{"mintMoney"},
+		functions: []testFunction{{export: "init", code: endCode}},
+	}, makeTestOptions(executorWasmer2, "B", "A"))
+
+	output := bytes.NewBuffer(nil)
+	require.Nil(t, writeVerdict(output, result))
+
+	decoded := make(map[string]interface{})
+	require.Nil(t, json.Unmarshal(output.Bytes(), &decoded))
+	require.Equal(t, false, decoded["valid"])
+	require.Equal(t, executorWasmer2, decoded["executor"])
+	require.Equal(t, []interface{}{"A", "B"}, decoded["enabledFlags"])
+	problems := decoded["problems"].([]interface{})
+	require.Len(t, problems, 1)
+	require.Equal(t, ruleUnknownVMHook, problems[0].(map[string]interface{})["rule"])
+}
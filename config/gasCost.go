@@ -10,6 +10,7 @@ type GasCost struct {
 	BaseOperationCost    BaseOperationCost
 	BigIntAPICost        BigIntAPICost
 	BigFloatAPICost      BigFloatAPICost
+	DecimalAPICost       DecimalAPICost
 	BaseOpsAPICost       BaseOpsAPICost
 	ManagedBufferAPICost ManagedBufferAPICost
 	ManagedMapAPICost    ManagedMapAPICost
@@ -27,6 +28,7 @@ type BaseOperationCost struct {
 	CompilePerByte    uint64
 	AoTPreparePerByte uint64
 	GetCode           uint64
+	MemoryGrowPerPage uint64
 }
 
 // BaseOpsAPICost defines the API operations gas cost config structure
@@ -82,7 +84,19 @@ type BaseOpsAPICost struct {
 	DeleteFromReturnData    uint64
 	GetCodeMetadata         uint64
 	IsBuiltinFunction       uint64
-	IsReservedFunctionName 	uint64
+	IsReservedFunctionName  uint64
+	TransientStore          uint64
+	TransientLoad           uint64
+	GetStorageDeposit       uint64
+	GetMemoryUsage          uint64
+	SetUpgradePolicy        uint64
+	ProposeUpgrade          uint64
+	ApproveUpgrade          uint64
+	CancelUpgrade           uint64
+	ComputeContractAddress  uint64
+	GetCodeHash             uint64
+	GetCodeDeployer         uint64
+	GetContractDeployInfo   uint64
 }
 
 // DynamicStorageLoadCostCoefficients holds the signed coefficients of the func that will compute the gas cost
@@ -109,45 +123,50 @@ type DynamicStorageLoadUnsigned struct {
 
 // BigIntAPICost defines the big int operations gas cost config structure
 type BigIntAPICost struct {
-	BigIntNew                  uint64
-	BigIntUnsignedByteLength   uint64
-	BigIntSignedByteLength     uint64
-	BigIntGetUnsignedBytes     uint64
-	BigIntGetSignedBytes       uint64
-	BigIntSetUnsignedBytes     uint64
-	BigIntSetSignedBytes       uint64
-	BigIntIsInt64              uint64
-	BigIntGetInt64             uint64
-	BigIntSetInt64             uint64
-	BigIntAdd                  uint64
-	BigIntSub                  uint64
-	BigIntMul                  uint64
-	BigIntSqrt                 uint64
-	BigIntPow                  uint64
-	BigIntLog                  uint64
-	BigIntTDiv                 uint64
-	BigIntTMod                 uint64
-	BigIntEDiv                 uint64
-	BigIntEMod                 uint64
-	BigIntAbs                  uint64
-	BigIntNeg                  uint64
-	BigIntSign                 uint64
-	BigIntCmp                  uint64
-	BigIntNot                  uint64
-	BigIntAnd                  uint64
-	BigIntOr                   uint64
-	BigIntXor                  uint64
-	BigIntShr                  uint64
-	BigIntShl                  uint64
-	BigIntFinishUnsigned       uint64
-	BigIntFinishSigned         uint64
-	BigIntStorageLoadUnsigned  uint64
-	BigIntStorageStoreUnsigned uint64
-	BigIntGetUnsignedArgument  uint64
-	BigIntGetSignedArgument    uint64
-	BigIntGetCallValue         uint64
-	BigIntGetExternalBalance   uint64
-	CopyPerByteForTooBig       uint64
+	BigIntNew                       uint64
+	BigIntUnsignedByteLength        uint64
+	BigIntSignedByteLength          uint64
+	BigIntGetUnsignedBytes          uint64
+	BigIntGetSignedBytes            uint64
+	BigIntSetUnsignedBytes          uint64
+	BigIntSetSignedBytes            uint64
+	BigIntIsInt64                   uint64
+	BigIntGetInt64                  uint64
+	BigIntSetInt64                  uint64
+	BigIntAdd                       uint64
+	BigIntSub                       uint64
+	BigIntMul                       uint64
+	BigIntSqrt                      uint64
+	BigIntPow                       uint64
+	BigIntLog                       uint64
+	BigIntTDiv                      uint64
+	BigIntTMod                      uint64
+	BigIntEDiv                      uint64
+	BigIntEMod                      uint64
+	BigIntAbs                       uint64
+	BigIntNeg                       uint64
+	BigIntSign                      uint64
+	BigIntCmp                       uint64
+	BigIntNot                       uint64
+	BigIntAnd                       uint64
+	BigIntOr                        uint64
+	BigIntXor                       uint64
+	BigIntShr                       uint64
+	BigIntShl                       uint64
+	BigIntFinishUnsigned            uint64
+	BigIntFinishSigned              uint64
+	BigIntStorageLoadUnsigned       uint64
+	BigIntStorageStoreUnsigned      uint64
+	BigIntGetUnsignedArgument       uint64
+	BigIntGetSignedArgument         uint64
+	BigIntGetCallValue              uint64
+	BigIntGetExternalBalance        uint64
+	BigIntModPow                    uint64
+	BigIntModInverse                uint64
+	BigIntMulMod                    uint64
+	BigIntGCD                       uint64
+	ModularArithmeticPerWordSquared uint64
+	CopyPerByteForTooBig            uint64
 }
 
 // BigFloatAPICost defines the big float operations gas cost config structure
@@ -172,28 +191,46 @@ type BigFloatAPICost struct {
 	BigFloatGetConst     uint64
 }
 
+// DecimalAPICost defines the fixed-point decimal operations gas cost config structure
+type DecimalAPICost struct {
+	DecimalNew              uint64
+	DecimalFromBigInt       uint64
+	DecimalToBigInt         uint64
+	DecimalRescale          uint64
+	DecimalAdd              uint64
+	DecimalSub              uint64
+	DecimalMul              uint64
+	DecimalDiv              uint64
+	DecimalCmp              uint64
+	DecimalLn               uint64
+	DecimalExp              uint64
+	DecimalLnPerScaleDigit  uint64
+	DecimalExpPerScaleDigit uint64
+}
+
 // CryptoAPICost defines the crypto operations gas cost config structure
 type CryptoAPICost struct {
-	SHA256                  uint64
-	Keccak256               uint64
-	Ripemd160               uint64
-	VerifyBLS               uint64
-	VerifyEd25519           uint64
-	VerifySecp256k1         uint64
-	EllipticCurveNew        uint64
-	AddECC                  uint64
-	DoubleECC               uint64
-	IsOnCurveECC            uint64
-	ScalarMultECC           uint64
-	MarshalECC              uint64
-	MarshalCompressedECC    uint64
-	UnmarshalECC            uint64
-	UnmarshalCompressedECC  uint64
-	GenerateKeyECC          uint64
-	EncodeDERSig            uint64
-	VerifySecp256r1         uint64
-	VerifyBLSSignatureShare uint64
-	VerifyBLSMultiSig       uint64
+	SHA256                     uint64
+	Keccak256                  uint64
+	Ripemd160                  uint64
+	VerifyBLS                  uint64
+	VerifyEd25519              uint64
+	VerifySecp256k1            uint64
+	EllipticCurveNew           uint64
+	AddECC                     uint64
+	DoubleECC                  uint64
+	IsOnCurveECC               uint64
+	ScalarMultECC              uint64
+	MarshalECC                 uint64
+	MarshalCompressedECC       uint64
+	UnmarshalECC               uint64
+	UnmarshalCompressedECC     uint64
+	GenerateKeyECC             uint64
+	EncodeDERSig               uint64
+	VerifySecp256r1            uint64
+	VerifyBLSSignatureShare    uint64
+	VerifyBLSMultiSig          uint64
+	ManagedEVMFunctionSelector uint64
 }
 
 // ManagedBufferAPICost defines the managed buffer operations gas cost config structure
@@ -213,11 +250,15 @@ type ManagedBufferAPICost struct {
 	MBufferFromBigIntSigned   uint64
 	MBufferToBigFloat         uint64
 	MBufferFromBigFloat       uint64
+	MBufferToDecimal          uint64
+	MBufferFromDecimal        uint64
 	MBufferStorageStore       uint64
 	MBufferStorageLoad        uint64
 	MBufferGetArgument        uint64
 	MBufferFinish             uint64
 	MBufferSetRandom          uint64
+	ManagedEVMAbiEncode       uint64
+	ManagedEVMAbiDecode       uint64
 }
 
 // ManagedMapAPICost defines the managed map operations gas cost config structure
@@ -57,6 +57,17 @@ func CreateGasConfig(gasMap GasScheduleMap) (*GasCost, error) {
 		return nil, err
 	}
 
+	decimalOps := &DecimalAPICost{}
+	err = mapstructure.Decode(gasMap["DecimalAPICost"], decimalOps)
+	if err != nil {
+		return nil, err
+	}
+
+	err = checkForZeroUint64Fields(*decimalOps)
+	if err != nil {
+		return nil, err
+	}
+
 	bigIntOps := &BigIntAPICost{}
 	err = mapstructure.Decode(gasMap["BigIntAPICost"], bigIntOps)
 	if err != nil {
@@ -118,6 +129,7 @@ func CreateGasConfig(gasMap GasScheduleMap) (*GasCost, error) {
 		BaseOperationCost:    *baseOps,
 		BigIntAPICost:        *bigIntOps,
 		BigFloatAPICost:      *bigFloatOps,
+		DecimalAPICost:       *decimalOps,
 		BaseOpsAPICost:       *baseOpsAPI,
 		CryptoAPICost:        *cryptOps,
 		ManagedBufferAPICost: *MBufferOps,
@@ -171,6 +183,16 @@ func getSignedCoefficient(coefficient uint64, sign uint64) int64 {
 }
 
 func checkForZeroUint64Fields(arg interface{}) error {
+	zeroFields := findZeroUint64Fields(arg)
+	if len(zeroFields) > 0 {
+		return fmt.Errorf("gas cost for operation %s has been set to 0 or is not set", zeroFields[0])
+	}
+
+	return nil
+}
+
+func findZeroUint64Fields(arg interface{}) []string {
+	zeroFields := make([]string, 0)
 	v := reflect.ValueOf(arg)
 	for i := 0; i < v.NumField(); i++ {
 		field := v.Field(i)
@@ -178,12 +200,11 @@ func checkForZeroUint64Fields(arg interface{}) error {
 			continue
 		}
 		if field.Uint() == 0 {
-			name := v.Type().Field(i).Name
-			return fmt.Errorf("gas cost for operation %s has been set to 0 or is not set", name)
+			zeroFields = append(zeroFields, v.Type().Field(i).Name)
 		}
 	}
 
-	return nil
+	return zeroFields
 }
 
 // MakeGasMap creates a new GasScheduleMap instance
@@ -202,6 +223,7 @@ func FillGasMap(gasMap GasScheduleMap, value, asyncCallbackGasLock uint64) GasSc
 	gasMap["EthAPICost"] = FillGasMapEthereumAPICosts(value)
 	gasMap["BigIntAPICost"] = FillGasMapBigIntAPICosts(value)
 	gasMap["BigFloatAPICost"] = FillGasMapBigFloatAPICosts(value)
+	gasMap["DecimalAPICost"] = FillGasMapDecimalAPICosts(value)
 	gasMap["CryptoAPICost"] = FillGasMapCryptoAPICosts(value)
 	gasMap["ManagedBufferAPICost"] = FillGasMapManagedBufferAPICosts(value)
 	gasMap["WASMOpcodeCost"] = FillGasMapWASMOpcodeValues(value)
@@ -255,6 +277,7 @@ func FillGasMapBaseOperationCosts(value uint64) map[string]uint64 {
 	gasMap["CompilePerByte"] = value
 	gasMap["AoTPreparePerByte"] = value
 	gasMap["GetCode"] = value
+	gasMap["MemoryGrowPerPage"] = value
 
 	return gasMap
 }
@@ -314,6 +337,18 @@ func FillGasMapBaseOpsAPICosts(value, asyncCallbackGasLock uint64) map[string]ui
 	gasMap["GetCodeMetadata"] = value
 	gasMap["IsBuiltinFunction"] = value
 	gasMap["IsReservedFunctionName"] = value
+	gasMap["TransientStore"] = value
+	gasMap["TransientLoad"] = value
+	gasMap["GetStorageDeposit"] = value
+	gasMap["GetMemoryUsage"] = value
+	gasMap["SetUpgradePolicy"] = value
+	gasMap["ProposeUpgrade"] = value
+	gasMap["ApproveUpgrade"] = value
+	gasMap["CancelUpgrade"] = value
+	gasMap["ComputeContractAddress"] = value
+	gasMap["GetCodeHash"] = value
+	gasMap["GetCodeDeployer"] = value
+	gasMap["GetContractDeployInfo"] = value
 
 	return gasMap
 }
@@ -400,6 +435,11 @@ func FillGasMapBigIntAPICosts(value uint64) map[string]uint64 {
 	gasMap["BigIntGetCallValue"] = value
 	gasMap["BigIntGetExternalBalance"] = value
 	gasMap["CopyPerByteForTooBig"] = value
+	gasMap["BigIntModPow"] = value
+	gasMap["BigIntModInverse"] = value
+	gasMap["BigIntMulMod"] = value
+	gasMap["BigIntGCD"] = value
+	gasMap["ModularArithmeticPerWordSquared"] = value
 
 	return gasMap
 }
@@ -429,6 +469,26 @@ func FillGasMapBigFloatAPICosts(value uint64) map[string]uint64 {
 	return gasMap
 }
 
+// FillGasMapDecimalAPICosts fills the fixed-point decimals costs
+func FillGasMapDecimalAPICosts(value uint64) map[string]uint64 {
+	gasMap := make(map[string]uint64)
+	gasMap["DecimalNew"] = value
+	gasMap["DecimalFromBigInt"] = value
+	gasMap["DecimalToBigInt"] = value
+	gasMap["DecimalRescale"] = value
+	gasMap["DecimalAdd"] = value
+	gasMap["DecimalSub"] = value
+	gasMap["DecimalMul"] = value
+	gasMap["DecimalDiv"] = value
+	gasMap["DecimalCmp"] = value
+	gasMap["DecimalLn"] = value
+	gasMap["DecimalExp"] = value
+	gasMap["DecimalLnPerScaleDigit"] = value
+	gasMap["DecimalExpPerScaleDigit"] = value
+
+	return gasMap
+}
+
 // FillGasMapCryptoAPICosts fills the crypto costs
 func FillGasMapCryptoAPICosts(value uint64) map[string]uint64 {
 	gasMap := make(map[string]uint64)
@@ -452,6 +512,7 @@ func FillGasMapCryptoAPICosts(value uint64) map[string]uint64 {
 	gasMap["VerifySecp256r1"] = value
 	gasMap["VerifyBLSSignatureShare"] = value
 	gasMap["VerifyBLSMultiSig"] = value
+	gasMap["ManagedEVMFunctionSelector"] = value
 
 	return gasMap
 }
@@ -474,11 +535,15 @@ func FillGasMapManagedBufferAPICosts(value uint64) map[string]uint64 {
 	gasMap["MBufferFromBigIntSigned"] = value
 	gasMap["MBufferToBigFloat"] = value
 	gasMap["MBufferFromBigFloat"] = value
+	gasMap["MBufferToDecimal"] = value
+	gasMap["MBufferFromDecimal"] = value
 	gasMap["MBufferStorageStore"] = value
 	gasMap["MBufferStorageLoad"] = value
 	gasMap["MBufferGetArgument"] = value
 	gasMap["MBufferFinish"] = value
 	gasMap["MBufferSetRandom"] = value
+	gasMap["ManagedEVMAbiEncode"] = value
+	gasMap["ManagedEVMAbiDecode"] = value
 
 	return gasMap
 }
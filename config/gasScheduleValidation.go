@@ -0,0 +1,128 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/multiversx/mx-chain-vm-go/executor"
+)
+
+const dynamicStorageLoadSection = "DynamicStorageLoad"
+
+// GasScheduleIssue describes a problem found while validating a gas schedule.
+// Warnings do not prevent CreateGasConfig from accepting the gas schedule.
+type GasScheduleIssue struct {
+	Section   string
+	Key       string
+	Message   string
+	IsWarning bool
+}
+
+// String returns a human-readable description of the issue
+func (issue *GasScheduleIssue) String() string {
+	location := issue.Section
+	if len(issue.Key) > 0 {
+		location += "." + issue.Key
+	}
+	severity := "error"
+	if issue.IsWarning {
+		severity = "warning"
+	}
+
+	return fmt.Sprintf("%s: %s: %s", severity, location, issue.Message)
+}
+
+// gasCostSections returns, for each section checked by CreateGasConfig, an empty instance of the structure it is decoded into
+func gasCostSections() map[string]interface{} {
+	return map[string]interface{}{
+		"BaseOperationCost":    &BaseOperationCost{},
+		"BaseOpsAPICost":       &BaseOpsAPICost{},
+		"BigFloatAPICost":      &BigFloatAPICost{},
+		"DecimalAPICost":       &DecimalAPICost{},
+		"BigIntAPICost":        &BigIntAPICost{},
+		"CryptoAPICost":        &CryptoAPICost{},
+		"ManagedBufferAPICost": &ManagedBufferAPICost{},
+		"WASMOpcodeCost":       &executor.WASMOpcodeCost{},
+	}
+}
+
+// ValidateGasSchedule performs the same checks as CreateGasConfig, but reports every problem instead of
+// stopping at the first one. Keys which are not used by the VM are reported as warnings.
+func ValidateGasSchedule(gasMap GasScheduleMap) []*GasScheduleIssue {
+	issues := make([]*GasScheduleIssue, 0)
+
+	for section, costs := range gasCostSections() {
+		sectionValues, ok := gasMap[section]
+		if !ok {
+			issues = append(issues, &GasScheduleIssue{Section: section, Message: "section is missing"})
+			continue
+		}
+
+		metadata := &mapstructure.Metadata{}
+		err := decodeWithMetadata(sectionValues, costs, metadata)
+		if err != nil {
+			issues = append(issues, &GasScheduleIssue{Section: section, Message: err.Error()})
+			continue
+		}
+
+		for _, field := range findZeroUint64Fields(reflect.ValueOf(costs).Elem().Interface()) {
+			issues = append(issues, &GasScheduleIssue{
+				Section: section,
+				Key:     field,
+				Message: "gas cost has been set to 0 or is not set",
+			})
+		}
+		for _, unused := range metadata.Unused {
+			issues = append(issues, &GasScheduleIssue{
+				Section:   section,
+				Key:       unused,
+				Message:   "key is not used by the VM",
+				IsWarning: true,
+			})
+		}
+	}
+
+	issues = append(issues, validateDynamicStorageLoad(gasMap)...)
+	sort.SliceStable(issues, func(i, j int) bool {
+		if issues[i].Section != issues[j].Section {
+			return issues[i].Section < issues[j].Section
+		}
+		return issues[i].Key < issues[j].Key
+	})
+
+	return issues
+}
+
+func validateDynamicStorageLoad(gasMap GasScheduleMap) []*GasScheduleIssue {
+	dynamicStorageLoadUnsigned := &DynamicStorageLoadUnsigned{}
+	err := mapstructure.Decode(gasMap[dynamicStorageLoadSection], dynamicStorageLoadUnsigned)
+	if err != nil {
+		return []*GasScheduleIssue{{Section: dynamicStorageLoadSection, Message: err.Error()}}
+	}
+
+	parameters := convertFromUnsignedToSigned(dynamicStorageLoadUnsigned)
+	if isDynamicGasComputationFuncCorrectlyDefined(parameters) {
+		return nil
+	}
+
+	return []*GasScheduleIssue{{
+		Section: dynamicStorageLoadSection,
+		Message: fmt.Sprintf("dynamic gas computation func incorrectly defined, "+
+			"quadratic parameter = %d, linear parameter = %d, constant parameter = %d",
+			parameters.Quadratic, parameters.Linear, parameters.Constant),
+	}}
+}
+
+func decodeWithMetadata(input interface{}, output interface{}, metadata *mapstructure.Metadata) error {
+	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		Metadata: metadata,
+		Result:   output,
+	})
+	if err != nil {
+		return err
+	}
+
+	return decoder.Decode(input)
+}
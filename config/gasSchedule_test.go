@@ -172,3 +172,26 @@ func Test_isDynamicGasComputationFuncCorrectlyDefined(t *testing.T) {
 		assert.True(t, ok)
 	})
 }
+
+func TestValidateGasSchedule(t *testing.T) {
+	gasMap := MakeGasMapForTests()
+	assert.Empty(t, ValidateGasSchedule(gasMap))
+
+	delete(gasMap["BigIntAPICost"], "BigIntAdd")
+	gasMap["BaseOpsAPICost"]["GetSCAddress"] = 0
+	gasMap["BaseOpsAPICost"]["NotAnOperation"] = 5
+	delete(gasMap, "CryptoAPICost")
+	gasMap["DynamicStorageLoad"]["QuadraticCoefficient"] = 0
+
+	issues := ValidateGasSchedule(gasMap)
+	assert.Len(t, issues, 5)
+	assert.Equal(t, "error: BaseOpsAPICost.GetSCAddress: gas cost has been set to 0 or is not set", issues[0].String())
+	assert.Equal(t, "warning: BaseOpsAPICost.NotAnOperation: key is not used by the VM", issues[1].String())
+	assert.Equal(t, "error: BigIntAPICost.BigIntAdd: gas cost has been set to 0 or is not set", issues[2].String())
+	assert.Equal(t, "error: CryptoAPICost: section is missing", issues[3].String())
+	assert.Equal(t, "DynamicStorageLoad", issues[4].Section)
+	assert.False(t, issues[4].IsWarning)
+
+	_, err := CreateGasConfig(gasMap)
+	assert.NotNil(t, err)
+}
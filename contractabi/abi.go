@@ -0,0 +1,270 @@
+package contractabi
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// ConstructorName is the endpoint called when the contract is deployed.
+const ConstructorName = "init"
+
+// UpgradeConstructorName is the endpoint called when the contract is upgraded.
+const UpgradeConstructorName = "upgrade"
+
+// The types spreading over several arguments or results, as written in the ABI JSON.
+const (
+	typeOptional        = "optional"
+	typeVariadic        = "variadic"
+	typeCountedVariadic = "counted-variadic"
+	typeMulti           = "multi"
+)
+
+// Param is an input or an output of an endpoint, or an input of an event.
+type Param struct {
+	Name    string `json:"name"`
+	Type    string `json:"type"`
+	Indexed bool   `json:"indexed,omitempty"`
+}
+
+// Endpoint is a function of the contract which can be called by transactions and by other contracts.
+type Endpoint struct {
+	Name       string   `json:"name"`
+	Mutability string   `json:"mutability"`
+	Inputs     []*Param `json:"inputs"`
+	Outputs    []*Param `json:"outputs"`
+}
+
+// Event is a log entry which the contract can write, identified by its first topic.
+type Event struct {
+	Identifier string   `json:"identifier"`
+	Inputs     []*Param `json:"inputs"`
+}
+
+// ABI is the description of a contract produced by the contract framework, as embedded in the SectionName custom section.
+// Only the parts needed to check calls and to decode values are kept; the custom types are decoded as raw bytes.
+type ABI struct {
+	Name               string      `json:"name"`
+	Constructor        *Endpoint   `json:"constructor"`
+	UpgradeConstructor *Endpoint   `json:"upgradeConstructor"`
+	Endpoints          []*Endpoint `json:"endpoints"`
+	Events             []*Event    `json:"events"`
+
+	endpointsByName map[string]*Endpoint
+	eventsByName    map[string]*Event
+}
+
+// Parse reads an ABI JSON and checks that its endpoints and events are well formed.
+func Parse(data []byte) (*ABI, error) {
+	abi := &ABI{}
+	err := json.Unmarshal(data, abi)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidABI, err)
+	}
+
+	abi.endpointsByName = make(map[string]*Endpoint)
+	if abi.Constructor != nil {
+		abi.Constructor.Name = ConstructorName
+		abi.endpointsByName[ConstructorName] = abi.Constructor
+	}
+	if abi.UpgradeConstructor != nil {
+		abi.UpgradeConstructor.Name = UpgradeConstructorName
+		abi.endpointsByName[UpgradeConstructorName] = abi.UpgradeConstructor
+	}
+	for _, endpoint := range abi.Endpoints {
+		if endpoint == nil || len(endpoint.Name) == 0 {
+			return nil, fmt.Errorf("%w: endpoint without name", ErrInvalidABI)
+		}
+		abi.endpointsByName[endpoint.Name] = endpoint
+	}
+	for _, endpoint := range abi.endpointsByName {
+		err = checkParamTypes(endpoint.Inputs)
+		if err == nil {
+			err = checkParamTypes(endpoint.Outputs)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: endpoint %s: %s", ErrInvalidABI, endpoint.Name, err)
+		}
+	}
+
+	abi.eventsByName = make(map[string]*Event)
+	for _, event := range abi.Events {
+		if event == nil || len(event.Identifier) == 0 {
+			return nil, fmt.Errorf("%w: event without identifier", ErrInvalidABI)
+		}
+		err = checkParamTypes(event.Inputs)
+		if err != nil {
+			return nil, fmt.Errorf("%w: event %s: %s", ErrInvalidABI, event.Identifier, err)
+		}
+		abi.eventsByName[event.Identifier] = event
+	}
+
+	return abi, nil
+}
+
+// Endpoint returns the endpoint with the given function name, including the constructors, or nil if the ABI does not describe it.
+func (abi *ABI) Endpoint(functionName string) *Endpoint {
+	return abi.endpointsByName[functionName]
+}
+
+// Event returns the event with the given identifier, or nil if the ABI does not describe it.
+func (abi *ABI) Event(identifier string) *Event {
+	return abi.eventsByName[identifier]
+}
+
+// CheckArgumentCount verifies that the endpoint can be called with the given number of arguments,
+// considering that optional, variadic and multi-value inputs spread over several arguments.
+func (endpoint *Endpoint) CheckArgumentCount(numArgs int) error {
+	arity := paramsArity(endpoint.Inputs)
+	if numArgs < arity.min || (!arity.unbounded && numArgs > arity.max) {
+		return fmt.Errorf("%w for endpoint %s: expected %s, received %d", ErrArgumentCountMismatch, endpoint.Name, arity, numArgs)
+	}
+	return nil
+}
+
+// arity is the range of the number of encoded values taken by one or more params.
+type arity struct {
+	min       int
+	max       int
+	unbounded bool
+}
+
+func (a arity) add(other arity) arity {
+	return arity{
+		min:       a.min + other.min,
+		max:       a.max + other.max,
+		unbounded: a.unbounded || other.unbounded,
+	}
+}
+
+// String describes the range as in the error messages.
+func (a arity) String() string {
+	if a.unbounded {
+		return fmt.Sprintf("at least %d", a.min)
+	}
+	if a.min == a.max {
+		return fmt.Sprintf("%d", a.min)
+	}
+	return fmt.Sprintf("%d to %d", a.min, a.max)
+}
+
+func paramsArity(params []*Param) arity {
+	total := arity{}
+	for _, param := range params {
+		total = total.add(typeArity(param.Type))
+	}
+	return total
+}
+
+func typeArity(typ string) arity {
+	base, args, err := splitType(typ)
+	if err != nil || (len(args) == 0 && base != typeMulti) {
+		return arity{min: 1, max: 1}
+	}
+
+	switch base {
+	case typeOptional:
+		inner := typeArity(args[0])
+		return arity{min: 0, max: inner.max, unbounded: inner.unbounded}
+	case typeVariadic:
+		return arity{unbounded: true}
+	case typeCountedVariadic:
+		return arity{min: 1, max: 1, unbounded: true}
+	case typeMulti:
+		total := arity{}
+		for _, arg := range args {
+			total = total.add(typeArity(arg))
+		}
+		return total
+	default:
+		return arity{min: 1, max: 1}
+	}
+}
+
+func checkParamTypes(params []*Param) error {
+	for _, param := range params {
+		if param == nil {
+			return fmt.Errorf("missing param")
+		}
+		err := checkType(param.Type)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func checkType(typ string) error {
+	base, args, err := splitType(typ)
+	if err != nil {
+		return err
+	}
+
+	switch base {
+	case typeOptional, typeVariadic, typeCountedVariadic:
+		if len(args) != 1 {
+			return fmt.Errorf("type %s takes one type argument", typ)
+		}
+	case typeMulti:
+		if len(args) == 0 {
+			return fmt.Errorf("type %s takes type arguments", typ)
+		}
+	}
+	for _, arg := range args {
+		err = checkType(arg)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// splitType separates a type such as "multi<u32,List<u8>>" into its name and its top-level type arguments.
+func splitType(typ string) (string, []string, error) {
+	typ = strings.TrimSpace(typ)
+	if len(typ) == 0 {
+		return "", nil, fmt.Errorf("empty type")
+	}
+
+	open := strings.IndexByte(typ, '<')
+	if open < 0 {
+		if strings.ContainsAny(typ, ">,") {
+			return "", nil, fmt.Errorf("malformed type %s", typ)
+		}
+		return typ, nil, nil
+	}
+	if typ[len(typ)-1] != '>' {
+		return "", nil, fmt.Errorf("malformed type %s", typ)
+	}
+
+	args := make([]string, 0)
+	depth := 0
+	argStart := open + 1
+	for i := open + 1; i < len(typ)-1; i++ {
+		switch typ[i] {
+		case '<':
+			depth++
+		case '>':
+			depth--
+			if depth < 0 {
+				return "", nil, fmt.Errorf("malformed type %s", typ)
+			}
+		case ',':
+			if depth == 0 {
+				args = append(args, strings.TrimSpace(typ[argStart:i]))
+				argStart = i + 1
+			}
+		}
+	}
+	if depth != 0 {
+		return "", nil, fmt.Errorf("malformed type %s", typ)
+	}
+	args = append(args, strings.TrimSpace(typ[argStart:len(typ)-1]))
+	for _, arg := range args {
+		if len(arg) == 0 {
+			return "", nil, fmt.Errorf("malformed type %s", typ)
+		}
+	}
+
+	return typ[:open], args, nil
+}
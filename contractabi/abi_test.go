@@ -0,0 +1,125 @@
+package contractabi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testABIJSON = `{
+	"buildInfo": {"framework": {"name": "multiversx-sc", "version": "0.50.0"}},
+	"name": "Adder",
+	"constructor": {"inputs": [{"name": "initial_value", "type": "BigUint"}], "outputs": []},
+	"upgradeConstructor": {"inputs": [], "outputs": []},
+	"endpoints": [
+		{"name": "getSum", "mutability": "readonly", "inputs": [], "outputs": [{"type": "BigUint"}]},
+		{"name": "add", "mutability": "mutable", "inputs": [{"name": "value", "type": "BigUint"}], "outputs": []},
+		{"name": "setLimits", "mutability": "mutable", "inputs": [
+			{"name": "token", "type": "TokenIdentifier"},
+			{"name": "limits", "type": "multi<u64,u64>"},
+			{"name": "owner", "type": "optional<Address>"}
+		], "outputs": []},
+		{"name": "addMany", "mutability": "mutable", "inputs": [
+			{"name": "values", "type": "variadic<multi<u32,bool>>", "multi_arg": true}
+		], "outputs": [{"type": "variadic<i64>", "multi_result": true}]}
+	],
+	"events": [
+		{"identifier": "added", "inputs": [
+			{"name": "caller", "type": "Address", "indexed": true},
+			{"name": "value", "type": "BigUint", "indexed": true},
+			{"name": "note", "type": "utf-8 string"}
+		]}
+	],
+	"types": {}
+}`
+
+var emptyModule = []byte{0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00}
+
+func parseTestABI(t *testing.T) *ABI {
+	abi, err := Parse([]byte(testABIJSON))
+	require.Nil(t, err)
+	return abi
+}
+
+func TestParse(t *testing.T) {
+	abi := parseTestABI(t)
+	require.Equal(t, "Adder", abi.Name)
+	require.Len(t, abi.Endpoints, 4)
+
+	require.Equal(t, ConstructorName, abi.Endpoint(ConstructorName).Name)
+	require.Equal(t, UpgradeConstructorName, abi.Endpoint(UpgradeConstructorName).Name)
+	require.Equal(t, "readonly", abi.Endpoint("getSum").Mutability)
+	require.Nil(t, abi.Endpoint("missing"))
+	require.NotNil(t, abi.Event("added"))
+	require.Nil(t, abi.Event("missing"))
+}
+
+func TestParse_Invalid(t *testing.T) {
+	invalid := []string{
+		`not json`,
+		`{"endpoints": [{"inputs": []}]}`,
+		`{"endpoints": [{"name": "f", "inputs": [{"name": "a", "type": "optional<u8"}]}]}`,
+		`{"endpoints": [{"name": "f", "inputs": [{"name": "a", "type": "variadic<u8,u8>"}]}]}`,
+		`{"endpoints": [{"name": "f", "outputs": [{"type": ""}]}]}`,
+		`{"events": [{"inputs": []}]}`,
+	}
+
+	for _, data := range invalid {
+		_, err := Parse([]byte(data))
+		require.ErrorIs(t, err, ErrInvalidABI, data)
+	}
+}
+
+func TestEndpoint_CheckArgumentCount(t *testing.T) {
+	abi := parseTestABI(t)
+	testCases := []struct {
+		endpoint string
+		accepted []int
+		rejected []int
+	}{
+		{"getSum", []int{0}, []int{1}},
+		{"add", []int{1}, []int{0, 2}},
+		{ConstructorName, []int{1}, []int{0, 2}},
+		{"setLimits", []int{3, 4}, []int{0, 2, 5}},
+		{"addMany", []int{0, 1, 2, 100}, nil},
+	}
+
+	for _, testCase := range testCases {
+		endpoint := abi.Endpoint(testCase.endpoint)
+		for _, numArgs := range testCase.accepted {
+			require.Nil(t, endpoint.CheckArgumentCount(numArgs), testCase.endpoint)
+		}
+		for _, numArgs := range testCase.rejected {
+			require.ErrorIs(t, endpoint.CheckArgumentCount(numArgs), ErrArgumentCountMismatch, testCase.endpoint)
+		}
+	}
+
+	err := abi.Endpoint("setLimits").CheckArgumentCount(5)
+	require.Equal(t, "wrong number of arguments for endpoint setLimits: expected 3 to 4, received 5", err.Error())
+}
+
+func TestExtractFromCode(t *testing.T) {
+	code := append([]byte{}, emptyModule...)
+	// a custom section with another name, before the ABI
+	code = append(code, 0x00, 0x05, 0x04, 'n', 'a', 'm', 'e')
+
+	abi, err := ExtractFromCode(code)
+	require.Nil(t, err)
+	require.Nil(t, abi)
+
+	abi, err = ExtractFromCode(AppendToCode(code, []byte(testABIJSON)))
+	require.Nil(t, err)
+	require.Equal(t, "Adder", abi.Name)
+}
+
+func TestExtractFromCode_Invalid(t *testing.T) {
+	abi, err := ExtractFromCode([]byte("not wasm"))
+	require.Nil(t, err)
+	require.Nil(t, abi)
+
+	_, err = ExtractFromCode(append(append([]byte{}, emptyModule...), 0x01, 0x10, 0x00))
+	require.Equal(t, ErrInvalidSection, err)
+
+	_, err = ExtractFromCode(AppendToCode(emptyModule, []byte("{")))
+	require.ErrorIs(t, err, ErrInvalidABI)
+}
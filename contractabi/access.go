@@ -0,0 +1,100 @@
+package contractabi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// AccessSectionName is the name of the WASM custom section holding the endpoint attributes of a contract.
+const AccessSectionName = "mx-endpoint-access"
+
+// EGLDTokenIdentifier stands for the call value in the tokens accepted by an endpoint.
+const EGLDTokenIdentifier = "EGLD"
+
+// AnyTokenIdentifier declares that an endpoint accepts every token.
+const AnyTokenIdentifier = "*"
+
+// EndpointAccess holds the attributes of an endpoint which the VM enforces before executing the contract.
+// A nil Payable list leaves the payments unchecked, while an empty one declares the endpoint as non-payable.
+type EndpointAccess struct {
+	OnlyOwner  bool     `json:"onlyOwner"`
+	Payable    []string `json:"payable"`
+	ReadOnly   bool     `json:"readOnly"`
+	Deprecated bool     `json:"deprecated"`
+}
+
+// AccessControl is the set of endpoint attributes embedded in the AccessSectionName custom section.
+type AccessControl struct {
+	Endpoints map[string]*EndpointAccess `json:"endpoints"`
+}
+
+// ParseAccessControl reads an access control JSON.
+func ParseAccessControl(data []byte) (*AccessControl, error) {
+	accessControl := &AccessControl{}
+	err := json.Unmarshal(data, accessControl)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidAccessControl, err)
+	}
+
+	for name, endpoint := range accessControl.Endpoints {
+		if len(name) == 0 || endpoint == nil {
+			return nil, fmt.Errorf("%w: invalid endpoint %q", ErrInvalidAccessControl, name)
+		}
+	}
+
+	return accessControl, nil
+}
+
+// ExtractAccessControlFromCode returns the endpoint attributes embedded in the contract code,
+// or nil if the code has no access control section.
+func ExtractAccessControlFromCode(code []byte) (*AccessControl, error) {
+	contents, found, err := findCustomSection(code, AccessSectionName)
+	if err != nil || !found {
+		return nil, err
+	}
+
+	return ParseAccessControl(contents)
+}
+
+// AppendAccessControlToCode returns a copy of the contract code with the access control JSON appended
+// in an AccessSectionName custom section, which the executors ignore.
+func AppendAccessControlToCode(code []byte, accessControlJSON []byte) []byte {
+	return appendCustomSection(code, AccessSectionName, accessControlJSON)
+}
+
+// Endpoint returns the attributes of the given endpoint, or nil if none were declared.
+func (accessControl *AccessControl) Endpoint(functionName string) *EndpointAccess {
+	return accessControl.Endpoints[functionName]
+}
+
+// CheckCaller verifies that an only-owner endpoint is called by the owner of the contract.
+func (endpoint *EndpointAccess) CheckCaller(caller []byte, owner []byte) error {
+	if endpoint.OnlyOwner && !bytes.Equal(caller, owner) {
+		return ErrEndpointOnlyOwner
+	}
+	return nil
+}
+
+// CheckPayment verifies that the endpoint accepts each of the transferred tokens, EGLDTokenIdentifier standing for the call value.
+func (endpoint *EndpointAccess) CheckPayment(tokenIdentifiers [][]byte) error {
+	if endpoint.Payable == nil {
+		return nil
+	}
+
+	for _, tokenIdentifier := range tokenIdentifiers {
+		if !endpoint.accepts(string(tokenIdentifier)) {
+			return fmt.Errorf("%w (%s)", ErrEndpointNotPayable, tokenIdentifier)
+		}
+	}
+	return nil
+}
+
+func (endpoint *EndpointAccess) accepts(tokenIdentifier string) bool {
+	for _, accepted := range endpoint.Payable {
+		if accepted == AnyTokenIdentifier || accepted == tokenIdentifier {
+			return true
+		}
+	}
+	return false
+}
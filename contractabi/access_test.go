@@ -0,0 +1,72 @@
+package contractabi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testAccessControlJSON = `{
+	"endpoints": {
+		"setFee": {"onlyOwner": true, "payable": []},
+		"deposit": {"payable": ["EGLD", "WEGLD-abcdef"]},
+		"donate": {"payable": ["*"]},
+		"getFee": {"readOnly": true},
+		"claimOld": {"deprecated": true}
+	}
+}`
+
+func TestExtractAccessControlFromCode(t *testing.T) {
+	accessControl, err := ExtractAccessControlFromCode(emptyModule)
+	require.Nil(t, err)
+	require.Nil(t, accessControl)
+
+	// the ABI section is not mistaken for the access control section
+	code := AppendToCode(emptyModule, []byte(testABIJSON))
+	accessControl, err = ExtractAccessControlFromCode(code)
+	require.Nil(t, err)
+	require.Nil(t, accessControl)
+
+	accessControl, err = ExtractAccessControlFromCode(AppendAccessControlToCode(code, []byte(testAccessControlJSON)))
+	require.Nil(t, err)
+	require.True(t, accessControl.Endpoint("setFee").OnlyOwner)
+	require.True(t, accessControl.Endpoint("getFee").ReadOnly)
+	require.True(t, accessControl.Endpoint("claimOld").Deprecated)
+	require.Nil(t, accessControl.Endpoint("missing"))
+
+	_, err = ExtractAccessControlFromCode(AppendAccessControlToCode(emptyModule, []byte(`{"endpoints": {"": {}}}`)))
+	require.ErrorIs(t, err, ErrInvalidAccessControl)
+	_, err = ExtractAccessControlFromCode(AppendAccessControlToCode(emptyModule, []byte("[")))
+	require.ErrorIs(t, err, ErrInvalidAccessControl)
+}
+
+func TestEndpointAccess_CheckCaller(t *testing.T) {
+	accessControl, err := ParseAccessControl([]byte(testAccessControlJSON))
+	require.Nil(t, err)
+
+	owner := []byte("owner")
+	require.Nil(t, accessControl.Endpoint("setFee").CheckCaller(owner, owner))
+	require.ErrorIs(t, accessControl.Endpoint("setFee").CheckCaller([]byte("other"), owner), ErrEndpointOnlyOwner)
+	require.Nil(t, accessControl.Endpoint("deposit").CheckCaller([]byte("other"), owner))
+}
+
+func TestEndpointAccess_CheckPayment(t *testing.T) {
+	accessControl, err := ParseAccessControl([]byte(testAccessControlJSON))
+	require.Nil(t, err)
+
+	egld := []byte(EGLDTokenIdentifier)
+	wegld := []byte("WEGLD-abcdef")
+	other := []byte("OTHER-123456")
+
+	setFee := accessControl.Endpoint("setFee")
+	require.Nil(t, setFee.CheckPayment(nil))
+	require.ErrorIs(t, setFee.CheckPayment([][]byte{egld}), ErrEndpointNotPayable)
+	require.ErrorIs(t, setFee.CheckPayment([][]byte{wegld}), ErrEndpointAccessDenied)
+
+	deposit := accessControl.Endpoint("deposit")
+	require.Nil(t, deposit.CheckPayment([][]byte{egld, wegld}))
+	require.ErrorIs(t, deposit.CheckPayment([][]byte{wegld, other}), ErrEndpointNotPayable)
+
+	require.Nil(t, accessControl.Endpoint("donate").CheckPayment([][]byte{egld, other}))
+	require.Nil(t, accessControl.Endpoint("getFee").CheckPayment([][]byte{other}))
+}
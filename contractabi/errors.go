@@ -0,0 +1,42 @@
+package contractabi
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrInvalidSection is raised when the sections of the contract code cannot be walked to find the ABI
+var ErrInvalidSection = errors.New("invalid WASM section in contract code")
+
+// ErrInvalidABI is raised when the embedded ABI is not a valid ABI JSON
+var ErrInvalidABI = errors.New("invalid contract ABI")
+
+// ErrEndpointNotFound is raised when decoding values for an endpoint missing from the ABI
+var ErrEndpointNotFound = errors.New("endpoint not found in contract ABI")
+
+// ErrEventNotFound is raised when decoding an event missing from the ABI
+var ErrEventNotFound = errors.New("event not found in contract ABI")
+
+// ErrArgumentCountMismatch is raised when the number of arguments does not match the signature of the endpoint
+var ErrArgumentCountMismatch = errors.New("wrong number of arguments")
+
+// ErrValueCountMismatch is raised when the number of encoded values does not match the parameters they are decoded for
+var ErrValueCountMismatch = errors.New("contract ABI value count mismatch")
+
+// ErrInvalidValue is raised when an encoded value does not have the representation required by its type
+var ErrInvalidValue = errors.New("invalid value for contract ABI type")
+
+// ErrInvalidAccessControl is raised when the embedded endpoint attributes are not a valid access control JSON
+var ErrInvalidAccessControl = errors.New("invalid endpoint access control")
+
+// ErrEndpointAccessDenied is raised when a call does not satisfy the attributes declared for its endpoint
+var ErrEndpointAccessDenied = errors.New("endpoint access denied")
+
+// ErrEndpointOnlyOwner is raised when an only-owner endpoint is called by another address than the owner
+var ErrEndpointOnlyOwner = fmt.Errorf("%w: endpoint can only be called by the owner", ErrEndpointAccessDenied)
+
+// ErrEndpointNotPayable is raised when an endpoint receives a token which it does not accept
+var ErrEndpointNotPayable = fmt.Errorf("%w: endpoint does not accept the transferred token", ErrEndpointAccessDenied)
+
+// ErrEndpointDeprecated is raised when calling an endpoint declared as deprecated
+var ErrEndpointDeprecated = fmt.Errorf("%w: endpoint is deprecated", ErrEndpointAccessDenied)
@@ -0,0 +1,101 @@
+package contractabi
+
+import (
+	"bytes"
+)
+
+// SectionName is the name of the WASM custom section holding the ABI JSON of a contract.
+const SectionName = "mx-abi"
+
+const customSectionID = 0
+
+var wasmMagicAndVersion = []byte{0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00}
+
+// ExtractFromCode returns the ABI embedded in the contract code, or nil if the code has no ABI section.
+// Only the headers of the sections are read, so that contracts without an ABI are not slowed down.
+func ExtractFromCode(code []byte) (*ABI, error) {
+	contents, found, err := findCustomSection(code, SectionName)
+	if err != nil || !found {
+		return nil, err
+	}
+
+	return Parse(contents)
+}
+
+// AppendToCode returns a copy of the contract code with the ABI JSON appended in a SectionName custom section,
+// which the executors ignore.
+func AppendToCode(code []byte, abiJSON []byte) []byte {
+	return appendCustomSection(code, SectionName, abiJSON)
+}
+
+func appendCustomSection(code []byte, name string, data []byte) []byte {
+	contents := append(encodeU32(uint32(len(name))), name...)
+	contents = append(contents, data...)
+
+	result := make([]byte, 0, len(code)+len(contents)+6)
+	result = append(result, code...)
+	result = append(result, customSectionID)
+	result = append(result, encodeU32(uint32(len(contents)))...)
+	return append(result, contents...)
+}
+
+func findCustomSection(code []byte, name string) ([]byte, bool, error) {
+	if !bytes.HasPrefix(code, wasmMagicAndVersion) {
+		return nil, false, nil
+	}
+
+	position := len(wasmMagicAndVersion)
+	for position < len(code) {
+		sectionID := code[position]
+		sectionSize, sizeLength, ok := readU32(code[position+1:])
+		if !ok {
+			return nil, false, ErrInvalidSection
+		}
+		sectionStart := position + 1 + sizeLength
+		sectionEnd := sectionStart + int(sectionSize)
+		if sectionEnd > len(code) || sectionEnd < sectionStart {
+			return nil, false, ErrInvalidSection
+		}
+		position = sectionEnd
+
+		if sectionID != customSectionID {
+			continue
+		}
+
+		section := code[sectionStart:sectionEnd]
+		nameLength, nameLengthSize, ok := readU32(section)
+		if !ok || uint64(nameLengthSize)+uint64(nameLength) > uint64(len(section)) {
+			return nil, false, ErrInvalidSection
+		}
+		nameEnd := nameLengthSize + int(nameLength)
+		if string(section[nameLengthSize:nameEnd]) == name {
+			return section[nameEnd:], true, nil
+		}
+	}
+
+	return nil, false, nil
+}
+
+// readU32 decodes an unsigned LEB128 value of at most 5 bytes and returns its length.
+func readU32(data []byte) (uint32, int, bool) {
+	result := uint64(0)
+	for i := 0; i < 5 && i < len(data); i++ {
+		result |= uint64(data[i]&0x7f) << (7 * i)
+		if data[i]&0x80 == 0 {
+			return uint32(result), i + 1, result <= 0xffffffff
+		}
+	}
+	return 0, 0, false
+}
+
+func encodeU32(value uint32) []byte {
+	encoded := make([]byte, 0, 5)
+	for {
+		b := byte(value & 0x7f)
+		value >>= 7
+		if value == 0 {
+			return append(encoded, b)
+		}
+		encoded = append(encoded, b|0x80)
+	}
+}
@@ -0,0 +1,229 @@
+package contractabi
+
+import (
+	"fmt"
+	"math/big"
+
+	twos "github.com/multiversx/mx-components-big-int/twos-complement"
+)
+
+const addressLength = 32
+
+// TypedValue is an argument, a result or an event input decoded according to the ABI.
+// Value holds a uint64 or an int64 for the fixed-size integers, a *big.Int for BigUint and BigInt,
+// a bool, a string for the token identifiers and strings, and the raw bytes for everything else,
+// including the custom types of the contract.
+type TypedValue struct {
+	Name  string      `json:"name"`
+	Type  string      `json:"type"`
+	Value interface{} `json:"value"`
+}
+
+// DecodedEvent is a log entry decoded according to the event of the ABI with the same identifier.
+type DecodedEvent struct {
+	Identifier string        `json:"identifier"`
+	Values     []*TypedValue `json:"values"`
+}
+
+// DecodeArguments decodes the arguments of a call to the given endpoint.
+func (abi *ABI) DecodeArguments(functionName string, arguments [][]byte) ([]*TypedValue, error) {
+	endpoint := abi.Endpoint(functionName)
+	if endpoint == nil {
+		return nil, fmt.Errorf("%w: %s", ErrEndpointNotFound, functionName)
+	}
+	return decodeParams(endpoint.Inputs, arguments)
+}
+
+// DecodeResults decodes the data returned by a call to the given endpoint.
+func (abi *ABI) DecodeResults(functionName string, returnData [][]byte) ([]*TypedValue, error) {
+	endpoint := abi.Endpoint(functionName)
+	if endpoint == nil {
+		return nil, fmt.Errorf("%w: %s", ErrEndpointNotFound, functionName)
+	}
+	return decodeParams(endpoint.Outputs, returnData)
+}
+
+// DecodeEvent decodes a log entry, whose first topic is the identifier of the event;
+// the indexed inputs are read from the other topics and the rest of the inputs from the data.
+func (abi *ABI) DecodeEvent(topics [][]byte, data [][]byte) (*DecodedEvent, error) {
+	if len(topics) == 0 {
+		return nil, fmt.Errorf("%w: no identifier", ErrEventNotFound)
+	}
+
+	identifier := string(topics[0])
+	event := abi.Event(identifier)
+	if event == nil {
+		return nil, fmt.Errorf("%w: %s", ErrEventNotFound, identifier)
+	}
+
+	indexed := make([]*Param, 0, len(event.Inputs))
+	notIndexed := make([]*Param, 0, len(event.Inputs))
+	for _, input := range event.Inputs {
+		if input.Indexed {
+			indexed = append(indexed, input)
+		} else {
+			notIndexed = append(notIndexed, input)
+		}
+	}
+
+	values, err := decodeParams(indexed, topics[1:])
+	if err != nil {
+		return nil, err
+	}
+	dataValues, err := decodeParams(notIndexed, data)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DecodedEvent{
+		Identifier: identifier,
+		Values:     append(values, dataValues...),
+	}, nil
+}
+
+func decodeParams(params []*Param, encoded [][]byte) ([]*TypedValue, error) {
+	values := make([]*TypedValue, 0, len(encoded))
+	remaining := encoded
+	for _, param := range params {
+		var err error
+		values, remaining, err = decodeMultiValue(param.Name, param.Type, remaining, values)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if len(remaining) > 0 {
+		return nil, fmt.Errorf("%w: %d values left over", ErrValueCountMismatch, len(remaining))
+	}
+	return values, nil
+}
+
+// decodeMultiValue decodes a param which may spread over several encoded values, and returns the values left.
+func decodeMultiValue(name string, typ string, encoded [][]byte, values []*TypedValue) ([]*TypedValue, [][]byte, error) {
+	base, args, err := splitType(typ)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	switch base {
+	case typeOptional:
+		if len(encoded) == 0 {
+			return values, encoded, nil
+		}
+		return decodeMultiValue(name, args[0], encoded, values)
+	case typeVariadic:
+		for len(encoded) > 0 {
+			numRemaining := len(encoded)
+			values, encoded, err = decodeMultiValue(name, args[0], encoded, values)
+			if err != nil {
+				return nil, nil, err
+			}
+			if len(encoded) == numRemaining {
+				return nil, nil, fmt.Errorf("%w: %s takes no values", ErrValueCountMismatch, typ)
+			}
+		}
+		return values, encoded, nil
+	case typeCountedVariadic:
+		if len(encoded) == 0 {
+			return nil, nil, fmt.Errorf("%w: missing count of %s", ErrValueCountMismatch, name)
+		}
+		count, err := decodeUnsigned(encoded[0], 32)
+		if err != nil {
+			return nil, nil, err
+		}
+		encoded = encoded[1:]
+		for i := uint64(0); i < count; i++ {
+			values, encoded, err = decodeMultiValue(name, args[0], encoded, values)
+			if err != nil {
+				return nil, nil, err
+			}
+		}
+		return values, encoded, nil
+	case typeMulti:
+		for _, arg := range args {
+			values, encoded, err = decodeMultiValue(name, arg, encoded, values)
+			if err != nil {
+				return nil, nil, err
+			}
+		}
+		return values, encoded, nil
+	}
+
+	if len(encoded) == 0 {
+		return nil, nil, fmt.Errorf("%w: missing %s", ErrValueCountMismatch, name)
+	}
+	value, err := DecodeTopValue(typ, encoded[0])
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", name, err)
+	}
+	values = append(values, &TypedValue{Name: name, Type: typ, Value: value})
+	return values, encoded[1:], nil
+}
+
+// DecodeTopValue decodes a single value encoded as a whole argument, result or topic,
+// where integers are written in big-endian without leading zeros.
+func DecodeTopValue(typ string, data []byte) (interface{}, error) {
+	switch typ {
+	case "bool":
+		return decodeBool(data)
+	case "u8":
+		return decodeUnsigned(data, 8)
+	case "u16":
+		return decodeUnsigned(data, 16)
+	case "u32", "usize":
+		return decodeUnsigned(data, 32)
+	case "u64":
+		return decodeUnsigned(data, 64)
+	case "i8":
+		return decodeSigned(data, 8)
+	case "i16":
+		return decodeSigned(data, 16)
+	case "i32", "isize":
+		return decodeSigned(data, 32)
+	case "i64":
+		return decodeSigned(data, 64)
+	case "BigUint":
+		return big.NewInt(0).SetBytes(data), nil
+	case "BigInt":
+		return twos.FromBytes(data), nil
+	case "Address", "ManagedAddress", "H256":
+		if len(data) != addressLength {
+			return nil, fmt.Errorf("%w: %s of %d bytes", ErrInvalidValue, typ, len(data))
+		}
+		return data, nil
+	case "TokenIdentifier", "EgldOrEsdtTokenIdentifier", "utf-8 string":
+		return string(data), nil
+	default:
+		return data, nil
+	}
+}
+
+func decodeBool(data []byte) (bool, error) {
+	switch {
+	case len(data) == 0:
+		return false, nil
+	case len(data) == 1 && data[0] == 1:
+		return true, nil
+	default:
+		return false, fmt.Errorf("%w: bool %x", ErrInvalidValue, data)
+	}
+}
+
+func decodeUnsigned(data []byte, bits int) (uint64, error) {
+	if len(data) > bits/8 {
+		return 0, fmt.Errorf("%w: %d bytes for u%d", ErrInvalidValue, len(data), bits)
+	}
+
+	value := uint64(0)
+	for _, b := range data {
+		value = value<<8 | uint64(b)
+	}
+	return value, nil
+}
+
+func decodeSigned(data []byte, bits int) (int64, error) {
+	if len(data) > bits/8 {
+		return 0, fmt.Errorf("%w: %d bytes for i%d", ErrInvalidValue, len(data), bits)
+	}
+
+	return twos.FromBytes(data).Int64(), nil
+}
@@ -0,0 +1,121 @@
+package contractabi
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var testAddress = bytes.Repeat([]byte{0xaa}, addressLength)
+
+func TestDecodeTopValue(t *testing.T) {
+	testCases := []struct {
+		typ      string
+		data     []byte
+		expected interface{}
+	}{
+		{"bool", []byte{}, false},
+		{"bool", []byte{0x01}, true},
+		{"u8", []byte{}, uint64(0)},
+		{"u32", []byte{0x01, 0x00}, uint64(256)},
+		{"u64", []byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}, uint64(18446744073709551615)},
+		{"i8", []byte{0xff}, int64(-1)},
+		{"i64", []byte{0x00, 0xff}, int64(255)},
+		{"isize", []byte{0xff, 0x00}, int64(-256)},
+		{"BigUint", []byte{0xff}, big.NewInt(255)},
+		{"BigInt", []byte{0xff}, big.NewInt(-1)},
+		{"TokenIdentifier", []byte("WEGLD-abcdef"), "WEGLD-abcdef"},
+		{"Address", testAddress, testAddress},
+		{"List<u8>", []byte{0x01, 0x02}, []byte{0x01, 0x02}},
+	}
+
+	for _, testCase := range testCases {
+		value, err := DecodeTopValue(testCase.typ, testCase.data)
+		require.Nil(t, err, testCase.typ)
+		require.Equal(t, testCase.expected, value, testCase.typ)
+	}
+}
+
+func TestDecodeTopValue_Invalid(t *testing.T) {
+	invalid := []struct {
+		typ  string
+		data []byte
+	}{
+		{"bool", []byte{0x02}},
+		{"bool", []byte{0x00, 0x01}},
+		{"u8", []byte{0x01, 0x00}},
+		{"i16", []byte{0x01, 0x00, 0x00}},
+		{"Address", []byte{0x01}},
+	}
+
+	for _, testCase := range invalid {
+		_, err := DecodeTopValue(testCase.typ, testCase.data)
+		require.ErrorIs(t, err, ErrInvalidValue, testCase.typ)
+	}
+}
+
+func TestDecodeArguments(t *testing.T) {
+	abi := parseTestABI(t)
+
+	values, err := abi.DecodeArguments("setLimits", [][]byte{[]byte("TOKEN-123456"), {0x01}, {0x02}})
+	require.Nil(t, err)
+	require.Equal(t, []*TypedValue{
+		{Name: "token", Type: "TokenIdentifier", Value: "TOKEN-123456"},
+		{Name: "limits", Type: "u64", Value: uint64(1)},
+		{Name: "limits", Type: "u64", Value: uint64(2)},
+	}, values)
+
+	values, err = abi.DecodeArguments("setLimits", [][]byte{[]byte("TOKEN-123456"), {0x01}, {0x02}, testAddress})
+	require.Nil(t, err)
+	require.Equal(t, &TypedValue{Name: "owner", Type: "Address", Value: testAddress}, values[3])
+
+	values, err = abi.DecodeArguments("addMany", [][]byte{{0x05}, {0x01}, {0x06}, {}})
+	require.Nil(t, err)
+	require.Equal(t, []*TypedValue{
+		{Name: "values", Type: "u32", Value: uint64(5)},
+		{Name: "values", Type: "bool", Value: true},
+		{Name: "values", Type: "u32", Value: uint64(6)},
+		{Name: "values", Type: "bool", Value: false},
+	}, values)
+
+	_, err = abi.DecodeArguments("addMany", [][]byte{{0x05}})
+	require.ErrorIs(t, err, ErrValueCountMismatch)
+	_, err = abi.DecodeArguments("add", [][]byte{{0x05}, {0x06}})
+	require.ErrorIs(t, err, ErrValueCountMismatch)
+	_, err = abi.DecodeArguments("missing", nil)
+	require.ErrorIs(t, err, ErrEndpointNotFound)
+}
+
+func TestDecodeResults(t *testing.T) {
+	abi := parseTestABI(t)
+
+	values, err := abi.DecodeResults("getSum", [][]byte{{0x01, 0x00}})
+	require.Nil(t, err)
+	require.Equal(t, []*TypedValue{{Type: "BigUint", Value: big.NewInt(256)}}, values)
+
+	values, err = abi.DecodeResults("addMany", [][]byte{{0xff}, {0x01}})
+	require.Nil(t, err)
+	require.Equal(t, []*TypedValue{{Type: "i64", Value: int64(-1)}, {Type: "i64", Value: int64(1)}}, values)
+}
+
+func TestDecodeEvent(t *testing.T) {
+	abi := parseTestABI(t)
+
+	event, err := abi.DecodeEvent([][]byte{[]byte("added"), testAddress, {0x07}}, [][]byte{[]byte("hello")})
+	require.Nil(t, err)
+	require.Equal(t, &DecodedEvent{
+		Identifier: "added",
+		Values: []*TypedValue{
+			{Name: "caller", Type: "Address", Value: testAddress},
+			{Name: "value", Type: "BigUint", Value: big.NewInt(7)},
+			{Name: "note", Type: "utf-8 string", Value: "hello"},
+		},
+	}, event)
+
+	_, err = abi.DecodeEvent([][]byte{[]byte("removed")}, nil)
+	require.ErrorIs(t, err, ErrEventNotFound)
+	_, err = abi.DecodeEvent(nil, nil)
+	require.ErrorIs(t, err, ErrEventNotFound)
+}
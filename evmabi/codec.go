@@ -0,0 +1,346 @@
+package evmabi
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/big"
+
+	"github.com/multiversx/mx-chain-vm-go/math"
+	twos "github.com/multiversx/mx-components-big-int/twos-complement"
+)
+
+const addressLength = 20
+
+// Value is a value of a Type. Elementary values are held in Bytes:
+//   - unsigned integers as big-endian bytes, decoded without leading zeros;
+//   - signed integers in two's complement, decoded in the shortest form;
+//   - addresses as 20 bytes;
+//   - booleans as 0x01 for true, and as no bytes or 0x00 for false;
+//   - fixed byte arrays as exactly Size bytes, dynamic byte arrays and strings as they are.
+//
+// Arrays and tuples hold their elements in Items.
+type Value struct {
+	Bytes []byte
+	Items []*Value
+}
+
+// Encode ABI-encodes the values, as the arguments of a function call with the given parameter types, without the selector.
+func Encode(types []*Type, values []*Value) ([]byte, error) {
+	if len(types) != len(values) {
+		return nil, ErrValueCountMismatch
+	}
+	return encodeSequence(types, values)
+}
+
+// encodeSequence encodes all the values before building the head, so that the item counts of the arrays
+// are checked against their values before anything is allocated for them.
+func encodeSequence(types []*Type, values []*Value) ([]byte, error) {
+	encodedValues := make([][]byte, len(types))
+	headLength := uint64(0)
+	for i, typ := range types {
+		encoded, err := encodeValue(typ, values[i])
+		if err != nil {
+			return nil, err
+		}
+
+		encodedValues[i] = encoded
+		if typ.IsDynamic() {
+			headLength += wordSize
+		} else {
+			headLength += uint64(len(encoded))
+		}
+	}
+
+	var head, tail []byte
+	for i, typ := range types {
+		if typ.IsDynamic() {
+			head = append(head, encodeLength(headLength+uint64(len(tail)))...)
+			tail = append(tail, encodedValues[i]...)
+		} else {
+			head = append(head, encodedValues[i]...)
+		}
+	}
+
+	return append(head, tail...), nil
+}
+
+func encodeValue(typ *Type, value *Value) ([]byte, error) {
+	if value == nil {
+		return nil, fmt.Errorf("%w: missing %s", ErrInvalidValue, typ)
+	}
+
+	switch typ.Kind {
+	case KindUint:
+		unsigned := big.NewInt(0).SetBytes(value.Bytes)
+		if unsigned.BitLen() > typ.Size {
+			return nil, fmt.Errorf("%w: %s", ErrValueOutOfRange, typ)
+		}
+		return padLeft(unsigned.Bytes()), nil
+	case KindInt:
+		signed := twos.FromBytes(value.Bytes)
+		if !fitsSigned(signed, typ.Size) {
+			return nil, fmt.Errorf("%w: %s", ErrValueOutOfRange, typ)
+		}
+		return twos.ToBytesOfLength(signed, wordSize)
+	case KindAddress:
+		if len(value.Bytes) != addressLength {
+			return nil, fmt.Errorf("%w: address of %d bytes", ErrInvalidValue, len(value.Bytes))
+		}
+		return padLeft(value.Bytes), nil
+	case KindBool:
+		boolean := big.NewInt(0).SetBytes(value.Bytes)
+		if boolean.BitLen() > 1 {
+			return nil, fmt.Errorf("%w: bool", ErrValueOutOfRange)
+		}
+		return padLeft(boolean.Bytes()), nil
+	case KindFixedBytes:
+		if len(value.Bytes) != typ.Size {
+			return nil, fmt.Errorf("%w: %s of %d bytes", ErrInvalidValue, typ, len(value.Bytes))
+		}
+		return padRight(value.Bytes), nil
+	case KindBytes, KindString:
+		return append(encodeLength(uint64(len(value.Bytes))), padRight(value.Bytes)...), nil
+	case KindArray:
+		if len(value.Items) != typ.Size {
+			return nil, fmt.Errorf("%w: %d items for %s", ErrValueCountMismatch, len(value.Items), typ)
+		}
+		return encodeSequence(repeatType(typ.Elem, typ.Size), value.Items)
+	case KindSlice:
+		encodedItems, err := encodeSequence(repeatType(typ.Elem, len(value.Items)), value.Items)
+		if err != nil {
+			return nil, err
+		}
+		return append(encodeLength(uint64(len(value.Items))), encodedItems...), nil
+	case KindTuple:
+		if len(value.Items) != len(typ.Components) {
+			return nil, fmt.Errorf("%w: %d items for %s", ErrValueCountMismatch, len(value.Items), typ)
+		}
+		return encodeSequence(typ.Components, value.Items)
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrInvalidType, typ)
+	}
+}
+
+// Decode decodes the ABI-encoded arguments of a function call with the given parameter types, without the selector.
+// Trailing data is ignored, but the padding of each value is checked.
+func Decode(types []*Type, data []byte) ([]*Value, error) {
+	decoder := &valueDecoder{
+		remainingBudget: uint64(len(data)),
+	}
+	return decoder.decodeSequence(types, data)
+}
+
+// valueDecoder decodes values while limiting their total size to the size of the encoded data, which
+// holds for all the standard encodings and prevents offsets from referencing the same data over and over.
+type valueDecoder struct {
+	remainingBudget uint64
+}
+
+func (decoder *valueDecoder) useBudget(size uint64) error {
+	if size > decoder.remainingBudget {
+		return ErrDecodedDataTooLarge
+	}
+	decoder.remainingBudget -= size
+	return nil
+}
+
+// decodeSequence decodes the values of the given types, whose offsets are relative to the start of data.
+func (decoder *valueDecoder) decodeSequence(types []*Type, data []byte) ([]*Value, error) {
+	if sequenceHeadSize(types) > uint64(len(data)) {
+		return nil, ErrDataTooShort
+	}
+
+	values := make([]*Value, 0, len(types))
+	headOffset := uint64(0)
+	for _, typ := range types {
+		headSize := typ.headSize()
+		valueData := data[headOffset : headOffset+headSize]
+		if typ.IsDynamic() {
+			offset, err := decodeLength(valueData, uint64(len(data)))
+			if err != nil {
+				return nil, err
+			}
+			valueData = data[offset:]
+		}
+
+		value, err := decoder.decodeValue(typ, valueData)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+		headOffset += headSize
+	}
+
+	return values, nil
+}
+
+func (decoder *valueDecoder) decodeValue(typ *Type, data []byte) (*Value, error) {
+	switch typ.Kind {
+	case KindBytes, KindString:
+		return decoder.decodeBytes(data)
+	case KindArray:
+		if math.MulUint64(uint64(typ.Size), typ.Elem.headSize()) > uint64(len(data)) {
+			return nil, ErrDataTooShort
+		}
+		items, err := decoder.decodeSequence(repeatType(typ.Elem, typ.Size), data)
+		if err != nil {
+			return nil, err
+		}
+		return &Value{Items: items}, nil
+	case KindSlice:
+		return decoder.decodeSlice(typ, data)
+	case KindTuple:
+		items, err := decoder.decodeSequence(typ.Components, data)
+		if err != nil {
+			return nil, err
+		}
+		return &Value{Items: items}, nil
+	}
+
+	if len(data) < wordSize {
+		return nil, ErrDataTooShort
+	}
+	err := decoder.useBudget(wordSize)
+	if err != nil {
+		return nil, err
+	}
+
+	word := data[:wordSize]
+	switch typ.Kind {
+	case KindUint:
+		if !isZero(word[:wordSize-typ.Size/8]) {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidPadding, typ)
+		}
+		return &Value{Bytes: big.NewInt(0).SetBytes(word).Bytes()}, nil
+	case KindInt:
+		signed := twos.FromBytes(word)
+		if !fitsSigned(signed, typ.Size) {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidPadding, typ)
+		}
+		return &Value{Bytes: twos.ToBytes(signed)}, nil
+	case KindAddress:
+		if !isZero(word[:wordSize-addressLength]) {
+			return nil, fmt.Errorf("%w: address", ErrInvalidPadding)
+		}
+		return &Value{Bytes: copyBytes(word[wordSize-addressLength:])}, nil
+	case KindBool:
+		if !isZero(word[:wordSize-1]) || word[wordSize-1] > 1 {
+			return nil, fmt.Errorf("%w: bool", ErrValueOutOfRange)
+		}
+		return &Value{Bytes: big.NewInt(0).SetBytes(word).Bytes()}, nil
+	case KindFixedBytes:
+		if !isZero(word[typ.Size:]) {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidPadding, typ)
+		}
+		return &Value{Bytes: copyBytes(word[:typ.Size])}, nil
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrInvalidType, typ)
+	}
+}
+
+func (decoder *valueDecoder) decodeBytes(data []byte) (*Value, error) {
+	if len(data) < wordSize {
+		return nil, ErrDataTooShort
+	}
+	contents := data[wordSize:]
+	length, err := decodeLength(data[:wordSize], uint64(len(contents)))
+	if err != nil {
+		return nil, err
+	}
+
+	paddedLength := roundUpToWord(length)
+	if paddedLength > uint64(len(contents)) {
+		return nil, ErrDataTooShort
+	}
+	if !isZero(contents[length:paddedLength]) {
+		return nil, fmt.Errorf("%w: bytes", ErrInvalidPadding)
+	}
+
+	err = decoder.useBudget(wordSize + paddedLength)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Value{Bytes: copyBytes(contents[:length])}, nil
+}
+
+func (decoder *valueDecoder) decodeSlice(typ *Type, data []byte) (*Value, error) {
+	if len(data) < wordSize {
+		return nil, ErrDataTooShort
+	}
+	items := data[wordSize:]
+
+	// every element takes at least a word, which bounds the length before allocating
+	length, err := decodeLength(data[:wordSize], uint64(len(items))/wordSize)
+	if err != nil {
+		return nil, err
+	}
+
+	err = decoder.useBudget(wordSize)
+	if err != nil {
+		return nil, err
+	}
+
+	values, err := decoder.decodeSequence(repeatType(typ.Elem, int(length)), items)
+	if err != nil {
+		return nil, err
+	}
+	return &Value{Items: values}, nil
+}
+
+// decodeLength reads an offset or a length from a word, which must not exceed the given limit.
+func decodeLength(word []byte, limit uint64) (uint64, error) {
+	const uint64Size = 8
+	if !isZero(word[:wordSize-uint64Size]) {
+		return 0, ErrInvalidOffset
+	}
+	length := binary.BigEndian.Uint64(word[wordSize-uint64Size:])
+	if length > limit {
+		return 0, ErrInvalidOffset
+	}
+	return length, nil
+}
+
+func encodeLength(length uint64) []byte {
+	encoded := make([]byte, wordSize)
+	binary.BigEndian.PutUint64(encoded[wordSize-8:], length)
+	return encoded
+}
+
+func fitsSigned(value *big.Int, bits int) bool {
+	limit := big.NewInt(0).Lsh(big.NewInt(1), uint(bits-1))
+	if value.Cmp(limit) >= 0 {
+		return false
+	}
+	return value.Cmp(big.NewInt(0).Neg(limit)) >= 0
+}
+
+func padLeft(data []byte) []byte {
+	padded := make([]byte, wordSize-len(data), wordSize)
+	return append(padded, data...)
+}
+
+func padRight(data []byte) []byte {
+	padded := make([]byte, roundUpToWord(uint64(len(data))))
+	copy(padded, data)
+	return padded
+}
+
+func roundUpToWord(length uint64) uint64 {
+	return math.MulUint64((length+wordSize-1)/wordSize, wordSize)
+}
+
+func isZero(data []byte) bool {
+	for _, b := range data {
+		if b != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func copyBytes(data []byte) []byte {
+	copied := make([]byte, len(data))
+	copy(copied, data)
+	return copied
+}
@@ -0,0 +1,188 @@
+package evmabi
+
+import (
+	"bytes"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func parseTypes(t *testing.T, list string) []*Type {
+	types, err := ParseTypes(list)
+	require.Nil(t, err)
+	return types
+}
+
+func words(t *testing.T, hexWords ...string) []byte {
+	data, err := hex.DecodeString(strings.Join(hexWords, ""))
+	require.Nil(t, err)
+	return data
+}
+
+func word(hexValue string) string {
+	return strings.Repeat("0", 2*wordSize-len(hexValue)) + hexValue
+}
+
+func elementary(data []byte) *Value {
+	return &Value{Bytes: data}
+}
+
+func items(values ...*Value) *Value {
+	return &Value{Items: values}
+}
+
+func requireRoundTrip(t *testing.T, types []*Type, values []*Value, expected []byte) {
+	encoded, err := Encode(types, values)
+	require.Nil(t, err)
+	require.Equal(t, hex.EncodeToString(expected), hex.EncodeToString(encoded))
+
+	decoded, err := Decode(types, encoded)
+	require.Nil(t, err)
+	require.Equal(t, values, decoded)
+}
+
+func TestCodec_StaticAndDynamic(t *testing.T) {
+	// example from the Solidity ABI specification: f(uint256,uint32[],bytes10,bytes)
+	types := parseTypes(t, "uint,uint32[],bytes10,bytes")
+	values := []*Value{
+		elementary([]byte{0x01, 0x23}),
+		items(elementary([]byte{0x04, 0x56}), elementary([]byte{0x07, 0x89})),
+		elementary([]byte("1234567890")),
+		elementary([]byte("Hello, world!")),
+	}
+
+	requireRoundTrip(t, types, values, words(t,
+		word("123"),
+		word("80"),
+		"3132333435363738393000000000000000000000000000000000000000000000",
+		word("e0"),
+		word("2"),
+		word("456"),
+		word("789"),
+		word("d"),
+		"48656c6c6f2c20776f726c642100000000000000000000000000000000000000",
+	))
+}
+
+func TestCodec_NestedDynamic(t *testing.T) {
+	// example from the Solidity ABI specification: g(uint256[][],string[])
+	types := parseTypes(t, "uint[][],string[]")
+	values := []*Value{
+		items(
+			items(elementary([]byte{1}), elementary([]byte{2})),
+			items(elementary([]byte{3})),
+		),
+		items(elementary([]byte("one")), elementary([]byte("two")), elementary([]byte("three"))),
+	}
+
+	requireRoundTrip(t, types, values, words(t,
+		word("40"), word("140"),
+		word("2"), word("40"), word("a0"),
+		word("2"), word("1"), word("2"),
+		word("1"), word("3"),
+		word("3"), word("60"), word("a0"), word("e0"),
+		word("3"), "6f6e650000000000000000000000000000000000000000000000000000000000",
+		word("3"), "74776f0000000000000000000000000000000000000000000000000000000000",
+		word("5"), "7468726565000000000000000000000000000000000000000000000000000000",
+	))
+}
+
+func TestCodec_TuplesAndElementary(t *testing.T) {
+	types := parseTypes(t, "(address,bool,int8[2]),(bytes,uint8)")
+	address := bytes.Repeat([]byte{0xaa}, addressLength)
+	values := []*Value{
+		items(elementary(address), elementary([]byte{1}), items(elementary([]byte{0x80}), elementary([]byte{0x7f}))),
+		items(elementary([]byte{}), elementary([]byte{})),
+	}
+
+	requireRoundTrip(t, types, values, words(t,
+		word(hex.EncodeToString(address)),
+		word("1"),
+		strings.Repeat("ff", wordSize-1)+"80",
+		word("7f"),
+		word("a0"),
+		word("40"), word(""), word(""),
+	))
+}
+
+func TestEncode_InvalidValues(t *testing.T) {
+	testCases := []struct {
+		list     string
+		value    *Value
+		expected error
+	}{
+		{"uint8", elementary([]byte{1, 0}), ErrValueOutOfRange},
+		{"int8", elementary([]byte{0, 0x80}), ErrValueOutOfRange},
+		{"int8", elementary([]byte{0xff, 0x7f}), ErrValueOutOfRange},
+		{"bool", elementary([]byte{2}), ErrValueOutOfRange},
+		{"address", elementary([]byte{1}), ErrInvalidValue},
+		{"bytes2", elementary([]byte{1}), ErrInvalidValue},
+		{"uint[2]", items(elementary(nil)), ErrValueCountMismatch},
+		{"(uint,uint)", items(elementary(nil)), ErrValueCountMismatch},
+		{"uint[]", items(nil), ErrInvalidValue},
+		// the item counts are checked before anything is allocated for them
+		{"uint256[2147483647]", items(elementary(nil)), ErrValueCountMismatch},
+		{"uint256[2147483647][2147483647]", items(items(elementary(nil))), ErrValueCountMismatch},
+	}
+
+	for _, testCase := range testCases {
+		_, err := Encode(parseTypes(t, testCase.list), []*Value{testCase.value})
+		require.ErrorIs(t, err, testCase.expected, testCase.list)
+	}
+
+	_, err := Encode(parseTypes(t, "uint,uint"), []*Value{elementary(nil)})
+	require.Equal(t, ErrValueCountMismatch, err)
+}
+
+func TestDecode_InvalidData(t *testing.T) {
+	maxUint := strings.Repeat("ff", wordSize)
+	testCases := []struct {
+		list     string
+		data     []byte
+		expected error
+	}{
+		{"uint", words(t, "00"), ErrDataTooShort},
+		{"uint8", words(t, word("100")), ErrInvalidPadding},
+		{"int8", words(t, word("80")), ErrInvalidPadding},
+		{"int8", words(t, strings.Repeat("ff", wordSize-1)+"7f"), ErrInvalidPadding},
+		{"address", words(t, maxUint), ErrInvalidPadding},
+		{"bool", words(t, word("2")), ErrValueOutOfRange},
+		{"bytes1", words(t, word("1")), ErrInvalidPadding},
+		{"bytes", words(t, word("20")), ErrDataTooShort},
+		{"bytes", words(t, word("40")), ErrInvalidOffset},
+		{"bytes", words(t, maxUint), ErrInvalidOffset},
+		{"bytes", words(t, word("20"), word("21"), word("1")), ErrInvalidOffset},
+		{"bytes", words(t, word("20"), word("1"), word("1")), ErrInvalidPadding},
+		{"uint[]", words(t, word("20"), word("2"), word("1")), ErrInvalidOffset},
+		{"uint[3]", words(t, word("1"), word("2")), ErrDataTooShort},
+		{"bytes[2147483647]", words(t, word("20"), word("20")), ErrDataTooShort},
+	}
+
+	for _, testCase := range testCases {
+		_, err := Decode(parseTypes(t, testCase.list), testCase.data)
+		require.ErrorIs(t, err, testCase.expected, testCase.list)
+	}
+}
+
+func TestDecode_AliasedOffsets(t *testing.T) {
+	types := parseTypes(t, "bytes[]")
+	contents := strings.Repeat("ab", 4*wordSize)
+
+	// both elements reference the same bytes, which would decode to twice the contents
+	data := words(t, word("20"), word("2"), word("40"), word("40"), word("80"), contents)
+	_, err := Decode(types, data)
+	require.Equal(t, ErrDecodedDataTooLarge, err)
+
+	data = words(t, word("20"), word("1"), word("20"), word("80"), contents)
+	values, err := Decode(types, data)
+	require.Nil(t, err)
+	require.Equal(t, words(t, contents), values[0].Items[0].Bytes)
+}
+
+func TestDecode_IgnoresTrailingData(t *testing.T) {
+	values, err := Decode(parseTypes(t, "uint16"), words(t, word("ffff"), word("1")))
+	require.Nil(t, err)
+	require.Equal(t, []*Value{elementary([]byte{0xff, 0xff})}, values)
+}
@@ -0,0 +1,32 @@
+package evmabi
+
+import (
+	"errors"
+)
+
+// ErrInvalidType is raised when a type or a function signature does not follow the Solidity ABI grammar
+var ErrInvalidType = errors.New("invalid EVM ABI type")
+
+// ErrTypeTooDeep is raised when tuples and arrays are nested deeper than allowed
+var ErrTypeTooDeep = errors.New("EVM ABI type nested too deep")
+
+// ErrValueCountMismatch is raised when the number of values does not match the number of types or the length of a fixed array
+var ErrValueCountMismatch = errors.New("EVM ABI value count mismatch")
+
+// ErrInvalidValue is raised when a value does not have the representation required by its type
+var ErrInvalidValue = errors.New("invalid value for EVM ABI type")
+
+// ErrValueOutOfRange is raised when an integer does not fit in the size of its type
+var ErrValueOutOfRange = errors.New("value out of range for EVM ABI type")
+
+// ErrDataTooShort is raised when the encoded data ends before all the values are decoded
+var ErrDataTooShort = errors.New("EVM ABI data too short")
+
+// ErrInvalidOffset is raised when an offset or a length of the encoded data points outside of it
+var ErrInvalidOffset = errors.New("invalid offset in EVM ABI data")
+
+// ErrInvalidPadding is raised when the padding of an encoded value is not zero, or not the sign extension for signed integers
+var ErrInvalidPadding = errors.New("invalid padding in EVM ABI data")
+
+// ErrDecodedDataTooLarge is raised when offsets pointing to the same data would decode to more bytes than were encoded
+var ErrDecodedDataTooLarge = errors.New("decoded EVM ABI values exceed the size of the encoded data")
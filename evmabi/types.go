@@ -0,0 +1,350 @@
+package evmabi
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/multiversx/mx-chain-vm-go/math"
+)
+
+const wordSize = 32
+
+const maxTypeDepth = 16
+
+// Kind identifies the Solidity type families supported by the ABI encoding.
+type Kind uint8
+
+const (
+	// KindUint is an unsigned integer of Size bits
+	KindUint Kind = iota
+	// KindInt is a signed integer of Size bits
+	KindInt
+	// KindAddress is a 20 bytes address
+	KindAddress
+	// KindBool is a boolean
+	KindBool
+	// KindFixedBytes is a byte array of Size bytes
+	KindFixedBytes
+	// KindBytes is a dynamic byte array
+	KindBytes
+	// KindString is a dynamic UTF-8 string, encoded as a byte array
+	KindString
+	// KindArray is an array of Size elements of type Elem
+	KindArray
+	// KindSlice is a dynamic array of elements of type Elem
+	KindSlice
+	// KindTuple is a tuple of the Components types
+	KindTuple
+)
+
+// Type is a Solidity type, as used by the ABI encoding.
+type Type struct {
+	Kind       Kind
+	Size       int
+	Elem       *Type
+	Components []*Type
+}
+
+// String returns the canonical name of the type, as used in function signatures.
+func (typ *Type) String() string {
+	switch typ.Kind {
+	case KindUint:
+		return "uint" + strconv.Itoa(typ.Size)
+	case KindInt:
+		return "int" + strconv.Itoa(typ.Size)
+	case KindAddress:
+		return "address"
+	case KindBool:
+		return "bool"
+	case KindFixedBytes:
+		return "bytes" + strconv.Itoa(typ.Size)
+	case KindBytes:
+		return "bytes"
+	case KindString:
+		return "string"
+	case KindArray:
+		return typ.Elem.String() + "[" + strconv.Itoa(typ.Size) + "]"
+	case KindSlice:
+		return typ.Elem.String() + "[]"
+	case KindTuple:
+		return "(" + typesString(typ.Components) + ")"
+	default:
+		return fmt.Sprintf("unknown(%d)", typ.Kind)
+	}
+}
+
+// IsDynamic returns true if the encoding of the type has a variable length, and is then referenced by an offset.
+func (typ *Type) IsDynamic() bool {
+	switch typ.Kind {
+	case KindBytes, KindString, KindSlice:
+		return true
+	case KindArray:
+		return typ.Elem.IsDynamic()
+	case KindTuple:
+		for _, component := range typ.Components {
+			if component.IsDynamic() {
+				return true
+			}
+		}
+		return false
+	default:
+		return false
+	}
+}
+
+// headSize returns the size the type takes in the head of an encoding: an offset for dynamic types,
+// the whole encoding for static types. It saturates instead of overflowing.
+func (typ *Type) headSize() uint64 {
+	if typ.IsDynamic() {
+		return wordSize
+	}
+
+	switch typ.Kind {
+	case KindArray:
+		return math.MulUint64(uint64(typ.Size), typ.Elem.headSize())
+	case KindTuple:
+		return sequenceHeadSize(typ.Components)
+	default:
+		return wordSize
+	}
+}
+
+func sequenceHeadSize(types []*Type) uint64 {
+	size := uint64(0)
+	for _, typ := range types {
+		size = math.AddUint64(size, typ.headSize())
+	}
+	return size
+}
+
+func repeatType(typ *Type, count int) []*Type {
+	types := make([]*Type, count)
+	for i := range types {
+		types[i] = typ
+	}
+	return types
+}
+
+func typesString(types []*Type) string {
+	names := make([]string, len(types))
+	for i, typ := range types {
+		names[i] = typ.String()
+	}
+	return strings.Join(names, ",")
+}
+
+// ParseTypes parses a comma-separated list of Solidity types, such as "uint256,address[],(bytes,bool)".
+// The uint and int aliases are accepted; fixed-point and function types are not supported.
+func ParseTypes(list string) ([]*Type, error) {
+	parser := &typeParser{input: removeWhitespace(list)}
+	types, err := parser.parseList(0)
+	if err != nil {
+		return nil, err
+	}
+	if !parser.isAtEnd() {
+		return nil, fmt.Errorf("%w: unexpected %q", ErrInvalidType, parser.input[parser.position:])
+	}
+	return types, nil
+}
+
+// CanonicalSignature returns the canonical form of a function signature such as "transfer(address, uint)",
+// which is hashed to compute the function selector.
+func CanonicalSignature(signature string) (string, error) {
+	signature = removeWhitespace(signature)
+	openIndex := strings.IndexByte(signature, '(')
+	if openIndex < 0 || !strings.HasSuffix(signature, ")") {
+		return "", fmt.Errorf("%w: %q is not a function signature", ErrInvalidType, signature)
+	}
+
+	name := signature[:openIndex]
+	if !isIdentifier(name) {
+		return "", fmt.Errorf("%w: invalid function name %q", ErrInvalidType, name)
+	}
+
+	types, err := ParseTypes(signature[openIndex+1 : len(signature)-1])
+	if err != nil {
+		return "", err
+	}
+
+	return name + "(" + typesString(types) + ")", nil
+}
+
+type typeParser struct {
+	input    string
+	position int
+}
+
+func (parser *typeParser) isAtEnd() bool {
+	return parser.position >= len(parser.input)
+}
+
+func (parser *typeParser) peek() byte {
+	if parser.isAtEnd() {
+		return 0
+	}
+	return parser.input[parser.position]
+}
+
+func (parser *typeParser) consume(expected byte) bool {
+	if parser.peek() != expected {
+		return false
+	}
+	parser.position++
+	return true
+}
+
+func (parser *typeParser) readWhile(accept func(byte) bool) string {
+	start := parser.position
+	for !parser.isAtEnd() && accept(parser.input[parser.position]) {
+		parser.position++
+	}
+	return parser.input[start:parser.position]
+}
+
+// parseList parses comma-separated types, up to the end of the input or a closing parenthesis.
+func (parser *typeParser) parseList(depth int) ([]*Type, error) {
+	types := make([]*Type, 0)
+	if parser.isAtEnd() || parser.peek() == ')' {
+		return types, nil
+	}
+
+	for {
+		typ, err := parser.parseType(depth)
+		if err != nil {
+			return nil, err
+		}
+		types = append(types, typ)
+
+		if !parser.consume(',') {
+			return types, nil
+		}
+	}
+}
+
+func (parser *typeParser) parseType(depth int) (*Type, error) {
+	if depth > maxTypeDepth {
+		return nil, ErrTypeTooDeep
+	}
+
+	var typ *Type
+	if parser.consume('(') {
+		components, err := parser.parseList(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		if !parser.consume(')') {
+			return nil, fmt.Errorf("%w: unclosed tuple", ErrInvalidType)
+		}
+		if len(components) == 0 {
+			return nil, fmt.Errorf("%w: empty tuple", ErrInvalidType)
+		}
+		typ = &Type{Kind: KindTuple, Components: components}
+	} else {
+		name := parser.readWhile(isLowerAlphanumeric)
+		elementary, err := parseElementaryType(name)
+		if err != nil {
+			return nil, err
+		}
+		typ = elementary
+	}
+
+	for parser.consume('[') {
+		depth++
+		if depth > maxTypeDepth {
+			return nil, ErrTypeTooDeep
+		}
+
+		length := parser.readWhile(isDigit)
+		if !parser.consume(']') {
+			return nil, fmt.Errorf("%w: unclosed array of %s", ErrInvalidType, typ)
+		}
+		if len(length) == 0 {
+			typ = &Type{Kind: KindSlice, Elem: typ}
+			continue
+		}
+
+		size, ok := parseCanonicalInt(length, 1, maxArrayLength)
+		if !ok {
+			return nil, fmt.Errorf("%w: invalid array length %s", ErrInvalidType, length)
+		}
+		typ = &Type{Kind: KindArray, Size: size, Elem: typ}
+	}
+
+	return typ, nil
+}
+
+// maxArrayLength keeps the sizes of fixed arrays within int on all platforms.
+const maxArrayLength = 1<<31 - 1
+
+func parseElementaryType(name string) (*Type, error) {
+	switch name {
+	case "uint":
+		return &Type{Kind: KindUint, Size: 256}, nil
+	case "int":
+		return &Type{Kind: KindInt, Size: 256}, nil
+	case "address":
+		return &Type{Kind: KindAddress}, nil
+	case "bool":
+		return &Type{Kind: KindBool}, nil
+	case "bytes":
+		return &Type{Kind: KindBytes}, nil
+	case "string":
+		return &Type{Kind: KindString}, nil
+	}
+
+	if strings.HasPrefix(name, "uint") {
+		bits, ok := parseCanonicalInt(name[len("uint"):], 8, 256)
+		if ok && bits%8 == 0 {
+			return &Type{Kind: KindUint, Size: bits}, nil
+		}
+	} else if strings.HasPrefix(name, "int") {
+		bits, ok := parseCanonicalInt(name[len("int"):], 8, 256)
+		if ok && bits%8 == 0 {
+			return &Type{Kind: KindInt, Size: bits}, nil
+		}
+	} else if strings.HasPrefix(name, "bytes") {
+		size, ok := parseCanonicalInt(name[len("bytes"):], 1, wordSize)
+		if ok {
+			return &Type{Kind: KindFixedBytes, Size: size}, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: unknown type %q", ErrInvalidType, name)
+}
+
+// parseCanonicalInt parses a decimal number without leading zeros within the given bounds.
+func parseCanonicalInt(text string, min int, max int) (int, bool) {
+	value, err := strconv.Atoi(text)
+	if err != nil || strconv.Itoa(value) != text {
+		return 0, false
+	}
+	return value, value >= min && value <= max
+}
+
+func removeWhitespace(text string) string {
+	return strings.Join(strings.FieldsFunc(text, unicode.IsSpace), "")
+}
+
+func isLowerAlphanumeric(c byte) bool {
+	return (c >= 'a' && c <= 'z') || isDigit(c)
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isIdentifier(name string) bool {
+	if len(name) == 0 || isDigit(name[0]) {
+		return false
+	}
+	for i := 0; i < len(name); i++ {
+		c := name[i]
+		isLetter := (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+		if !isLetter && !isDigit(c) && c != '_' && c != '$' {
+			return false
+		}
+	}
+	return true
+}
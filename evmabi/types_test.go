@@ -0,0 +1,73 @@
+package evmabi
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTypes_Canonical(t *testing.T) {
+	testCases := []struct {
+		list     string
+		expected string
+	}{
+		{"", ""},
+		{"uint", "uint256"},
+		{"int, uint8", "int256,uint8"},
+		{"address[], bytes32[2][]", "address[],bytes32[2][]"},
+		{"(uint, (bool, string)[])[3]", "(uint256,(bool,string)[])[3]"},
+		{"bytes,bytes1,int256", "bytes,bytes1,int256"},
+	}
+
+	for _, testCase := range testCases {
+		types, err := ParseTypes(testCase.list)
+		require.Nil(t, err, testCase.list)
+		require.Equal(t, testCase.expected, typesString(types))
+	}
+}
+
+func TestParseTypes_Invalid(t *testing.T) {
+	invalid := []string{
+		"uint7", "uint264", "uint08", "int0", "bytes0", "bytes33", "fixed128x18", "function",
+		"uint[0]", "uint[01]", "uint[", "()", "(uint", "uint)", "uint,", ",uint", "Uint",
+	}
+
+	for _, list := range invalid {
+		_, err := ParseTypes(list)
+		require.ErrorIs(t, err, ErrInvalidType, list)
+	}
+
+	_, err := ParseTypes(strings.Repeat("(", maxTypeDepth+1) + "uint" + strings.Repeat(")", maxTypeDepth+1))
+	require.Equal(t, ErrTypeTooDeep, err)
+
+	_, err = ParseTypes("uint" + strings.Repeat("[]", maxTypeDepth+1))
+	require.Equal(t, ErrTypeTooDeep, err)
+}
+
+func TestType_IsDynamic(t *testing.T) {
+	types, err := ParseTypes("uint8[3],string,bytes[2],(bool,address),(bool,bytes),uint[]")
+	require.Nil(t, err)
+
+	expected := []bool{false, true, true, false, true, true}
+	for i, typ := range types {
+		require.Equal(t, expected[i], typ.IsDynamic(), typ.String())
+	}
+	require.Equal(t, uint64(3*wordSize), types[0].headSize())
+	require.Equal(t, uint64(2*wordSize), types[3].headSize())
+}
+
+func TestCanonicalSignature(t *testing.T) {
+	signature, err := CanonicalSignature(" transfer(address, uint) ")
+	require.Nil(t, err)
+	require.Equal(t, "transfer(address,uint256)", signature)
+
+	signature, err = CanonicalSignature("_f$1()")
+	require.Nil(t, err)
+	require.Equal(t, "_f$1()", signature)
+
+	for _, invalid := range []string{"transfer", "(uint)", "1f(uint)", "f(uint", "f-g(uint)", "f(uint9)"} {
+		_, err = CanonicalSignature(invalid)
+		require.ErrorIs(t, err, ErrInvalidType, invalid)
+	}
+}
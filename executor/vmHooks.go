@@ -11,6 +11,7 @@ type VMHooks interface {
 	MainVMHooks
 	ManagedVMHooks
 	BigFloatVMHooks
+	DecimalVMHooks
 	BigIntVMHooks
 	ManagedBufferVMHooks
 	ManagedMapVMHooks
@@ -20,6 +21,9 @@ type VMHooks interface {
 
 type MainVMHooks interface {
 	GetGasLeft() int64
+	GetMemoryUsage() int64
+	CountInstructions(count int64)
+	ChargeMemoryGrowth(previousPages int32) int32
 	GetSCAddress(resultOffset MemPtr)
 	GetOwnerAddress(resultOffset MemPtr)
 	GetShardOfAddress(addressOffset MemPtr) int32
@@ -57,6 +61,9 @@ type MainVMHooks interface {
 	GetStorageLock(keyOffset MemPtr, keyLength MemLength) int64
 	IsStorageLocked(keyOffset MemPtr, keyLength MemLength) int32
 	ClearStorageLock(keyOffset MemPtr, keyLength MemLength) int32
+	TransientStore(keyHandle int32, valueHandle int32) int32
+	TransientLoad(keyHandle int32, destinationHandle int32) int32
+	GetStorageDeposit(resultHandle int32)
 	GetCaller(resultOffset MemPtr)
 	CheckNoPayment()
 	GetCallValue(resultOffset MemPtr) int32
@@ -126,7 +133,12 @@ type ManagedVMHooks interface {
 	ManagedUpgradeContract(destHandle int32, gas int64, valueHandle int32, codeHandle int32, codeMetadataHandle int32, argumentsHandle int32, resultHandle int32)
 	ManagedDeleteContract(destHandle int32, gasLimit int64, argumentsHandle int32)
 	ManagedDeployFromSourceContract(gas int64, valueHandle int32, addressHandle int32, codeMetadataHandle int32, argumentsHandle int32, resultAddressHandle int32, resultHandle int32) int32
+	ManagedDeployFromSourceContractWithSalt(gas int64, valueHandle int32, addressHandle int32, codeMetadataHandle int32, argumentsHandle int32, saltHandle int32, resultAddressHandle int32, resultHandle int32) int32
 	ManagedCreateContract(gas int64, valueHandle int32, codeHandle int32, codeMetadataHandle int32, argumentsHandle int32, resultAddressHandle int32, resultHandle int32) int32
+	ManagedCreateContractWithSalt(gas int64, valueHandle int32, codeHandle int32, codeMetadataHandle int32, argumentsHandle int32, saltHandle int32, resultAddressHandle int32, resultHandle int32) int32
+	ManagedDeployFromCodeHash(gas int64, valueHandle int32, codeHashHandle int32, codeMetadataHandle int32, argumentsHandle int32, resultAddressHandle int32, resultHandle int32) int32
+	ManagedRegisterCode(sourceContractAddressHandle int32, resultCodeHashHandle int32) int32
+	ManagedComputeContractAddress(creatorHandle int32, saltHandle int32, codeHashHandle int32, resultHandle int32) int32
 	ManagedExecuteReadOnly(gas int64, addressHandle int32, functionHandle int32, argumentsHandle int32, resultHandle int32) int32
 	ManagedExecuteOnSameContext(gas int64, addressHandle int32, valueHandle int32, functionHandle int32, argumentsHandle int32, resultHandle int32) int32
 	ManagedExecuteOnDestContext(gas int64, addressHandle int32, valueHandle int32, functionHandle int32, argumentsHandle int32, resultHandle int32) int32
@@ -138,7 +150,16 @@ type ManagedVMHooks interface {
 	ManagedIsESDTPaused(tokenIDHandle int32) int32
 	ManagedBufferToHex(sourceHandle int32, destHandle int32)
 	ManagedGetCodeMetadata(addressHandle int32, responseHandle int32)
+	ManagedGetCodeHash(addressHandle int32, resultHandle int32)
+	ManagedGetCodeDeployer(addressHandle int32, resultHandle int32)
+	ManagedGetContractDeployInfo(addressHandle int32, deployerHandle int32, blockNonceHandle int32, blockTimestampHandle int32) int32
 	ManagedIsBuiltinFunction(functionNameHandle int32) int32
+	ManagedEVMAbiEncode(typesHandle int32, valuesHandle int32, resultHandle int32) int32
+	ManagedEVMAbiDecode(typesHandle int32, dataHandle int32, resultHandle int32) int32
+	ManagedSetUpgradePolicy(delayRounds int64, threshold int32, approversHandle int32) int32
+	ManagedProposeUpgrade(codeHashHandle int32, codeMetadataHandle int32) int32
+	ManagedApproveUpgrade() int32
+	ManagedCancelUpgrade() int32
 }
 
 type BigFloatVMHooks interface {
@@ -166,6 +187,20 @@ type BigFloatVMHooks interface {
 	BigFloatGetConstE(destinationHandle int32)
 }
 
+type DecimalVMHooks interface {
+	DecimalNew(significand int64, scale int32) int32
+	DecimalFromBigInt(destinationHandle int32, bigIntHandle int32, scale int32)
+	DecimalToBigInt(destBigIntHandle int32, opHandle int32) int32
+	DecimalRescale(destinationHandle int32, opHandle int32, scale int32, roundingMode int32)
+	DecimalAdd(destinationHandle int32, op1Handle int32, op2Handle int32)
+	DecimalSub(destinationHandle int32, op1Handle int32, op2Handle int32)
+	DecimalMul(destinationHandle int32, op1Handle int32, op2Handle int32, scale int32, roundingMode int32)
+	DecimalDiv(destinationHandle int32, op1Handle int32, op2Handle int32, scale int32, roundingMode int32)
+	DecimalCmp(op1Handle int32, op2Handle int32) int32
+	DecimalLn(destinationHandle int32, opHandle int32, scale int32)
+	DecimalExp(destinationHandle int32, opHandle int32, scale int32)
+}
+
 type BigIntVMHooks interface {
 	BigIntGetUnsignedArgument(id int32, destinationHandle int32)
 	BigIntGetSignedArgument(id int32, destinationHandle int32)
@@ -195,6 +230,10 @@ type BigIntVMHooks interface {
 	BigIntEMod(destinationHandle int32, op1Handle int32, op2Handle int32)
 	BigIntSqrt(destinationHandle int32, opHandle int32)
 	BigIntPow(destinationHandle int32, op1Handle int32, op2Handle int32)
+	BigIntModPow(destinationHandle int32, baseHandle int32, exponentHandle int32, modulusHandle int32)
+	BigIntModInverse(destinationHandle int32, opHandle int32, modulusHandle int32)
+	BigIntMulMod(destinationHandle int32, op1Handle int32, op2Handle int32, modulusHandle int32)
+	BigIntGCD(destinationHandle int32, op1Handle int32, op2Handle int32)
 	BigIntLog2(op1Handle int32) int32
 	BigIntAbs(destinationHandle int32, opHandle int32)
 	BigIntNeg(destinationHandle int32, opHandle int32)
@@ -229,6 +268,8 @@ type ManagedBufferVMHooks interface {
 	MBufferFromBigIntSigned(mBufferHandle int32, bigIntHandle int32) int32
 	MBufferToBigFloat(mBufferHandle int32, bigFloatHandle int32) int32
 	MBufferFromBigFloat(mBufferHandle int32, bigFloatHandle int32) int32
+	MBufferToDecimal(mBufferHandle int32, decimalHandle int32) int32
+	MBufferFromDecimal(mBufferHandle int32, decimalHandle int32) int32
 	MBufferStorageStore(keyHandle int32, sourceHandle int32) int32
 	MBufferStorageLoad(keyHandle int32, destinationHandle int32) int32
 	MBufferStorageLoadFromAddress(addressHandle int32, keyHandle int32, destinationHandle int32)
@@ -302,4 +343,5 @@ type CryptoVMHooks interface {
 	ManagedVerifySecp256r1(keyHandle int32, messageHandle int32, sigHandle int32) int32
 	ManagedVerifyBLSSignatureShare(keyHandle int32, messageHandle int32, sigHandle int32) int32
 	ManagedVerifyBLSAggregatedSignature(keyHandle int32, messageHandle int32, sigHandle int32) int32
+	ManagedEVMFunctionSelector(signatureHandle int32, resultHandle int32) int32
 }
@@ -27,6 +27,32 @@ func (w *WrapperVMHooks) GetGasLeft() int64 {
 	return result
 }
 
+// GetMemoryUsage VM hook wrapper
+func (w *WrapperVMHooks) GetMemoryUsage() int64 {
+	callInfo := "GetMemoryUsage()"
+	w.logger.LogVMHookCallBefore(callInfo)
+	result := w.wrappedVMHooks.GetMemoryUsage()
+	w.logger.LogVMHookCallAfter(callInfo)
+	return result
+}
+
+// CountInstructions VM hook wrapper
+func (w *WrapperVMHooks) CountInstructions(count int64) {
+	callInfo := fmt.Sprintf("CountInstructions(%d)", count)
+	w.logger.LogVMHookCallBefore(callInfo)
+	w.wrappedVMHooks.CountInstructions(count)
+	w.logger.LogVMHookCallAfter(callInfo)
+}
+
+// ChargeMemoryGrowth VM hook wrapper
+func (w *WrapperVMHooks) ChargeMemoryGrowth(previousPages int32) int32 {
+	callInfo := fmt.Sprintf("ChargeMemoryGrowth(%d)", previousPages)
+	w.logger.LogVMHookCallBefore(callInfo)
+	result := w.wrappedVMHooks.ChargeMemoryGrowth(previousPages)
+	w.logger.LogVMHookCallAfter(callInfo)
+	return result
+}
+
 // GetSCAddress VM hook wrapper
 func (w *WrapperVMHooks) GetSCAddress(resultOffset executor.MemPtr) {
 	callInfo := fmt.Sprintf("GetSCAddress(%d)", resultOffset)
@@ -352,6 +378,32 @@ func (w *WrapperVMHooks) ClearStorageLock(keyOffset executor.MemPtr, keyLength e
 	return result
 }
 
+// TransientStore VM hook wrapper
+func (w *WrapperVMHooks) TransientStore(keyHandle int32, valueHandle int32) int32 {
+	callInfo := fmt.Sprintf("TransientStore(%d, %d)", keyHandle, valueHandle)
+	w.logger.LogVMHookCallBefore(callInfo)
+	result := w.wrappedVMHooks.TransientStore(keyHandle, valueHandle)
+	w.logger.LogVMHookCallAfter(callInfo)
+	return result
+}
+
+// TransientLoad VM hook wrapper
+func (w *WrapperVMHooks) TransientLoad(keyHandle int32, destinationHandle int32) int32 {
+	callInfo := fmt.Sprintf("TransientLoad(%d, %d)", keyHandle, destinationHandle)
+	w.logger.LogVMHookCallBefore(callInfo)
+	result := w.wrappedVMHooks.TransientLoad(keyHandle, destinationHandle)
+	w.logger.LogVMHookCallAfter(callInfo)
+	return result
+}
+
+// GetStorageDeposit VM hook wrapper
+func (w *WrapperVMHooks) GetStorageDeposit(resultHandle int32) {
+	callInfo := fmt.Sprintf("GetStorageDeposit(%d)", resultHandle)
+	w.logger.LogVMHookCallBefore(callInfo)
+	w.wrappedVMHooks.GetStorageDeposit(resultHandle)
+	w.logger.LogVMHookCallAfter(callInfo)
+}
+
 // GetCaller VM hook wrapper
 func (w *WrapperVMHooks) GetCaller(resultOffset executor.MemPtr) {
 	callInfo := fmt.Sprintf("GetCaller(%d)", resultOffset)
@@ -912,6 +964,15 @@ func (w *WrapperVMHooks) ManagedDeployFromSourceContract(gas int64, valueHandle
 	return result
 }
 
+// ManagedDeployFromSourceContractWithSalt VM hook wrapper
+func (w *WrapperVMHooks) ManagedDeployFromSourceContractWithSalt(gas int64, valueHandle int32, addressHandle int32, codeMetadataHandle int32, argumentsHandle int32, saltHandle int32, resultAddressHandle int32, resultHandle int32) int32 {
+	callInfo := fmt.Sprintf("ManagedDeployFromSourceContractWithSalt(%d, %d, %d, %d, %d, %d, %d, %d)", gas, valueHandle, addressHandle, codeMetadataHandle, argumentsHandle, saltHandle, resultAddressHandle, resultHandle)
+	w.logger.LogVMHookCallBefore(callInfo)
+	result := w.wrappedVMHooks.ManagedDeployFromSourceContractWithSalt(gas, valueHandle, addressHandle, codeMetadataHandle, argumentsHandle, saltHandle, resultAddressHandle, resultHandle)
+	w.logger.LogVMHookCallAfter(callInfo)
+	return result
+}
+
 // ManagedCreateContract VM hook wrapper
 func (w *WrapperVMHooks) ManagedCreateContract(gas int64, valueHandle int32, codeHandle int32, codeMetadataHandle int32, argumentsHandle int32, resultAddressHandle int32, resultHandle int32) int32 {
 	callInfo := fmt.Sprintf("ManagedCreateContract(%d, %d, %d, %d, %d, %d, %d)", gas, valueHandle, codeHandle, codeMetadataHandle, argumentsHandle, resultAddressHandle, resultHandle)
@@ -921,6 +982,42 @@ func (w *WrapperVMHooks) ManagedCreateContract(gas int64, valueHandle int32, cod
 	return result
 }
 
+// ManagedCreateContractWithSalt VM hook wrapper
+func (w *WrapperVMHooks) ManagedCreateContractWithSalt(gas int64, valueHandle int32, codeHandle int32, codeMetadataHandle int32, argumentsHandle int32, saltHandle int32, resultAddressHandle int32, resultHandle int32) int32 {
+	callInfo := fmt.Sprintf("ManagedCreateContractWithSalt(%d, %d, %d, %d, %d, %d, %d, %d)", gas, valueHandle, codeHandle, codeMetadataHandle, argumentsHandle, saltHandle, resultAddressHandle, resultHandle)
+	w.logger.LogVMHookCallBefore(callInfo)
+	result := w.wrappedVMHooks.ManagedCreateContractWithSalt(gas, valueHandle, codeHandle, codeMetadataHandle, argumentsHandle, saltHandle, resultAddressHandle, resultHandle)
+	w.logger.LogVMHookCallAfter(callInfo)
+	return result
+}
+
+// ManagedDeployFromCodeHash VM hook wrapper
+func (w *WrapperVMHooks) ManagedDeployFromCodeHash(gas int64, valueHandle int32, codeHashHandle int32, codeMetadataHandle int32, argumentsHandle int32, resultAddressHandle int32, resultHandle int32) int32 {
+	callInfo := fmt.Sprintf("ManagedDeployFromCodeHash(%d, %d, %d, %d, %d, %d, %d)", gas, valueHandle, codeHashHandle, codeMetadataHandle, argumentsHandle, resultAddressHandle, resultHandle)
+	w.logger.LogVMHookCallBefore(callInfo)
+	result := w.wrappedVMHooks.ManagedDeployFromCodeHash(gas, valueHandle, codeHashHandle, codeMetadataHandle, argumentsHandle, resultAddressHandle, resultHandle)
+	w.logger.LogVMHookCallAfter(callInfo)
+	return result
+}
+
+// ManagedRegisterCode VM hook wrapper
+func (w *WrapperVMHooks) ManagedRegisterCode(sourceContractAddressHandle int32, resultCodeHashHandle int32) int32 {
+	callInfo := fmt.Sprintf("ManagedRegisterCode(%d, %d)", sourceContractAddressHandle, resultCodeHashHandle)
+	w.logger.LogVMHookCallBefore(callInfo)
+	result := w.wrappedVMHooks.ManagedRegisterCode(sourceContractAddressHandle, resultCodeHashHandle)
+	w.logger.LogVMHookCallAfter(callInfo)
+	return result
+}
+
+// ManagedComputeContractAddress VM hook wrapper
+func (w *WrapperVMHooks) ManagedComputeContractAddress(creatorHandle int32, saltHandle int32, codeHashHandle int32, resultHandle int32) int32 {
+	callInfo := fmt.Sprintf("ManagedComputeContractAddress(%d, %d, %d, %d)", creatorHandle, saltHandle, codeHashHandle, resultHandle)
+	w.logger.LogVMHookCallBefore(callInfo)
+	result := w.wrappedVMHooks.ManagedComputeContractAddress(creatorHandle, saltHandle, codeHashHandle, resultHandle)
+	w.logger.LogVMHookCallAfter(callInfo)
+	return result
+}
+
 // ManagedExecuteReadOnly VM hook wrapper
 func (w *WrapperVMHooks) ManagedExecuteReadOnly(gas int64, addressHandle int32, functionHandle int32, argumentsHandle int32, resultHandle int32) int32 {
 	callInfo := fmt.Sprintf("ManagedExecuteReadOnly(%d, %d, %d, %d, %d)", gas, addressHandle, functionHandle, argumentsHandle, resultHandle)
@@ -1018,6 +1115,31 @@ func (w *WrapperVMHooks) ManagedGetCodeMetadata(addressHandle int32, responseHan
 	w.logger.LogVMHookCallAfter(callInfo)
 }
 
+// ManagedGetCodeHash VM hook wrapper
+func (w *WrapperVMHooks) ManagedGetCodeHash(addressHandle int32, resultHandle int32) {
+	callInfo := fmt.Sprintf("ManagedGetCodeHash(%d, %d)", addressHandle, resultHandle)
+	w.logger.LogVMHookCallBefore(callInfo)
+	w.wrappedVMHooks.ManagedGetCodeHash(addressHandle, resultHandle)
+	w.logger.LogVMHookCallAfter(callInfo)
+}
+
+// ManagedGetCodeDeployer VM hook wrapper
+func (w *WrapperVMHooks) ManagedGetCodeDeployer(addressHandle int32, resultHandle int32) {
+	callInfo := fmt.Sprintf("ManagedGetCodeDeployer(%d, %d)", addressHandle, resultHandle)
+	w.logger.LogVMHookCallBefore(callInfo)
+	w.wrappedVMHooks.ManagedGetCodeDeployer(addressHandle, resultHandle)
+	w.logger.LogVMHookCallAfter(callInfo)
+}
+
+// ManagedGetContractDeployInfo VM hook wrapper
+func (w *WrapperVMHooks) ManagedGetContractDeployInfo(addressHandle int32, deployerHandle int32, blockNonceHandle int32, blockTimestampHandle int32) int32 {
+	callInfo := fmt.Sprintf("ManagedGetContractDeployInfo(%d, %d, %d, %d)", addressHandle, deployerHandle, blockNonceHandle, blockTimestampHandle)
+	w.logger.LogVMHookCallBefore(callInfo)
+	result := w.wrappedVMHooks.ManagedGetContractDeployInfo(addressHandle, deployerHandle, blockNonceHandle, blockTimestampHandle)
+	w.logger.LogVMHookCallAfter(callInfo)
+	return result
+}
+
 // ManagedIsBuiltinFunction VM hook wrapper
 func (w *WrapperVMHooks) ManagedIsBuiltinFunction(functionNameHandle int32) int32 {
 	callInfo := fmt.Sprintf("ManagedIsBuiltinFunction(%d)", functionNameHandle)
@@ -1027,6 +1149,60 @@ func (w *WrapperVMHooks) ManagedIsBuiltinFunction(functionNameHandle int32) int3
 	return result
 }
 
+// ManagedEVMAbiEncode VM hook wrapper
+func (w *WrapperVMHooks) ManagedEVMAbiEncode(typesHandle int32, valuesHandle int32, resultHandle int32) int32 {
+	callInfo := fmt.Sprintf("ManagedEVMAbiEncode(%d, %d, %d)", typesHandle, valuesHandle, resultHandle)
+	w.logger.LogVMHookCallBefore(callInfo)
+	result := w.wrappedVMHooks.ManagedEVMAbiEncode(typesHandle, valuesHandle, resultHandle)
+	w.logger.LogVMHookCallAfter(callInfo)
+	return result
+}
+
+// ManagedEVMAbiDecode VM hook wrapper
+func (w *WrapperVMHooks) ManagedEVMAbiDecode(typesHandle int32, dataHandle int32, resultHandle int32) int32 {
+	callInfo := fmt.Sprintf("ManagedEVMAbiDecode(%d, %d, %d)", typesHandle, dataHandle, resultHandle)
+	w.logger.LogVMHookCallBefore(callInfo)
+	result := w.wrappedVMHooks.ManagedEVMAbiDecode(typesHandle, dataHandle, resultHandle)
+	w.logger.LogVMHookCallAfter(callInfo)
+	return result
+}
+
+// ManagedSetUpgradePolicy VM hook wrapper
+func (w *WrapperVMHooks) ManagedSetUpgradePolicy(delayRounds int64, threshold int32, approversHandle int32) int32 {
+	callInfo := fmt.Sprintf("ManagedSetUpgradePolicy(%d, %d, %d)", delayRounds, threshold, approversHandle)
+	w.logger.LogVMHookCallBefore(callInfo)
+	result := w.wrappedVMHooks.ManagedSetUpgradePolicy(delayRounds, threshold, approversHandle)
+	w.logger.LogVMHookCallAfter(callInfo)
+	return result
+}
+
+// ManagedProposeUpgrade VM hook wrapper
+func (w *WrapperVMHooks) ManagedProposeUpgrade(codeHashHandle int32, codeMetadataHandle int32) int32 {
+	callInfo := fmt.Sprintf("ManagedProposeUpgrade(%d, %d)", codeHashHandle, codeMetadataHandle)
+	w.logger.LogVMHookCallBefore(callInfo)
+	result := w.wrappedVMHooks.ManagedProposeUpgrade(codeHashHandle, codeMetadataHandle)
+	w.logger.LogVMHookCallAfter(callInfo)
+	return result
+}
+
+// ManagedApproveUpgrade VM hook wrapper
+func (w *WrapperVMHooks) ManagedApproveUpgrade() int32 {
+	callInfo := "ManagedApproveUpgrade()"
+	w.logger.LogVMHookCallBefore(callInfo)
+	result := w.wrappedVMHooks.ManagedApproveUpgrade()
+	w.logger.LogVMHookCallAfter(callInfo)
+	return result
+}
+
+// ManagedCancelUpgrade VM hook wrapper
+func (w *WrapperVMHooks) ManagedCancelUpgrade() int32 {
+	callInfo := "ManagedCancelUpgrade()"
+	w.logger.LogVMHookCallBefore(callInfo)
+	result := w.wrappedVMHooks.ManagedCancelUpgrade()
+	w.logger.LogVMHookCallAfter(callInfo)
+	return result
+}
+
 // BigFloatNewFromParts VM hook wrapper
 func (w *WrapperVMHooks) BigFloatNewFromParts(integralPart int32, fractionalPart int32, exponent int32) int32 {
 	callInfo := fmt.Sprintf("BigFloatNewFromParts(%d, %d, %d)", integralPart, fractionalPart, exponent)
@@ -1209,6 +1385,97 @@ func (w *WrapperVMHooks) BigFloatGetConstE(destinationHandle int32) {
 	w.logger.LogVMHookCallAfter(callInfo)
 }
 
+// DecimalNew VM hook wrapper
+func (w *WrapperVMHooks) DecimalNew(significand int64, scale int32) int32 {
+	callInfo := fmt.Sprintf("DecimalNew(%d, %d)", significand, scale)
+	w.logger.LogVMHookCallBefore(callInfo)
+	result := w.wrappedVMHooks.DecimalNew(significand, scale)
+	w.logger.LogVMHookCallAfter(callInfo)
+	return result
+}
+
+// DecimalFromBigInt VM hook wrapper
+func (w *WrapperVMHooks) DecimalFromBigInt(destinationHandle int32, bigIntHandle int32, scale int32) {
+	callInfo := fmt.Sprintf("DecimalFromBigInt(%d, %d, %d)", destinationHandle, bigIntHandle, scale)
+	w.logger.LogVMHookCallBefore(callInfo)
+	w.wrappedVMHooks.DecimalFromBigInt(destinationHandle, bigIntHandle, scale)
+	w.logger.LogVMHookCallAfter(callInfo)
+}
+
+// DecimalToBigInt VM hook wrapper
+func (w *WrapperVMHooks) DecimalToBigInt(destBigIntHandle int32, opHandle int32) int32 {
+	callInfo := fmt.Sprintf("DecimalToBigInt(%d, %d)", destBigIntHandle, opHandle)
+	w.logger.LogVMHookCallBefore(callInfo)
+	result := w.wrappedVMHooks.DecimalToBigInt(destBigIntHandle, opHandle)
+	w.logger.LogVMHookCallAfter(callInfo)
+	return result
+}
+
+// DecimalRescale VM hook wrapper
+func (w *WrapperVMHooks) DecimalRescale(destinationHandle int32, opHandle int32, scale int32, roundingMode int32) {
+	callInfo := fmt.Sprintf("DecimalRescale(%d, %d, %d, %d)", destinationHandle, opHandle, scale, roundingMode)
+	w.logger.LogVMHookCallBefore(callInfo)
+	w.wrappedVMHooks.DecimalRescale(destinationHandle, opHandle, scale, roundingMode)
+	w.logger.LogVMHookCallAfter(callInfo)
+}
+
+// DecimalAdd VM hook wrapper
+func (w *WrapperVMHooks) DecimalAdd(destinationHandle int32, op1Handle int32, op2Handle int32) {
+	callInfo := fmt.Sprintf("DecimalAdd(%d, %d, %d)", destinationHandle, op1Handle, op2Handle)
+	w.logger.LogVMHookCallBefore(callInfo)
+	w.wrappedVMHooks.DecimalAdd(destinationHandle, op1Handle, op2Handle)
+	w.logger.LogVMHookCallAfter(callInfo)
+}
+
+// DecimalSub VM hook wrapper
+func (w *WrapperVMHooks) DecimalSub(destinationHandle int32, op1Handle int32, op2Handle int32) {
+	callInfo := fmt.Sprintf("DecimalSub(%d, %d, %d)", destinationHandle, op1Handle, op2Handle)
+	w.logger.LogVMHookCallBefore(callInfo)
+	w.wrappedVMHooks.DecimalSub(destinationHandle, op1Handle, op2Handle)
+	w.logger.LogVMHookCallAfter(callInfo)
+}
+
+// DecimalMul VM hook wrapper
+func (w *WrapperVMHooks) DecimalMul(destinationHandle int32, op1Handle int32, op2Handle int32, scale int32, roundingMode int32) {
+	callInfo := fmt.Sprintf("DecimalMul(%d, %d, %d, %d, %d)", destinationHandle, op1Handle, op2Handle, scale, roundingMode)
+	w.logger.LogVMHookCallBefore(callInfo)
+	w.wrappedVMHooks.DecimalMul(destinationHandle, op1Handle, op2Handle, scale, roundingMode)
+	w.logger.LogVMHookCallAfter(callInfo)
+}
+
+// DecimalDiv VM hook wrapper
+func (w *WrapperVMHooks) DecimalDiv(destinationHandle int32, op1Handle int32, op2Handle int32, scale int32, roundingMode int32) {
+	callInfo := fmt.Sprintf("DecimalDiv(%d, %d, %d, %d, %d)", destinationHandle, op1Handle, op2Handle, scale, roundingMode)
+	w.logger.LogVMHookCallBefore(callInfo)
+	w.wrappedVMHooks.DecimalDiv(destinationHandle, op1Handle, op2Handle, scale, roundingMode)
+	w.logger.LogVMHookCallAfter(callInfo)
+}
+
+// DecimalCmp VM hook wrapper
+func (w *WrapperVMHooks) DecimalCmp(op1Handle int32, op2Handle int32) int32 {
+	callInfo := fmt.Sprintf("DecimalCmp(%d, %d)", op1Handle, op2Handle)
+	w.logger.LogVMHookCallBefore(callInfo)
+	result := w.wrappedVMHooks.DecimalCmp(op1Handle, op2Handle)
+	w.logger.LogVMHookCallAfter(callInfo)
+	return result
+}
+
+// DecimalLn VM hook wrapper
+func (w *WrapperVMHooks) DecimalLn(destinationHandle int32, opHandle int32, scale int32) {
+	callInfo := fmt.Sprintf("DecimalLn(%d, %d, %d)", destinationHandle, opHandle, scale)
+	w.logger.LogVMHookCallBefore(callInfo)
+	w.wrappedVMHooks.DecimalLn(destinationHandle, opHandle, scale)
+	w.logger.LogVMHookCallAfter(callInfo)
+}
+
+// DecimalExp VM hook wrapper
+func (w *WrapperVMHooks) DecimalExp(destinationHandle int32, opHandle int32, scale int32) {
+	callInfo := fmt.Sprintf("DecimalExp(%d, %d, %d)", destinationHandle, opHandle, scale)
+	w.logger.LogVMHookCallBefore(callInfo)
+	w.wrappedVMHooks.DecimalExp(destinationHandle, opHandle, scale)
+	w.logger.LogVMHookCallAfter(callInfo)
+}
+
 // BigIntGetUnsignedArgument VM hook wrapper
 func (w *WrapperVMHooks) BigIntGetUnsignedArgument(id int32, destinationHandle int32) {
 	callInfo := fmt.Sprintf("BigIntGetUnsignedArgument(%d, %d)", id, destinationHandle)
@@ -1442,6 +1709,38 @@ func (w *WrapperVMHooks) BigIntPow(destinationHandle int32, op1Handle int32, op2
 	w.logger.LogVMHookCallAfter(callInfo)
 }
 
+// BigIntModPow VM hook wrapper
+func (w *WrapperVMHooks) BigIntModPow(destinationHandle int32, baseHandle int32, exponentHandle int32, modulusHandle int32) {
+	callInfo := fmt.Sprintf("BigIntModPow(%d, %d, %d, %d)", destinationHandle, baseHandle, exponentHandle, modulusHandle)
+	w.logger.LogVMHookCallBefore(callInfo)
+	w.wrappedVMHooks.BigIntModPow(destinationHandle, baseHandle, exponentHandle, modulusHandle)
+	w.logger.LogVMHookCallAfter(callInfo)
+}
+
+// BigIntModInverse VM hook wrapper
+func (w *WrapperVMHooks) BigIntModInverse(destinationHandle int32, opHandle int32, modulusHandle int32) {
+	callInfo := fmt.Sprintf("BigIntModInverse(%d, %d, %d)", destinationHandle, opHandle, modulusHandle)
+	w.logger.LogVMHookCallBefore(callInfo)
+	w.wrappedVMHooks.BigIntModInverse(destinationHandle, opHandle, modulusHandle)
+	w.logger.LogVMHookCallAfter(callInfo)
+}
+
+// BigIntMulMod VM hook wrapper
+func (w *WrapperVMHooks) BigIntMulMod(destinationHandle int32, op1Handle int32, op2Handle int32, modulusHandle int32) {
+	callInfo := fmt.Sprintf("BigIntMulMod(%d, %d, %d, %d)", destinationHandle, op1Handle, op2Handle, modulusHandle)
+	w.logger.LogVMHookCallBefore(callInfo)
+	w.wrappedVMHooks.BigIntMulMod(destinationHandle, op1Handle, op2Handle, modulusHandle)
+	w.logger.LogVMHookCallAfter(callInfo)
+}
+
+// BigIntGCD VM hook wrapper
+func (w *WrapperVMHooks) BigIntGCD(destinationHandle int32, op1Handle int32, op2Handle int32) {
+	callInfo := fmt.Sprintf("BigIntGCD(%d, %d, %d)", destinationHandle, op1Handle, op2Handle)
+	w.logger.LogVMHookCallBefore(callInfo)
+	w.wrappedVMHooks.BigIntGCD(destinationHandle, op1Handle, op2Handle)
+	w.logger.LogVMHookCallAfter(callInfo)
+}
+
 // BigIntLog2 VM hook wrapper
 func (w *WrapperVMHooks) BigIntLog2(op1Handle int32) int32 {
 	callInfo := fmt.Sprintf("BigIntLog2(%d)", op1Handle)
@@ -1710,6 +2009,24 @@ func (w *WrapperVMHooks) MBufferFromBigFloat(mBufferHandle int32, bigFloatHandle
 	return result
 }
 
+// MBufferToDecimal VM hook wrapper
+func (w *WrapperVMHooks) MBufferToDecimal(mBufferHandle int32, decimalHandle int32) int32 {
+	callInfo := fmt.Sprintf("MBufferToDecimal(%d, %d)", mBufferHandle, decimalHandle)
+	w.logger.LogVMHookCallBefore(callInfo)
+	result := w.wrappedVMHooks.MBufferToDecimal(mBufferHandle, decimalHandle)
+	w.logger.LogVMHookCallAfter(callInfo)
+	return result
+}
+
+// MBufferFromDecimal VM hook wrapper
+func (w *WrapperVMHooks) MBufferFromDecimal(mBufferHandle int32, decimalHandle int32) int32 {
+	callInfo := fmt.Sprintf("MBufferFromDecimal(%d, %d)", mBufferHandle, decimalHandle)
+	w.logger.LogVMHookCallBefore(callInfo)
+	result := w.wrappedVMHooks.MBufferFromDecimal(mBufferHandle, decimalHandle)
+	w.logger.LogVMHookCallAfter(callInfo)
+	return result
+}
+
 // MBufferStorageStore VM hook wrapper
 func (w *WrapperVMHooks) MBufferStorageStore(keyHandle int32, sourceHandle int32) int32 {
 	callInfo := fmt.Sprintf("MBufferStorageStore(%d, %d)", keyHandle, sourceHandle)
@@ -2279,3 +2596,12 @@ func (w *WrapperVMHooks) ManagedVerifyBLSAggregatedSignature(keyHandle int32, me
 	w.logger.LogVMHookCallAfter(callInfo)
 	return result
 }
+
+// ManagedEVMFunctionSelector VM hook wrapper
+func (w *WrapperVMHooks) ManagedEVMFunctionSelector(signatureHandle int32, resultHandle int32) int32 {
+	callInfo := fmt.Sprintf("ManagedEVMFunctionSelector(%d, %d)", signatureHandle, resultHandle)
+	w.logger.LogVMHookCallBefore(callInfo)
+	result := w.wrappedVMHooks.ManagedEVMFunctionSelector(signatureHandle, resultHandle)
+	w.logger.LogVMHookCallAfter(callInfo)
+	return result
+}
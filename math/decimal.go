@@ -0,0 +1,326 @@
+package math
+
+import (
+	"encoding/binary"
+	"math/big"
+
+	twos "github.com/multiversx/mx-components-big-int/twos-complement"
+)
+
+// RoundingMode defines how a decimal is rounded when digits are dropped from its scale
+type RoundingMode int32
+
+const (
+	// RoundDown rounds towards zero
+	RoundDown RoundingMode = iota
+	// RoundUp rounds away from zero
+	RoundUp
+	// RoundFloor rounds towards negative infinity
+	RoundFloor
+	// RoundCeiling rounds towards positive infinity
+	RoundCeiling
+	// RoundHalfUp rounds to the nearest neighbour, ties away from zero
+	RoundHalfUp
+	// RoundHalfEven rounds to the nearest neighbour, ties to the even neighbour
+	RoundHalfEven
+)
+
+// MaxDecimalScale is the maximum number of fractional digits a decimal can hold
+const MaxDecimalScale = 64
+
+// MaxDecimalExpArgument is the maximum absolute value accepted by ExpDecimal
+const MaxDecimalExpArgument = 512
+
+// decimalGuardDigits are the extra fractional digits used internally by the ln/exp approximations
+const decimalGuardDigits = 20
+
+const encodedDecimalScaleLength = 4
+
+// Decimal is a fixed-point number, with the value Unscaled * 10^-Scale
+type Decimal struct {
+	Unscaled *big.Int
+	Scale    uint32
+}
+
+// NewDecimal creates a new decimal out of its unscaled value and scale
+func NewDecimal(unscaled *big.Int, scale uint32) (*Decimal, error) {
+	if scale > MaxDecimalScale {
+		return nil, ErrInvalidDecimalScale
+	}
+
+	return &Decimal{
+		Unscaled: big.NewInt(0).Set(unscaled),
+		Scale:    scale,
+	}, nil
+}
+
+// Clone returns a deep copy of the decimal
+func (d *Decimal) Clone() *Decimal {
+	return &Decimal{
+		Unscaled: big.NewInt(0).Set(d.Unscaled),
+		Scale:    d.Scale,
+	}
+}
+
+// Set copies the value of the given decimal
+func (d *Decimal) Set(other *Decimal) {
+	d.Unscaled = big.NewInt(0).Set(other.Unscaled)
+	d.Scale = other.Scale
+}
+
+// Cmp compares the values of two decimals, regardless of their scale
+func (d *Decimal) Cmp(other *Decimal) int {
+	scale := maxScale(d.Scale, other.Scale)
+	return upscale(d, scale).Cmp(upscale(other, scale))
+}
+
+// String returns the decimal representation of the value
+func (d *Decimal) String() string {
+	return new(big.Rat).SetFrac(d.Unscaled, pow10(d.Scale)).FloatString(int(d.Scale))
+}
+
+// Rescale returns the value of the decimal with the given scale, rounding if digits are dropped
+func (d *Decimal) Rescale(scale uint32, mode RoundingMode) (*Decimal, error) {
+	if scale > MaxDecimalScale {
+		return nil, ErrInvalidDecimalScale
+	}
+	if !mode.isValid() {
+		return nil, ErrInvalidRoundingMode
+	}
+
+	return &Decimal{
+		Unscaled: rescaleUnscaled(d.Unscaled, d.Scale, scale, mode),
+		Scale:    scale,
+	}, nil
+}
+
+// AddDecimal returns op1 + op2, with the larger of the two scales
+func AddDecimal(op1, op2 *Decimal) *Decimal {
+	scale := maxScale(op1.Scale, op2.Scale)
+	return &Decimal{
+		Unscaled: big.NewInt(0).Add(upscale(op1, scale), upscale(op2, scale)),
+		Scale:    scale,
+	}
+}
+
+// SubDecimal returns op1 - op2, with the larger of the two scales
+func SubDecimal(op1, op2 *Decimal) *Decimal {
+	scale := maxScale(op1.Scale, op2.Scale)
+	return &Decimal{
+		Unscaled: big.NewInt(0).Sub(upscale(op1, scale), upscale(op2, scale)),
+		Scale:    scale,
+	}
+}
+
+// MulDecimal returns op1 * op2 with the given scale
+func MulDecimal(op1, op2 *Decimal, scale uint32, mode RoundingMode) (*Decimal, error) {
+	product := &Decimal{
+		Unscaled: big.NewInt(0).Mul(op1.Unscaled, op2.Unscaled),
+		Scale:    op1.Scale + op2.Scale,
+	}
+	return product.Rescale(scale, mode)
+}
+
+// DivDecimal returns op1 / op2 with the given scale
+func DivDecimal(op1, op2 *Decimal, scale uint32, mode RoundingMode) (*Decimal, error) {
+	if scale > MaxDecimalScale {
+		return nil, ErrInvalidDecimalScale
+	}
+	if !mode.isValid() {
+		return nil, ErrInvalidRoundingMode
+	}
+	if op2.Unscaled.Sign() == 0 {
+		return nil, ErrDecimalDivZero
+	}
+
+	numerator := big.NewInt(0).Mul(op1.Unscaled, pow10(scale+op2.Scale))
+	denominator := big.NewInt(0).Mul(op2.Unscaled, pow10(op1.Scale))
+	return &Decimal{
+		Unscaled: divRound(numerator, denominator, mode),
+		Scale:    scale,
+	}, nil
+}
+
+// DecimalWorkScale returns the number of fractional digits with which LnDecimal and ExpDecimal approximate
+// their result for the given operand and result scale; their work grows with it
+func DecimalWorkScale(op *Decimal, scale uint32) uint32 {
+	return maxScale(scale, op.Scale) + decimalGuardDigits
+}
+
+// LnDecimal approximates the natural logarithm of the operand, rounded half-even to the given scale
+func LnDecimal(op *Decimal, scale uint32) (*Decimal, error) {
+	if scale > MaxDecimalScale {
+		return nil, ErrInvalidDecimalScale
+	}
+	if op.Unscaled.Sign() <= 0 {
+		return nil, ErrDecimalLnOfNonPositive
+	}
+
+	workScale := DecimalWorkScale(op, scale)
+	result := lnFixed(upscale(op, workScale), workScale)
+	return &Decimal{
+		Unscaled: rescaleUnscaled(result, workScale, scale, RoundHalfEven),
+		Scale:    scale,
+	}, nil
+}
+
+// ExpDecimal approximates e raised to the operand, rounded half-even to the given scale
+func ExpDecimal(op *Decimal, scale uint32) (*Decimal, error) {
+	if scale > MaxDecimalScale {
+		return nil, ErrInvalidDecimalScale
+	}
+	limit := &Decimal{Unscaled: big.NewInt(MaxDecimalExpArgument)}
+	if big.NewInt(0).Abs(op.Unscaled).Cmp(upscale(limit, op.Scale)) > 0 {
+		return nil, ErrDecimalExpArgumentTooLarge
+	}
+
+	workScale := DecimalWorkScale(op, scale)
+	result := expFixed(upscale(op, workScale), workScale)
+	return &Decimal{
+		Unscaled: rescaleUnscaled(result, workScale, scale, RoundHalfEven),
+		Scale:    scale,
+	}, nil
+}
+
+// Encode serializes the decimal as the 4-byte big-endian scale followed by the two's complement unscaled value
+func (d *Decimal) Encode() []byte {
+	encoded := make([]byte, encodedDecimalScaleLength)
+	binary.BigEndian.PutUint32(encoded, d.Scale)
+	return append(encoded, twos.ToBytes(d.Unscaled)...)
+}
+
+// DecodeDecimal deserializes a decimal produced by Encode
+func DecodeDecimal(encoded []byte) (*Decimal, error) {
+	if len(encoded) < encodedDecimalScaleLength {
+		return nil, ErrInvalidEncodedDecimal
+	}
+
+	scale := binary.BigEndian.Uint32(encoded[:encodedDecimalScaleLength])
+	return NewDecimal(twos.SetBytes(big.NewInt(0), encoded[encodedDecimalScaleLength:]), scale)
+}
+
+func (mode RoundingMode) isValid() bool {
+	return mode >= RoundDown && mode <= RoundHalfEven
+}
+
+func maxScale(scale1, scale2 uint32) uint32 {
+	if scale1 > scale2 {
+		return scale1
+	}
+	return scale2
+}
+
+func pow10(exponent uint32) *big.Int {
+	return big.NewInt(0).Exp(big.NewInt(10), big.NewInt(int64(exponent)), nil)
+}
+
+// upscale returns the unscaled value of the decimal at a scale which is not smaller than its own
+func upscale(d *Decimal, scale uint32) *big.Int {
+	return big.NewInt(0).Mul(d.Unscaled, pow10(scale-d.Scale))
+}
+
+func rescaleUnscaled(unscaled *big.Int, fromScale uint32, toScale uint32, mode RoundingMode) *big.Int {
+	if toScale >= fromScale {
+		return big.NewInt(0).Mul(unscaled, pow10(toScale-fromScale))
+	}
+	return divRound(unscaled, pow10(fromScale-toScale), mode)
+}
+
+// divRound divides two integers, rounding the quotient according to the given mode
+func divRound(numerator, denominator *big.Int, mode RoundingMode) *big.Int {
+	quotient, remainder := big.NewInt(0).QuoRem(numerator, denominator, big.NewInt(0))
+	if remainder.Sign() == 0 {
+		return quotient
+	}
+
+	sign := int64(numerator.Sign() * denominator.Sign())
+	doubleRemainder := big.NewInt(0).Abs(remainder)
+	doubleRemainder.Lsh(doubleRemainder, 1)
+	halfCmp := doubleRemainder.Cmp(big.NewInt(0).Abs(denominator))
+
+	increment := false
+	switch mode {
+	case RoundUp:
+		increment = true
+	case RoundFloor:
+		increment = sign < 0
+	case RoundCeiling:
+		increment = sign > 0
+	case RoundHalfUp:
+		increment = halfCmp >= 0
+	case RoundHalfEven:
+		increment = halfCmp > 0 || (halfCmp == 0 && quotient.Bit(0) == 1)
+	}
+
+	if increment {
+		quotient.Add(quotient, big.NewInt(sign))
+	}
+	return quotient
+}
+
+// lnFixed computes ln(x) for x > 0, both represented with the given number of fractional digits.
+// x is reduced to m * 2^k with m close to 1, and ln(m) is obtained from the atanh series.
+func lnFixed(x *big.Int, scale uint32) *big.Int {
+	one := pow10(scale)
+	k := x.BitLen() - one.BitLen()
+
+	m := big.NewInt(0)
+	if k >= 0 {
+		m.Rsh(x, uint(k))
+	} else {
+		m.Lsh(x, uint(-k))
+	}
+
+	result := lnAroundOne(m, one)
+	ln2 := lnAroundOne(big.NewInt(0).Lsh(one, 1), one)
+	return result.Add(result, ln2.Mul(ln2, big.NewInt(int64(k))))
+}
+
+// lnAroundOne computes ln(m) = 2 * atanh((m - 1) / (m + 1)), which converges quickly for m close to 1
+func lnAroundOne(m *big.Int, one *big.Int) *big.Int {
+	z := big.NewInt(0).Sub(m, one)
+	z.Mul(z, one)
+	z.Quo(z, big.NewInt(0).Add(m, one))
+
+	zSquared := big.NewInt(0).Mul(z, z)
+	zSquared.Quo(zSquared, one)
+
+	sum := big.NewInt(0)
+	term := z
+	for n := int64(1); term.Sign() != 0; n += 2 {
+		sum.Add(sum, big.NewInt(0).Quo(term, big.NewInt(n)))
+		term.Mul(term, zSquared)
+		term.Quo(term, one)
+	}
+
+	return sum.Lsh(sum, 1)
+}
+
+// expFixed computes e^x, both represented with the given number of fractional digits.
+// x is reduced to r + k * ln(2) with |r| <= ln(2) / 2, and e^r is obtained from the Taylor series.
+func expFixed(x *big.Int, scale uint32) *big.Int {
+	one := pow10(scale)
+	ln2 := lnAroundOne(big.NewInt(0).Lsh(one, 1), one)
+	k := divRound(x, ln2, RoundHalfEven)
+
+	r := big.NewInt(0).Mul(k, ln2)
+	r.Sub(x, r)
+
+	sum := big.NewInt(0).Set(one)
+	term := big.NewInt(0).Set(one)
+	for n := int64(1); ; n++ {
+		term.Mul(term, r)
+		term.Quo(term, one)
+		term.Quo(term, big.NewInt(n))
+		if term.Sign() == 0 {
+			break
+		}
+		sum.Add(sum, term)
+	}
+
+	shift := k.Int64()
+	if shift >= 0 {
+		return sum.Lsh(sum, uint(shift))
+	}
+	return sum.Rsh(sum, uint(-shift))
+}
@@ -0,0 +1,123 @@
+package math
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func decimal(t *testing.T, unscaled int64, scale uint32) *Decimal {
+	d, err := NewDecimal(big.NewInt(unscaled), scale)
+	require.Nil(t, err)
+	return d
+}
+
+func TestDecimal_NewInvalidScale(t *testing.T) {
+	d, err := NewDecimal(big.NewInt(1), MaxDecimalScale+1)
+	require.Nil(t, d)
+	require.Equal(t, ErrInvalidDecimalScale, err)
+}
+
+func TestDecimal_Rescale(t *testing.T) {
+	testCases := []struct {
+		unscaled int64
+		mode     RoundingMode
+		expected int64
+	}{
+		{125, RoundDown, 12},
+		{125, RoundUp, 13},
+		{-125, RoundFloor, -13},
+		{-125, RoundCeiling, -12},
+		{125, RoundHalfUp, 13},
+		{-125, RoundHalfUp, -13},
+		{125, RoundHalfEven, 12},
+		{135, RoundHalfEven, 14},
+		{126, RoundHalfEven, 13},
+		{120, RoundUp, 12},
+	}
+
+	for _, testCase := range testCases {
+		result, err := decimal(t, testCase.unscaled, 2).Rescale(1, testCase.mode)
+		require.Nil(t, err)
+		require.Equal(t, uint32(1), result.Scale)
+		require.Equal(t, big.NewInt(testCase.expected), result.Unscaled, "%d mode %d", testCase.unscaled, testCase.mode)
+	}
+
+	result, err := decimal(t, 15, 1).Rescale(3, RoundDown)
+	require.Nil(t, err)
+	require.Equal(t, "1.500", result.String())
+
+	_, err = decimal(t, 15, 1).Rescale(0, RoundingMode(42))
+	require.Equal(t, ErrInvalidRoundingMode, err)
+}
+
+func TestDecimal_Arithmetic(t *testing.T) {
+	op1 := decimal(t, 1050, 2)
+	op2 := decimal(t, 25, 1)
+
+	require.Equal(t, "13.00", AddDecimal(op1, op2).String())
+	require.Equal(t, "8.00", SubDecimal(op1, op2).String())
+	require.Equal(t, 1, op1.Cmp(op2))
+	require.Equal(t, 0, decimal(t, 25, 1).Cmp(decimal(t, 2500, 3)))
+
+	product, err := MulDecimal(op1, op2, 2, RoundHalfEven)
+	require.Nil(t, err)
+	require.Equal(t, "26.25", product.String())
+
+	quotient, err := DivDecimal(decimal(t, 1, 0), decimal(t, 3, 0), 4, RoundHalfUp)
+	require.Nil(t, err)
+	require.Equal(t, "0.3333", quotient.String())
+
+	quotient, err = DivDecimal(decimal(t, -2, 0), decimal(t, 3, 0), 3, RoundHalfUp)
+	require.Nil(t, err)
+	require.Equal(t, "-0.667", quotient.String())
+
+	_, err = DivDecimal(op1, decimal(t, 0, 5), 2, RoundDown)
+	require.Equal(t, ErrDecimalDivZero, err)
+}
+
+func TestDecimal_LnExp(t *testing.T) {
+	result, err := LnDecimal(decimal(t, 2, 0), 18)
+	require.Nil(t, err)
+	require.Equal(t, "0.693147180559945309", result.String())
+
+	result, err = LnDecimal(decimal(t, 1, 3), 10)
+	require.Nil(t, err)
+	require.Equal(t, "-6.9077552790", result.String())
+
+	result, err = ExpDecimal(decimal(t, 1, 0), 18)
+	require.Nil(t, err)
+	require.Equal(t, "2.718281828459045235", result.String())
+
+	result, err = ExpDecimal(decimal(t, -15, 1), 10)
+	require.Nil(t, err)
+	require.Equal(t, "0.2231301601", result.String())
+
+	_, err = LnDecimal(decimal(t, 0, 0), 2)
+	require.Equal(t, ErrDecimalLnOfNonPositive, err)
+
+	_, err = ExpDecimal(decimal(t, MaxDecimalExpArgument+1, 0), 2)
+	require.Equal(t, ErrDecimalExpArgumentTooLarge, err)
+}
+
+func TestDecimal_WorkScale(t *testing.T) {
+	require.Equal(t, uint32(18+decimalGuardDigits), DecimalWorkScale(decimal(t, 2, 0), 18))
+	require.Equal(t, uint32(30+decimalGuardDigits), DecimalWorkScale(decimal(t, 2, 30), 18))
+	require.Equal(t, uint32(MaxDecimalScale+decimalGuardDigits), DecimalWorkScale(decimal(t, 2, 0), MaxDecimalScale))
+}
+
+func TestDecimal_EncodeDecode(t *testing.T) {
+	for _, d := range []*Decimal{decimal(t, 0, 0), decimal(t, -12345, 3), decimal(t, 255, 18)} {
+		decoded, err := DecodeDecimal(d.Encode())
+		require.Nil(t, err)
+		require.Equal(t, d.Scale, decoded.Scale)
+		require.Equal(t, 0, d.Unscaled.Cmp(decoded.Unscaled))
+	}
+
+	_, err := DecodeDecimal([]byte{0, 0})
+	require.Equal(t, ErrInvalidEncodedDecimal, err)
+
+	_, err = DecodeDecimal([]byte{0, 0, 1, 0, 1})
+	require.Equal(t, ErrInvalidDecimalScale, err)
+}
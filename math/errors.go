@@ -24,3 +24,21 @@ var ErrBigFloatMul = errors.New("this big Float operation is not permitted while
 
 // ErrBigFloatSqrt is raised when sqrt of floats produces a panic
 var ErrBigFloatSqrt = errors.New("this big Float operation is not permitted while doing float.Sqrt")
+
+// ErrInvalidDecimalScale is raised when a decimal scale exceeds the maximum allowed scale
+var ErrInvalidDecimalScale = errors.New("invalid decimal scale")
+
+// ErrInvalidRoundingMode is raised when an unknown rounding mode is requested
+var ErrInvalidRoundingMode = errors.New("invalid rounding mode")
+
+// ErrDecimalDivZero is raised when a decimal is divided by zero
+var ErrDecimalDivZero = errors.New("decimal division by 0")
+
+// ErrDecimalLnOfNonPositive is raised when the logarithm of a decimal which is not positive is requested
+var ErrDecimalLnOfNonPositive = errors.New("logarithm of a non-positive decimal")
+
+// ErrDecimalExpArgumentTooLarge is raised when the argument of a decimal exponential is out of bounds
+var ErrDecimalExpArgumentTooLarge = errors.New("decimal exponential argument too large")
+
+// ErrInvalidEncodedDecimal is raised when decoding a decimal from malformed bytes
+var ErrInvalidEncodedDecimal = errors.New("invalid encoded decimal")
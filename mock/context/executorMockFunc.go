@@ -10,6 +10,9 @@ var empty struct{}
 
 var functionNames = map[string]struct{}{
 	"getGasLeft":                               empty,
+	"getMemoryUsage":                           empty,
+	"countInstructions":                        empty,
+	"chargeMemoryGrowth":                       empty,
 	"getSCAddress":                             empty,
 	"getOwnerAddress":                          empty,
 	"getShardOfAddress":                        empty,
@@ -47,6 +50,9 @@ var functionNames = map[string]struct{}{
 	"getStorageLock":                           empty,
 	"isStorageLocked":                          empty,
 	"clearStorageLock":                         empty,
+	"transientStore":                           empty,
+	"transientLoad":                            empty,
+	"getStorageDeposit":                        empty,
 	"getCaller":                                empty,
 	"checkNoPayment":                           empty,
 	"getCallValue":                             empty,
@@ -113,7 +119,12 @@ var functionNames = map[string]struct{}{
 	"managedUpgradeContract":                   empty,
 	"managedDeleteContract":                    empty,
 	"managedDeployFromSourceContract":          empty,
+	"managedDeployFromSourceContractWithSalt":  empty,
 	"managedCreateContract":                    empty,
+	"managedCreateContractWithSalt":            empty,
+	"managedDeployFromCodeHash":                empty,
+	"managedRegisterCode":                      empty,
+	"managedComputeContractAddress":            empty,
 	"managedExecuteReadOnly":                   empty,
 	"managedExecuteOnSameContext":              empty,
 	"managedExecuteOnDestContext":              empty,
@@ -125,7 +136,16 @@ var functionNames = map[string]struct{}{
 	"managedIsESDTPaused":                      empty,
 	"managedBufferToHex":                       empty,
 	"managedGetCodeMetadata":                   empty,
+	"managedGetCodeHash":                       empty,
+	"managedGetCodeDeployer":                   empty,
+	"managedGetContractDeployInfo":             empty,
 	"managedIsBuiltinFunction":                 empty,
+	"managedEVMAbiEncode":                      empty,
+	"managedEVMAbiDecode":                      empty,
+	"managedSetUpgradePolicy":                  empty,
+	"managedProposeUpgrade":                    empty,
+	"managedApproveUpgrade":                    empty,
+	"managedCancelUpgrade":                     empty,
 	"bigFloatNewFromParts":                     empty,
 	"bigFloatNewFromFrac":                      empty,
 	"bigFloatNewFromSci":                       empty,
@@ -148,6 +168,17 @@ var functionNames = map[string]struct{}{
 	"bigFloatSetBigInt":                        empty,
 	"bigFloatGetConstPi":                       empty,
 	"bigFloatGetConstE":                        empty,
+	"decimalNew":                               empty,
+	"decimalFromBigInt":                        empty,
+	"decimalToBigInt":                          empty,
+	"decimalRescale":                           empty,
+	"decimalAdd":                               empty,
+	"decimalSub":                               empty,
+	"decimalMul":                               empty,
+	"decimalDiv":                               empty,
+	"decimalCmp":                               empty,
+	"decimalLn":                                empty,
+	"decimalExp":                               empty,
 	"bigIntGetUnsignedArgument":                empty,
 	"bigIntGetSignedArgument":                  empty,
 	"bigIntStorageStoreUnsigned":               empty,
@@ -176,6 +207,10 @@ var functionNames = map[string]struct{}{
 	"bigIntEMod":                               empty,
 	"bigIntSqrt":                               empty,
 	"bigIntPow":                                empty,
+	"bigIntModPow":                             empty,
+	"bigIntModInverse":                         empty,
+	"bigIntMulMod":                             empty,
+	"bigIntGCD":                                empty,
 	"bigIntLog2":                               empty,
 	"bigIntAbs":                                empty,
 	"bigIntNeg":                                empty,
@@ -207,6 +242,8 @@ var functionNames = map[string]struct{}{
 	"mBufferFromBigIntSigned":                  empty,
 	"mBufferToBigFloat":                        empty,
 	"mBufferFromBigFloat":                      empty,
+	"mBufferToDecimal":                         empty,
+	"mBufferFromDecimal":                       empty,
 	"mBufferStorageStore":                      empty,
 	"mBufferStorageLoad":                       empty,
 	"mBufferStorageLoadFromAddress":            empty,
@@ -271,4 +308,5 @@ var functionNames = map[string]struct{}{
 	"managedVerifySecp256r1":                   empty,
 	"managedVerifyBLSSignatureShare":           empty,
 	"managedVerifyBLSAggregatedSignature":      empty,
+	"managedEVMFunctionSelector":               empty,
 }
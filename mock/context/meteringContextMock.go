@@ -69,6 +69,19 @@ func (m *MeteringContextMock) FreeGas(gas uint64) {
 	m.GasFreedMock += gas
 }
 
+// GetGasRefund mocked method
+func (m *MeteringContextMock) GetGasRefund() uint64 {
+	return m.GasFreedMock
+}
+
+// ApplyGasRefundCap mocked method
+func (m *MeteringContextMock) ApplyGasRefundCap(_ *vmcommon.VMOutput) *vmhost.GasRefundBreakdown {
+	return &vmhost.GasRefundBreakdown{
+		Accumulated: m.GasFreedMock,
+		Applied:     m.GasFreedMock,
+	}
+}
+
 // RestoreGas mocked method
 func (m *MeteringContextMock) RestoreGas(gas uint64) {
 	m.GasLeftMock += gas
@@ -162,6 +175,16 @@ func (m *MeteringContextMock) UseGasBounded(gas uint64) error {
 	return nil
 }
 
+// UseGasBoundedInCategory mocked method
+func (m *MeteringContextMock) UseGasBoundedInCategory(_ string, gas uint64) error {
+	return m.UseGasBounded(gas)
+}
+
+// UseGasBoundedForChildExecution mocked method
+func (m *MeteringContextMock) UseGasBoundedForChildExecution(gas uint64) error {
+	return m.UseGasBounded(gas)
+}
+
 // UnlockGasIfAsyncCallback mocked method
 func (m *MeteringContextMock) UnlockGasIfAsyncCallback() {}
 
@@ -198,6 +221,11 @@ func (m *MeteringContextMock) DeductInitialGasForIndirectDeployment(_ vmhost.Cod
 	return m.Err
 }
 
+// DeductInitialGasForStorageWrite mocked method
+func (m *MeteringContextMock) DeductInitialGasForStorageWrite(_ []byte) error {
+	return m.Err
+}
+
 // EnableRestoreGas mocked method
 func (m *MeteringContextMock) EnableRestoreGas() {}
 
@@ -214,3 +242,11 @@ func (m *MeteringContextMock) SetGasTracing(_ bool) {}
 func (m *MeteringContextMock) GetGasTrace() map[string]map[string][]uint64 {
 	return nil
 }
+
+// SetGasBreakdown mocked method
+func (m *MeteringContextMock) SetGasBreakdown(_ bool) {}
+
+// GetGasBreakdown returns an empty gas breakdown
+func (m *MeteringContextMock) GetGasBreakdown(_ *vmcommon.VMOutput) map[string]*vmhost.GasBreakdown {
+	return make(map[string]*vmhost.GasBreakdown)
+}
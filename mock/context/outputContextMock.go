@@ -102,6 +102,11 @@ func (o *OutputContextMock) GetOutputAccount(_ []byte) (*vmcommon.OutputAccount,
 func (o *OutputContextMock) DeleteOutputAccount(_ []byte) {
 }
 
+// HasDeployedCode mocked method
+func (o *OutputContextMock) HasDeployedCode(_ []byte) bool {
+	return false
+}
+
 // GetRefund mocked method
 func (o *OutputContextMock) GetRefund() uint64 {
 	return uint64(o.GasRefund.Int64())
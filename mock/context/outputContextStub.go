@@ -23,6 +23,7 @@ type OutputContextStub struct {
 	GetOutputAccountsCalled           func() map[string]*vmcommon.OutputAccount
 	GetOutputAccountCalled            func(address []byte) (*vmcommon.OutputAccount, bool)
 	DeleteOutputAccountCalled         func(address []byte)
+	HasDeployedCodeCalled             func(address []byte) bool
 	WriteLogCalled                    func(address []byte, topics [][]byte, data [][]byte)
 	WriteLogWithIdentifierCalled      func(address []byte, topics [][]byte, data [][]byte, identifier []byte)
 	TransferCalled                    func(destination []byte, sender []byte, gasLimit uint64, gasLocked uint64, value *big.Int, asyncData []byte, input []byte) error
@@ -138,6 +139,14 @@ func (o *OutputContextStub) DeleteOutputAccount(address []byte) {
 	}
 }
 
+// HasDeployedCode mocked method
+func (o *OutputContextStub) HasDeployedCode(address []byte) bool {
+	if o.HasDeployedCodeCalled != nil {
+		return o.HasDeployedCodeCalled(address)
+	}
+	return false
+}
+
 // WriteLog mocked method
 func (o *OutputContextStub) WriteLog(address []byte, topics [][]byte, data [][]byte) {
 	if o.WriteLogCalled != nil {
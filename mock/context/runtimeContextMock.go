@@ -2,6 +2,7 @@ package mock
 
 import (
 	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	"github.com/multiversx/mx-chain-vm-go/contractabi"
 	"github.com/multiversx/mx-chain-vm-go/executor"
 	"github.com/multiversx/mx-chain-vm-go/vmhost"
 )
@@ -16,6 +17,8 @@ type RuntimeContextMock struct {
 	SCAddress                []byte
 	SCCode                   []byte
 	SCCodeSize               uint64
+	ContractABI              *contractabi.ABI
+	AccessControl            *contractabi.AccessControl
 	CallFunction             string
 	VMType                   []byte
 	ReadOnlyFlag             bool
@@ -30,6 +33,7 @@ type RuntimeContextMock struct {
 	FailSyncExecAPI          bool
 	FailBigIntAPI            bool
 	FailBigFloatAPI          bool
+	FailDecimalAPI           bool
 	FailManagedBuffersAPI    bool
 	FailManagedMapAPI        bool
 	AsyncCallInfo            *vmhost.AsyncCallInfo
@@ -122,6 +126,20 @@ func (r *RuntimeContextMock) GetInstanceStackSize() uint64 {
 func (r *RuntimeContextMock) SetMaxInstanceStackSize(uint64) {
 }
 
+// SetExecutionBudget mocked method
+func (r *RuntimeContextMock) SetExecutionBudget(_ uint64) {
+}
+
+// UseInstructions mocked method
+func (r *RuntimeContextMock) UseInstructions(_ uint64) error {
+	return nil
+}
+
+// IsExecutionBudgetExceeded mocked method
+func (r *RuntimeContextMock) IsExecutionBudgetExceeded() bool {
+	return false
+}
+
 // ClearInstanceStack mocked method
 func (r *RuntimeContextMock) ClearInstanceStack() {
 }
@@ -176,6 +194,16 @@ func (r *RuntimeContextMock) GetSCCodeSize() uint64 {
 	return r.SCCodeSize
 }
 
+// GetContractABI mocked method
+func (r *RuntimeContextMock) GetContractABI() *contractabi.ABI {
+	return r.ContractABI
+}
+
+// GetAccessControl mocked method
+func (r *RuntimeContextMock) GetAccessControl() *contractabi.AccessControl {
+	return r.AccessControl
+}
+
 // FunctionName mocked method
 func (r *RuntimeContextMock) FunctionName() string {
 	return r.CallFunction
@@ -229,10 +257,14 @@ func (r *RuntimeContextMock) PrepareLegacyAsyncCall(_ []byte, _ []byte, _ []byte
 }
 
 // VerifyContractCode mocked method
-func (r *RuntimeContextMock) VerifyContractCode() error {
+func (r *RuntimeContextMock) VerifyContractCode(_ []byte) error {
 	return r.Err
 }
 
+// SetCodeLimits mocked method
+func (r *RuntimeContextMock) SetCodeLimits(_ vmhost.CodeLimits) {
+}
+
 // GetPointsUsed mocked method
 func (r *RuntimeContextMock) GetPointsUsed() uint64 {
 	return r.PointsUsed
@@ -243,6 +275,11 @@ func (r *RuntimeContextMock) SetPointsUsed(gasPoints uint64) {
 	r.PointsUsed = gasPoints
 }
 
+// UseGasForMemoryGrowth mocked method
+func (r *RuntimeContextMock) UseGasForMemoryGrowth() error {
+	return nil
+}
+
 // ReadOnly mocked method
 func (r *RuntimeContextMock) ReadOnly() bool {
 	return r.ReadOnlyFlag
@@ -305,6 +342,11 @@ func (r *RuntimeContextMock) BigFloatAPIErrorShouldFailExecution() bool {
 	return r.FailBigFloatAPI
 }
 
+// DecimalAPIErrorShouldFailExecution mocked method
+func (r *RuntimeContextMock) DecimalAPIErrorShouldFailExecution() bool {
+	return r.FailDecimalAPI
+}
+
 // ManagedBufferAPIErrorShouldFailExecution mocked method
 func (r *RuntimeContextMock) ManagedBufferAPIErrorShouldFailExecution() bool {
 	return r.FailManagedBuffersAPI
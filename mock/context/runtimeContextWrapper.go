@@ -2,6 +2,7 @@ package mock
 
 import (
 	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	"github.com/multiversx/mx-chain-vm-go/contractabi"
 	"github.com/multiversx/mx-chain-vm-go/executor"
 	"github.com/multiversx/mx-chain-vm-go/vmhost"
 )
@@ -32,6 +33,10 @@ type RuntimeContextWrapper struct {
 	// function that will be called by the corresponding RuntimeContext function implementation (by default this will call the same wrapped context function)
 	GetSCCodeSizeFunc func() uint64
 	// function that will be called by the corresponding RuntimeContext function implementation (by default this will call the same wrapped context function)
+	GetContractABIFunc func() *contractabi.ABI
+	// function that will be called by the corresponding RuntimeContext function implementation (by default this will call the same wrapped context function)
+	GetAccessControlFunc func() *contractabi.AccessControl
+	// function that will be called by the corresponding RuntimeContext function implementation (by default this will call the same wrapped context function)
 	GetVMTypeFunc func() []byte
 	// function that will be called by the corresponding RuntimeContext function implementation (by default this will call the same wrapped context function)
 	FunctionFunc func() string
@@ -78,7 +83,15 @@ type RuntimeContextWrapper struct {
 	// function that will be called by the corresponding RuntimeContext function implementation (by default this will call the same wrapped context function)
 	SetMaxInstanceStackSizeFunc func(maxInstanceStackSize uint64)
 	// function that will be called by the corresponding RuntimeContext function implementation (by default this will call the same wrapped context function)
-	VerifyContractCodeFunc func() error
+	SetExecutionBudgetFunc func(budget uint64)
+	// function that will be called by the corresponding RuntimeContext function implementation (by default this will call the same wrapped context function)
+	UseInstructionsFunc func(count uint64) error
+	// function that will be called by the corresponding RuntimeContext function implementation (by default this will call the same wrapped context function)
+	IsExecutionBudgetExceededFunc func() bool
+	// function that will be called by the corresponding RuntimeContext function implementation (by default this will call the same wrapped context function)
+	VerifyContractCodeFunc func(code []byte) error
+	// function that will be called by the corresponding RuntimeContext function implementation (by default this will call the same wrapped context function)
+	SetCodeLimitsFunc func(limits vmhost.CodeLimits)
 	// function that will be called by the corresponding RuntimeContext function implementation (by default this will call the same wrapped context function)
 	GetInstanceFunc func() executor.Instance
 	// function that will be called by the corresponding RuntimeContext function implementation (by default this will call the same wrapped context function)
@@ -90,6 +103,8 @@ type RuntimeContextWrapper struct {
 	// function that will be called by the corresponding RuntimeContext function implementation (by default this will call the same wrapped context function)
 	SetPointsUsedFunc func(gasPoints uint64)
 	// function that will be called by the corresponding RuntimeContext function implementation (by default this will call the same wrapped context function)
+	UseGasForMemoryGrowthFunc func() error
+	// function that will be called by the corresponding RuntimeContext function implementation (by default this will call the same wrapped context function)
 	BaseOpsErrorShouldFailExecutionFunc func() bool
 	// function that will be called by the corresponding RuntimeContext function implementation (by default this will call the same wrapped context function)
 	SyncExecAPIErrorShouldFailExecutionFunc func() bool
@@ -164,6 +179,14 @@ func NewRuntimeContextWrapper(inputRuntimeContext *vmhost.RuntimeContext) *Runti
 		return runtimeWrapper.runtimeContext.GetSCCodeSize()
 	}
 
+	runtimeWrapper.GetContractABIFunc = func() *contractabi.ABI {
+		return runtimeWrapper.runtimeContext.GetContractABI()
+	}
+
+	runtimeWrapper.GetAccessControlFunc = func() *contractabi.AccessControl {
+		return runtimeWrapper.runtimeContext.GetAccessControl()
+	}
+
 	runtimeWrapper.GetVMTypeFunc = func() []byte {
 		return runtimeWrapper.runtimeContext.GetVMType()
 	}
@@ -236,8 +259,24 @@ func NewRuntimeContextWrapper(inputRuntimeContext *vmhost.RuntimeContext) *Runti
 		runtimeWrapper.runtimeContext.SetMaxInstanceStackSize(maxInstanceStackSize)
 	}
 
-	runtimeWrapper.VerifyContractCodeFunc = func() error {
-		return runtimeWrapper.runtimeContext.VerifyContractCode()
+	runtimeWrapper.SetExecutionBudgetFunc = func(budget uint64) {
+		runtimeWrapper.runtimeContext.SetExecutionBudget(budget)
+	}
+
+	runtimeWrapper.UseInstructionsFunc = func(count uint64) error {
+		return runtimeWrapper.runtimeContext.UseInstructions(count)
+	}
+
+	runtimeWrapper.IsExecutionBudgetExceededFunc = func() bool {
+		return runtimeWrapper.runtimeContext.IsExecutionBudgetExceeded()
+	}
+
+	runtimeWrapper.VerifyContractCodeFunc = func(code []byte) error {
+		return runtimeWrapper.runtimeContext.VerifyContractCode(code)
+	}
+
+	runtimeWrapper.SetCodeLimitsFunc = func(limits vmhost.CodeLimits) {
+		runtimeWrapper.runtimeContext.SetCodeLimits(limits)
 	}
 
 	runtimeWrapper.GetInstanceFunc = func() executor.Instance {
@@ -260,6 +299,10 @@ func NewRuntimeContextWrapper(inputRuntimeContext *vmhost.RuntimeContext) *Runti
 		runtimeWrapper.runtimeContext.SetPointsUsed(gasPoints)
 	}
 
+	runtimeWrapper.UseGasForMemoryGrowthFunc = func() error {
+		return runtimeWrapper.runtimeContext.UseGasForMemoryGrowth()
+	}
+
 	runtimeWrapper.BaseOpsErrorShouldFailExecutionFunc = func() bool {
 		return runtimeWrapper.runtimeContext.BaseOpsErrorShouldFailExecution()
 	}
@@ -373,6 +416,16 @@ func (contextWrapper *RuntimeContextWrapper) GetSCCodeSize() uint64 {
 	return contextWrapper.GetSCCodeSizeFunc()
 }
 
+// GetContractABI calls corresponding xxxFunc function, that by default in turn calls the original method of the wrapped RuntimeContext
+func (contextWrapper *RuntimeContextWrapper) GetContractABI() *contractabi.ABI {
+	return contextWrapper.GetContractABIFunc()
+}
+
+// GetAccessControl calls corresponding xxxFunc function, that by default in turn calls the original method of the wrapped RuntimeContext
+func (contextWrapper *RuntimeContextWrapper) GetAccessControl() *contractabi.AccessControl {
+	return contextWrapper.GetAccessControlFunc()
+}
+
 // GetVMType calls corresponding xxxFunc function, that by default in turn calls the original method of the wrapped RuntimeContext
 func (contextWrapper *RuntimeContextWrapper) GetVMType() []byte {
 	return contextWrapper.GetVMTypeFunc()
@@ -468,9 +521,29 @@ func (contextWrapper *RuntimeContextWrapper) SetMaxInstanceStackSize(maxInstance
 	contextWrapper.SetMaxInstanceStackSizeFunc(maxInstanceStackSize)
 }
 
+// SetExecutionBudget calls corresponding xxxFunc function, that by default in turn calls the original method of the wrapped RuntimeContext
+func (contextWrapper *RuntimeContextWrapper) SetExecutionBudget(budget uint64) {
+	contextWrapper.SetExecutionBudgetFunc(budget)
+}
+
+// UseInstructions calls corresponding xxxFunc function, that by default in turn calls the original method of the wrapped RuntimeContext
+func (contextWrapper *RuntimeContextWrapper) UseInstructions(count uint64) error {
+	return contextWrapper.UseInstructionsFunc(count)
+}
+
+// IsExecutionBudgetExceeded calls corresponding xxxFunc function, that by default in turn calls the original method of the wrapped RuntimeContext
+func (contextWrapper *RuntimeContextWrapper) IsExecutionBudgetExceeded() bool {
+	return contextWrapper.IsExecutionBudgetExceededFunc()
+}
+
 // VerifyContractCode calls corresponding xxxFunc function, that by default in turn calls the original method of the wrapped RuntimeContext
-func (contextWrapper *RuntimeContextWrapper) VerifyContractCode() error {
-	return contextWrapper.VerifyContractCodeFunc()
+func (contextWrapper *RuntimeContextWrapper) VerifyContractCode(code []byte) error {
+	return contextWrapper.VerifyContractCodeFunc(code)
+}
+
+// SetCodeLimits calls corresponding xxxFunc function, that by default in turn calls the original method of the wrapped RuntimeContext
+func (contextWrapper *RuntimeContextWrapper) SetCodeLimits(limits vmhost.CodeLimits) {
+	contextWrapper.SetCodeLimitsFunc(limits)
 }
 
 // GetInstance calls corresponding xxxFunc function, that by default in turn calls the original method of the wrapped RuntimeContext
@@ -498,6 +571,11 @@ func (contextWrapper *RuntimeContextWrapper) SetPointsUsed(gasPoints uint64) {
 	contextWrapper.SetPointsUsedFunc(gasPoints)
 }
 
+// UseGasForMemoryGrowth calls corresponding xxxFunc function, that by default in turn calls the original method of the wrapped RuntimeContext
+func (contextWrapper *RuntimeContextWrapper) UseGasForMemoryGrowth() error {
+	return contextWrapper.UseGasForMemoryGrowthFunc()
+}
+
 // BaseOpsErrorShouldFailExecution calls corresponding xxxFunc function, that by default in turn calls the original method of the wrapped RuntimeContext
 func (contextWrapper *RuntimeContextWrapper) BaseOpsErrorShouldFailExecution() bool {
 	return contextWrapper.BaseOpsErrorShouldFailExecutionFunc()
@@ -523,6 +601,11 @@ func (contextWrapper *RuntimeContextWrapper) BigFloatAPIErrorShouldFailExecution
 	return contextWrapper.BigFloatAPIErrorShouldFailExecutionFunc()
 }
 
+// DecimalAPIErrorShouldFailExecution calls corresponding xxxFunc function, that by default in turn calls the original method of the wrapped RuntimeContext
+func (contextWrapper *RuntimeContextWrapper) DecimalAPIErrorShouldFailExecution() bool {
+	return contextWrapper.runtimeContext.DecimalAPIErrorShouldFailExecution()
+}
+
 // ManagedBufferAPIErrorShouldFailExecution calls corresponding xxxFunc function, that by default in turn calls the original method of the wrapped RuntimeContext
 func (contextWrapper *RuntimeContextWrapper) ManagedBufferAPIErrorShouldFailExecution() bool {
 	return contextWrapper.runtimeContext.ManagedBufferAPIErrorShouldFailExecution()
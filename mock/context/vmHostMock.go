@@ -14,6 +14,8 @@ var _ vmhost.VMHost = (*VMHostMock)(nil)
 type VMHostMock struct {
 	BlockChainHook vmcommon.BlockchainHook
 	CryptoHook     crypto.VMCrypto
+	HashComputer   vmhost.HashComputer
+	QueryExecution bool
 
 	EthInput []byte
 
@@ -45,6 +47,16 @@ func (host *VMHostMock) Crypto() crypto.VMCrypto {
 	return host.CryptoHook
 }
 
+// Hasher mocked method
+func (host *VMHostMock) Hasher() vmhost.HashComputer {
+	return host.HashComputer
+}
+
+// IsQueryExecution mocked method
+func (host *VMHostMock) IsQueryExecution() bool {
+	return host.QueryExecution
+}
+
 // Blockchain mocked method
 func (host *VMHostMock) Blockchain() vmhost.BlockchainContext {
 	return host.BlockchainContext
@@ -113,7 +125,7 @@ func (host *VMHostMock) ExecuteESDTTransfer(_ *vmhost.ESDTTransfersArgs, _ vm.Ca
 }
 
 // CreateNewContract mocked method
-func (host *VMHostMock) CreateNewContract(_ *vmcommon.ContractCreateInput, _ int) ([]byte, error) {
+func (host *VMHostMock) CreateNewContract(_ *vmcommon.ContractCreateInput, _ int, _ []byte) ([]byte, error) {
 	return nil, nil
 }
 
@@ -19,6 +19,8 @@ type VMHostStub struct {
 	GetVersionCalled      func() string
 
 	CryptoCalled              func() crypto.VMCrypto
+	HasherCalled              func() vmhost.HashComputer
+	IsQueryExecutionCalled    func() bool
 	BlockchainCalled          func() vmhost.BlockchainContext
 	RuntimeCalled             func() vmhost.RuntimeContext
 	OutputCalled              func() vmhost.OutputContext
@@ -30,7 +32,7 @@ type VMHostStub struct {
 	ManagedTypesCalled        func() vmhost.ManagedTypesContext
 
 	ExecuteESDTTransferCalled   func(transfersArgs *vmhost.ESDTTransfersArgs, callType vm.CallType) (*vmcommon.VMOutput, uint64, error)
-	CreateNewContractCalled     func(input *vmcommon.ContractCreateInput, createContractCallType int) ([]byte, error)
+	CreateNewContractCalled     func(input *vmcommon.ContractCreateInput, createContractCallType int, salt []byte) ([]byte, error)
 	ExecuteOnSameContextCalled  func(input *vmcommon.ContractCallInput) error
 	ExecuteOnDestContextCalled  func(input *vmcommon.ContractCallInput) (*vmcommon.VMOutput, bool, error)
 	IsBuiltinFunctionNameCalled func(functionName string) bool
@@ -97,6 +99,22 @@ func (vhs *VMHostStub) Crypto() crypto.VMCrypto {
 	return nil
 }
 
+// Hasher mocked method
+func (vhs *VMHostStub) Hasher() vmhost.HashComputer {
+	if vhs.HasherCalled != nil {
+		return vhs.HasherCalled()
+	}
+	return nil
+}
+
+// IsQueryExecution mocked method
+func (vhs *VMHostStub) IsQueryExecution() bool {
+	if vhs.IsQueryExecutionCalled != nil {
+		return vhs.IsQueryExecutionCalled()
+	}
+	return false
+}
+
 // Blockchain mocked method
 func (vhs *VMHostStub) Blockchain() vmhost.BlockchainContext {
 	if vhs.BlockchainCalled != nil {
@@ -185,9 +203,9 @@ func (vhs *VMHostStub) ExecuteESDTTransfer(transfersArgs *vmhost.ESDTTransfersAr
 }
 
 // CreateNewContract mocked method
-func (vhs *VMHostStub) CreateNewContract(input *vmcommon.ContractCreateInput, createContractCallType int) ([]byte, error) {
+func (vhs *VMHostStub) CreateNewContract(input *vmcommon.ContractCreateInput, createContractCallType int, salt []byte) ([]byte, error) {
 	if vhs.CreateNewContractCalled != nil {
-		return vhs.CreateNewContractCalled(input, createContractCallType)
+		return vhs.CreateNewContractCalled(input, createContractCallType, salt)
 	}
 	return nil, nil
 }
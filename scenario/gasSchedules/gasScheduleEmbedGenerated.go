@@ -68,6 +68,7 @@ const (
     CompilePerByte = 300
     AoTPreparePerByte = 300
     GetCode = 1000000
+    MemoryGrowPerPage = 10000
 
 [BaseOpsAPICost]
     GetSCAddress       = 100
@@ -122,6 +123,18 @@ const (
     GetCodeMetadata = 100
     IsBuiltinFunction = 100
     IsReservedFunctionName = 100
+    TransientStore = 1000
+    TransientLoad = 1000
+    GetStorageDeposit = 5000
+    GetMemoryUsage = 100
+    SetUpgradePolicy = 10000
+    ProposeUpgrade = 10000
+    ApproveUpgrade = 10000
+    CancelUpgrade = 10000
+    ComputeContractAddress = 10000
+    GetCodeHash = 100
+    GetCodeDeployer = 100
+    GetContractDeployInfo = 100
 
 [EthAPICost]
     UseGas = 100
@@ -202,6 +215,11 @@ const (
     BigIntGetCallValue = 1000
     BigIntGetExternalBalance = 10000
     CopyPerByteForTooBig = 1000
+    BigIntModPow = 20000
+    BigIntModInverse = 10000
+    BigIntMulMod = 6000
+    BigIntGCD = 6000
+    ModularArithmeticPerWordSquared = 30
 
 [BigFloatAPICost]
     BigFloatNewFromParts = 3000
@@ -223,6 +241,21 @@ const (
     BigFloatSetInt64 = 1000
     BigFloatGetConst = 1000
 
+[DecimalAPICost]
+    DecimalNew = 1000
+    DecimalFromBigInt = 2000
+    DecimalToBigInt = 2000
+    DecimalRescale = 3000
+    DecimalAdd = 5000
+    DecimalSub = 5000
+    DecimalMul = 7000
+    DecimalDiv = 7000
+    DecimalCmp = 3000
+    DecimalLn = 50000
+    DecimalExp = 50000
+    DecimalLnPerScaleDigit = 2000
+    DecimalExpPerScaleDigit = 2000
+
 [CryptoAPICost]
     SHA256 = 1000000
     Keccak256 = 1000000
@@ -244,6 +277,7 @@ const (
     VerifySecp256r1 = 2000000
     VerifyBLSSignatureShare = 2000000
     VerifyBLSMultiSig = 2000000
+    ManagedEVMFunctionSelector = 1000000
 
 [ManagedBufferAPICost]
     MBufferNew = 2000
@@ -261,11 +295,15 @@ const (
     MBufferFromBigIntSigned = 10000
     MBufferToBigFloat = 2000
     MBufferFromBigFloat = 2000
+    MBufferToDecimal = 2000
+    MBufferFromDecimal = 2000
     MBufferStorageStore = 75000
     MBufferStorageLoad = 50000
     MBufferGetArgument = 1000
     MBufferFinish = 1000
     MBufferSetRandom = 6000
+    ManagedEVMAbiEncode = 10000
+    ManagedEVMAbiDecode = 10000
 
 [WASMOpcodeCost]
     AtomicFence = 10
@@ -899,6 +937,7 @@ const (
     CompilePerByte = 300
     AoTPreparePerByte = 100
     GetCode = 1000000
+    MemoryGrowPerPage = 10000
 
 [BaseOpsAPICost]
     GetSCAddress       = 100
@@ -953,6 +992,18 @@ const (
     GetCodeMetadata = 100
     IsBuiltinFunction = 100
     IsReservedFunctionName = 100
+    TransientStore = 1000
+    TransientLoad = 1000
+    GetStorageDeposit = 5000
+    GetMemoryUsage = 100
+    SetUpgradePolicy = 10000
+    ProposeUpgrade = 10000
+    ApproveUpgrade = 10000
+    CancelUpgrade = 10000
+    ComputeContractAddress = 10000
+    GetCodeHash = 100
+    GetCodeDeployer = 100
+    GetContractDeployInfo = 100
 
 [EthAPICost]
     UseGas = 100
@@ -1033,6 +1084,11 @@ const (
     BigIntGetCallValue = 1000
     BigIntGetExternalBalance = 10000
     CopyPerByteForTooBig = 1000
+    BigIntModPow = 20000
+    BigIntModInverse = 10000
+    BigIntMulMod = 6000
+    BigIntGCD = 6000
+    ModularArithmeticPerWordSquared = 30
 
 [BigFloatAPICost]
     BigFloatNewFromParts = 3000
@@ -1054,6 +1110,21 @@ const (
     BigFloatSetInt64 = 1000
     BigFloatGetConst = 1000
 
+[DecimalAPICost]
+    DecimalNew = 1000
+    DecimalFromBigInt = 2000
+    DecimalToBigInt = 2000
+    DecimalRescale = 3000
+    DecimalAdd = 5000
+    DecimalSub = 5000
+    DecimalMul = 7000
+    DecimalDiv = 7000
+    DecimalCmp = 3000
+    DecimalLn = 50000
+    DecimalExp = 50000
+    DecimalLnPerScaleDigit = 2000
+    DecimalExpPerScaleDigit = 2000
+
 [CryptoAPICost]
     SHA256 = 1000000
     Keccak256 = 1000000
@@ -1072,9 +1143,10 @@ const (
     UnmarshalCompressedECC = 270000
     GenerateKeyECC = 7000000
     EncodeDERSig = 10000000
-VerifySecp256r1 = 2000000
-VerifyBLSSignatureShare = 2000000
-VerifyBLSMultiSig = 2000000
+    VerifySecp256r1 = 2000000
+    VerifyBLSSignatureShare = 2000000
+    VerifyBLSMultiSig = 2000000
+    ManagedEVMFunctionSelector = 1000000
 
 [ManagedBufferAPICost]
     MBufferNew = 2000
@@ -1092,11 +1164,15 @@ VerifyBLSMultiSig = 2000000
     MBufferFromBigIntSigned = 5000
     MBufferToBigFloat = 2000
     MBufferFromBigFloat = 2000
+    MBufferToDecimal = 2000
+    MBufferFromDecimal = 2000
     MBufferStorageStore = 75000
     MBufferStorageLoad = 50000
     MBufferGetArgument = 1000
     MBufferFinish = 1000
     MBufferSetRandom = 6000
+    ManagedEVMAbiEncode = 10000
+    ManagedEVMAbiDecode = 10000
 
 [WASMOpcodeCost]
     AtomicFence = 10
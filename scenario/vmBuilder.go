@@ -7,6 +7,7 @@ import (
 	scenexec "github.com/multiversx/mx-chain-scenario-go/scenario/executor"
 	scenmodel "github.com/multiversx/mx-chain-scenario-go/scenario/model"
 	"github.com/multiversx/mx-chain-scenario-go/worldmock"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
 	"github.com/multiversx/mx-chain-vm-common-go/parsers"
 	"github.com/multiversx/mx-chain-vm-go/config"
 	"github.com/multiversx/mx-chain-vm-go/executor"
@@ -29,6 +30,14 @@ type ScenarioVMHostBuilder struct {
 	OverrideVMExecutor                  executor.ExecutorAbstractFactory
 	VMType                              []byte
 	TimeOutForSCExecutionInMilliseconds uint32
+	EpochGasSchedules                   []EpochGasSchedule
+}
+
+// EpochGasSchedule selects the gas schedule used starting with the given block epoch.
+// Before the first start epoch, the gas schedule requested by the scenario applies.
+type EpochGasSchedule struct {
+	StartEpoch  uint32
+	GasSchedule scenmodel.GasSchedule
 }
 
 // NewScenarioVMHostBuilder creates a default ScenarioVMHostBuilder.
@@ -77,10 +86,22 @@ func (svb *ScenarioVMHostBuilder) NewVM(
 		return nil, err
 	}
 
+	epochGasSchedules, err := svb.resolveEpochGasSchedules()
+	if err != nil {
+		return nil, err
+	}
+
+	var epochNotifier vmcommon.EpochNotifier = &mock.EpochNotifierStub{}
+	var worldNotifier *worldEpochNotifier
+	if len(epochGasSchedules) > 0 {
+		worldNotifier = newWorldEpochNotifier(world)
+		epochNotifier = worldNotifier
+	}
+
 	blockGasLimit := uint64(10000000)
 	esdtTransferParser, _ := parsers.NewESDTTransferParser(worldmock.WorldMarshalizer)
 
-	return hostCore.NewVMHost(
+	host, err := hostCore.NewVMHost(
 		world,
 		&vmhost.VMHostParameters{
 			VMType:                    svb.VMType,
@@ -90,14 +111,35 @@ func (svb *ScenarioVMHostBuilder) NewVM(
 			BuiltInFuncContainer:      world.BuiltinFuncs.Container,
 			ProtectedKeyPrefix:        []byte(core.ProtectedKeyPrefix),
 			ESDTTransferParser:        esdtTransferParser,
-			EpochNotifier:             &mock.EpochNotifierStub{},
+			EpochNotifier:             epochNotifier,
 			EnableEpochsHandler:       world.EnableEpochsHandler,
 			WasmerSIGSEGVPassthrough:  false,
 			Hasher:                    worldmock.DefaultHasher,
 			MapOpcodeAddressIsAllowed: map[string]map[string]struct{}{},
 			TimeOutForSCExecutionInMilliseconds: svb.TimeOutForSCExecutionInMilliseconds,
+			EpochGasSchedules:                   epochGasSchedules,
 		})
+	if err != nil || worldNotifier == nil {
+		return host, err
+	}
+
+	return &epochNotifyingVM{VMHost: host, notifier: worldNotifier}, nil
+}
+
+func (svb *ScenarioVMHostBuilder) resolveEpochGasSchedules() ([]vmhost.EpochGasSchedule, error) {
+	epochGasSchedules := make([]vmhost.EpochGasSchedule, 0, len(svb.EpochGasSchedules))
+	for _, epochGasSchedule := range svb.EpochGasSchedules {
+		gasSchedule, err := svb.GasScheduleMapFromScenarios(epochGasSchedule.GasSchedule)
+		if err != nil {
+			return nil, err
+		}
+		epochGasSchedules = append(epochGasSchedules, vmhost.EpochGasSchedule{
+			StartEpoch:  epochGasSchedule.StartEpoch,
+			GasSchedule: gasSchedule,
+		})
+	}
 
+	return epochGasSchedules, nil
 }
 
 // DefaultScenarioExecutor provides a scenario executor with VM 1.5, default configuration
@@ -0,0 +1,71 @@
+package scenario
+
+import (
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-scenario-go/worldmock"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	"github.com/multiversx/mx-chain-vm-go/vmhost"
+)
+
+// worldEpochNotifier confirms the block epoch of the mock world to its subscribers, whenever it changes.
+// The mock world does not signal epoch changes, so checkEpoch is called before each execution.
+type worldEpochNotifier struct {
+	world     *worldmock.MockWorld
+	handlers  []vmcommon.EpochSubscriberHandler
+	lastEpoch uint32
+}
+
+func newWorldEpochNotifier(world *worldmock.MockWorld) *worldEpochNotifier {
+	return &worldEpochNotifier{
+		world:     world,
+		lastEpoch: world.CurrentEpoch(),
+	}
+}
+
+// RegisterNotifyHandler subscribes the handler and confirms the current epoch to it
+func (notifier *worldEpochNotifier) RegisterNotifyHandler(handler vmcommon.EpochSubscriberHandler) {
+	if check.IfNil(handler) {
+		return
+	}
+
+	notifier.handlers = append(notifier.handlers, handler)
+	handler.EpochConfirmed(notifier.lastEpoch, 0)
+}
+
+// checkEpoch notifies the subscribers if the epoch of the mock world changed since the last check
+func (notifier *worldEpochNotifier) checkEpoch() {
+	epoch := notifier.world.CurrentEpoch()
+	if epoch == notifier.lastEpoch {
+		return
+	}
+
+	notifier.lastEpoch = epoch
+	for _, handler := range notifier.handlers {
+		handler.EpochConfirmed(epoch, 0)
+	}
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (notifier *worldEpochNotifier) IsInterfaceNil() bool {
+	return notifier == nil
+}
+
+// epochNotifyingVM checks the epoch of the mock world before each execution,
+// so that the host switches gas schedules as soon as a scenario step changes the block epoch.
+// The gas costs of the built-in functions stay the ones from the gas schedule requested by the scenario.
+type epochNotifyingVM struct {
+	vmhost.VMHost
+	notifier *worldEpochNotifier
+}
+
+// RunSmartContractCreate confirms the current epoch, then deploys the contract
+func (vm *epochNotifyingVM) RunSmartContractCreate(input *vmcommon.ContractCreateInput) (*vmcommon.VMOutput, error) {
+	vm.notifier.checkEpoch()
+	return vm.VMHost.RunSmartContractCreate(input)
+}
+
+// RunSmartContractCall confirms the current epoch, then executes the call
+func (vm *epochNotifyingVM) RunSmartContractCall(input *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+	vm.notifier.checkEpoch()
+	return vm.VMHost.RunSmartContractCall(input)
+}
@@ -0,0 +1,22 @@
+(module
+  (type $void (func))
+  (type $finish (func (param i64)))
+  (import "env" "int64finish" (func $int64finish (type $finish)))
+  (func $infiniteLoop (type $void)
+    (loop $forever
+      (br $forever))
+  )
+  (func $boundedLoop (type $void)
+    (local $i i32)
+    (loop $next
+      (local.set $i (i32.add (local.get $i) (i32.const 1)))
+      (br_if $next (i32.lt_u (local.get $i) (i32.const 1000))))
+    (local.get $i)
+    (i64.extend_i32_u)
+    (call $int64finish)
+  )
+  (memory $mem 1)
+  (export "memory" (memory $mem))
+  (export "infiniteLoop" (func $infiniteLoop))
+  (export "boundedLoop" (func $boundedLoop))
+)
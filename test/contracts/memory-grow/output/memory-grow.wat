@@ -0,0 +1,30 @@
+(module
+  (type $void (func))
+  (type $finish (func (param i64)))
+  (type $gasLeft (func (result i64)))
+  (import "env" "int64finish" (func $int64finish (type $finish)))
+  (import "env" "getGasLeft" (func $getGasLeft (type $gasLeft)))
+  (func $growMemory (type $void)
+    (memory.grow (i32.const 3))   ;; returns the previous number of pages
+    (i64.extend_i32_u)
+    (call $int64finish)
+    (memory.size)
+    (i64.extend_i32_u)
+    (call $int64finish)
+  )
+  (func $memorySize (type $void)
+    (memory.size)
+    (i64.extend_i32_u)
+    (call $int64finish)
+  )
+  (func $growMemoryAndGetGasLeft (type $void)
+    (drop (memory.grow (i32.const 3)))
+    (call $getGasLeft)
+    (call $int64finish)
+  )
+  (memory $mem 2)
+  (export "memory" (memory $mem))
+  (export "growMemory" (func $growMemory))
+  (export "memorySize" (func $memorySize))
+  (export "growMemoryAndGetGasLeft" (func $growMemoryAndGetGasLeft))
+)
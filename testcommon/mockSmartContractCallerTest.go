@@ -1,6 +1,7 @@
 package testcommon
 
 import (
+	"math/big"
 	"testing"
 
 	logger "github.com/multiversx/mx-chain-logger-go"
@@ -21,6 +22,9 @@ const (
 
 	// CreateTest indicates a test with SC creation
 	CreateTest
+
+	// QueryTest indicates a test with SC queries
+	QueryTest
 )
 
 // SetupFunction -
@@ -42,9 +46,13 @@ type testTemplateConfig struct {
 // MockInstancesTestTemplate holds the data to build a mock contract call test
 type MockInstancesTestTemplate struct {
 	testTemplateConfig
-	contracts     *[]MockTestSmartContract
-	setup         SetupFunction
-	assertResults func(*TestCallNode, *worldmock.MockWorld, *VMOutputVerifier, []string)
+	contracts             *[]MockTestSmartContract
+	setup                 SetupFunction
+	assertResults         func(*TestCallNode, *worldmock.MockWorld, *VMOutputVerifier, []string)
+	storageDepositPerByte *big.Int
+	executionWatchdog     vmhost.ExecutionWatchdog
+	instructionBudget     uint64
+	queryGasLimit         uint64
 }
 
 // BuildMockInstanceCallTest starts the building process for a mock contract call test
@@ -77,6 +85,25 @@ func (callerTest *MockInstancesTestTemplate) WithSetup(setup SetupFunction) *Moc
 	return callerTest
 }
 
+// WithStorageDeposit builds the host of the mock contract call test with the storage deposit mode
+func (callerTest *MockInstancesTestTemplate) WithStorageDeposit(depositPerByte *big.Int) *MockInstancesTestTemplate {
+	callerTest.storageDepositPerByte = depositPerByte
+	return callerTest
+}
+
+// WithExecutionWatchdog builds the host of the mock contract call test with the given execution watchdog
+func (callerTest *MockInstancesTestTemplate) WithExecutionWatchdog(watchdog vmhost.ExecutionWatchdog, instructionBudget uint64) *MockInstancesTestTemplate {
+	callerTest.executionWatchdog = watchdog
+	callerTest.instructionBudget = instructionBudget
+	return callerTest
+}
+
+// WithQueryGasLimit builds the host of the mock contract call test with the given gas limit for queries
+func (callerTest *MockInstancesTestTemplate) WithQueryGasLimit(gasLimit uint64) *MockInstancesTestTemplate {
+	callerTest.queryGasLimit = gasLimit
+	return callerTest
+}
+
 // WithWasmerSIGSEGVPassthrough sets the wasmerSIGSEGVPassthrough flag
 func (callerTest *MockInstancesTestTemplate) WithWasmerSIGSEGVPassthrough(wasmerSIGSEGVPassthrough bool) *MockInstancesTestTemplate {
 	callerTest.wasmerSIGSEGVPassthrough = wasmerSIGSEGVPassthrough
@@ -97,6 +124,13 @@ func (callerTest *MockInstancesTestTemplate) AndCreateAndAssertResults(assertRes
 	})
 }
 
+// AndQueryAndAssertResults provides the function that will run the input as a query and aserts the results
+func (callerTest *MockInstancesTestTemplate) AndQueryAndAssertResults(assertResults AssertResultsFunc) (*vmcommon.VMOutput, error) {
+	return callerTest.andAssertResultsWithWorld(nil, true, nil, QueryTest, nil, func(startNode *TestCallNode, world *worldmock.MockWorld, verify *VMOutputVerifier, expectedErrorsForRound []string) {
+		assertResults(world, verify)
+	})
+}
+
 // AndAssertResultsWithWorld provides the function that will aserts the results
 func (callerTest *MockInstancesTestTemplate) AndAssertResultsWithWorld(
 	world *worldmock.MockWorld,
@@ -157,6 +191,9 @@ func (callerTest *MockInstancesTestTemplate) RunTest(
 	host := NewTestHostBuilder(callerTest.tb).
 		WithExecutorFactory(executorFactory).
 		WithBlockchainHook(world).
+		WithStorageDeposit(callerTest.storageDepositPerByte).
+		WithExecutionWatchdog(callerTest.executionWatchdog, callerTest.instructionBudget).
+		WithQueryLimits(callerTest.queryGasLimit, 0).
 		Build()
 
 	defer func() {
@@ -181,6 +218,8 @@ func (callerTest *MockInstancesTestTemplate) RunTest(
 			VMInput:      callerTest.input.VMInput,
 			ContractCode: callerTest.input.RecipientAddr,
 		})
+	case QueryTest:
+		vmOutput, err = host.(vmhost.QueryExecutor).RunSmartContractQuery(callerTest.input)
 	}
 
 	return host, vmOutput, err
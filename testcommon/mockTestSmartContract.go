@@ -94,6 +94,7 @@ type testSmartContract struct {
 // MockTestSmartContract represents the config data for the mock smart contract instance to be tested
 type MockTestSmartContract struct {
 	testSmartContract
+	code        []byte
 	initMethods []func(*mock.InstanceMock, interface{})
 	// used only temporarly for call graph building
 	tempFunctionsList map[string]bool
@@ -152,6 +153,12 @@ func (mockSC *MockTestSmartContract) WithCodeHash(codeHash []byte) *MockTestSmar
 	return mockSC
 }
 
+// WithCode provides the code of the MockTestSmartContract, which otherwise is its address
+func (mockSC *MockTestSmartContract) WithCode(code []byte) *MockTestSmartContract {
+	mockSC.code = code
+	return mockSC
+}
+
 // WithOwnerAddress provides the owner address for the MockTestSmartContract
 func (mockSC *MockTestSmartContract) WithOwnerAddress(ownerAddress []byte) *MockTestSmartContract {
 	mockSC.ownerAddress = ownerAddress
@@ -186,4 +193,11 @@ func (mockSC *MockTestSmartContract) Initialize(
 	for _, initMethod := range mockSC.initMethods {
 		initMethod(instance, mockSC.config)
 	}
+
+	if mockSC.code != nil {
+		imb.InstanceMap[string(mockSC.code)] = *instance
+		if createContractAccounts {
+			imb.World.AcctMap.GetAccount(mockSC.address).Code = mockSC.code
+		}
+	}
 }
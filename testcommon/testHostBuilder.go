@@ -1,6 +1,7 @@
 package testcommon
 
 import (
+	"math/big"
 	"testing"
 
 	"github.com/multiversx/mx-chain-core-go/core/check"
@@ -106,6 +107,38 @@ func (thb *TestHostBuilder) WithWasmerSIGSEGVPassthrough(wasmerSIGSEGVPassthroug
 	return thb
 }
 
+// WithGasBreakdown allows tests to enable the gas breakdown per account.
+func (thb *TestHostBuilder) WithGasBreakdown(enableGasBreakdown bool) *TestHostBuilder {
+	thb.vmHostParameters.EnableGasBreakdown = enableGasBreakdown
+	return thb
+}
+
+// WithExecutionWatchdog allows tests to choose how executions which run for too long are aborted.
+func (thb *TestHostBuilder) WithExecutionWatchdog(watchdog vmhost.ExecutionWatchdog, instructionBudget uint64) *TestHostBuilder {
+	thb.vmHostParameters.ExecutionWatchdog = watchdog
+	thb.vmHostParameters.ExecutionInstructionBudget = instructionBudget
+	return thb
+}
+
+// WithExecutionTimeout allows tests to configure the wall-clock timeout of the executions.
+func (thb *TestHostBuilder) WithExecutionTimeout(timeoutInMilliseconds uint32) *TestHostBuilder {
+	thb.vmHostParameters.TimeOutForSCExecutionInMilliseconds = timeoutInMilliseconds
+	return thb
+}
+
+// WithQueryLimits allows tests to configure the gas limit and the wall-clock timeout of the queries.
+func (thb *TestHostBuilder) WithQueryLimits(gasLimit uint64, timeoutInMilliseconds uint32) *TestHostBuilder {
+	thb.vmHostParameters.QueryGasLimit = gasLimit
+	thb.vmHostParameters.TimeOutForSCQueryInMilliseconds = timeoutInMilliseconds
+	return thb
+}
+
+// WithStorageDeposit allows tests to lock a deposit for each byte added to the storage of the contracts.
+func (thb *TestHostBuilder) WithStorageDeposit(depositPerByte *big.Int) *TestHostBuilder {
+	thb.vmHostParameters.StorageDepositPerByte = depositPerByte
+	return thb
+}
+
 // WithGasSchedule allows tests to use the gas costs. The default is config.MakeGasMapForTests().
 func (thb *TestHostBuilder) WithGasSchedule(gasSchedule config.GasScheduleMap) *TestHostBuilder {
 	thb.vmHostParameters.GasSchedule = gasSchedule
@@ -0,0 +1,28 @@
+package vmhost
+
+import (
+	"bytes"
+)
+
+// codeReferenceMagic starts the code of a contract deployed by reference, which cannot be mistaken
+// for WASM code, starting with "\x00asm".
+const codeReferenceMagic = "\x00ref"
+
+const codeReferenceLen = len(codeReferenceMagic) + HashLen
+
+// NewCodeReference creates the code stored by a contract deployed by reference to the code registered
+// under the given code hash, in place of the code itself.
+func NewCodeReference(codeHash []byte) []byte {
+	reference := make([]byte, 0, codeReferenceLen)
+	reference = append(reference, codeReferenceMagic...)
+	return append(reference, codeHash...)
+}
+
+// ParseCodeReference returns the code hash referenced by the given contract code, or false if the code
+// is not a code reference.
+func ParseCodeReference(code []byte) ([]byte, bool) {
+	if len(code) != codeReferenceLen || !bytes.HasPrefix(code, []byte(codeReferenceMagic)) {
+		return nil, false
+	}
+	return code[len(codeReferenceMagic):], true
+}
@@ -0,0 +1,22 @@
+package vmhost
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCodeReference(t *testing.T) {
+	codeHash := bytes.Repeat([]byte{0x01}, HashLen)
+	referencedHash, isReference := ParseCodeReference(NewCodeReference(codeHash))
+	require.True(t, isReference)
+	require.Equal(t, codeHash, referencedHash)
+
+	_, isReference = ParseCodeReference(append([]byte("\x00asm"), codeHash...))
+	require.False(t, isReference)
+	_, isReference = ParseCodeReference(NewCodeReference(codeHash)[1:])
+	require.False(t, isReference)
+	_, isReference = ParseCodeReference(nil)
+	require.False(t, isReference)
+}
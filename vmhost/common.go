@@ -2,6 +2,8 @@
 package vmhost
 
 import (
+	"math/big"
+
 	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
 	"github.com/multiversx/mx-chain-vm-go/config"
 	"github.com/multiversx/mx-chain-vm-go/executor"
@@ -139,12 +141,47 @@ const (
 // function of a smart contract
 const CallbackFunctionName = "callBack"
 
+// VMStoragePrefix is the prefix appended to the protected key prefix for VM-internal storage keys.
+const VMStoragePrefix = "VM@"
+
 // TimeLockKeyPrefix is the storage key prefix used for timelock-related storage.
 const TimeLockKeyPrefix = "TIMELOCK"
 
 // AsyncDataPrefix is the storage key prefix used for AsyncContext-related storage.
 const AsyncDataPrefix = "ASYNC"
 
+// DefaultMaxGasRefundPercentage is the maximum gas refund of a transaction, as a percentage of its gas used,
+// applied when VMHostParameters.MaxGasRefundPercentage is not set.
+const DefaultMaxGasRefundPercentage = uint64(20)
+
+// StorageDepositKey is the storage key under which the deposit locked for the storage of a contract is kept.
+const StorageDepositKey = "STORAGEDEPOSIT"
+
+// UpgradePolicyKey is the storage key under which the delay and the approvers of the upgrades of a contract are kept.
+const UpgradePolicyKey = "UPGRADEPOLICY"
+
+// UpgradeConditionsKey is the storage key under which the upgrade conditions of the code metadata of a contract are
+// kept, because these bits are not part of vmcommon.CodeMetadata and are lost when the code metadata is rewritten.
+const UpgradeConditionsKey = "UPGRADECONDITIONS"
+
+// PendingUpgradeKey is the storage key under which the upgrade proposed by a contract is kept until it is applied or cancelled.
+const PendingUpgradeKey = "PENDINGUPGRADE"
+
+// DeployInfoKey is the storage key under which the deployer and the deploy block of a contract are kept.
+const DeployInfoKey = "DEPLOYINFO"
+
+// FrozenContractKey is the storage key under which the freeze of a contract and its allowed endpoints are kept.
+const FrozenContractKey = "FROZEN"
+
+// CodeRegistryKey prefixes the storage keys of the system account under which the codes registered by contracts
+// are kept by their hash, to be deployed by reference.
+const CodeRegistryKey = "CODEREGISTRY"
+
+// CodeReferenceKey prefixes the storage keys of the system account under which the hashes of the registered codes
+// are kept by the hash of their references, so that the code hash of a contract deployed by reference is found
+// without loading its code.
+const CodeReferenceKey = "CODEREFERENCE"
+
 // AsyncCallStatus represents the different status an async call can have
 type AsyncCallStatus uint8
 
@@ -185,6 +222,12 @@ const (
 
 	// DeleteFunctionName specifies if the call is an deleteContract call
 	DeleteFunctionName = "deleteContract"
+
+	// FreezeFunctionName specifies if the call is a freezeContract call, whose arguments are the endpoints left callable by anyone
+	FreezeFunctionName = "freezeContract"
+
+	// UnfreezeFunctionName specifies if the call is an unfreezeContract call
+	UnfreezeFunctionName = "unfreezeContract"
 )
 
 // CodeDeployInput contains code deploy state, whether it comes from a ContractCreateInput or a ContractCallInput
@@ -210,6 +253,107 @@ type VMHostParameters struct {
 	Hasher                              HashComputer
 	TimeOutForSCExecutionInMilliseconds uint32
 	MapOpcodeAddressIsAllowed           map[string]map[string]struct{}
+	StorageDepositPerByte               *big.Int
+	EpochGasSchedules                   []EpochGasSchedule
+	MaxGasRefundPercentage              uint64
+	EnableGasBreakdown                  bool
+	ExecutionWatchdog                   ExecutionWatchdog
+	ExecutionInstructionBudget          uint64
+	CodeLimits                          CodeLimits
+	EpochCodeLimits                     []EpochCodeLimits
+	QueryGasLimit                       uint64
+	TimeOutForSCQueryInMilliseconds     uint32
+}
+
+// ExecutionWatchdog selects how the VM aborts executions which run for too long
+type ExecutionWatchdog uint8
+
+const (
+	// WallClockWatchdog aborts executions which exceed TimeOutForSCExecutionInMilliseconds,
+	// which depends on the speed of the machine
+	WallClockWatchdog ExecutionWatchdog = iota
+
+	// InstructionBudgetWatchdog aborts transactions which execute more than ExecutionInstructionBudget WASM
+	// instructions, regardless of the gas provided; the outcome is the same on all machines and the
+	// wall-clock timeout is only kept as a safety net, so it should be configured generously.
+	// The code injected into the contracts to count their instructions is metered like their own code, so the
+	// contracts use more gas than with WallClockWatchdog: all the nodes must use the same watchdog and budget
+	InstructionBudgetWatchdog
+)
+
+// CountInstructionsHookName is the VM hook to which the instrumented contracts report the instructions they
+// execute, when the instruction budget is enabled.
+const CountInstructionsHookName = "countInstructions"
+
+// MemoryGrowthHookName is the VM hook which the instrumented contracts call after each memory.grow, so that the
+// new pages are charged before the contract uses them, when the executor provides it.
+const MemoryGrowthHookName = "chargeMemoryGrowth"
+
+// CountInstructionsChunk is the number of instructions which the instrumented contracts execute between two calls
+// to the CountInstructionsHookName hook; the budget is checked with this granularity.
+const CountInstructionsChunk = 1000
+
+// EpochGasSchedule is a gas schedule which the VM switches to when the given epoch is confirmed.
+// It stays active until the start epoch of the next gas schedule.
+type EpochGasSchedule struct {
+	StartEpoch  uint32
+	GasSchedule config.GasScheduleMap
+}
+
+// CodeLimits are static limits on the code of the contracts, checked when they are deployed or upgraded.
+// A limit set to 0 is not checked.
+type CodeLimits struct {
+	MaxCodeSize          uint64
+	MaxFunctions         uint32
+	MaxLocalsPerFunction uint32
+	MaxTableSize         uint32
+	MaxDataSegments      uint32
+	MaxImports           uint32
+}
+
+// IsUnlimited returns true if none of the limits is set.
+func (limits CodeLimits) IsUnlimited() bool {
+	return limits == CodeLimits{}
+}
+
+// EpochCodeLimits are code limits which the VM switches to when the given epoch is confirmed.
+// They stay active until the start epoch of the next code limits.
+type EpochCodeLimits struct {
+	StartEpoch uint32
+	CodeLimits CodeLimits
+}
+
+// GasRefundBreakdown details how the gas refund of a transaction was computed: the refund accumulated
+// while releasing storage, the cap derived from the gas used and the refund actually applied.
+type GasRefundBreakdown struct {
+	Accumulated uint64
+	Cap         uint64
+	Applied     uint64
+}
+
+// GasBreakdown details the GasUsed of an account in the call tree: the compilation of its code, the WASM
+// opcodes executed and the VM hooks called, by category. The gas forwarded to other contracts or to
+// cross-shard calls and the gas locked for async callbacks are not part of GasUsed and are reported apart.
+type GasBreakdown struct {
+	GasUsed            uint64
+	Compilation        uint64
+	Opcodes            uint64
+	VMHooks            map[string]uint64
+	Forwarded          uint64
+	LockedForCallbacks uint64
+}
+
+// GasEstimation is the minimal gas limit with which a call has the same successful outcome
+// as with the gas limit provided for the estimation, together with how that gas is spent.
+// Execution is all the gas used which is neither compilation nor locked for async callbacks,
+// including the gas forwarded to other contracts.
+type GasEstimation struct {
+	GasLimit    uint64
+	Compilation uint64
+	Execution   uint64
+	AsyncLocked uint64
+	Refund      *big.Int
+	VMOutput    *vmcommon.VMOutput
 }
 
 // AsyncCallInfo contains the information required to handle the asynchronous call of another SmartContract
@@ -355,7 +355,7 @@ func (context *asyncContext) SetContextCallback(callbackName string, data []byte
 
 	metering := context.host.Metering()
 	gasToLock := math.AddUint64(gas, metering.ComputeExtraGasLockedForAsync())
-	err = metering.UseGasBounded(gasToLock)
+	err = metering.UseGasBoundedForChildExecution(gasToLock)
 	if err != nil {
 		return err
 	}
@@ -495,6 +495,10 @@ func (context *asyncContext) IsComplete() bool {
 // RegisterAsyncCall validates the provided AsyncCall adds it to the specified
 // group (adding the AsyncCall consumes its gas entirely).
 func (context *asyncContext) RegisterAsyncCall(groupID string, call *vmhost.AsyncCall) error {
+	if context.host.IsQueryExecution() {
+		return vmhost.ErrAsyncCallInQuery
+	}
+
 	runtime := context.host.Runtime()
 	metering := context.host.Metering()
 
@@ -541,6 +545,9 @@ func (context *asyncContext) RegisterAsyncCall(groupID string, call *vmhost.Asyn
 func (context *asyncContext) RegisterLegacyAsyncCall(address []byte, data []byte, value []byte) error {
 	metering := context.host.Metering()
 	logAsync.Trace("RegisterLegacyAsyncCall", "gas left", metering.GasLeft())
+	if context.host.IsQueryExecution() {
+		return vmhost.ErrAsyncCallInQuery
+	}
 	if !context.canRegisterLegacyAsyncCall() {
 		return vmhost.ErrLegacyAsyncCallInvalid
 	}
@@ -604,11 +611,11 @@ func (context *asyncContext) addAsyncCall(groupID string, call *vmhost.AsyncCall
 
 	metering := context.host.Metering()
 
-	err := metering.UseGasBounded(call.GasLocked)
+	err := metering.UseGasBoundedForChildExecution(call.GasLocked)
 	if err != nil {
 		return err
 	}
-	err = metering.UseGasBounded(call.GasLimit)
+	err = metering.UseGasBoundedForChildExecution(call.GasLimit)
 	if err != nil {
 		return err
 	}
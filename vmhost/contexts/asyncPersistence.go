@@ -9,7 +9,12 @@ import (
 )
 
 // Save serializes and saves the AsyncContext to the storage of the contract, under a protected key.
+// Queries never persist the AsyncContext, since they cannot register async calls.
 func (context *asyncContext) Save() error {
+	if context.host.IsQueryExecution() {
+		return nil
+	}
+
 	address := context.address
 	callID := context.callID
 	storage := context.host.Storage()
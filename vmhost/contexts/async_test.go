@@ -91,13 +91,13 @@ func initializeVMAndWasmerAsyncContextWithBuiltIn(tb testing.TB, isBuiltinFunc b
 	runtimeCtx.iTracker.instance = mockWasmerInstance
 	host.RuntimeContext = runtimeCtx
 
-	storageCtx, err := NewStorageContext(host, world, reservedTestPrefix)
+	storageCtx, err := NewStorageContext(host, world, reservedTestPrefix, nil)
 	require.Nil(tb, err)
 	host.StorageContext = storageCtx
 
 	host.OutputContext, _ = NewOutputContext(host)
 	host.CryptoHook, _ = factory.NewVMCrypto()
-	host.StorageContext, _ = NewStorageContext(host, world, reservedTestPrefix)
+	host.StorageContext, _ = NewStorageContext(host, world, reservedTestPrefix, nil)
 	host.EnableEpochsHandlerField = worldmock.EnableEpochsHandlerStubNoFlags()
 	host.IsBuiltinFunc = isBuiltinFunc
 
@@ -52,6 +52,13 @@ func (context *blockchainContext) NewAddress(creatorAddress []byte) ([]byte, err
 	return context.blockChainHook.NewAddress(creatorAddress, nonce, vmType)
 }
 
+// NewSaltedAddress returns the address of a contract deployed by the provided creator address with the given salt
+// and code hash, which does not depend on the nonce of the creator.
+func (context *blockchainContext) NewSaltedAddress(creatorAddress []byte, salt []byte, codeHash []byte) ([]byte, error) {
+	vmType := context.host.Runtime().GetVMType()
+	return vmhost.ComputeContractAddress(context.host.Hasher(), creatorAddress, salt, codeHash, vmType)
+}
+
 // AccountExists verifies if the provided address exists.
 func (context *blockchainContext) AccountExists(address []byte) bool {
 	account, err := context.blockChainHook.GetUserAccount(address)
@@ -130,8 +137,22 @@ func (context *blockchainContext) GetESDTToken(address []byte, tokenID []byte, n
 	return context.blockChainHook.GetESDTToken(address, tokenID, nonce)
 }
 
-// GetCodeHash retrieves the hash of the code stored under the given address.
+// GetCodeHash retrieves the hash of the code stored under the given address. For a contract which references a
+// registered code, the referenced code hash is returned instead, so that it is the same as the hash of a contract
+// deployed with the full code and the instances compiled for the registered code are reused. The referenced code
+// hash is looked up by the code hash of the account, recorded when the code was registered, so the code of
+// the account is not loaded.
 func (context *blockchainContext) GetCodeHash(address []byte) []byte {
+	isCodeRegistryEnabled := context.host.EnableEpochsHandler().IsFlagEnabled(vmhost.CodeRegistryFlag)
+
+	outputAccount, ok := context.host.Output().GetOutputAccounts()[string(address)]
+	if isCodeRegistryEnabled && ok && len(outputAccount.Code) > 0 {
+		codeHash, isReference := vmhost.ParseCodeReference(outputAccount.Code)
+		if isReference {
+			return codeHash
+		}
+	}
+
 	account, err := context.blockChainHook.GetUserAccount(address)
 	if err != nil {
 		return nil
@@ -141,7 +162,16 @@ func (context *blockchainContext) GetCodeHash(address []byte) []byte {
 	}
 
 	codeHash := account.GetCodeHash()
-	return codeHash
+	if !isCodeRegistryEnabled || len(codeHash) == 0 {
+		return codeHash
+	}
+
+	referencedCodeHash, _, err := context.host.Storage().GetReferencedCodeHash(codeHash)
+	if err != nil || len(referencedCodeHash) == 0 {
+		return codeHash
+	}
+
+	return referencedCodeHash
 }
 
 // GetCode retrieves the code stored under the given address.
@@ -149,7 +179,7 @@ func (context *blockchainContext) GetCode(address []byte) ([]byte, error) {
 	outputAccount, isNew := context.host.Output().GetOutputAccount(address)
 	hasCode := !isNew && len(outputAccount.Code) > 0
 	if hasCode {
-		return outputAccount.Code, nil
+		return context.resolveCode(outputAccount.Code)
 	}
 
 	account, err := context.blockChainHook.GetUserAccount(address)
@@ -167,7 +197,7 @@ func (context *blockchainContext) GetCode(address []byte) ([]byte, error) {
 
 	outputAccount.Code = code
 
-	return code, nil
+	return context.resolveCode(code)
 }
 
 // GetCodeSize returns the size of the code stored under the given address.
@@ -177,11 +207,45 @@ func (context *blockchainContext) GetCodeSize(address []byte) (int32, error) {
 		return 0, err
 	}
 
-	code := context.blockChainHook.GetCode(account)
+	code, err := context.resolveCode(context.blockChainHook.GetCode(account))
+	if err != nil {
+		return 0, err
+	}
+
 	result := int32(len(code))
 	return result, nil
 }
 
+// resolveCode returns the registered code referenced by the given code, or the code itself
+// if it is not a reference.
+func (context *blockchainContext) resolveCode(code []byte) ([]byte, error) {
+	codeHash, isReference := context.parseCodeReference(code)
+	if !isReference {
+		return code, nil
+	}
+
+	registeredCode, _, err := context.host.Storage().GetRegisteredCode(codeHash)
+	if err != nil {
+		return nil, err
+	}
+	if len(registeredCode) == 0 {
+		return nil, vmhost.ErrCodeHashNotRegistered
+	}
+
+	return registeredCode, nil
+}
+
+func (context *blockchainContext) parseCodeReference(code []byte) ([]byte, bool) {
+	codeHash, isReference := vmhost.ParseCodeReference(code)
+	if !isReference {
+		return nil, false
+	}
+	if !context.host.EnableEpochsHandler().IsFlagEnabled(vmhost.CodeRegistryFlag) {
+		return nil, false
+	}
+	return codeHash, true
+}
+
 // BlockHash returns the hash of the block that has the given nonce.
 func (context *blockchainContext) BlockHash(number uint64) []byte {
 	block, err := context.blockChainHook.GetBlockhash(number)
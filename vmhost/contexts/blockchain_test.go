@@ -195,7 +195,7 @@ func TestBlockchainContext_GetCodeHashAndSize(t *testing.T) {
 
 	outputContext := &contextmock.OutputContextMock{}
 
-	host := &contextmock.VMHostMock{}
+	host := &contextmock.VMHostMock{EnableEpochsHandlerField: &worldmock.EnableEpochsHandlerStub{}}
 	host.CryptoHook = mockCrypto
 	host.OutputContext = outputContext
 
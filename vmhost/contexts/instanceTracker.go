@@ -8,6 +8,7 @@ import (
 	"github.com/multiversx/mx-chain-core-go/core/check"
 	logger "github.com/multiversx/mx-chain-logger-go"
 	"github.com/multiversx/mx-chain-storage-go/lrucache"
+	"github.com/multiversx/mx-chain-vm-go/contractabi"
 	"github.com/multiversx/mx-chain-vm-go/executor"
 	"github.com/multiversx/mx-chain-vm-go/vmhost"
 )
@@ -43,6 +44,16 @@ type instanceTracker struct {
 	instanceStack       []executor.Instance
 	codeHashStack       [][]byte
 	codeSizeStack       []uint64
+	chargedMemoryPages  uint32
+	chargedPagesStack   []uint32
+	memoryPagesCache    Cacher
+	contractABI         *contractabi.ABI
+	contractABIStack    []*contractabi.ABI
+	contractABICache    Cacher
+	accessControl       *contractabi.AccessControl
+	accessControlStack  []*contractabi.AccessControl
+	accessControlCache  Cacher
+	warmCacheFrozen     bool
 
 	instances map[string]executor.Instance
 }
@@ -54,10 +65,26 @@ func NewInstanceTracker() (*instanceTracker, error) {
 		instanceStack:       make([]executor.Instance, 0),
 		codeHashStack:       make([][]byte, 0),
 		codeSizeStack:       make([]uint64, 0),
+		chargedPagesStack:   make([]uint32, 0),
+		contractABIStack:    make([]*contractabi.ABI, 0),
+		accessControlStack:  make([]*contractabi.AccessControl, 0),
 		numRunningInstances: 0,
 	}
 
 	var err error
+	tracker.contractABICache, err = lrucache.NewCache(warmCacheSize)
+	if err != nil {
+		return nil, err
+	}
+	tracker.accessControlCache, err = lrucache.NewCache(warmCacheSize)
+	if err != nil {
+		return nil, err
+	}
+	tracker.memoryPagesCache, err = lrucache.NewCache(warmCacheSize)
+	if err != nil {
+		return nil, err
+	}
+
 	instanceEvictedCallback := tracker.makeInstanceEvictionCallback()
 	if WarmInstancesEnabled {
 		tracker.warmInstanceCache, err = lrucache.NewCacheWithEviction(warmCacheSize, instanceEvictedCallback)
@@ -77,6 +104,9 @@ func (tracker *instanceTracker) InitState() {
 	tracker.codeHash = make([]byte, 0)
 	tracker.instances = make(map[string]executor.Instance)
 	tracker.codeSize = 0
+	tracker.chargedMemoryPages = 0
+	tracker.contractABI = nil
+	tracker.accessControl = nil
 }
 
 // PushState pushes the active instance and codeHash on the state stacks
@@ -84,6 +114,9 @@ func (tracker *instanceTracker) PushState() {
 	tracker.instanceStack = append(tracker.instanceStack, tracker.instance)
 	tracker.codeHashStack = append(tracker.codeHashStack, tracker.codeHash)
 	tracker.codeSizeStack = append(tracker.codeSizeStack, tracker.codeSize)
+	tracker.chargedPagesStack = append(tracker.chargedPagesStack, tracker.chargedMemoryPages)
+	tracker.contractABIStack = append(tracker.contractABIStack, tracker.contractABI)
+	tracker.accessControlStack = append(tracker.accessControlStack, tracker.accessControl)
 	logTracker.Trace("pushing instance", "id", tracker.instance.ID(), "codeHash", tracker.codeHash)
 }
 
@@ -100,7 +133,7 @@ func (tracker *instanceTracker) PopSetActiveState() {
 
 	onStack := tracker.IsCodeHashOnTheStack(activeCodeHash)
 	activeInstanceIsTopOfStack := stackedPrevInstance == activeInstance
-	cold := !WarmInstancesEnabled
+	cold := !WarmInstancesEnabled || tracker.isColdWhileFrozen(activeInstance, activeCodeHash)
 
 	if !activeInstanceIsTopOfStack && (onStack || cold) {
 		tracker.cleanPoppedInstance(activeInstance, activeCodeHash)
@@ -114,6 +147,15 @@ func (tracker *instanceTracker) PopSetActiveState() {
 
 	tracker.codeSize = tracker.codeSizeStack[instanceStackLen-1]
 	tracker.codeSizeStack = tracker.codeSizeStack[:instanceStackLen-1]
+
+	tracker.chargedMemoryPages = tracker.chargedPagesStack[instanceStackLen-1]
+	tracker.chargedPagesStack = tracker.chargedPagesStack[:instanceStackLen-1]
+
+	tracker.contractABI = tracker.contractABIStack[instanceStackLen-1]
+	tracker.contractABIStack = tracker.contractABIStack[:instanceStackLen-1]
+
+	tracker.accessControl = tracker.accessControlStack[instanceStackLen-1]
+	tracker.accessControlStack = tracker.accessControlStack[:instanceStackLen-1]
 }
 
 func (tracker *instanceTracker) cleanPoppedInstance(instance executor.Instance, codeHash []byte) {
@@ -135,6 +177,9 @@ func (tracker *instanceTracker) ClearStateStack() {
 	tracker.codeHashStack = make([][]byte, 0)
 	tracker.instanceStack = make([]executor.Instance, 0)
 	tracker.codeSizeStack = make([]uint64, 0)
+	tracker.chargedPagesStack = make([]uint32, 0)
+	tracker.contractABIStack = make([]*contractabi.ABI, 0)
+	tracker.accessControlStack = make([]*contractabi.AccessControl, 0)
 }
 
 // StackSize returns the size of the instance stack
@@ -149,7 +194,13 @@ func (tracker *instanceTracker) Instance() executor.Instance {
 
 // GetWarmInstance retrieves a warm instance from the internal cache
 func (tracker *instanceTracker) GetWarmInstance(codeHash []byte) (executor.Instance, bool) {
-	cachedObject, ok := tracker.warmInstanceCache.Get(codeHash)
+	var cachedObject interface{}
+	var ok bool
+	if tracker.warmCacheFrozen {
+		cachedObject, ok = tracker.warmInstanceCache.Peek(codeHash)
+	} else {
+		cachedObject, ok = tracker.warmInstanceCache.Get(codeHash)
+	}
 	if !ok {
 		return nil, false
 	}
@@ -174,6 +225,22 @@ func (tracker *instanceTracker) ClearWarmInstanceCache() {
 	}
 }
 
+// SetWarmCacheFrozen prevents or allows changes to the warm instance cache; while frozen, the warm instances
+// are still used, but the new instances are not saved and the recency of the cached ones is not updated
+func (tracker *instanceTracker) SetWarmCacheFrozen(frozen bool) {
+	tracker.warmCacheFrozen = frozen
+}
+
+// isColdWhileFrozen returns true if the warm instance cache is frozen and the given instance is not kept in it
+func (tracker *instanceTracker) isColdWhileFrozen(instance executor.Instance, codeHash []byte) bool {
+	if !tracker.warmCacheFrozen {
+		return false
+	}
+
+	warmInstance, ok := tracker.GetWarmInstance(codeHash)
+	return !ok || warmInstance != instance
+}
+
 // TrackedInstances returns the internal map of tracked instances
 func (tracker *instanceTracker) TrackedInstances() map[string]executor.Instance {
 	return tracker.instances
@@ -228,11 +295,11 @@ func (tracker *instanceTracker) ForceCleanInstance(bypassWarmAndStackChecks bool
 
 	onStack := tracker.IsCodeHashOnTheStack(tracker.codeHash)
 	coldOnlyEnabled := !WarmInstancesEnabled
-	if onStack || coldOnlyEnabled {
+	if onStack || coldOnlyEnabled || tracker.isColdWhileFrozen(tracker.instance, tracker.codeHash) {
 		if tracker.instance.Clean() {
 			tracker.updateNumRunningInstances(-1)
 		}
-	} else {
+	} else if !tracker.warmCacheFrozen {
 		tracker.warmInstanceCache.Remove(tracker.codeHash)
 	}
 }
@@ -290,6 +357,99 @@ func (tracker *instanceTracker) GetCodeSize() uint64 {
 	return tracker.codeSize
 }
 
+// SetChargedMemoryPages sets the number of memory pages of the active instance already paid for
+func (tracker *instanceTracker) SetChargedMemoryPages(numPages uint32) {
+	tracker.chargedMemoryPages = numPages
+}
+
+// GetChargedMemoryPages returns the number of memory pages of the active instance already paid for
+func (tracker *instanceTracker) GetChargedMemoryPages() uint32 {
+	return tracker.chargedMemoryPages
+}
+
+// SetContractABI sets the ABI embedded in the active code, or nil if it has none
+func (tracker *instanceTracker) SetContractABI(contractABI *contractabi.ABI) {
+	tracker.contractABI = contractABI
+}
+
+// GetContractABI returns the ABI embedded in the active code, or nil if it has none
+func (tracker *instanceTracker) GetContractABI() *contractabi.ABI {
+	return tracker.contractABI
+}
+
+// GetCachedContractABI returns the ABI parsed earlier from the code with the given hash;
+// a code without ABI is cached as nil, so the second result tells whether the code was parsed at all
+func (tracker *instanceTracker) GetCachedContractABI(codeHash []byte) (*contractabi.ABI, bool) {
+	value, ok := tracker.contractABICache.Get(codeHash)
+	if !ok {
+		return nil, false
+	}
+
+	contractABI, ok := value.(*contractabi.ABI)
+	return contractABI, ok
+}
+
+// CacheContractABI keeps the ABI parsed from the code with the given hash,
+// alongside the compiled code, so that it is not parsed again on each call
+func (tracker *instanceTracker) CacheContractABI(codeHash []byte, contractABI *contractabi.ABI) {
+	if len(codeHash) == 0 {
+		return
+	}
+	tracker.contractABICache.Put(codeHash, contractABI, 0)
+}
+
+// SetAccessControl sets the endpoint attributes embedded in the active code, or nil if it has none
+func (tracker *instanceTracker) SetAccessControl(accessControl *contractabi.AccessControl) {
+	tracker.accessControl = accessControl
+}
+
+// GetAccessControl returns the endpoint attributes embedded in the active code, or nil if it has none
+func (tracker *instanceTracker) GetAccessControl() *contractabi.AccessControl {
+	return tracker.accessControl
+}
+
+// GetCachedAccessControl returns the endpoint attributes parsed earlier from the code with the given hash;
+// a code without attributes is cached as nil, so the second result tells whether the code was parsed at all
+func (tracker *instanceTracker) GetCachedAccessControl(codeHash []byte) (*contractabi.AccessControl, bool) {
+	value, ok := tracker.accessControlCache.Get(codeHash)
+	if !ok {
+		return nil, false
+	}
+
+	accessControl, ok := value.(*contractabi.AccessControl)
+	return accessControl, ok
+}
+
+// CacheAccessControl keeps the endpoint attributes parsed from the code with the given hash,
+// alongside the compiled code, so that they are not parsed again on each call
+func (tracker *instanceTracker) CacheAccessControl(codeHash []byte, accessControl *contractabi.AccessControl) {
+	if len(codeHash) == 0 {
+		return
+	}
+	tracker.accessControlCache.Put(codeHash, accessControl, 0)
+}
+
+// GetCachedMemoryPages returns the number of memory pages declared by the code with the given hash, if it was
+// parsed earlier
+func (tracker *instanceTracker) GetCachedMemoryPages(codeHash []byte) (uint32, bool) {
+	value, ok := tracker.memoryPagesCache.Get(codeHash)
+	if !ok {
+		return 0, false
+	}
+
+	numPages, ok := value.(uint32)
+	return numPages, ok
+}
+
+// CacheMemoryPages keeps the number of memory pages declared by the code with the given hash,
+// so that the code is not parsed again on each call
+func (tracker *instanceTracker) CacheMemoryPages(codeHash []byte, numPages uint32) {
+	if len(codeHash) == 0 {
+		return
+	}
+	tracker.memoryPagesCache.Put(codeHash, numPages, 0)
+}
+
 // SetNewInstance sets the given instance as active and tracks its creation
 func (tracker *instanceTracker) SetNewInstance(instance executor.Instance, cacheLevel instanceCacheLevel) error {
 	tracker.ReplaceInstance(instance)
@@ -305,6 +465,14 @@ func (tracker *instanceTracker) SetNewInstance(instance executor.Instance, cache
 	return nil
 }
 
+func memoryPagesOf(instance executor.Instance) uint32 {
+	if check.IfNil(instance) || !instance.HasMemory() {
+		return 0
+	}
+
+	return instance.MemLength() / vmhost.WASMPageSize
+}
+
 // ReplaceInstance replaces the currently active instance with the given one
 func (tracker *instanceTracker) ReplaceInstance(instance executor.Instance) {
 	var previousInstanceID string
@@ -4,6 +4,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/multiversx/mx-chain-vm-go/contractabi"
 	mock "github.com/multiversx/mx-chain-vm-go/mock/context"
 	"github.com/multiversx/mx-chain-vm-go/wasmer"
 	"github.com/stretchr/testify/require"
@@ -56,6 +57,103 @@ func TestInstanceTracker_InitState(t *testing.T) {
 	require.Equal(t, 5, iTracker.numRunningInstances)
 }
 
+func TestInstanceTracker_ChargedMemoryPages(t *testing.T) {
+	iTracker, err := NewInstanceTracker()
+	require.Nil(t, err)
+
+	codeHash := []byte("code hash")
+	_, found := iTracker.GetCachedMemoryPages(codeHash)
+	require.False(t, found)
+	iTracker.CacheMemoryPages(codeHash, 2)
+	numPages, found := iTracker.GetCachedMemoryPages(codeHash)
+	require.True(t, found)
+	require.Equal(t, uint32(2), numPages)
+
+	_ = iTracker.SetNewInstance(mock.NewInstanceMock([]byte("parent")), Bytecode)
+	iTracker.SetChargedMemoryPages(5)
+	iTracker.PushState()
+
+	_ = iTracker.SetNewInstance(mock.NewInstanceMock([]byte("child")), Bytecode)
+	iTracker.SetChargedMemoryPages(2)
+	require.Equal(t, uint32(2), iTracker.GetChargedMemoryPages())
+
+	iTracker.PopSetActiveState()
+	require.Equal(t, uint32(5), iTracker.GetChargedMemoryPages())
+
+	iTracker.InitState()
+	require.Zero(t, iTracker.GetChargedMemoryPages())
+}
+
+func TestInstanceTracker_ContractABI(t *testing.T) {
+	iTracker, err := NewInstanceTracker()
+	require.Nil(t, err)
+
+	codeHash := []byte("code hash")
+	_, found := iTracker.GetCachedContractABI(codeHash)
+	require.False(t, found)
+
+	parentABI := &contractabi.ABI{Name: "parent"}
+	iTracker.CacheContractABI(codeHash, parentABI)
+	cached, found := iTracker.GetCachedContractABI(codeHash)
+	require.True(t, found)
+	require.True(t, cached == parentABI)
+
+	// a code without ABI is cached too, so that it is not parsed again
+	iTracker.CacheContractABI([]byte("other code hash"), nil)
+	cached, found = iTracker.GetCachedContractABI([]byte("other code hash"))
+	require.True(t, found)
+	require.Nil(t, cached)
+
+	_ = iTracker.SetNewInstance(mock.NewInstanceMock([]byte("parent")), Bytecode)
+	iTracker.SetContractABI(parentABI)
+	iTracker.PushState()
+
+	_ = iTracker.SetNewInstance(mock.NewInstanceMock([]byte("child")), Bytecode)
+	iTracker.SetContractABI(nil)
+	require.Nil(t, iTracker.GetContractABI())
+
+	iTracker.PopSetActiveState()
+	require.Equal(t, parentABI, iTracker.GetContractABI())
+
+	iTracker.InitState()
+	require.Nil(t, iTracker.GetContractABI())
+}
+
+func TestInstanceTracker_AccessControl(t *testing.T) {
+	iTracker, err := NewInstanceTracker()
+	require.Nil(t, err)
+
+	codeHash := []byte("code hash")
+	_, found := iTracker.GetCachedAccessControl(codeHash)
+	require.False(t, found)
+
+	parentAccessControl := &contractabi.AccessControl{}
+	iTracker.CacheAccessControl(codeHash, parentAccessControl)
+	cached, found := iTracker.GetCachedAccessControl(codeHash)
+	require.True(t, found)
+	require.True(t, cached == parentAccessControl)
+
+	// a code without access control is cached too, so that it is not parsed again
+	iTracker.CacheAccessControl([]byte("other code hash"), nil)
+	cached, found = iTracker.GetCachedAccessControl([]byte("other code hash"))
+	require.True(t, found)
+	require.Nil(t, cached)
+
+	_ = iTracker.SetNewInstance(mock.NewInstanceMock([]byte("parent")), Bytecode)
+	iTracker.SetAccessControl(parentAccessControl)
+	iTracker.PushState()
+
+	_ = iTracker.SetNewInstance(mock.NewInstanceMock([]byte("child")), Bytecode)
+	iTracker.SetAccessControl(nil)
+	require.Nil(t, iTracker.GetAccessControl())
+
+	iTracker.PopSetActiveState()
+	require.True(t, iTracker.GetAccessControl() == parentAccessControl)
+
+	iTracker.InitState()
+	require.Nil(t, iTracker.GetAccessControl())
+}
+
 func TestInstanceTracker_GetWarmInstance(t *testing.T) {
 	iTracker, err := NewInstanceTracker()
 	require.Nil(t, err)
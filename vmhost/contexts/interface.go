@@ -1,5 +1,14 @@
 package contexts
 
+// ContractCode is the part of a contract instance inspected by the checks performed when the code is deployed,
+// which is implemented by the instances of the executors and by offline representations of the code
+type ContractCode interface {
+	HasMemory() bool
+	GetFunctionNames() []string
+	ValidateFunctionArities() error
+	IsFunctionImported(name string) bool
+}
+
 // Cacher provides caching services
 type Cacher interface {
 	// Clear is used to completely clear the cache.
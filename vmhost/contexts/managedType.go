@@ -50,6 +50,7 @@ const handleLen = 4
 type managedBufferMap map[int32][]byte
 type bigIntMap map[int32]*big.Int
 type bigFloatMap map[int32]*big.Float
+type decimalMap map[int32]*math.Decimal
 type ellipticCurveMap map[int32]*elliptic.CurveParams
 type managedMapMap map[int32]map[string][]byte
 
@@ -69,6 +70,7 @@ type backTransfers struct {
 type managedTypesState struct {
 	bigIntValues   bigIntMap
 	bigFloatValues bigFloatMap
+	decimalValues  decimalMap
 	ecValues       ellipticCurveMap
 	mBufferValues  managedBufferMap
 	mMapValues     managedMapMap
@@ -86,6 +88,7 @@ func NewManagedTypesContext(host vmhost.VMHost) (*managedTypesContext, error) {
 		managedTypesValues: managedTypesState{
 			bigIntValues:   make(bigIntMap),
 			bigFloatValues: make(bigFloatMap),
+			decimalValues:  make(decimalMap),
 			ecValues:       make(ellipticCurveMap),
 			mBufferValues:  make(managedBufferMap),
 			mMapValues:     make(managedMapMap),
@@ -127,6 +130,7 @@ func (context *managedTypesContext) InitState() {
 	context.managedTypesValues = managedTypesState{
 		bigIntValues:   make(bigIntMap),
 		bigFloatValues: make(bigFloatMap),
+		decimalValues:  make(decimalMap),
 		ecValues:       make(ellipticCurveMap),
 		mBufferValues:  make(managedBufferMap),
 		mMapValues:     make(managedMapMap),
@@ -139,11 +143,12 @@ func (context *managedTypesContext) InitState() {
 
 // PushState appends the values map to the state stack
 func (context *managedTypesContext) PushState() {
-	newBigIntState, newBigFloatState, newEcState, newmBufferState, newmMapState := context.clone()
+	newBigIntState, newBigFloatState, newDecimalState, newEcState, newmBufferState, newmMapState := context.clone()
 	newTransfers := cloneBackTransfers(context.managedTypesValues.backTransfers)
 	context.managedTypesStack = append(context.managedTypesStack, managedTypesState{
 		bigIntValues:   newBigIntState,
 		bigFloatValues: newBigFloatState,
+		decimalValues:  newDecimalState,
 		ecValues:       newEcState,
 		mBufferValues:  newmBufferState,
 		mMapValues:     newmMapState,
@@ -176,6 +181,7 @@ func (context *managedTypesContext) PopSetActiveState() {
 	prevState := context.managedTypesStack[managedTypesStackLen-1]
 	prevBigIntValues := prevState.bigIntValues
 	prevBigFloatValues := prevState.bigFloatValues
+	prevDecimalValues := prevState.decimalValues
 	prevEcValues := prevState.ecValues
 	prevmBufferValues := prevState.mBufferValues
 	prevmMapValues := prevState.mMapValues
@@ -183,6 +189,7 @@ func (context *managedTypesContext) PopSetActiveState() {
 
 	context.managedTypesValues.bigIntValues = prevBigIntValues
 	context.managedTypesValues.bigFloatValues = prevBigFloatValues
+	context.managedTypesValues.decimalValues = prevDecimalValues
 	context.managedTypesValues.ecValues = prevEcValues
 	context.managedTypesValues.mBufferValues = prevmBufferValues
 	context.managedTypesValues.mMapValues = prevmMapValues
@@ -206,9 +213,10 @@ func (context *managedTypesContext) ClearStateStack() {
 	context.randomnessGenerator = nil
 }
 
-func (context *managedTypesContext) clone() (bigIntMap, bigFloatMap, ellipticCurveMap, managedBufferMap, managedMapMap) {
+func (context *managedTypesContext) clone() (bigIntMap, bigFloatMap, decimalMap, ellipticCurveMap, managedBufferMap, managedMapMap) {
 	newBigIntState := make(bigIntMap, len(context.managedTypesValues.bigIntValues))
 	newBigFloatState := make(bigFloatMap, len(context.managedTypesValues.bigFloatValues))
+	newDecimalState := make(decimalMap, len(context.managedTypesValues.decimalValues))
 	newEcState := make(ellipticCurveMap, len(context.managedTypesValues.ecValues))
 	newmBufferState := make(managedBufferMap, len(context.managedTypesValues.mBufferValues))
 	newmMapState := make(managedMapMap, len(context.managedTypesValues.mMapValues))
@@ -218,6 +226,9 @@ func (context *managedTypesContext) clone() (bigIntMap, bigFloatMap, ellipticCur
 	for bigFloatHandle, bigFloat := range context.managedTypesValues.bigFloatValues {
 		newBigFloatState[bigFloatHandle] = big.NewFloat(0).Set(bigFloat)
 	}
+	for decimalHandle, decimal := range context.managedTypesValues.decimalValues {
+		newDecimalState[decimalHandle] = decimal.Clone()
+	}
 	for ecHandle, ec := range context.managedTypesValues.ecValues {
 		newEcState[ecHandle] = ec
 	}
@@ -227,7 +238,7 @@ func (context *managedTypesContext) clone() (bigIntMap, bigFloatMap, ellipticCur
 	for mMapHandle, mMap := range context.managedTypesValues.mMapValues {
 		newmMapState[mMapHandle] = mMap
 	}
-	return newBigIntState, newBigFloatState, newEcState, newmBufferState, newmMapState
+	return newBigIntState, newBigFloatState, newDecimalState, newEcState, newmBufferState, newmMapState
 }
 
 // IsInterfaceNil returns true if there is no value under the interface
@@ -457,6 +468,63 @@ func (context *managedTypesContext) PutBigFloat(value *big.Float) (int32, error)
 	return newHandle, nil
 }
 
+// ConsumeGasForDecimalCopy uses gas for the given decimal values, based on the byte length of their unscaled values
+func (context *managedTypesContext) ConsumeGasForDecimalCopy(values ...*math.Decimal) error {
+	for _, value := range values {
+		err := context.ConsumeGasForBigIntCopy(value.Unscaled)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetDecimalOrCreate returns the value at the given handle. If there is no value under that handle, it will set a new one with value 0
+func (context *managedTypesContext) GetDecimalOrCreate(handle int32) *math.Decimal {
+	value, ok := context.managedTypesValues.decimalValues[handle]
+	if !ok {
+		value = &math.Decimal{Unscaled: big.NewInt(0)}
+		context.managedTypesValues.decimalValues[handle] = value
+	}
+	return value
+}
+
+// GetDecimal returns the value at the given handle. If there is no value under that handle, it will return error
+func (context *managedTypesContext) GetDecimal(handle int32) (*math.Decimal, error) {
+	value, ok := context.managedTypesValues.decimalValues[handle]
+	if !ok {
+		return nil, vmhost.ErrNoDecimalUnderThisHandle
+	}
+	return value, nil
+}
+
+// GetTwoDecimals returns the values at the two given handles. If there is at least one missing value, it will return error
+func (context *managedTypesContext) GetTwoDecimals(handle1 int32, handle2 int32) (*math.Decimal, *math.Decimal, error) {
+	value1, err := context.GetDecimal(handle1)
+	if err != nil {
+		return nil, nil, err
+	}
+	value2, err := context.GetDecimal(handle2)
+	if err != nil {
+		return nil, nil, err
+	}
+	return value1, value2, nil
+}
+
+// PutDecimal adds a copy of the given value to the current values map and returns the handle
+func (context *managedTypesContext) PutDecimal(value *math.Decimal) int32 {
+	newHandle := int32(len(context.managedTypesValues.decimalValues))
+	for {
+		if _, ok := context.managedTypesValues.decimalValues[newHandle]; !ok {
+			break
+		}
+		newHandle++
+	}
+
+	context.managedTypesValues.decimalValues[newHandle] = value.Clone()
+	return newHandle
+}
+
 // NewBigInt adds the given value to the current values map and returns the handle
 func (context *managedTypesContext) NewBigInt(value *big.Int) int32 {
 	return context.newBigIntNoCopy(big.NewInt(0).Set(value))
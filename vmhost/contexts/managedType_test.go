@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-vm-go/math"
 	contextmock "github.com/multiversx/mx-chain-vm-go/mock/context"
 	"github.com/multiversx/mx-chain-vm-go/vmhost"
 	"github.com/multiversx/mx-chain-vm-go/vmhost/mock"
@@ -465,6 +466,41 @@ func TestManagedTypesContext_PutGetBigInt(t *testing.T) {
 	require.Nil(t, err)
 }
 
+func TestManagedTypesContext_PutGetDecimal(t *testing.T) {
+	t.Parallel()
+	host := &contextmock.VMHostStub{}
+	managedTypesCtx, _ := NewManagedTypesContext(host)
+
+	decimal1 := &math.Decimal{Unscaled: big.NewInt(12345), Scale: 2}
+	decimalHandle1 := managedTypesCtx.PutDecimal(decimal1)
+	require.Equal(t, int32(0), decimalHandle1)
+	decimal1.Unscaled.SetInt64(1)
+
+	value, err := managedTypesCtx.GetDecimal(decimalHandle1)
+	require.Nil(t, err)
+	require.Equal(t, "123.45", value.String())
+
+	value, err = managedTypesCtx.GetDecimal(1)
+	require.Nil(t, value)
+	require.Equal(t, vmhost.ErrNoDecimalUnderThisHandle, err)
+
+	_, _, err = managedTypesCtx.GetTwoDecimals(decimalHandle1, 1)
+	require.Equal(t, vmhost.ErrNoDecimalUnderThisHandle, err)
+
+	managedTypesCtx.PushState()
+	created := managedTypesCtx.GetDecimalOrCreate(1)
+	require.Equal(t, "0", created.String())
+	value, _ = managedTypesCtx.GetDecimal(decimalHandle1)
+	value.Unscaled.SetInt64(7)
+
+	managedTypesCtx.PopSetActiveState()
+	_, err = managedTypesCtx.GetDecimal(1)
+	require.Equal(t, vmhost.ErrNoDecimalUnderThisHandle, err)
+	value, err = managedTypesCtx.GetDecimal(decimalHandle1)
+	require.Nil(t, err)
+	require.Equal(t, "123.45", value.String())
+}
+
 func TestManagedTypesContext_PutGetBigFloat(t *testing.T) {
 	t.Parallel()
 	host := &contextmock.VMHostStub{}
@@ -2,6 +2,8 @@ package contexts
 
 import (
 	"fmt"
+	"math/big"
+
 	"github.com/multiversx/mx-chain-core-go/core/check"
 	"github.com/multiversx/mx-chain-core-go/data/vm"
 	logger "github.com/multiversx/mx-chain-logger-go"
@@ -23,20 +25,32 @@ type meteringContext struct {
 	gasForExecution    uint64
 	gasUsedByAccounts  map[string]uint64
 	restoreGasEnabled  bool
+	gasRefund          uint64
+
+	maxGasRefundPercentage uint64
 
 	gasTracer       vmhost.GasTracing
 	traceGasEnabled bool
+
+	gasBreakdownEnabled    bool
+	gasBreakdownByAccounts map[string]*vmhost.GasBreakdown
+	currentGasCategory     string
 }
 
-// NewMeteringContext creates a new meteringContext
+// NewMeteringContext creates a new meteringContext. The gas refund of a transaction is capped
+// at maxGasRefundPercentage of its gas used, once the GasRefundCapFlag is active.
 func NewMeteringContext(
 	host vmhost.VMHost,
 	gasMap config.GasScheduleMap,
 	blockGasLimit uint64,
+	maxGasRefundPercentage uint64,
 ) (*meteringContext, error) {
 	if check.IfNil(host) {
 		return nil, vmhost.ErrNilVMHost
 	}
+	if maxGasRefundPercentage > 100 {
+		return nil, vmhost.ErrInvalidMaxGasRefundPercentage
+	}
 
 	gasSchedule, err := config.CreateGasConfig(gasMap)
 	if err != nil {
@@ -50,6 +64,8 @@ func NewMeteringContext(
 		blockGasLimit:     blockGasLimit,
 		gasUsedByAccounts: make(map[string]uint64),
 		restoreGasEnabled: true,
+
+		maxGasRefundPercentage: maxGasRefundPercentage,
 	}
 
 	context.InitState()
@@ -65,6 +81,9 @@ func (context *meteringContext) InitState() {
 	context.gasForExecution = 0
 	context.gasUsedByAccounts = make(map[string]uint64)
 	context.restoreGasEnabled = true
+	context.gasRefund = 0
+	context.gasBreakdownByAccounts = make(map[string]*vmhost.GasBreakdown)
+	context.currentGasCategory = ""
 
 	var newGasTracer vmhost.GasTracing
 	if context.traceGasEnabled {
@@ -92,6 +111,10 @@ func (context *meteringContext) PushState() {
 		gasForExecution:    context.gasForExecution,
 		gasUsedByAccounts:  context.cloneGasUsedByAccounts(),
 		restoreGasEnabled:  context.restoreGasEnabled,
+		gasRefund:          context.gasRefund,
+
+		gasBreakdownByAccounts: context.cloneGasBreakdownByAccounts(),
+		currentGasCategory:     context.currentGasCategory,
 	}
 
 	context.stateStack = append(context.stateStack, newState)
@@ -113,16 +136,24 @@ func (context *meteringContext) PopSetActiveState() {
 	context.gasForExecution = prevState.gasForExecution
 	context.gasUsedByAccounts = prevState.gasUsedByAccounts
 	context.restoreGasEnabled = prevState.restoreGasEnabled
+	context.gasRefund = prevState.gasRefund
+	context.gasBreakdownByAccounts = prevState.gasBreakdownByAccounts
+	context.currentGasCategory = prevState.currentGasCategory
 }
 
-// PopDiscard pops the state at the top of the internal state stack, and discards it
+// PopDiscard pops the state at the top of the internal state stack, and discards it.
+// The gas refunds accumulated before the state was pushed are kept, because only
+// PopSetActiveState reverts an execution.
 func (context *meteringContext) PopDiscard() {
 	stateStackLen := len(context.stateStack)
 	if stateStackLen == 0 {
 		return
 	}
 
+	prevState := context.stateStack[stateStackLen-1]
 	context.stateStack = context.stateStack[:stateStackLen-1]
+
+	context.gasRefund = math.AddUint64(prevState.gasRefund, context.gasRefund)
 }
 
 // PopMergeActiveState pops the state at the top of the internal stack and
@@ -140,8 +171,11 @@ func (context *meteringContext) PopMergeActiveState() {
 	context.initialCost = prevState.initialCost
 	context.gasForExecution = prevState.gasForExecution
 	context.restoreGasEnabled = prevState.restoreGasEnabled
+	context.gasRefund = math.AddUint64(prevState.gasRefund, context.gasRefund)
+	context.currentGasCategory = prevState.currentGasCategory
 
 	context.addToGasUsedByAccounts(prevState.gasUsedByAccounts)
+	context.addToGasBreakdownByAccounts(prevState.gasBreakdownByAccounts)
 }
 
 func (context *meteringContext) cloneGasUsedByAccounts() map[string]uint64 {
@@ -160,6 +194,48 @@ func (context *meteringContext) addToGasUsedByAccounts(gasUsed map[string]uint64
 	}
 }
 
+func (context *meteringContext) cloneGasBreakdownByAccounts() map[string]*vmhost.GasBreakdown {
+	clone := make(map[string]*vmhost.GasBreakdown, len(context.gasBreakdownByAccounts))
+
+	for address, breakdown := range context.gasBreakdownByAccounts {
+		clone[address] = cloneGasBreakdown(breakdown)
+	}
+
+	return clone
+}
+
+func (context *meteringContext) addToGasBreakdownByAccounts(gasBreakdown map[string]*vmhost.GasBreakdown) {
+	for address, breakdown := range gasBreakdown {
+		accountBreakdown := context.getAccountGasBreakdown(address)
+		accountBreakdown.Compilation = math.AddUint64(accountBreakdown.Compilation, breakdown.Compilation)
+		accountBreakdown.Forwarded = math.AddUint64(accountBreakdown.Forwarded, breakdown.Forwarded)
+		accountBreakdown.LockedForCallbacks = math.AddUint64(accountBreakdown.LockedForCallbacks, breakdown.LockedForCallbacks)
+		for category, gas := range breakdown.VMHooks {
+			accountBreakdown.VMHooks[category] = math.AddUint64(accountBreakdown.VMHooks[category], gas)
+		}
+	}
+}
+
+func (context *meteringContext) getAccountGasBreakdown(address string) *vmhost.GasBreakdown {
+	breakdown, ok := context.gasBreakdownByAccounts[address]
+	if !ok {
+		breakdown = &vmhost.GasBreakdown{VMHooks: make(map[string]uint64)}
+		context.gasBreakdownByAccounts[address] = breakdown
+	}
+
+	return breakdown
+}
+
+func cloneGasBreakdown(breakdown *vmhost.GasBreakdown) *vmhost.GasBreakdown {
+	clone := *breakdown
+	clone.VMHooks = make(map[string]uint64, len(breakdown.VMHooks))
+	for category, gas := range breakdown.VMHooks {
+		clone.VMHooks[category] = gas
+	}
+
+	return &clone
+}
+
 // UpdateGasStateOnSuccess performs final gas accounting after a successful execution.
 func (context *meteringContext) UpdateGasStateOnSuccess(vmOutput *vmcommon.VMOutput) error {
 	logMetering.Trace("UpdateGasStateOnSuccess")
@@ -208,6 +284,61 @@ func (context *meteringContext) updateSCGasUsed() {
 	gasUsed = math.SubUint64(gasUsed, gasUsedByOthers)
 
 	context.gasUsedByAccounts[string(currentAccountAddress)] = gasUsed
+	context.updateGasBreakdown(currentContractAccount, gasUsed)
+}
+
+// updateGasBreakdown completes the gas breakdown of the current account at the end of its execution.
+// All the gas spent by the execution which is neither used by the account nor locked for callbacks
+// was forwarded to other contracts. Each execution adds its own amounts, so those of the executions
+// which re-entered the account are kept.
+func (context *meteringContext) updateGasBreakdown(account *vmcommon.OutputAccount, gasUsed uint64) {
+	if !context.gasBreakdownEnabled {
+		return
+	}
+
+	gasLocked := uint64(0)
+	for _, outputTransfer := range account.OutputTransfers {
+		gasLocked = math.AddUint64(gasLocked, outputTransfer.GasLocked)
+	}
+
+	gasForwarded := math.SubUint64(math.SubUint64(context.GasSpentByContract(), gasUsed), gasLocked)
+
+	// the totals above also cover the nested executions of the account, which are already in its breakdown
+	breakdown := context.getAccountGasBreakdown(string(account.Address))
+	gasLockedByExecution := math.SubUint64(gasLocked, breakdown.LockedForCallbacks)
+	gasForwardedByExecution := math.SubUint64(gasForwarded, breakdown.Forwarded)
+
+	breakdown.Compilation = math.AddUint64(breakdown.Compilation, context.initialCost)
+	breakdown.LockedForCallbacks = math.AddUint64(breakdown.LockedForCallbacks, gasLockedByExecution)
+	breakdown.Forwarded = math.AddUint64(breakdown.Forwarded, gasForwardedByExecution)
+}
+
+// GetGasBreakdown returns the gas breakdown of each account of a successful VMOutput, which has
+// been executed with the gas breakdown enabled. The WASM opcodes are charged directly on the
+// executor instance, therefore their gas is what remains from GasUsed after compilation and VM hooks.
+func (context *meteringContext) GetGasBreakdown(vmOutput *vmcommon.VMOutput) map[string]*vmhost.GasBreakdown {
+	gasBreakdown := make(map[string]*vmhost.GasBreakdown)
+	if !context.gasBreakdownEnabled || vmOutput == nil || vmOutput.ReturnCode != vmcommon.Ok {
+		return gasBreakdown
+	}
+
+	for address, breakdown := range context.gasBreakdownByAccounts {
+		account, ok := vmOutput.OutputAccounts[address]
+		if !ok {
+			continue
+		}
+
+		accountBreakdown := cloneGasBreakdown(breakdown)
+		accountBreakdown.GasUsed = account.GasUsed
+		opcodes := math.SubUint64(account.GasUsed, accountBreakdown.Compilation)
+		for _, gas := range accountBreakdown.VMHooks {
+			opcodes = math.SubUint64(opcodes, gas)
+		}
+		accountBreakdown.Opcodes = opcodes
+		gasBreakdown[address] = accountBreakdown
+	}
+
+	return gasBreakdown
 }
 
 // TrackGasUsedByOutOfVMFunction computes the gas used by a builtin function
@@ -230,6 +361,7 @@ func (context *meteringContext) TrackGasUsedByOutOfVMFunction(
 	}
 
 	context.useGas(gasUsed)
+	context.addToGasBreakdown(vmhost.GasCategoryTransfers, gasUsed)
 	logMetering.Trace("gas used by builtin function", "gas", gasUsed)
 }
 
@@ -373,12 +505,51 @@ func (context *meteringContext) EnableRestoreGas() {
 	context.restoreGasEnabled = true
 }
 
-// FreeGas refunds the specified amount of gas to the caller.
+// FreeGas refunds the specified amount of gas to the caller. Once the GasRefundCapFlag is active,
+// the refund is accumulated in the metering state, to be capped at the end of the transaction.
 func (context *meteringContext) FreeGas(gas uint64) {
+	if context.isGasRefundCapEnabled() {
+		context.gasRefund = math.AddUint64(context.gasRefund, gas)
+		return
+	}
+
 	refund := math.AddUint64(context.host.Output().GetRefund(), gas)
 	context.host.Output().SetRefund(refund)
 }
 
+// GetGasRefund returns the gas refund accumulated so far, before applying the cap
+func (context *meteringContext) GetGasRefund() uint64 {
+	return context.gasRefund
+}
+
+// ApplyGasRefundCap sets in the VMOutput the accumulated gas refund, limited to the
+// maximum percentage of the gas used by the transaction.
+func (context *meteringContext) ApplyGasRefundCap(vmOutput *vmcommon.VMOutput) *vmhost.GasRefundBreakdown {
+	gasUsed := math.SubUint64(context.initialGasProvided, vmOutput.GasRemaining)
+	refundCap := math.MulUint64(gasUsed, context.maxGasRefundPercentage) / 100
+
+	applied := context.gasRefund
+	if applied > refundCap {
+		applied = refundCap
+	}
+	vmOutput.GasRefund = big.NewInt(0).SetUint64(applied)
+
+	logMetering.Trace("gas refund", "accumulated", context.gasRefund, "cap", refundCap, "applied", applied)
+	return &vmhost.GasRefundBreakdown{
+		Accumulated: context.gasRefund,
+		Cap:         refundCap,
+		Applied:     applied,
+	}
+}
+
+func (context *meteringContext) isGasRefundCapEnabled() bool {
+	enableEpochsHandler := context.host.EnableEpochsHandler()
+	if check.IfNil(enableEpochsHandler) {
+		return false
+	}
+	return enableEpochsHandler.IsFlagEnabled(vmhost.GasRefundCapFlag)
+}
+
 // GasLeft computes the amount of gas left on the currently running Wasmer instance.
 func (context *meteringContext) GasLeft() uint64 {
 	gasProvided := context.gasForExecution
@@ -440,7 +611,7 @@ func (context *meteringContext) BoundGasLimit(value int64) uint64 {
 func (context *meteringContext) UseGasForAsyncStep() error {
 	gasSchedule := context.GasSchedule().BaseOpsAPICost
 	gasToDeduct := gasSchedule.AsyncCallStep
-	return context.UseGasBounded(gasToDeduct)
+	return context.UseGasBoundedInCategory(vmhost.GasCategoryTransfers, gasToDeduct)
 }
 
 // UseGasForContractInit consumes gas on the previous wasmer instance in SC to SC call
@@ -450,8 +621,29 @@ func (context *meteringContext) UseGasForContractInit(gasToUse uint64) {
 }
 
 // UseGasBounded consumes the specified amount of gas on the currently running
-// Wasmer instance, but returns an error if there is not enough gas left.
+// Wasmer instance, but returns an error if there is not enough gas left. The gas
+// is reported in the category of the VM hook being executed.
 func (context *meteringContext) UseGasBounded(gasToUse uint64) error {
+	return context.UseGasBoundedInCategory(context.currentGasCategory, gasToUse)
+}
+
+// UseGasBoundedInCategory consumes gas like UseGasBounded, but reports it in the given category of the
+// gas breakdown; it is meant for the gas which the VM charges outside of the VM hooks.
+func (context *meteringContext) UseGasBoundedInCategory(category string, gasToUse uint64) error {
+	gasLeft := context.GasLeft()
+	if gasLeft < gasToUse {
+		context.useGas(gasLeft)
+		return vmhost.ErrNotEnoughGas
+	}
+	context.useGas(gasToUse)
+	context.traceGas(gasToUse)
+	context.addToGasBreakdown(category, gasToUse)
+	return nil
+}
+
+// UseGasBoundedForChildExecution consumes gas like UseGasBounded, for gas which is forwarded
+// to another execution or locked for a callback, and not spent by the current VM hook.
+func (context *meteringContext) UseGasBoundedForChildExecution(gasToUse uint64) error {
 	gasLeft := context.GasLeft()
 	if gasLeft < gasToUse {
 		context.useGas(gasLeft)
@@ -472,6 +664,8 @@ func (context *meteringContext) UseGasBoundedAndAddTracedGas(functionName string
 
 	context.useGas(gasToUse)
 	context.addToGasTrace(functionName, gasToUse)
+	context.setCurrentGasCategory(functionName)
+	context.addToGasBreakdown(context.currentGasCategory, gasToUse)
 	return nil
 }
 
@@ -533,6 +727,16 @@ func (context *meteringContext) DeductInitialGasForIndirectDeployment(input vmho
 	)
 }
 
+// DeductInitialGasForStorageWrite deducts gas for a value which the VM writes in the storage of a contract
+// without instantiating it, as for the freeze of a contract
+func (context *meteringContext) DeductInitialGasForStorageWrite(value []byte) error {
+	return context.deductInitialGas(
+		value,
+		context.gasSchedule.BaseOpsAPICost.StorageStore,
+		context.gasSchedule.BaseOperationCost.StorePerByte,
+	)
+}
+
 func (context *meteringContext) deductInitialGas(
 	code []byte,
 	baseCost uint64,
@@ -562,8 +766,15 @@ func (context *meteringContext) SetGasTracing(enableGasTracing bool) {
 	}
 }
 
+// SetGasBreakdown enables/disables the gas breakdown per account
+func (context *meteringContext) SetGasBreakdown(enableGasBreakdown bool) {
+	context.gasBreakdownEnabled = enableGasBreakdown
+	context.gasBreakdownByAccounts = make(map[string]*vmhost.GasBreakdown)
+}
+
 // StartGasTracing sets initial trace for the upcoming gas usage.
 func (context *meteringContext) StartGasTracing(functionName string) {
+	context.setCurrentGasCategory(functionName)
 	if context.traceGasEnabled {
 		scAddress := context.getSCAddress()
 		if len(scAddress) != 0 {
@@ -572,6 +783,14 @@ func (context *meteringContext) StartGasTracing(functionName string) {
 	}
 }
 
+// setCurrentGasCategory marks the VM hook being executed, so that the gas which it charges through
+// UseGasBounded is reported in its category; it is called when a hook charges its base cost.
+func (context *meteringContext) setCurrentGasCategory(functionName string) {
+	if context.gasBreakdownEnabled {
+		context.currentGasCategory = vmhost.GasCategoryOfVMHook(functionName)
+	}
+}
+
 func (context *meteringContext) traceGas(usedGas uint64) {
 	context.gasTracer.AddToCurrentTrace(usedGas)
 }
@@ -581,6 +800,20 @@ func (context *meteringContext) addToGasTrace(functionName string, usedGas uint6
 	context.gasTracer.AddTracedGas(scAddress, functionName, usedGas)
 }
 
+// addToGasBreakdown attributes gas consumed outside of the executor instance to a category of the current account;
+// gas consumed before any VM hook was called is reported under GasCategoryOther.
+func (context *meteringContext) addToGasBreakdown(category string, gas uint64) {
+	if !context.gasBreakdownEnabled {
+		return
+	}
+	if len(category) == 0 {
+		category = vmhost.GasCategoryOther
+	}
+
+	breakdown := context.getAccountGasBreakdown(context.getSCAddress())
+	breakdown.VMHooks[category] = math.AddUint64(breakdown.VMHooks[category], gas)
+}
+
 func (context *meteringContext) getSCAddress() string {
 	return string(context.host.Runtime().GetContextAddress())
 }
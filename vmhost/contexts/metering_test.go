@@ -4,7 +4,9 @@ import (
 	"math/big"
 	"testing"
 
+	"github.com/multiversx/mx-chain-core-go/core"
 	"github.com/multiversx/mx-chain-core-go/data/vm"
+	"github.com/multiversx/mx-chain-scenario-go/worldmock"
 	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
 	"github.com/multiversx/mx-chain-vm-go/config"
 	"github.com/multiversx/mx-chain-vm-go/math"
@@ -18,7 +20,7 @@ func TestNewMeteringContext(t *testing.T) {
 	const BlockGasLimit = uint64(15000)
 	host := &contextmock.VMHostMock{}
 
-	meteringCtx, err := NewMeteringContext(host, config.MakeGasMapForTests(), BlockGasLimit)
+	meteringCtx, err := NewMeteringContext(host, config.MakeGasMapForTests(), BlockGasLimit, vmhost.DefaultMaxGasRefundPercentage)
 	require.Nil(t, err)
 	require.NotNil(t, meteringCtx)
 	require.NotNil(t, meteringCtx.gasTracer)
@@ -29,7 +31,7 @@ func TestNewMeteringContext_NilGasSchedule(t *testing.T) {
 	const BlockGasLimit = uint64(15000)
 	host := &contextmock.VMHostMock{}
 
-	meteringCtx, err := NewMeteringContext(host, nil, BlockGasLimit)
+	meteringCtx, err := NewMeteringContext(host, nil, BlockGasLimit, vmhost.DefaultMaxGasRefundPercentage)
 	require.NotNil(t, err)
 	require.Nil(t, meteringCtx)
 }
@@ -39,7 +41,7 @@ func TestMeteringContext_GasSchedule(t *testing.T) {
 	const BlockGasLimit = uint64(15000)
 
 	host := &contextmock.VMHostStub{}
-	meteringCtx, _ := NewMeteringContext(host, config.MakeGasMapForTests(), BlockGasLimit)
+	meteringCtx, _ := NewMeteringContext(host, config.MakeGasMapForTests(), BlockGasLimit, vmhost.DefaultMaxGasRefundPercentage)
 
 	schedule := meteringCtx.GasSchedule()
 	require.NotNil(t, schedule)
@@ -53,7 +55,7 @@ func TestMeteringContext_UseGas(t *testing.T) {
 	host := &contextmock.VMHostMock{
 		RuntimeContext: mockRuntime,
 	}
-	meteringCtx, _ := NewMeteringContext(host, config.MakeGasMapForTests(), BlockGasLimit)
+	meteringCtx, _ := NewMeteringContext(host, config.MakeGasMapForTests(), BlockGasLimit, vmhost.DefaultMaxGasRefundPercentage)
 
 	gasProvided := uint64(1001)
 	meteringCtx.gasForExecution = gasProvided
@@ -64,7 +66,7 @@ func TestMeteringContext_UseGas(t *testing.T) {
 
 	gasProvided = uint64(10000)
 	mockRuntime.SetPointsUsed(0)
-	meteringCtx, _ = NewMeteringContext(host, config.MakeGasMapForTests(), BlockGasLimit)
+	meteringCtx, _ = NewMeteringContext(host, config.MakeGasMapForTests(), BlockGasLimit, vmhost.DefaultMaxGasRefundPercentage)
 	meteringCtx.gasForExecution = gasProvided
 
 	require.Equal(t, gasProvided, meteringCtx.GasLeft())
@@ -81,7 +83,7 @@ func TestMeteringContext_FreeGas(t *testing.T) {
 		OutputContext: mockOutput,
 	}
 
-	meteringCtx, _ := NewMeteringContext(host, config.MakeGasMapForTests(), BlockGasLimit)
+	meteringCtx, _ := NewMeteringContext(host, config.MakeGasMapForTests(), BlockGasLimit, vmhost.DefaultMaxGasRefundPercentage)
 
 	gasToFree := uint64(1000)
 	mockOutput.GasRefund = big.NewInt(0)
@@ -95,6 +97,70 @@ func TestMeteringContext_FreeGas(t *testing.T) {
 	require.Equal(t, gasToFree+moreGasToFree, gasRefunded)
 }
 
+func TestNewMeteringContext_InvalidMaxGasRefundPercentage(t *testing.T) {
+	t.Parallel()
+	const BlockGasLimit = uint64(15000)
+	host := &contextmock.VMHostMock{}
+
+	meteringCtx, err := NewMeteringContext(host, config.MakeGasMapForTests(), BlockGasLimit, 101)
+	require.Equal(t, vmhost.ErrInvalidMaxGasRefundPercentage, err)
+	require.Nil(t, meteringCtx)
+}
+
+func TestMeteringContext_GasRefundCap(t *testing.T) {
+	t.Parallel()
+	const BlockGasLimit = uint64(15000)
+
+	mockOutput := &contextmock.OutputContextMock{}
+	mockOutput.GasRefund = big.NewInt(0)
+	host := &contextmock.VMHostMock{
+		OutputContext: mockOutput,
+		EnableEpochsHandlerField: &worldmock.EnableEpochsHandlerStub{
+			IsFlagEnabledCalled: func(flag core.EnableEpochFlag) bool {
+				return flag == vmhost.GasRefundCapFlag
+			},
+		},
+	}
+
+	meteringCtx, _ := NewMeteringContext(host, config.MakeGasMapForTests(), BlockGasLimit, 20)
+	meteringCtx.InitStateFromContractCallInput(&vmcommon.VMInput{GasProvided: 1000})
+
+	meteringCtx.FreeGas(100)
+	require.Equal(t, uint64(100), meteringCtx.GetGasRefund())
+	require.Equal(t, uint64(0), mockOutput.GetRefund())
+
+	// the refunds of a failed child execution are reverted
+	meteringCtx.PushState()
+	meteringCtx.InitState()
+	meteringCtx.FreeGas(50)
+	meteringCtx.PopSetActiveState()
+	require.Equal(t, uint64(100), meteringCtx.GetGasRefund())
+
+	// the refunds of a successful child execution are kept
+	meteringCtx.PushState()
+	meteringCtx.InitState()
+	meteringCtx.FreeGas(30)
+	meteringCtx.PopMergeActiveState()
+	require.Equal(t, uint64(130), meteringCtx.GetGasRefund())
+
+	meteringCtx.PushState()
+	meteringCtx.InitState()
+	meteringCtx.FreeGas(20)
+	meteringCtx.PopDiscard()
+	require.Equal(t, uint64(150), meteringCtx.GetGasRefund())
+
+	meteringCtx.initialGasProvided = 1000
+	vmOutput := &vmcommon.VMOutput{GasRemaining: 500}
+	breakdown := meteringCtx.ApplyGasRefundCap(vmOutput)
+	require.Equal(t, &vmhost.GasRefundBreakdown{Accumulated: 150, Cap: 100, Applied: 100}, breakdown)
+	require.Equal(t, big.NewInt(100), vmOutput.GasRefund)
+
+	vmOutput = &vmcommon.VMOutput{GasRemaining: 0}
+	breakdown = meteringCtx.ApplyGasRefundCap(vmOutput)
+	require.Equal(t, &vmhost.GasRefundBreakdown{Accumulated: 150, Cap: 200, Applied: 150}, breakdown)
+	require.Equal(t, big.NewInt(150), vmOutput.GasRefund)
+}
+
 func TestMeteringContext_BoundGasLimit(t *testing.T) {
 	t.Parallel()
 	const BlockGasLimit = uint64(15000)
@@ -103,7 +169,7 @@ func TestMeteringContext_BoundGasLimit(t *testing.T) {
 	host := &contextmock.VMHostMock{
 		RuntimeContext: mockRuntime,
 	}
-	meteringCtx, _ := NewMeteringContext(host, config.MakeGasMapForTests(), BlockGasLimit)
+	meteringCtx, _ := NewMeteringContext(host, config.MakeGasMapForTests(), BlockGasLimit, vmhost.DefaultMaxGasRefundPercentage)
 
 	gasProvided := uint64(10000)
 	meteringCtx.gasForExecution = gasProvided
@@ -136,7 +202,7 @@ func TestMeteringContext_DeductInitialGasForExecution(t *testing.T) {
 		RuntimeContext: mockRuntime,
 	}
 
-	meteringCtx, _ := NewMeteringContext(host, config.MakeGasMapForTests(), uint64(15000))
+	meteringCtx, _ := NewMeteringContext(host, config.MakeGasMapForTests(), uint64(15000), vmhost.DefaultMaxGasRefundPercentage)
 
 	contract := []byte("contract")
 	err := meteringCtx.DeductInitialGasForExecution(contract)
@@ -166,7 +232,7 @@ func TestDeductInitialGasForDirectDeployment(t *testing.T) {
 		RuntimeContext: mockRuntime,
 	}
 
-	meteringCtx, _ := NewMeteringContext(host, config.MakeGasMapForTests(), uint64(15000))
+	meteringCtx, _ := NewMeteringContext(host, config.MakeGasMapForTests(), uint64(15000), vmhost.DefaultMaxGasRefundPercentage)
 
 	mockRuntime.SetPointsUsed(0)
 	err := meteringCtx.DeductInitialGasForDirectDeployment(vmhost.CodeDeployInput{ContractCode: contractCode})
@@ -200,7 +266,7 @@ func TestDeductInitialGasForIndirectDeployment(t *testing.T) {
 		RuntimeContext: mockRuntime,
 	}
 
-	meteringCtx, _ := NewMeteringContext(host, config.MakeGasMapForTests(), uint64(15000))
+	meteringCtx, _ := NewMeteringContext(host, config.MakeGasMapForTests(), uint64(15000), vmhost.DefaultMaxGasRefundPercentage)
 
 	mockRuntime.SetPointsUsed(0)
 	err := meteringCtx.DeductInitialGasForIndirectDeployment(vmhost.CodeDeployInput{ContractCode: contractCode})
@@ -214,6 +280,34 @@ func TestDeductInitialGasForIndirectDeployment(t *testing.T) {
 	require.Equal(t, vmhost.ErrNotEnoughGas, err)
 }
 
+func TestMeteringContext_DeductInitialGasForStorageWrite(t *testing.T) {
+	t.Parallel()
+
+	mockRuntime := &contextmock.RuntimeContextMock{}
+	gasProvided := uint64(10000)
+	vmInput := &vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{GasProvided: gasProvided},
+	}
+	mockRuntime.SetVMInput(vmInput)
+
+	host := &contextmock.VMHostMock{
+		RuntimeContext: mockRuntime,
+	}
+
+	meteringCtx, _ := NewMeteringContext(host, config.MakeGasMapForTests(), uint64(15000), vmhost.DefaultMaxGasRefundPercentage)
+	gasSchedule := meteringCtx.GasSchedule()
+
+	value := []byte("value")
+	err := meteringCtx.DeductInitialGasForStorageWrite(value)
+	require.Nil(t, err)
+	writeCost := gasSchedule.BaseOpsAPICost.StorageStore + uint64(len(value))*gasSchedule.BaseOperationCost.StorePerByte
+	require.Equal(t, gasProvided-writeCost, meteringCtx.GasLeft())
+
+	vmInput.GasProvided = writeCost - 1
+	err = meteringCtx.DeductInitialGasForStorageWrite(value)
+	require.Equal(t, vmhost.ErrNotEnoughGas, err)
+}
+
 func TestMeteringContext_AsyncCallGasLocking(t *testing.T) {
 	t.Parallel()
 
@@ -233,7 +327,7 @@ func TestMeteringContext_AsyncCallGasLocking(t *testing.T) {
 		RuntimeContext: mockRuntime,
 	}
 
-	meteringCtx, _ := NewMeteringContext(host, config.MakeGasMapForTests(), uint64(15000))
+	meteringCtx, _ := NewMeteringContext(host, config.MakeGasMapForTests(), uint64(15000), vmhost.DefaultMaxGasRefundPercentage)
 
 	input.GasProvided = 0
 	err := meteringCtx.UseGasForAsyncStep()
@@ -276,7 +370,7 @@ func TestMeteringContext_GasUsed_NoStacking(t *testing.T) {
 	mockRuntime.SetVMInput(input)
 	mockRuntime.SetPointsUsed(0)
 
-	metering, _ := NewMeteringContext(host, config.MakeGasMapForTests(), BlockGasLimit)
+	metering, _ := NewMeteringContext(host, config.MakeGasMapForTests(), BlockGasLimit, vmhost.DefaultMaxGasRefundPercentage)
 
 	input.GasProvided = 2000
 	metering.InitStateFromContractCallInput(&input.VMInput)
@@ -313,7 +407,7 @@ func setUpStackOneLevel(t *testing.T, parentInput *vmcommon.ContractCallInput, c
 	mockRuntime.SetPointsUsed(0)
 	mockRuntime.SetVMInput(parentInput)
 
-	metering, _ := NewMeteringContext(host, config.MakeGasMapForTests(), uint64(15000))
+	metering, _ := NewMeteringContext(host, config.MakeGasMapForTests(), uint64(15000), vmhost.DefaultMaxGasRefundPercentage)
 	host.MeteringContext = metering
 	zeroCodeCosts(metering)
 
@@ -501,7 +595,7 @@ func TestMeteringContext_TrackGasUsedByBuiltinFunction_GasRemaining(t *testing.T
 	}
 	mockRuntime.SetVMInput(input)
 
-	metering, _ := NewMeteringContext(host, config.MakeGasMapForTests(), uint64(15000))
+	metering, _ := NewMeteringContext(host, config.MakeGasMapForTests(), uint64(15000), vmhost.DefaultMaxGasRefundPercentage)
 	host.MeteringContext = metering
 	zeroCodeCosts(metering)
 
@@ -534,7 +628,7 @@ func TestMeteringContext_GasTracer(t *testing.T) {
 		RuntimeContext: mockRuntime,
 	}
 
-	meteringCtx, _ := NewMeteringContext(host, config.MakeGasMapForTests(), BlockGasLimit)
+	meteringCtx, _ := NewMeteringContext(host, config.MakeGasMapForTests(), BlockGasLimit, vmhost.DefaultMaxGasRefundPercentage)
 	meteringCtx.InitState()
 
 	gasProvided := uint64(10000)
@@ -569,3 +663,86 @@ func TestMeteringContext_GasTracer(t *testing.T) {
 	require.Equal(t, 2, len(gasTrace))
 	require.Equal(t, gasUsed2, gasTrace["scAddress2"]["function2"][0])
 }
+
+func TestMeteringContext_GasBreakdown(t *testing.T) {
+	t.Parallel()
+
+	parentInput := &vmcommon.ContractCallInput{VMInput: vmcommon.VMInput{}}
+	parentInput.CallerAddr = []byte("user")
+	parentInput.RecipientAddr = []byte("parent")
+	parentInput.GasProvided = 4000
+
+	childInput := &vmcommon.ContractCallInput{VMInput: vmcommon.VMInput{}}
+	childInput.CallerAddr = parentInput.RecipientAddr
+	childInput.RecipientAddr = []byte("child")
+	childInput.GasProvided = 500
+
+	mockRuntime := &contextmock.RuntimeContextMock{SCAddress: parentInput.RecipientAddr}
+	mockRuntime.SetVMInput(parentInput)
+	host := &contextmock.VMHostMock{RuntimeContext: mockRuntime}
+	metering, _ := NewMeteringContext(host, config.MakeGasMapForTests(), uint64(15000), vmhost.DefaultMaxGasRefundPercentage)
+	host.MeteringContext = metering
+	output, _ := NewOutputContext(host)
+	host.OutputContext = output
+
+	metering.SetGasBreakdown(true)
+	metering.InitStateFromContractCallInput(&parentInput.VMInput)
+	_ = metering.DeductInitialGasForExecution(make([]byte, 100))
+	parentCompilation := metering.GetSCPrepareInitialCost()
+
+	metering.StartGasTracing("mBufferSetBytes")
+	_ = metering.UseGasBounded(30)
+	_ = metering.UseGasBoundedAndAddTracedGas("storageStore", 20)
+	mockRuntime.SetPointsUsed(mockRuntime.GetPointsUsed() + 100)
+	metering.UseGasForContractInit(childInput.GasProvided)
+	parentPointsBeforeStacking := mockRuntime.GetPointsUsed()
+
+	// execute the child
+	metering.PushState()
+	metering.InitStateFromContractCallInput(&childInput.VMInput)
+	mockRuntime.SCAddress = childInput.RecipientAddr
+	mockRuntime.SetPointsUsed(0)
+	mockRuntime.SetVMInput(childInput)
+	_ = metering.DeductInitialGasForExecution(make([]byte, 50))
+	childCompilation := metering.GetSCPrepareInitialCost()
+
+	metering.StartGasTracing("bigIntAdd")
+	_ = metering.UseGasBounded(40)
+	mockRuntime.SetPointsUsed(mockRuntime.GetPointsUsed() + 10)
+	_ = output.GetVMOutput()
+	gasRemaining := metering.GasLeft()
+
+	// return to the parent
+	metering.PopMergeActiveState()
+	mockRuntime.SCAddress = parentInput.RecipientAddr
+	mockRuntime.SetPointsUsed(parentPointsBeforeStacking)
+	mockRuntime.SetVMInput(parentInput)
+	metering.RestoreGas(gasRemaining)
+
+	vmOutput := output.GetVMOutput()
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+	gasBreakdown := metering.GetGasBreakdown(vmOutput)
+	require.Len(t, gasBreakdown, 2)
+
+	childGasUsed := childCompilation + 40 + 10
+	require.Equal(t, &vmhost.GasBreakdown{
+		GasUsed:     parentCompilation + 30 + 20 + 100,
+		Compilation: parentCompilation,
+		Opcodes:     100,
+		VMHooks: map[string]uint64{
+			vmhost.GasCategoryManagedBuffer: 30,
+			vmhost.GasCategoryStorage:       20,
+		},
+		Forwarded: childGasUsed,
+	}, gasBreakdown["parent"])
+	require.Equal(t, &vmhost.GasBreakdown{
+		GasUsed:     childGasUsed,
+		Compilation: childCompilation,
+		Opcodes:     10,
+		VMHooks:     map[string]uint64{vmhost.GasCategoryBigInt: 40},
+	}, gasBreakdown["child"])
+	require.Equal(t, vmOutput.OutputAccounts["parent"].GasUsed, gasBreakdown["parent"].GasUsed)
+
+	metering.SetGasBreakdown(false)
+	require.Empty(t, metering.GetGasBreakdown(vmOutput))
+}
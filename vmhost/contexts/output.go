@@ -11,6 +11,7 @@ import (
 	logger "github.com/multiversx/mx-chain-logger-go"
 	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
 	"github.com/multiversx/mx-chain-vm-common-go/parsers"
+	"github.com/multiversx/mx-chain-vm-go/contractabi"
 	"github.com/multiversx/mx-chain-vm-go/executor"
 	"github.com/multiversx/mx-chain-vm-go/math"
 	"github.com/multiversx/mx-chain-vm-go/vmhost"
@@ -482,7 +483,7 @@ func (context *outputContext) TransferESDT(
 		}
 
 		if !sameShard {
-			err = context.host.Metering().UseGasBounded(gasRemaining)
+			err = context.host.Metering().UseGasBoundedInCategory(vmhost.GasCategoryTransfers, gasRemaining)
 			if err != nil {
 				logOutput.Trace("ESDT post-transfer execution", "error", vmhost.ErrNotEnoughGas)
 				return 0, vmhost.ErrNotEnoughGas
@@ -567,6 +568,12 @@ func (context *outputContext) DeployCode(input vmhost.CodeDeployInput) {
 	context.codeUpdates[string(input.ContractAddress)] = empty
 }
 
+// HasDeployedCode returns true if code was deployed or upgraded at the given address during the current execution.
+func (context *outputContext) HasDeployedCode(address []byte) bool {
+	_, ok := context.codeUpdates[string(address)]
+	return ok
+}
+
 // CreateVMOutputInCaseOfError creates a new vmOutput with the given error set as return message.
 func (context *outputContext) CreateVMOutputInCaseOfError(err error) *vmcommon.VMOutput {
 	runtime := context.host.Runtime()
@@ -637,6 +644,18 @@ func (context *outputContext) resolveReturnCodeFromError(err error) vmcommon.Ret
 	if errors.Is(err, vmhost.ErrCallBackFuncCalledInRun) {
 		return vmcommon.UserError
 	}
+	if errors.Is(err, contractabi.ErrArgumentCountMismatch) {
+		return vmcommon.UserError
+	}
+	if errors.Is(err, contractabi.ErrEndpointAccessDenied) {
+		return vmcommon.UserError
+	}
+	if errors.Is(err, vmhost.ErrContractFrozen) {
+		return vmcommon.UserError
+	}
+	if errors.Is(err, vmhost.ErrFreezeNotAllowed) || errors.Is(err, vmhost.ErrContractNotFrozen) {
+		return vmcommon.UserError
+	}
 	if errors.Is(err, vmhost.ErrNotEnoughGas) {
 		return vmcommon.OutOfGas
 	}
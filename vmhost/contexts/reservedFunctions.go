@@ -36,6 +36,8 @@ func NewReservedFunctions(
 	var empty struct{}
 	result.functionNames[vmhost.UpgradeFunctionName] = empty
 	result.functionNames[vmhost.DeleteFunctionName] = empty
+	result.functionNames[vmhost.FreezeFunctionName] = empty
+	result.functionNames[vmhost.UnfreezeFunctionName] = empty
 
 	return result
 }
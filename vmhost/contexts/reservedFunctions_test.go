@@ -26,4 +26,6 @@ func TestReservedFunctions_IsFunctionReserved(t *testing.T) {
 	require.True(t, reserved.IsReserved("protocolFunctionFoo"))
 	require.True(t, reserved.IsReserved("protocolFunctionBar"))
 	require.True(t, reserved.IsReserved(vmhost.DeleteFunctionName))
+	require.True(t, reserved.IsReserved(vmhost.FreezeFunctionName))
+	require.True(t, reserved.IsReserved(vmhost.UnfreezeFunctionName))
 }
@@ -7,8 +7,11 @@ import (
 	"github.com/multiversx/mx-chain-core-go/core/check"
 	logger "github.com/multiversx/mx-chain-logger-go"
 	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	"github.com/multiversx/mx-chain-vm-go/contractabi"
 	"github.com/multiversx/mx-chain-vm-go/executor"
+	"github.com/multiversx/mx-chain-vm-go/math"
 	"github.com/multiversx/mx-chain-vm-go/vmhost"
+	"github.com/multiversx/mx-chain-vm-go/wasmbinary"
 	builtinMath "math"
 	"math/big"
 )
@@ -17,15 +20,6 @@ var logRuntime = logger.GetOrCreate("vm/runtime")
 
 var _ vmhost.RuntimeContext = (*runtimeContext)(nil)
 
-var mapNewCryptoAPI = map[string]struct{}{
-	"managedVerifyBLSSignatureShare":           {},
-	"managedVerifyBLSAggregatedSignature":      {},
-	"managedVerifySecp256r1":                   {},
-	"managedGetOriginalCallerAddr":             {},
-	"managedGetRelayerAddr":                    {},
-	"managedMultiTransferESDTNFTExecuteByUser": {},
-}
-
 const warmCacheSize = 100
 
 // WarmInstancesEnabled controls the usage of warm instances
@@ -40,6 +34,10 @@ type runtimeContext struct {
 	readOnly             bool
 	verifyCode           bool
 	maxInstanceStackSize uint64
+	executionBudget      uint64
+	budgetEnabled        bool
+	instructionsUsed     uint64
+	codeLimits           vmhost.CodeLimits
 
 	vmExecutor executor.Executor
 
@@ -106,8 +104,12 @@ func (context *runtimeContext) InitState() {
 	context.codeAddress = make([]byte, 0)
 	context.callFunction = ""
 	context.verifyCode = false
-	context.readOnly = false
+	context.readOnly = context.host.IsQueryExecution()
+	// queries are bounded by their own gas limit and timeout, not by the instruction budget
+	context.budgetEnabled = context.executionBudget > 0 && !context.host.IsQueryExecution()
+	context.instructionsUsed = 0
 	context.iTracker.InitState()
+	context.iTracker.SetWarmCacheFrozen(context.host.IsQueryExecution())
 	context.errors = nil
 
 	logRuntime.Trace("init state")
@@ -154,12 +156,29 @@ func (context *runtimeContext) StartWasmerInstance(contract []byte, gasLimit uin
 
 	context.iTracker.SetCodeSize(uint64(len(contract)))
 	context.iTracker.SetCodeHash(codeHash)
+	context.iTracker.SetContractABI(context.contractABIOf(contract, codeHash))
+	context.iTracker.SetAccessControl(context.accessControlOf(contract, codeHash))
 
 	defer func() {
 		context.iTracker.LogCounts()
 		logRuntime.Trace("code was new", "new", newCode)
 	}()
 
+	err := context.makeInstance(contract, gasLimit, newCode)
+	if err != nil {
+		return err
+	}
+
+	// the memory declared by the module is never charged as growth, whichever cache the instance comes from
+	numPages, ok := context.declaredMemoryPagesOf(contract, codeHash)
+	if !ok {
+		numPages = memoryPagesOf(context.iTracker.Instance())
+	}
+	context.iTracker.SetChargedMemoryPages(numPages)
+	return nil
+}
+
+func (context *runtimeContext) makeInstance(contract []byte, gasLimit uint64, newCode bool) error {
 	warmInstanceUsed, err := context.useWarmInstanceIfExists(gasLimit, newCode)
 	if err != nil {
 		return err
@@ -179,6 +198,62 @@ func (context *runtimeContext) StartWasmerInstance(contract []byte, gasLimit uin
 	return context.makeInstanceFromContractByteCode(contract, gasLimit, newCode)
 }
 
+// declaredMemoryPagesOf returns the initial number of memory pages declared by the contract, parsed once per
+// code hash, or false if the contract cannot be parsed.
+func (context *runtimeContext) declaredMemoryPagesOf(contract []byte, codeHash []byte) (uint32, bool) {
+	numPages, ok := context.iTracker.GetCachedMemoryPages(codeHash)
+	if ok {
+		return numPages, true
+	}
+
+	module, err := wasmbinary.ParseModule(contract)
+	if err != nil {
+		logRuntime.Trace("declared memory pages", "error", err)
+		return 0, false
+	}
+
+	numPages = 0
+	for _, memoryPages := range module.MemoryPages {
+		numPages += memoryPages
+	}
+	context.iTracker.CacheMemoryPages(codeHash, numPages)
+	return numPages, true
+}
+
+// contractABIOf returns the optional ABI of the contract, parsed once per code hash; a malformed ABI is ignored,
+// like a missing one, because the executors ignore custom sections and such a contract was deployed successfully.
+func (context *runtimeContext) contractABIOf(contract []byte, codeHash []byte) *contractabi.ABI {
+	contractABI, ok := context.iTracker.GetCachedContractABI(codeHash)
+	if ok {
+		return contractABI
+	}
+
+	contractABI, err := contractabi.ExtractFromCode(contract)
+	if err != nil {
+		logRuntime.Trace("contract ABI ignored", "error", err)
+		contractABI = nil
+	}
+	context.iTracker.CacheContractABI(codeHash, contractABI)
+	return contractABI
+}
+
+// accessControlOf returns the endpoint attributes of the contract, parsed once per code hash;
+// malformed attributes are ignored for the same reason as a malformed ABI.
+func (context *runtimeContext) accessControlOf(contract []byte, codeHash []byte) *contractabi.AccessControl {
+	accessControl, ok := context.iTracker.GetCachedAccessControl(codeHash)
+	if ok {
+		return accessControl
+	}
+
+	accessControl, err := contractabi.ExtractAccessControlFromCode(contract)
+	if err != nil {
+		logRuntime.Trace("endpoint access control ignored", "error", err)
+		accessControl = nil
+	}
+	context.iTracker.CacheAccessControl(codeHash, accessControl)
+	return accessControl
+}
+
 func (context *runtimeContext) makeInstanceFromCompiledCode(gasLimit uint64, newCode bool) (bool, error) {
 	codeHash := context.iTracker.CodeHash()
 	if newCode || len(codeHash) == 0 {
@@ -186,7 +261,7 @@ func (context *runtimeContext) makeInstanceFromCompiledCode(gasLimit uint64, new
 	}
 
 	blockchain := context.host.Blockchain()
-	found, compiledCode := blockchain.GetCompiledCode(codeHash)
+	found, compiledCode := blockchain.GetCompiledCode(context.compiledCodeHash())
 	if !found {
 		logRuntime.Trace("instance creation", "code", "cached compilation", "error", "compiled code was not found")
 		return false, nil
@@ -233,7 +308,8 @@ func (context *runtimeContext) makeInstanceFromContractByteCode(contract []byte,
 		Metering:           true,
 		RuntimeBreakpoints: true,
 	}
-	newInstance, err := context.vmExecutor.NewInstanceWithOptions(contract, options)
+	instrumentedCode := context.instrumentContractCode(contract)
+	newInstance, err := context.vmExecutor.NewInstanceWithOptions(instrumentedCode, options)
 	if err != nil {
 		context.iTracker.UnsetInstance()
 		logRuntime.Trace("instance creation", "from", "bytecode", "error", err)
@@ -251,7 +327,7 @@ func (context *runtimeContext) makeInstanceFromContractByteCode(contract []byte,
 	}
 
 	if newCode {
-		err = context.VerifyContractCode()
+		err = context.VerifyContractCode(contract)
 		if err != nil {
 			context.iTracker.ForceCleanInstance(true)
 			logRuntime.Trace("instance creation", "from", "bytecode", "error", err)
@@ -269,6 +345,55 @@ func (context *runtimeContext) makeInstanceFromContractByteCode(contract []byte,
 	return nil
 }
 
+// instrumentation returns the code injected into the contracts, which report the instructions they execute
+// when the execution budget is set, and have their memory growth charged when it happens if the executor
+// provides the hook; otherwise the growth is only charged when the execution returns to the VM.
+func (context *runtimeContext) instrumentation() wasmbinary.Instrumentation {
+	instrumentation := wasmbinary.Instrumentation{}
+	if context.executionBudget > 0 {
+		instrumentation.CountInstructionsHook = vmhost.CountInstructionsHookName
+		instrumentation.InstructionsChunk = vmhost.CountInstructionsChunk
+	}
+
+	_, chargesMemoryGrowth := context.vmExecutor.FunctionNames()[vmhost.MemoryGrowthHookName]
+	if chargesMemoryGrowth && context.host.EnableEpochsHandler().IsFlagEnabled(vmhost.MemoryGrowthGasFlag) {
+		instrumentation.MemoryGrowthHook = vmhost.MemoryGrowthHookName
+	}
+	return instrumentation
+}
+
+// instrumentContractCode injects the instrumentation into the bytecode of a contract before it is compiled;
+// code which is not WASM, as run by the mock executors, is left unchanged. Code which cannot be instrumented
+// runs uninstrumented, as before the instrumentation existed: its memory growth is charged when the execution
+// returns to the VM and its instructions are not counted, leaving it to the wall-clock safety net.
+func (context *runtimeContext) instrumentContractCode(contract []byte) []byte {
+	instrumentedCode, err := wasmbinary.InstrumentModule(contract, context.instrumentation())
+	if errors.Is(err, wasmbinary.ErrNotWasmModule) {
+		return contract
+	}
+	if err != nil {
+		logRuntime.Warn("contract code not instrumented",
+			"codeHash", context.iTracker.CodeHash(),
+			"error", err,
+		)
+		return contract
+	}
+	return instrumentedCode
+}
+
+// compiledCodeHash returns the key of the compiled code of the current contract, which is derived from its code
+// hash when the code is instrumented, so that the compiled code is never shared with a differently configured VM.
+func (context *runtimeContext) compiledCodeHash() []byte {
+	codeHash := context.iTracker.CodeHash()
+	instrumentation := context.instrumentation()
+	if instrumentation.IsEmpty() {
+		return codeHash
+	}
+	marker := fmt.Sprintf("%s/%d/%s",
+		instrumentation.CountInstructionsHook, instrumentation.InstructionsChunk, instrumentation.MemoryGrowthHook)
+	return context.hasher.Compute(string(codeHash) + marker)
+}
+
 func (context *runtimeContext) useWarmInstanceIfExists(gasLimit uint64, newCode bool) (bool, error) {
 	if !WarmInstancesEnabled {
 		return false, nil
@@ -316,6 +441,16 @@ func (context *runtimeContext) GetSCCodeSize() uint64 {
 	return context.iTracker.GetCodeSize()
 }
 
+// GetContractABI returns the ABI embedded in the current SC code, or nil if it has none.
+func (context *runtimeContext) GetContractABI() *contractabi.ABI {
+	return context.iTracker.GetContractABI()
+}
+
+// GetAccessControl returns the endpoint attributes embedded in the current SC code, or nil if it has none.
+func (context *runtimeContext) GetAccessControl() *contractabi.AccessControl {
+	return context.iTracker.GetAccessControl()
+}
+
 func (context *runtimeContext) saveCompiledCode() {
 	compiledCode, err := context.iTracker.Instance().Cache()
 	if err != nil {
@@ -323,7 +458,7 @@ func (context *runtimeContext) saveCompiledCode() {
 		return
 	}
 
-	codeHash := context.iTracker.CodeHash()
+	codeHash := context.compiledCodeHash()
 	blockchain := context.host.Blockchain()
 	blockchain.SaveCompiledCode(codeHash, compiledCode)
 	logRuntime.Trace("save compiled code", "codeHash", codeHash)
@@ -337,7 +472,7 @@ func (context *runtimeContext) saveCompiledCode() {
 }
 
 func (context *runtimeContext) saveWarmInstance() {
-	if !WarmInstancesEnabled {
+	if !WarmInstancesEnabled || context.host.IsQueryExecution() {
 		return
 	}
 
@@ -360,6 +495,43 @@ func (context *runtimeContext) SetMaxInstanceStackSize(maxInstances uint64) {
 	context.maxInstanceStackSize = maxInstances
 }
 
+// SetCodeLimits sets the static limits checked on the code of the contracts being deployed or upgraded.
+func (context *runtimeContext) SetCodeLimits(limits vmhost.CodeLimits) {
+	context.codeLimits = limits
+}
+
+// SetExecutionBudget sets the maximum number of instructions which the contracts may execute during a transaction,
+// regardless of the gas provided; 0 disables the budget. The contracts are instrumented to report the instructions
+// they execute only when the budget is set.
+func (context *runtimeContext) SetExecutionBudget(budget uint64) {
+	context.executionBudget = budget
+	context.budgetEnabled = budget > 0
+}
+
+// UseInstructions adds the instructions executed by a contract to those of the transaction, which include the
+// instructions of the nested calls, and returns ErrExecutionBudgetExceeded once they exceed the execution budget.
+func (context *runtimeContext) UseInstructions(count uint64) error {
+	if !context.isExecutionBudgetEnabled() {
+		return nil
+	}
+
+	context.instructionsUsed = math.AddUint64(context.instructionsUsed, count)
+	if context.IsExecutionBudgetExceeded() {
+		return vmhost.ErrExecutionBudgetExceeded
+	}
+	return nil
+}
+
+// IsExecutionBudgetExceeded returns true if the contracts executed more instructions than the execution budget
+// during the current transaction.
+func (context *runtimeContext) IsExecutionBudgetExceeded() bool {
+	return context.isExecutionBudgetEnabled() && context.instructionsUsed > context.executionBudget
+}
+
+func (context *runtimeContext) isExecutionBudgetEnabled() bool {
+	return context.budgetEnabled
+}
+
 // InitStateFromContractCallInput initializes the state of the runtime context
 // (and the async context) from the provided ContractCallInput.
 func (context *runtimeContext) InitStateFromContractCallInput(input *vmcommon.ContractCallInput) {
@@ -653,55 +825,37 @@ func (context *runtimeContext) GetRuntimeBreakpointValue() vmhost.BreakpointValu
 	return vmhost.BreakpointValue(context.iTracker.Instance().GetBreakpointValue())
 }
 
-// VerifyContractCode performs validation on the WASM bytecode (declaration of memory and legal functions).
-func (context *runtimeContext) VerifyContractCode() error {
+// VerifyContractCode performs validation on the WASM bytecode (declaration of memory, legal functions and code limits).
+func (context *runtimeContext) VerifyContractCode(code []byte) error {
 	if !context.verifyCode {
 		return nil
 	}
 
 	context.verifyCode = false
 
-	err := context.validator.verifyMemoryDeclaration(context.iTracker.Instance())
+	err := verifyInstrumentationHooksNotImported(code)
 	if err != nil {
 		logRuntime.Trace("verify contract code", "error", err)
 		return err
 	}
 
-	err = context.validator.verifyFunctions(context.iTracker.Instance())
+	err = context.validator.verifyContractCode(context.iTracker.Instance(), context.host.EnableEpochsHandler())
 	if err != nil {
 		logRuntime.Trace("verify contract code", "error", err)
 		return err
 	}
 
-	err = context.validator.verifyProtectedFunctions(context.iTracker.Instance())
+	err = VerifyCodeLimits(code, context.codeLimits)
 	if err != nil {
 		logRuntime.Trace("verify contract code", "error", err)
 		return err
 	}
 
-	enableEpochsHandler := context.host.EnableEpochsHandler()
-	if !enableEpochsHandler.IsFlagEnabled(vmhost.CryptoOpcodesV2Flag) {
-		err = context.checkIfContainsNewCryptoApi()
-		if err != nil {
-			logRuntime.Trace("verify contract code", "error", err)
-			return err
-		}
-	}
-
 	logRuntime.Trace("verified contract code")
 
 	return nil
 }
 
-func (context *runtimeContext) checkIfContainsNewCryptoApi() error {
-	for funcName := range mapNewCryptoAPI {
-		if context.iTracker.Instance().IsFunctionImported(funcName) {
-			return vmhost.ErrContractInvalid
-		}
-	}
-	return nil
-}
-
 // BaseOpsErrorShouldFailExecution returns true
 func (context *runtimeContext) BaseOpsErrorShouldFailExecution() bool {
 	return true
@@ -724,6 +878,12 @@ func (context *runtimeContext) BigFloatAPIErrorShouldFailExecution() bool {
 	return true
 }
 
+// DecimalAPIErrorShouldFailExecution specifies whether an error in the EEI
+// functions for fixed-point decimal operations should abort contract execution.
+func (context *runtimeContext) DecimalAPIErrorShouldFailExecution() bool {
+	return true
+}
+
 // CryptoAPIErrorShouldFailExecution specifies whether an error in the EEI
 // functions for crypto operations should abort contract execution.
 func (context *runtimeContext) CryptoAPIErrorShouldFailExecution() bool {
@@ -745,6 +905,29 @@ func (context *runtimeContext) UseGasBoundedShouldFailExecution() bool {
 	return context.host.EnableEpochsHandler().IsFlagEnabled(vmhost.UseGasBoundedShouldFailExecutionFlag)
 }
 
+// UseGasForMemoryGrowth charges the memory pages allocated by the current instance since they were last charged.
+func (context *runtimeContext) UseGasForMemoryGrowth() error {
+	if !context.host.EnableEpochsHandler().IsFlagEnabled(vmhost.MemoryGrowthGasFlag) {
+		return nil
+	}
+
+	numPages := memoryPagesOf(context.iTracker.Instance())
+	chargedPages := context.iTracker.GetChargedMemoryPages()
+	if numPages <= chargedPages {
+		return nil
+	}
+
+	metering := context.host.Metering()
+	gasToUse := math.MulUint64(uint64(numPages-chargedPages), metering.GasSchedule().BaseOperationCost.MemoryGrowPerPage)
+	err := metering.UseGasBoundedInCategory(vmhost.GasCategoryMemory, gasToUse)
+	if err != nil {
+		return err
+	}
+
+	context.iTracker.SetChargedMemoryPages(numPages)
+	return nil
+}
+
 // GetPointsUsed returns the gas amount spent by the currently running Wasmer instance.
 func (context *runtimeContext) GetPointsUsed() uint64 {
 	if check.IfNil(context.iTracker.Instance()) {
@@ -863,6 +1046,11 @@ func (context *runtimeContext) GetAllErrors() error {
 
 // EndExecution performs final steps after execution ends
 func (context *runtimeContext) EndExecution() {
+	if context.host.IsQueryExecution() {
+		// the instances started by queries are not kept warm, so they are cleaned here
+		context.iTracker.ForceCleanInstance(false)
+		return
+	}
 	context.iTracker.UnsetInstance()
 }
 
@@ -34,7 +34,7 @@ func InitializeVMAndWasmer() *contextmock.VMHostMock {
 	gasCostConfig, _ := config.CreateGasConfig(gasSchedule)
 	wasmer.SetOpcodeCosts(gasCostConfig.WASMOpcodeCost)
 
-	host := &contextmock.VMHostMock{}
+	host := &contextmock.VMHostMock{EnableEpochsHandlerField: &worldmock.EnableEpochsHandlerStub{}}
 
 	mockMetering := &contextmock.MeteringContextMock{}
 	mockMetering.SetGasSchedule(gasSchedule)
@@ -171,6 +171,19 @@ func TestRuntimeContext_NewWasmerInstance(t *testing.T) {
 	require.Equal(t, uint64(len(contractCode)), runtimeCtx.GetSCCodeSize())
 }
 
+func TestRuntimeContext_InstrumentContractCode_FallsBackToUninstrumentedCode(t *testing.T) {
+	host := InitializeVMAndWasmer()
+	runtimeCtx := makeDefaultRuntimeContext(t, host)
+	runtimeCtx.SetExecutionBudget(1000)
+
+	// a WASM header followed by a truncated section, which cannot be instrumented
+	contractCode := []byte{0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00, 0x01, 0x05, 0x01}
+	require.Equal(t, contractCode, runtimeCtx.instrumentContractCode(contractCode))
+
+	notWasmCode := []byte("contract")
+	require.Equal(t, notWasmCode, runtimeCtx.instrumentContractCode(notWasmCode))
+}
+
 func TestRuntimeContext_IsFunctionImported(t *testing.T) {
 	t.Skip()
 	host := InitializeVMAndWasmer()
@@ -204,7 +217,7 @@ func TestRuntimeContext_IsFunctionImported(t *testing.T) {
 }
 
 func TestRuntimeContext_StateSettersAndGetters(t *testing.T) {
-	host := &contextmock.VMHostMock{}
+	host := &contextmock.VMHostMock{EnableEpochsHandlerField: &worldmock.EnableEpochsHandlerStub{}}
 
 	runtimeCtx := makeDefaultRuntimeContext(t, host)
 	defer runtimeCtx.ClearWarmInstanceCache()
@@ -292,7 +305,7 @@ func TestRuntimeContext_PushPopInstance(t *testing.T) {
 }
 
 func TestRuntimeContext_PushPopState(t *testing.T) {
-	host := &contextmock.VMHostMock{}
+	host := &contextmock.VMHostMock{EnableEpochsHandlerField: &worldmock.EnableEpochsHandlerStub{}}
 	runtimeCtx := makeDefaultRuntimeContext(t, host)
 	defer runtimeCtx.ClearWarmInstanceCache()
 
@@ -357,7 +370,7 @@ func TestRuntimeContext_CountContractInstancesOnStack(t *testing.T) {
 	beta := []byte("beta")
 	gamma := []byte("gamma")
 
-	host := &contextmock.VMHostMock{}
+	host := &contextmock.VMHostMock{EnableEpochsHandlerField: &worldmock.EnableEpochsHandlerStub{}}
 
 	testVMType := []byte("type")
 	execFactory := testexecutor.NewDefaultTestExecutorFactory(t)
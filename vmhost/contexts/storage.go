@@ -3,8 +3,9 @@ package contexts
 import (
 	"bytes"
 	"fmt"
-	"github.com/multiversx/mx-chain-core-go/core"
+	"math/big"
 
+	"github.com/multiversx/mx-chain-core-go/core"
 	"github.com/multiversx/mx-chain-core-go/core/check"
 	logger "github.com/multiversx/mx-chain-logger-go"
 	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
@@ -20,7 +21,7 @@ var logStorage = logger.GetOrCreate("vm/storage")
 var _ vmhost.StorageContext = (*storageContext)(nil)
 
 // VMStoragePrefix defines the VM prefix
-const VMStoragePrefix = "VM@"
+const VMStoragePrefix = vmhost.VMStoragePrefix
 
 type storageContext struct {
 	host                       vmhost.VMHost
@@ -30,13 +31,29 @@ type storageContext struct {
 	protectedKeyPrefix         []byte
 	vmProtectedKeyPrefix       []byte
 	vmStorageProtectionEnabled bool
+	storageDepositPerByte      *big.Int
+
+	transientStorage           map[string]map[string][]byte
+	transientStorageJournal    []transientStorageChange
+	transientStorageStateStack []int
+}
+
+// transientStorageChange records the value which a key of the transient storage had before a write, so that the
+// write can be undone when the nested call which made it is reverted; a nil value means that the key was absent.
+type transientStorageChange struct {
+	address       string
+	key           string
+	previousValue []byte
 }
 
-// NewStorageContext creates a new storageContext
+// NewStorageContext creates a new storageContext. A non-zero storageDepositPerByte, together with the
+// StorageDepositFlag, enables the storage deposit mode, in which the net storage growth of each contract is
+// paid for with a deposit moved from its balance to the system account and released when the storage is freed.
 func NewStorageContext(
 	host vmhost.VMHost,
 	blockChainHook vmcommon.BlockchainHook,
 	protectedKeyPrefix []byte,
+	storageDepositPerByte *big.Int,
 ) (*storageContext, error) {
 	if len(protectedKeyPrefix) == 0 {
 		return nil, vmhost.ErrEmptyProtectedKeyPrefix
@@ -59,21 +76,28 @@ func NewStorageContext(
 		protectedKeyPrefix:         protectedKeyPrefix,
 		vmProtectedKeyPrefix:       append(protectedKeyPrefix, []byte(VMStoragePrefix)...),
 		vmStorageProtectionEnabled: true,
+		storageDepositPerByte:      storageDepositPerByte,
 	}
 
+	context.InitState()
+
 	return context, nil
 }
 
-// InitState does nothing
+// InitState discards the transient storage of the previous transaction
 func (context *storageContext) InitState() {
+	context.ClearTransientStorage()
 }
 
-// PushState appends the current address to the state stack.
+// PushState appends the current address to the state stack, and marks the writes to the transient storage
+// which a revert of the nested call must undo.
 func (context *storageContext) PushState() {
 	context.stateStack = append(context.stateStack, context.address)
+	context.transientStorageStateStack = append(context.transientStorageStateStack, len(context.transientStorageJournal))
 }
 
-// PopSetActiveState removes the latest entry from the state stack and sets it as the current address
+// PopSetActiveState removes the latest entry from the state stack and sets it as the current address,
+// reverting the transient storage to the value it had when the state was pushed
 func (context *storageContext) PopSetActiveState() {
 	stateStackLen := len(context.stateStack)
 	if stateStackLen == 0 {
@@ -84,6 +108,22 @@ func (context *storageContext) PopSetActiveState() {
 	context.stateStack = context.stateStack[:stateStackLen-1]
 
 	context.address = prevAddress
+	context.popTransientStorageState(true)
+}
+
+// PopMergeActiveState removes the latest entry from the state stack and sets it as the current address,
+// keeping the transient storage written since the state was pushed
+func (context *storageContext) PopMergeActiveState() {
+	stateStackLen := len(context.stateStack)
+	if stateStackLen == 0 {
+		return
+	}
+
+	prevAddress := context.stateStack[stateStackLen-1]
+	context.stateStack = context.stateStack[:stateStackLen-1]
+
+	context.address = prevAddress
+	context.popTransientStorageState(false)
 }
 
 // PopDiscard removes the latest entry from the state stack
@@ -94,11 +134,36 @@ func (context *storageContext) PopDiscard() {
 	}
 
 	context.stateStack = context.stateStack[:stateStackLen-1]
+	context.popTransientStorageState(false)
+}
+
+func (context *storageContext) popTransientStorageState(restore bool) {
+	stateStackLen := len(context.transientStorageStateStack)
+	if stateStackLen == 0 {
+		return
+	}
+
+	journalLen := context.transientStorageStateStack[stateStackLen-1]
+	context.transientStorageStateStack = context.transientStorageStateStack[:stateStackLen-1]
+
+	if restore {
+		for i := len(context.transientStorageJournal) - 1; i >= journalLen; i-- {
+			change := context.transientStorageJournal[i]
+			context.setTransientValue(change.address, change.key, change.previousValue)
+		}
+		context.transientStorageJournal = context.transientStorageJournal[:journalLen]
+	}
+	if len(context.transientStorageStateStack) == 0 {
+		// no nested call is left to revert
+		context.transientStorageJournal = context.transientStorageJournal[:0]
+	}
 }
 
 // ClearStateStack clears the state stack from the current context.
 func (context *storageContext) ClearStateStack() {
 	context.stateStack = make([][]byte, 0)
+	context.transientStorageStateStack = make([]int, 0)
+	context.transientStorageJournal = make([]transientStorageChange, 0)
 }
 
 // SetAddress sets the given address as the address for the current context.
@@ -107,6 +172,62 @@ func (context *storageContext) SetAddress(address []byte) {
 	logStorage.Trace("storage under address set", "address", address)
 }
 
+// GetTransientStorage returns the value stored under the given key in the transient storage of the current address.
+func (context *storageContext) GetTransientStorage(key []byte) []byte {
+	value := context.transientStorage[string(context.address)][string(key)]
+	logStorage.Trace("transient get", "key", key, "value", value)
+	return value
+}
+
+// SetTransientStorage sets the given value at the given key in the transient storage of the current address.
+// Transient storage is never written to the trie and is discarded when the transaction ends.
+func (context *storageContext) SetTransientStorage(key []byte, value []byte) error {
+	if context.host.Runtime().ReadOnly() {
+		logStorage.Trace("transient set", "error", "cannot set transient storage in readonly mode")
+		return vmhost.ErrCannotWriteOnReadOnly
+	}
+
+	addressKey := string(context.address)
+	if len(context.transientStorageStateStack) > 0 {
+		// the stored values are never modified in place, so the journal keeps them without copying
+		context.transientStorageJournal = append(context.transientStorageJournal, transientStorageChange{
+			address:       addressKey,
+			key:           string(key),
+			previousValue: context.transientStorage[addressKey][string(key)],
+		})
+	}
+
+	if len(value) == 0 {
+		context.setTransientValue(addressKey, string(key), nil)
+		logStorage.Trace("transient deleted", "key", key)
+		return nil
+	}
+
+	context.setTransientValue(addressKey, string(key), append([]byte{}, value...))
+	logStorage.Trace("transient set", "key", key, "value", value)
+	return nil
+}
+
+func (context *storageContext) setTransientValue(address string, key string, value []byte) {
+	if value == nil {
+		delete(context.transientStorage[address], key)
+		return
+	}
+
+	accountStorage, ok := context.transientStorage[address]
+	if !ok {
+		accountStorage = make(map[string][]byte)
+		context.transientStorage[address] = accountStorage
+	}
+	accountStorage[key] = value
+}
+
+// ClearTransientStorage discards the transient storage of all accounts.
+func (context *storageContext) ClearTransientStorage() {
+	context.transientStorage = make(map[string]map[string][]byte)
+	context.transientStorageJournal = make([]transientStorageChange, 0)
+}
+
 // GetStorageUpdates returns the storage updates for the account mapped to the given address.
 func (context *storageContext) GetStorageUpdates(address []byte) map[string]*vmcommon.StorageUpdate {
 	account, _ := context.host.Output().GetOutputAccount(address)
@@ -320,6 +441,15 @@ func (context *storageContext) setStorageToAddress(address []byte, key []byte, v
 	}
 
 	deltaBytes := len(value) - len(oldValue)
+	if !context.isVMProtectedKey(key) {
+		// keys written by the VM itself, e.g. the upgrade policy, are not paid
+		// for by the contract
+		err = context.updateStorageDeposit(address, deltaBytes)
+		if err != nil {
+			return vmhost.StorageUnchanged, err
+		}
+	}
+
 	context.addDeltaBytes(deltaBytes)
 
 	context.changeStorageUpdate(key, value, storageUpdates)
@@ -394,6 +524,160 @@ func (context *storageContext) addDeltaBytes(deltaBytes int) {
 	}
 }
 
+func (context *storageContext) isStorageDepositEnabled() bool {
+	if context.storageDepositPerByte == nil || context.storageDepositPerByte.Sign() <= 0 {
+		return false
+	}
+	return context.host.EnableEpochsHandler().IsFlagEnabled(vmhost.StorageDepositFlag)
+}
+
+// updateStorageDeposit locks a deposit out of the balance of the account for the
+// bytes added to its storage, or releases the deposit of the bytes deleted from
+// it. The deposit is moved by the balance deltas of the output accounts, from the
+// account to the system account which holds all the deposits and back, so the
+// protocol applies it like any other balance change; the total locked by the
+// account is kept under a VM protected key.
+func (context *storageContext) updateStorageDeposit(address []byte, deltaBytes int) error {
+	if !context.isStorageDepositEnabled() || deltaBytes == 0 {
+		return nil
+	}
+
+	lockedDeposit, _, _, err := context.GetStorageDeposit(address)
+	if err != nil {
+		return err
+	}
+
+	output := context.host.Output()
+	if deltaBytes > 0 {
+		deposit := big.NewInt(0).Mul(context.storageDepositPerByte, big.NewInt(int64(deltaBytes)))
+		if context.host.Blockchain().GetBalanceBigInt(address).Cmp(deposit) < 0 {
+			logStorage.Trace("storage deposit", "error", vmhost.ErrStorageDepositInsufficientFunds, "deposit", deposit)
+			return vmhost.ErrStorageDepositInsufficientFunds
+		}
+
+		lockedDeposit.Add(lockedDeposit, deposit)
+		output.AddTxValueToAccount(address, big.NewInt(0).Neg(deposit))
+		output.AddTxValueToAccount(core.SystemAccountAddress, deposit)
+	} else {
+		// storage written before the deposit mode was enabled was never paid for,
+		// so no more than the locked deposit can be released
+		released := big.NewInt(0).Mul(context.storageDepositPerByte, big.NewInt(int64(-deltaBytes)))
+		if released.Cmp(lockedDeposit) > 0 {
+			released.Set(lockedDeposit)
+		}
+
+		lockedDeposit.Sub(lockedDeposit, released)
+		output.AddTxValueToAccount(core.SystemAccountAddress, big.NewInt(0).Neg(released))
+		output.AddTxValueToAccount(address, released)
+	}
+
+	_, err = context.SetProtectedStorageToAddressUnmetered(address, context.GetVmProtectedPrefix(vmhost.StorageDepositKey), lockedDeposit.Bytes())
+	logStorage.Trace("storage deposit", "address", address, "deltaBytes", deltaBytes, "locked", lockedDeposit)
+	return err
+}
+
+// GetStorageDeposit returns the deposit currently locked for the storage of the given address.
+func (context *storageContext) GetStorageDeposit(address []byte) (*big.Int, uint32, bool, error) {
+	key := context.GetVmProtectedPrefix(vmhost.StorageDepositKey)
+	value, trieDepth, usedCache, err := context.getStorageFromAddressUnmetered(address, key)
+	if err != nil {
+		return nil, trieDepth, false, err
+	}
+
+	return big.NewInt(0).SetBytes(value), trieDepth, usedCache, nil
+}
+
+// GetUpgradePolicy returns the upgrade policy stored by the contract at the given address.
+func (context *storageContext) GetUpgradePolicy(address []byte) (*vmhost.UpgradePolicy, uint32, bool, error) {
+	key := context.GetVmProtectedPrefix(vmhost.UpgradePolicyKey)
+	value, trieDepth, usedCache, err := context.getStorageFromAddressUnmetered(address, key)
+	if err != nil {
+		return nil, trieDepth, false, err
+	}
+
+	policy, err := vmhost.DeserializeUpgradePolicy(value)
+	return policy, trieDepth, usedCache, err
+}
+
+// GetUpgradeConditions returns the upgrade conditions recorded when the code of the contract at the given address was deployed.
+func (context *storageContext) GetUpgradeConditions(address []byte) (vmhost.UpgradeConditions, uint32, bool, error) {
+	key := context.GetVmProtectedPrefix(vmhost.UpgradeConditionsKey)
+	value, trieDepth, usedCache, err := context.getStorageFromAddressUnmetered(address, key)
+	if err != nil {
+		return vmhost.UpgradeConditions{}, trieDepth, false, err
+	}
+
+	return vmhost.UpgradeConditionsFromCodeMetadata(value), trieDepth, usedCache, nil
+}
+
+// GetPendingUpgrade returns the upgrade proposed by the contract at the given address, or nil if there is none.
+func (context *storageContext) GetPendingUpgrade(address []byte) (*vmhost.PendingUpgrade, uint32, bool, error) {
+	key := context.GetVmProtectedPrefix(vmhost.PendingUpgradeKey)
+	value, trieDepth, usedCache, err := context.getStorageFromAddressUnmetered(address, key)
+	if err != nil {
+		return nil, trieDepth, false, err
+	}
+
+	pending, err := vmhost.DeserializePendingUpgrade(value)
+	return pending, trieDepth, usedCache, err
+}
+
+// GetDeployInfo returns the deployer and the deploy block of the contract at the given address, or nil if they were not recorded.
+func (context *storageContext) GetDeployInfo(address []byte) (*vmhost.DeployInfo, uint32, bool, error) {
+	key := context.GetVmProtectedPrefix(vmhost.DeployInfoKey)
+	value, trieDepth, usedCache, err := context.getStorageFromAddressUnmetered(address, key)
+	if err != nil {
+		return nil, trieDepth, false, err
+	}
+
+	info, err := vmhost.DeserializeDeployInfo(value)
+	return info, trieDepth, usedCache, err
+}
+
+// GetContractFreeze returns the freeze of the contract at the given address, or nil if it is not frozen.
+// The freeze is checked on every call, so a value read from the blockchain is not kept in the storage
+// updates, which would otherwise add the key to the output of each called contract.
+func (context *storageContext) GetContractFreeze(address []byte) (*vmhost.ContractFreeze, uint32, bool, error) {
+	key := context.GetVmProtectedPrefix(vmhost.FrozenContractKey)
+	if storageUpdate, ok := context.GetStorageUpdates(address)[string(key)]; ok {
+		freeze, err := vmhost.DeserializeContractFreeze(storageUpdate.Data)
+		return freeze, 0, true, err
+	}
+
+	value, trieDepth, err := context.readFromBlockchain(address, key)
+	if err != nil {
+		return nil, trieDepth, false, err
+	}
+
+	freeze, err := vmhost.DeserializeContractFreeze(value)
+	return freeze, trieDepth, false, err
+}
+
+// GetRegisteredCode returns the code registered under the given code hash in the storage of the system account,
+// or nil if the code hash was not registered. As for the freeze, a code read from the blockchain is not kept
+// in the storage updates.
+func (context *storageContext) GetRegisteredCode(codeHash []byte) ([]byte, uint32, error) {
+	return context.getSystemAccountProtectedValue(vmhost.CodeRegistryKey, codeHash)
+}
+
+// GetReferencedCodeHash returns the hash of the registered code referenced by the code with the given hash,
+// or nil if that code is not a reference to a registered code.
+func (context *storageContext) GetReferencedCodeHash(referenceCodeHash []byte) ([]byte, uint32, error) {
+	return context.getSystemAccountProtectedValue(vmhost.CodeReferenceKey, referenceCodeHash)
+}
+
+func (context *storageContext) getSystemAccountProtectedValue(prefix string, suffix []byte) ([]byte, uint32, error) {
+	key := append(context.GetVmProtectedPrefix(prefix), suffix...)
+	// the system account is not added to the output only to look up the value
+	if account, ok := context.host.Output().GetOutputAccounts()[string(core.SystemAccountAddress)]; ok {
+		if storageUpdate, ok := account.StorageUpdates[string(key)]; ok {
+			return storageUpdate.Data, 0, nil
+		}
+	}
+
+	return context.readFromBlockchain(core.SystemAccountAddress, key)
+}
+
 func (context *storageContext) changeStorageUpdate(key []byte, value []byte, storageUpdates map[string]*vmcommon.StorageUpdate) {
 	length := len(value)
 	newUpdate := &vmcommon.StorageUpdate{
@@ -520,7 +804,10 @@ func (context *storageContext) IsInterfaceNil() bool {
 
 // GetVmProtectedPrefix returns the VM protected prefix as byte slice
 func (context *storageContext) GetVmProtectedPrefix(prefix string) []byte {
-	return append(context.vmProtectedKeyPrefix, []byte(prefix)...)
+	// a new slice, so that the keys returned to different callers never share the spare capacity of the prefix
+	key := make([]byte, 0, len(context.vmProtectedKeyPrefix)+len(prefix))
+	key = append(key, context.vmProtectedKeyPrefix...)
+	return append(key, prefix...)
 }
 
 // GetStorageLoadCost returns the gas cost for the storage load operation
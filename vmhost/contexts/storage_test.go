@@ -27,7 +27,7 @@ func TestNewStorageContext(t *testing.T) {
 		host := &contextmock.VMHostMock{}
 		mockBlockchain := worldmock.NewMockWorld()
 
-		storageCtx, err := NewStorageContext(host, mockBlockchain, make([]byte, 0))
+		storageCtx, err := NewStorageContext(host, mockBlockchain, make([]byte, 0), nil)
 		require.Equal(t, vmhost.ErrEmptyProtectedKeyPrefix, err)
 		require.True(t, check.IfNil(storageCtx))
 	})
@@ -36,7 +36,7 @@ func TestNewStorageContext(t *testing.T) {
 
 		mockBlockchain := worldmock.NewMockWorld()
 
-		storageCtx, err := NewStorageContext(nil, mockBlockchain, reservedTestPrefix)
+		storageCtx, err := NewStorageContext(nil, mockBlockchain, reservedTestPrefix, nil)
 		require.Equal(t, vmhost.ErrNilVMHost, err)
 		require.True(t, check.IfNil(storageCtx))
 	})
@@ -45,7 +45,7 @@ func TestNewStorageContext(t *testing.T) {
 
 		host := &contextmock.VMHostMock{}
 
-		storageCtx, err := NewStorageContext(host, nil, reservedTestPrefix)
+		storageCtx, err := NewStorageContext(host, nil, reservedTestPrefix, nil)
 		require.Equal(t, vmhost.ErrNilBlockChainHook, err)
 		require.True(t, check.IfNil(storageCtx))
 	})
@@ -58,7 +58,7 @@ func TestNewStorageContext(t *testing.T) {
 		}
 		mockBlockchain := worldmock.NewMockWorld()
 
-		storageCtx, err := NewStorageContext(host, mockBlockchain, reservedTestPrefix)
+		storageCtx, err := NewStorageContext(host, mockBlockchain, reservedTestPrefix, nil)
 		require.Nil(t, err)
 		require.False(t, check.IfNil(storageCtx))
 	})
@@ -109,7 +109,7 @@ func TestStorageContext_SetAddress(t *testing.T) {
 	}
 	bcHook := &contextmock.BlockchainHookStub{}
 
-	storageCtx, _ := NewStorageContext(host, bcHook, reservedTestPrefix)
+	storageCtx, _ := NewStorageContext(host, bcHook, reservedTestPrefix, nil)
 
 	keyA := []byte("keyA")
 	valueA := []byte("valueA")
@@ -165,7 +165,7 @@ func TestStorageContext_GetStorageUpdates(t *testing.T) {
 	}
 
 	mockBlockchainHook := worldmock.NewMockWorld()
-	storageCtx, _ := NewStorageContext(host, mockBlockchainHook, reservedTestPrefix)
+	storageCtx, _ := NewStorageContext(host, mockBlockchainHook, reservedTestPrefix, nil)
 
 	storageUpdates := storageCtx.GetStorageUpdates([]byte("account"))
 	require.Equal(t, 1, len(storageUpdates))
@@ -196,7 +196,7 @@ func TestStorageContext_SetStorage(t *testing.T) {
 		EnableEpochsHandlerField: enableEpochsHandler,
 	}
 	bcHook := &contextmock.BlockchainHookStub{}
-	storageCtx, _ := NewStorageContext(host, bcHook, reservedTestPrefix)
+	storageCtx, _ := NewStorageContext(host, bcHook, reservedTestPrefix, nil)
 	storageCtx.SetAddress(address)
 
 	val1 := []byte("value")
@@ -345,7 +345,7 @@ func TestStorageContext_SetStorage_GasUsage(t *testing.T) {
 	}
 	bcHook := &contextmock.BlockchainHookStub{}
 
-	storageCtx, _ := NewStorageContext(host, bcHook, reservedTestPrefix)
+	storageCtx, _ := NewStorageContext(host, bcHook, reservedTestPrefix, nil)
 	storageCtx.SetAddress(address)
 
 	gasProvided := 100
@@ -411,12 +411,16 @@ func TestStorageContext_StorageProtection(t *testing.T) {
 	}
 	bcHook := &contextmock.BlockchainHookStub{}
 
-	storageCtx, _ := NewStorageContext(host, bcHook, reservedTestPrefix)
+	storageCtx, _ := NewStorageContext(host, bcHook, reservedTestPrefix, nil)
 	storageCtx.SetAddress(address)
 
 	key := storageCtx.GetVmProtectedPrefix("something")
 	value := []byte("data")
 
+	// building another protected key leaves the previous one intact
+	_ = storageCtx.GetVmProtectedPrefix("other")
+	require.True(t, bytes.HasSuffix(key, []byte("something")))
+
 	storageStatus, err := storageCtx.SetStorage(key, value)
 	require.Equal(t, vmhost.StorageUnchanged, storageStatus)
 	require.True(t, errors.Is(err, vmhost.ErrCannotWriteProtectedKey))
@@ -485,7 +489,7 @@ func TestStorageContext_GetStorageFromAddress(t *testing.T) {
 			nil,
 			errTooManyRequests)
 
-		storageCtx, _ := NewStorageContext(host, bcHook, reservedTestPrefix)
+		storageCtx, _ := NewStorageContext(host, bcHook, reservedTestPrefix, nil)
 		storageCtx.SetAddress(scAddress)
 
 		key := []byte("key")
@@ -519,7 +523,7 @@ func TestStorageContext_GetStorageFromAddress(t *testing.T) {
 			internalData,
 			nil)
 
-		storageCtx, _ := NewStorageContext(host, bcHook, reservedTestPrefix)
+		storageCtx, _ := NewStorageContext(host, bcHook, reservedTestPrefix, nil)
 		storageCtx.SetAddress(scAddress)
 
 		key := []byte("key")
@@ -581,7 +585,7 @@ func TestStorageContext_PopSetActiveStateIfStackIsEmptyShouldNotPanic(t *testing
 		EnableEpochsHandlerField: enableEpochsHandler,
 	}
 
-	storageCtx, _ := NewStorageContext(host, &contextmock.BlockchainHookStub{}, reservedTestPrefix)
+	storageCtx, _ := NewStorageContext(host, &contextmock.BlockchainHookStub{}, reservedTestPrefix, nil)
 	storageCtx.PopSetActiveState()
 
 	require.Equal(t, 0, len(storageCtx.stateStack))
@@ -595,7 +599,7 @@ func TestStorageContext_PopDiscardIfStackIsEmptyShouldNotPanic(t *testing.T) {
 		EnableEpochsHandlerField: enableEpochsHandler,
 	}
 
-	storageCtx, _ := NewStorageContext(host, &contextmock.BlockchainHookStub{}, reservedTestPrefix)
+	storageCtx, _ := NewStorageContext(host, &contextmock.BlockchainHookStub{}, reservedTestPrefix, nil)
 	storageCtx.PopDiscard()
 
 	require.Equal(t, 0, len(storageCtx.stateStack))
@@ -623,7 +627,7 @@ func TestStorageContext_GetStorageLoadCost(t *testing.T) {
 			MeteringContext:          mockMetering,
 		}
 
-		storageContext, _ := NewStorageContext(host, &contextmock.BlockchainHookStub{}, reservedTestPrefix)
+		storageContext, _ := NewStorageContext(host, &contextmock.BlockchainHookStub{}, reservedTestPrefix, nil)
 		trieDepth := int64(0)
 		staticCost := uint64(40000)
 
@@ -651,7 +655,7 @@ func TestStorageContext_GetStorageLoadCost(t *testing.T) {
 			MeteringContext:          mockMetering,
 		}
 
-		storageContext, _ := NewStorageContext(host, &contextmock.BlockchainHookStub{}, reservedTestPrefix)
+		storageContext, _ := NewStorageContext(host, &contextmock.BlockchainHookStub{}, reservedTestPrefix, nil)
 		trieDepth := int64(5)
 		staticCost := uint64(40000)
 
@@ -679,7 +683,7 @@ func TestStorageContext_GetStorageLoadCost(t *testing.T) {
 			MeteringContext:          mockMetering,
 		}
 
-		storageContext, _ := NewStorageContext(host, &contextmock.BlockchainHookStub{}, reservedTestPrefix)
+		storageContext, _ := NewStorageContext(host, &contextmock.BlockchainHookStub{}, reservedTestPrefix, nil)
 		trieDepth := int64(5)
 		staticCost := uint64(40000)
 
@@ -708,7 +712,7 @@ func TestStorageContext_GetStorageLoadCost(t *testing.T) {
 			MeteringContext:          mockMetering,
 		}
 
-		storageContext, _ := NewStorageContext(host, &contextmock.BlockchainHookStub{}, reservedTestPrefix)
+		storageContext, _ := NewStorageContext(host, &contextmock.BlockchainHookStub{}, reservedTestPrefix, nil)
 		trieDepth := int64(5)
 		staticCost := uint64(40000)
 
@@ -717,3 +721,185 @@ func TestStorageContext_GetStorageLoadCost(t *testing.T) {
 		require.Equal(t, staticCost, cost)
 	})
 }
+
+func TestStorageContext_TransientStorage(t *testing.T) {
+	t.Parallel()
+
+	addressA := []byte("accountA")
+	addressB := []byte("accountB")
+	key := []byte("lock")
+	value := []byte("locked")
+
+	mockRuntime := &contextmock.RuntimeContextMock{}
+	host := &contextmock.VMHostMock{
+		RuntimeContext:           mockRuntime,
+		EnableEpochsHandlerField: &worldmock.EnableEpochsHandlerStub{},
+	}
+
+	storageCtx, _ := NewStorageContext(host, &contextmock.BlockchainHookStub{}, reservedTestPrefix, nil)
+	storageCtx.SetAddress(addressA)
+
+	err := storageCtx.SetTransientStorage(key, value)
+	require.Nil(t, err)
+	require.Equal(t, value, storageCtx.GetTransientStorage(key))
+
+	// successful nested call to another address, merged back into the parent
+	storageCtx.PushState()
+	storageCtx.SetAddress(addressB)
+	require.Nil(t, storageCtx.GetTransientStorage(key))
+	err = storageCtx.SetTransientStorage(key, []byte("other"))
+	require.Nil(t, err)
+	storageCtx.PopMergeActiveState()
+	require.Equal(t, addressA, storageCtx.address)
+	require.Equal(t, value, storageCtx.GetTransientStorage(key))
+
+	// failed nested call to the same address, reverted
+	storageCtx.PushState()
+	err = storageCtx.SetTransientStorage(key, nil)
+	require.Nil(t, err)
+	require.Nil(t, storageCtx.GetTransientStorage(key))
+	storageCtx.PopSetActiveState()
+	require.Equal(t, value, storageCtx.GetTransientStorage(key))
+
+	// successful nested call to the same address, kept
+	storageCtx.PushState()
+	err = storageCtx.SetTransientStorage(key, []byte("unlocked"))
+	require.Nil(t, err)
+	storageCtx.PopDiscard()
+	require.Equal(t, []byte("unlocked"), storageCtx.GetTransientStorage(key))
+
+	storageCtx.SetAddress(addressB)
+	require.Equal(t, []byte("other"), storageCtx.GetTransientStorage(key))
+
+	mockRuntime.SetReadOnly(true)
+	err = storageCtx.SetTransientStorage(key, value)
+	require.Equal(t, vmhost.ErrCannotWriteOnReadOnly, err)
+	mockRuntime.SetReadOnly(false)
+
+	storageCtx.ClearTransientStorage()
+	require.Nil(t, storageCtx.GetTransientStorage(key))
+	storageCtx.SetAddress(addressA)
+	require.Nil(t, storageCtx.GetTransientStorage(key))
+}
+
+func TestStorageContext_TransientStorage_NestedRevert(t *testing.T) {
+	t.Parallel()
+
+	address := []byte("account")
+	key := []byte("counter")
+	newKey := []byte("new")
+
+	host := &contextmock.VMHostMock{
+		RuntimeContext:           &contextmock.RuntimeContextMock{},
+		EnableEpochsHandlerField: &worldmock.EnableEpochsHandlerStub{},
+	}
+
+	storageCtx, _ := NewStorageContext(host, &contextmock.BlockchainHookStub{}, reservedTestPrefix, nil)
+	storageCtx.SetAddress(address)
+	_ = storageCtx.SetTransientStorage(key, []byte{1})
+
+	storageCtx.PushState()
+	_ = storageCtx.SetTransientStorage(key, []byte{2})
+
+	// inner call writing the same key several times, merged into the middle call
+	storageCtx.PushState()
+	_ = storageCtx.SetTransientStorage(key, []byte{3})
+	_ = storageCtx.SetTransientStorage(key, []byte{4})
+	_ = storageCtx.SetTransientStorage(newKey, []byte{5})
+	storageCtx.PopMergeActiveState()
+	require.Equal(t, []byte{4}, storageCtx.GetTransientStorage(key))
+	require.Equal(t, []byte{5}, storageCtx.GetTransientStorage(newKey))
+
+	// reverting the middle call also undoes the writes merged from the inner call
+	storageCtx.PopSetActiveState()
+	require.Equal(t, []byte{1}, storageCtx.GetTransientStorage(key))
+	require.Nil(t, storageCtx.GetTransientStorage(newKey))
+	require.Empty(t, storageCtx.transientStorageJournal)
+
+	// writes outside of nested calls are not journaled
+	_ = storageCtx.SetTransientStorage(key, []byte{6})
+	require.Empty(t, storageCtx.transientStorageJournal)
+	require.Equal(t, []byte{6}, storageCtx.GetTransientStorage(key))
+}
+
+func TestStorageContext_StorageDeposit(t *testing.T) {
+	t.Parallel()
+
+	address := []byte("account")
+	storageCtx, account := makeStorageDepositContext(address, true)
+
+	key := []byte("key")
+	_, err := storageCtx.SetStorage(key, []byte("0123456789"))
+	require.Nil(t, err)
+	deposit, _, _, err := storageCtx.GetStorageDeposit(address)
+	require.Nil(t, err)
+	require.Equal(t, big.NewInt(20), deposit)
+
+	depositKey := storageCtx.GetVmProtectedPrefix(vmhost.StorageDepositKey)
+	require.Equal(t, big.NewInt(20).Bytes(), account.StorageUpdates[string(depositKey)].Data)
+
+	_, err = storageCtx.SetStorage(key, []byte("0123"))
+	require.Nil(t, err)
+	deposit, _, _, _ = storageCtx.GetStorageDeposit(address)
+	require.Equal(t, big.NewInt(8), deposit)
+
+	// the balance of 100 does not cover the deposit of another 60 bytes
+	status, err := storageCtx.SetStorage([]byte("big"), make([]byte, 60))
+	require.Equal(t, vmhost.ErrStorageDepositInsufficientFunds, err)
+	require.Equal(t, vmhost.StorageUnchanged, status)
+	deposit, _, _, _ = storageCtx.GetStorageDeposit(address)
+	require.Equal(t, big.NewInt(8), deposit)
+
+	_, err = storageCtx.SetStorage(key, nil)
+	require.Nil(t, err)
+	deposit, _, _, _ = storageCtx.GetStorageDeposit(address)
+	require.Zero(t, deposit.Sign())
+}
+
+func TestStorageContext_StorageDepositFlagDisabled(t *testing.T) {
+	t.Parallel()
+
+	address := []byte("account")
+	storageCtx, account := makeStorageDepositContext(address, false)
+
+	_, err := storageCtx.SetStorage([]byte("key"), make([]byte, 80))
+	require.Nil(t, err)
+
+	depositKey := storageCtx.GetVmProtectedPrefix(vmhost.StorageDepositKey)
+	require.NotContains(t, account.StorageUpdates, string(depositKey))
+}
+
+func makeStorageDepositContext(address []byte, flagEnabled bool) (*storageContext, *vmcommon.OutputAccount) {
+	mockAccount := &worldmock.Account{
+		Address: address,
+		Balance: big.NewInt(100),
+		Storage: make(map[string][]byte),
+	}
+	mockWorld := worldmock.NewMockWorld()
+	mockWorld.AcctMap.PutAccount(mockAccount)
+
+	mockOutput := &contextmock.OutputContextMock{}
+	account := mockOutput.NewVMOutputAccountFromMockAccount(mockAccount)
+	mockOutput.OutputAccountMock = account
+	mockOutput.OutputAccountIsNew = false
+
+	mockMetering := &contextmock.MeteringContextMock{}
+	mockMetering.SetGasSchedule(config.MakeGasMapForTests())
+	mockMetering.GasLeftMock = 20000
+
+	host := &contextmock.VMHostMock{
+		OutputContext:   mockOutput,
+		MeteringContext: mockMetering,
+		RuntimeContext:  &contextmock.RuntimeContextMock{},
+		EnableEpochsHandlerField: &worldmock.EnableEpochsHandlerStub{
+			IsFlagEnabledCalled: func(flag core.EnableEpochFlag) bool {
+				return flagEnabled && flag == vmhost.StorageDepositFlag
+			},
+		},
+	}
+	host.BlockchainContext, _ = NewBlockchainContext(host, mockWorld)
+
+	storageCtx, _ := NewStorageContext(host, mockWorld, reservedTestPrefix, big.NewInt(2))
+	storageCtx.SetAddress(address)
+	return storageCtx, account
+}
@@ -1,19 +1,60 @@
 package contexts
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 
+	"github.com/multiversx/mx-chain-core-go/core"
 	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
-	"github.com/multiversx/mx-chain-vm-go/executor"
 	"github.com/multiversx/mx-chain-vm-go/vmhost"
+	"github.com/multiversx/mx-chain-vm-go/wasmbinary"
 )
 
 const allowedCharsInFunctionName = "abcdefghijklmnopqrstuvwxyz0123456789_"
 
+var mapNewCryptoAPI = map[string]struct{}{
+	"managedVerifyBLSSignatureShare":           {},
+	"managedVerifyBLSAggregatedSignature":      {},
+	"managedVerifySecp256r1":                   {},
+	"managedGetOriginalCallerAddr":             {},
+	"managedGetRelayerAddr":                    {},
+	"managedMultiTransferESDTNFTExecuteByUser": {},
+}
+
+// vmHooksByFlag lists the VM hooks which contracts may import only once the flag activating them is enabled and
+// only with an executor which provides them.
+var vmHooksByFlag = []struct {
+	flag  core.EnableEpochFlag
+	hooks []string
+}{
+	{vmhost.TransientStorageFlag, []string{"transientStore", "transientLoad"}},
+	{vmhost.StorageDepositFlag, []string{"getStorageDeposit"}},
+	{vmhost.DecimalFlag, []string{
+		"decimalNew", "decimalFromBigInt", "decimalToBigInt", "decimalRescale", "decimalAdd", "decimalSub",
+		"decimalMul", "decimalDiv", "decimalCmp", "decimalLn", "decimalExp", "mBufferToDecimal", "mBufferFromDecimal",
+	}},
+	{vmhost.ModularArithmeticFlag, []string{"bigIntModPow", "bigIntModInverse", "bigIntMulMod", "bigIntGCD"}},
+	{vmhost.MemoryGrowthGasFlag, []string{"getMemoryUsage"}},
+	{vmhost.EVMAbiFlag, []string{"managedEVMAbiEncode", "managedEVMAbiDecode", "managedEVMFunctionSelector"}},
+	{vmhost.UpgradePolicyFlag, []string{
+		"managedSetUpgradePolicy", "managedProposeUpgrade", "managedApproveUpgrade", "managedCancelUpgrade",
+	}},
+	{vmhost.SaltedDeployFlag, []string{
+		"managedDeployFromSourceContractWithSalt", "managedCreateContractWithSalt", "managedComputeContractAddress",
+	}},
+	{vmhost.ContractDeployInfoFlag, []string{"managedGetCodeHash", "managedGetCodeDeployer", "managedGetContractDeployInfo"}},
+	{vmhost.CodeRegistryFlag, []string{"managedDeployFromCodeHash", "managedRegisterCode"}},
+}
+
+// instrumentationHooks lists the VM hooks which only the code injected by the VM into the contracts may call; the
+// contracts may not import them themselves.
+var instrumentationHooks = []string{vmhost.CountInstructionsHookName, vmhost.MemoryGrowthHookName}
+
 // wasmValidator is a validator for WASM SmartContracts
 type wasmValidator struct {
-	reserved *reservedFunctions
+	reserved   *reservedFunctions
+	scAPINames vmcommon.FunctionNames
 }
 
 // newWASMValidator creates a new WASMValidator
@@ -22,11 +63,130 @@ func newWASMValidator(
 	builtInFuncContainer vmcommon.BuiltInFunctionContainer,
 ) *wasmValidator {
 	return &wasmValidator{
-		reserved: NewReservedFunctions(scAPINames, builtInFuncContainer),
+		reserved:   NewReservedFunctions(scAPINames, builtInFuncContainer),
+		scAPINames: scAPINames,
+	}
+}
+
+// VerifyContractCode performs the checks which the VM performs on the code of a contract when it is deployed or
+// upgraded, after the executor accepted it, so that contracts can be validated before being deployed.
+func VerifyContractCode(
+	code ContractCode,
+	scAPINames vmcommon.FunctionNames,
+	builtInFuncContainer vmcommon.BuiltInFunctionContainer,
+	enableEpochsHandler vmhost.EnableEpochsHandler,
+) error {
+	err := checkInstrumentationHooksNotImported(code.IsFunctionImported)
+	if err != nil {
+		return err
+	}
+
+	return newWASMValidator(scAPINames, builtInFuncContainer).verifyContractCode(code, enableEpochsHandler)
+}
+
+// verifyInstrumentationHooksNotImported rejects the WASM bytecode of a contract which imports one of the hooks
+// reserved to the code injected by the VM. It must be checked on the code as deployed, since the instrumented code
+// which the executor compiles imports them. Code which is not WASM, as run by the mock executors, is accepted.
+func verifyInstrumentationHooksNotImported(code []byte) error {
+	module, err := wasmbinary.ParseModule(code)
+	if errors.Is(err, wasmbinary.ErrNotWasmModule) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("%w: %s", vmhost.ErrContractInvalid, err)
+	}
+
+	return checkInstrumentationHooksNotImported(func(name string) bool {
+		for _, functionImport := range module.Imports {
+			if functionImport.Name == name {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+func checkInstrumentationHooksNotImported(isFunctionImported func(name string) bool) error {
+	for _, funcName := range instrumentationHooks {
+		if isFunctionImported(funcName) {
+			return fmt.Errorf("%w: %s is reserved to the code injected by the VM", vmhost.ErrContractInvalid, funcName)
+		}
+	}
+	return nil
+}
+
+// VerifyCodeLimits checks the WASM bytecode of a contract against static limits. The module is only parsed when
+// at least one of the limits is set; each violated limit is reported by a distinct error.
+func VerifyCodeLimits(code []byte, limits vmhost.CodeLimits) error {
+	if limits.IsUnlimited() {
+		return nil
+	}
+	if limits.MaxCodeSize > 0 && uint64(len(code)) > limits.MaxCodeSize {
+		return fmt.Errorf("%w: %d bytes, limit %d", vmhost.ErrCodeSizeLimitExceeded, len(code), limits.MaxCodeSize)
+	}
+
+	module, err := wasmbinary.ParseModule(code)
+	if err != nil {
+		return fmt.Errorf("%w: %s", vmhost.ErrContractInvalid, err)
+	}
+
+	if limits.MaxImports > 0 && module.NumImports > limits.MaxImports {
+		return fmt.Errorf("%w: %d imports, limit %d", vmhost.ErrImportCountLimitExceeded, module.NumImports, limits.MaxImports)
+	}
+	numFunctions := len(module.Functions)
+	if limits.MaxFunctions > 0 && uint64(numFunctions) > uint64(limits.MaxFunctions) {
+		return fmt.Errorf("%w: %d functions, limit %d", vmhost.ErrFunctionCountLimitExceeded, numFunctions, limits.MaxFunctions)
+	}
+	if limits.MaxLocalsPerFunction > 0 {
+		for i, function := range module.Functions {
+			if function.NumLocals > uint64(limits.MaxLocalsPerFunction) {
+				funcIndex := uint32(len(module.Imports) + i)
+				return fmt.Errorf("%w: %d locals in function %s, limit %d",
+					vmhost.ErrLocalsCountLimitExceeded, function.NumLocals, module.FunctionName(funcIndex), limits.MaxLocalsPerFunction)
+			}
+		}
+	}
+	if limits.MaxTableSize > 0 {
+		for _, tableSize := range module.TableSizes {
+			if tableSize > limits.MaxTableSize {
+				return fmt.Errorf("%w: %d elements, limit %d", vmhost.ErrTableSizeLimitExceeded, tableSize, limits.MaxTableSize)
+			}
+		}
+	}
+	if limits.MaxDataSegments > 0 && module.NumDataSegments > limits.MaxDataSegments {
+		return fmt.Errorf("%w: %d segments, limit %d", vmhost.ErrDataSegmentCountLimitExceeded, module.NumDataSegments, limits.MaxDataSegments)
+	}
+
+	return nil
+}
+
+func (validator *wasmValidator) verifyContractCode(code ContractCode, enableEpochsHandler vmhost.EnableEpochsHandler) error {
+	err := validator.verifyMemoryDeclaration(code)
+	if err != nil {
+		return err
+	}
+
+	err = validator.verifyFunctions(code)
+	if err != nil {
+		return err
+	}
+
+	err = validator.verifyProtectedFunctions(code)
+	if err != nil {
+		return err
 	}
+
+	if !enableEpochsHandler.IsFlagEnabled(vmhost.CryptoOpcodesV2Flag) {
+		err = validator.verifyNewCryptoAPINotImported(code)
+		if err != nil {
+			return err
+		}
+	}
+
+	return validator.verifyFlaggedVMHooksNotImported(code, enableEpochsHandler)
 }
 
-func (validator *wasmValidator) verifyMemoryDeclaration(instance executor.Instance) error {
+func (validator *wasmValidator) verifyMemoryDeclaration(instance ContractCode) error {
 	if !instance.HasMemory() {
 		return vmhost.ErrMemoryDeclarationMissing
 	}
@@ -34,7 +194,7 @@ func (validator *wasmValidator) verifyMemoryDeclaration(instance executor.Instan
 	return nil
 }
 
-func (validator *wasmValidator) verifyFunctions(instance executor.Instance) error {
+func (validator *wasmValidator) verifyFunctions(instance ContractCode) error {
 	for _, functionName := range instance.GetFunctionNames() {
 		err := validator.verifyValidFunctionName(functionName)
 		if err != nil {
@@ -52,7 +212,7 @@ var protectedFunctions = map[string]bool{
 	"signalError":       true,
 	"completedTxEvent":  true}
 
-func (validator *wasmValidator) verifyProtectedFunctions(instance executor.Instance) error {
+func (validator *wasmValidator) verifyProtectedFunctions(instance ContractCode) error {
 	for _, functionName := range instance.GetFunctionNames() {
 		_, found := protectedFunctions[functionName]
 		if found {
@@ -64,6 +224,34 @@ func (validator *wasmValidator) verifyProtectedFunctions(instance executor.Insta
 	return nil
 }
 
+func (validator *wasmValidator) verifyNewCryptoAPINotImported(instance ContractCode) error {
+	for funcName := range mapNewCryptoAPI {
+		if instance.IsFunctionImported(funcName) {
+			return vmhost.ErrContractInvalid
+		}
+	}
+	return nil
+}
+
+func (validator *wasmValidator) verifyFlaggedVMHooksNotImported(instance ContractCode, enableEpochsHandler vmhost.EnableEpochsHandler) error {
+	for _, flaggedHooks := range vmHooksByFlag {
+		isFlagEnabled := enableEpochsHandler.IsFlagEnabled(flaggedHooks.flag)
+		for _, funcName := range flaggedHooks.hooks {
+			if !instance.IsFunctionImported(funcName) {
+				continue
+			}
+			if !isFlagEnabled {
+				return fmt.Errorf("%w: %s imported before %s", vmhost.ErrContractInvalid, funcName, flaggedHooks.flag)
+			}
+			_, isProvided := validator.scAPINames[funcName]
+			if !isProvided {
+				return fmt.Errorf("%w: %s not provided by the executor", vmhost.ErrContractInvalid, funcName)
+			}
+		}
+	}
+	return nil
+}
+
 func (validator *wasmValidator) verifyValidFunctionName(functionName string) error {
 	err := verifyCallFunction(functionName)
 	if err != nil {
@@ -4,11 +4,14 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/multiversx/mx-chain-core-go/core"
 	"github.com/multiversx/mx-chain-scenario-go/worldmock"
 	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
 	"github.com/multiversx/mx-chain-vm-common-go/builtInFunctions"
 	contextmock "github.com/multiversx/mx-chain-vm-go/mock/context"
+	"github.com/multiversx/mx-chain-vm-go/vmhost"
 	"github.com/multiversx/mx-chain-vm-go/vmhost/mock"
+	"github.com/multiversx/mx-chain-vm-go/wasmbinary"
 	"github.com/stretchr/testify/require"
 )
 
@@ -70,3 +73,129 @@ func TestFunctionsProtected(t *testing.T) {
 	err := validator.verifyProtectedFunctions(instance)
 	require.NotNil(t, err)
 }
+
+func TestVerifyFlaggedVMHooksNotImported(t *testing.T) {
+	host := InitializeVMAndWasmer()
+
+	validator := newWASMValidator(testImportNames(), builtInFunctions.NewBuiltInFunctionContainer())
+
+	world := worldmock.NewMockWorld()
+	imb := contextmock.NewExecutorMock(world)
+	instance := imb.CreateAndStoreInstanceMock(t, host, []byte{}, []byte{}, []byte{}, []byte{}, 0, 0, false)
+	instance.AddMockMethod("transientStore", func() *contextmock.InstanceMock {
+		return contextmock.GetMockInstance(instance.Host)
+	})
+
+	enableEpochsHandler := &worldmock.EnableEpochsHandlerStub{
+		IsFlagEnabledCalled: func(flag core.EnableEpochFlag) bool {
+			return flag != vmhost.TransientStorageFlag
+		},
+	}
+	err := validator.verifyFlaggedVMHooksNotImported(instance, enableEpochsHandler)
+	require.ErrorIs(t, err, vmhost.ErrContractInvalid)
+	require.Contains(t, err.Error(), "transientStore")
+
+	enableEpochsHandler.IsFlagEnabledCalled = func(flag core.EnableEpochFlag) bool {
+		return true
+	}
+	err = validator.verifyFlaggedVMHooksNotImported(instance, enableEpochsHandler)
+	require.ErrorIs(t, err, vmhost.ErrContractInvalid)
+	require.Contains(t, err.Error(), "not provided by the executor")
+
+	importNames := testImportNames()
+	importNames["transientStore"] = struct{}{}
+	validator = newWASMValidator(importNames, builtInFunctions.NewBuiltInFunctionContainer())
+	require.Nil(t, validator.verifyFlaggedVMHooksNotImported(instance, enableEpochsHandler))
+}
+
+func TestVerifyInstrumentationHooksNotImported(t *testing.T) {
+	for _, hookName := range []string{vmhost.CountInstructionsHookName, vmhost.MemoryGrowthHookName} {
+		builder := wasmbinary.NewModuleBuilder()
+		builder.AddImport(hookName, []byte{wasmbinary.ValueTypeI64}, nil)
+		err := verifyInstrumentationHooksNotImported(builder.Build())
+		require.ErrorIs(t, err, vmhost.ErrContractInvalid)
+		require.Contains(t, err.Error(), hookName)
+	}
+
+	builder := wasmbinary.NewModuleBuilder()
+	builder.AddImport("getGasLeft", nil, []byte{wasmbinary.ValueTypeI64})
+	require.Nil(t, verifyInstrumentationHooksNotImported(builder.Build()))
+	require.Nil(t, verifyInstrumentationHooksNotImported([]byte("not WASM")))
+
+	host := InitializeVMAndWasmer()
+	world := worldmock.NewMockWorld()
+	imb := contextmock.NewExecutorMock(world)
+	instance := imb.CreateAndStoreInstanceMock(t, host, []byte{}, []byte{}, []byte{}, []byte{}, 0, 0, false)
+	instance.AddMockMethod(vmhost.MemoryGrowthHookName, func() *contextmock.InstanceMock {
+		return contextmock.GetMockInstance(instance.Host)
+	})
+	err := VerifyContractCode(instance, testImportNames(), builtInFunctions.NewBuiltInFunctionContainer(), worldmock.EnableEpochsHandlerStubAllFlags())
+	require.ErrorIs(t, err, vmhost.ErrContractInvalid)
+	require.Contains(t, err.Error(), vmhost.MemoryGrowthHookName)
+}
+
+func TestVMHooksByFlag_KnownHooks(t *testing.T) {
+	hookNames := contextmock.NewExecutorMock(worldmock.NewMockWorld()).FunctionNames()
+	for _, flaggedHooks := range vmHooksByFlag {
+		for _, funcName := range flaggedHooks.hooks {
+			_, ok := hookNames[funcName]
+			require.True(t, ok, funcName)
+		}
+	}
+}
+
+// codeLimitsTestModule imports env.f, defines main with 5 locals and another function without locals,
+// a table of 3 elements and 2 data segments.
+var codeLimitsTestModule = []byte{
+	0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00,
+	// types: () -> ()
+	0x01, 0x04, 0x01, 0x60, 0x00, 0x00,
+	// imports: env.f
+	0x02, 0x09, 0x01, 0x03, 'e', 'n', 'v', 0x01, 'f', 0x00, 0x00,
+	// functions
+	0x03, 0x03, 0x02, 0x00, 0x00,
+	// tables: a table of 3 elements
+	0x04, 0x04, 0x01, 0x70, 0x00, 0x03,
+	// exports: main
+	0x07, 0x08, 0x01, 0x04, 'm', 'a', 'i', 'n', 0x00, 0x01,
+	// code: main with 5 i32 locals, the other function without locals
+	0x0a, 0x09, 0x02, 0x04, 0x01, 0x05, 0x7f, 0x0b, 0x02, 0x00, 0x0b,
+	// data: 2 segments of 1 byte
+	0x0b, 0x0d, 0x02, 0x00, 0x41, 0x00, 0x0b, 0x01, 0xaa, 0x00, 0x41, 0x01, 0x0b, 0x01, 0xbb,
+}
+
+func TestVerifyCodeLimits(t *testing.T) {
+	atLimits := vmhost.CodeLimits{
+		MaxCodeSize:          uint64(len(codeLimitsTestModule)),
+		MaxFunctions:         2,
+		MaxLocalsPerFunction: 5,
+		MaxTableSize:         3,
+		MaxDataSegments:      2,
+		MaxImports:           1,
+	}
+	require.Nil(t, VerifyCodeLimits(codeLimitsTestModule, vmhost.CodeLimits{}))
+	require.Nil(t, VerifyCodeLimits(codeLimitsTestModule, atLimits))
+
+	testCases := []struct {
+		limits   vmhost.CodeLimits
+		expected error
+	}{
+		{vmhost.CodeLimits{MaxCodeSize: atLimits.MaxCodeSize - 1}, vmhost.ErrCodeSizeLimitExceeded},
+		{vmhost.CodeLimits{MaxFunctions: 1}, vmhost.ErrFunctionCountLimitExceeded},
+		{vmhost.CodeLimits{MaxLocalsPerFunction: 4}, vmhost.ErrLocalsCountLimitExceeded},
+		{vmhost.CodeLimits{MaxTableSize: 2}, vmhost.ErrTableSizeLimitExceeded},
+		{vmhost.CodeLimits{MaxDataSegments: 1}, vmhost.ErrDataSegmentCountLimitExceeded},
+		{vmhost.CodeLimits{MaxImports: 0, MaxFunctions: 3, MaxDataSegments: 1}, vmhost.ErrDataSegmentCountLimitExceeded},
+	}
+	for _, testCase := range testCases {
+		err := VerifyCodeLimits(codeLimitsTestModule, testCase.limits)
+		require.ErrorIs(t, err, testCase.expected)
+		require.ErrorIs(t, err, vmhost.ErrContractInvalid)
+	}
+
+	err := VerifyCodeLimits(codeLimitsTestModule, vmhost.CodeLimits{MaxLocalsPerFunction: 4})
+	require.Equal(t, "invalid contract code (locals per function limit exceeded): 5 locals in function main, limit 4", err.Error())
+
+	err = VerifyCodeLimits([]byte("not WASM"), vmhost.CodeLimits{MaxImports: 1})
+	require.ErrorIs(t, err, vmhost.ErrContractInvalid)
+}
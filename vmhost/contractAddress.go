@@ -0,0 +1,41 @@
+package vmhost
+
+import (
+	"github.com/multiversx/mx-chain-core-go/core"
+)
+
+// MaxContractAddressSaltLen is the maximum length of the salt of a contract address
+const MaxContractAddressSaltLen = 32
+
+// ComputeContractAddress derives the address of a contract deployed with a salt from the creator address,
+// the hash of the contract code and the salt, so that the address is known before the deployment.
+// Like the addresses derived from the nonce of the creator, the address starts with the smart contract
+// prefix followed by the VM type and ends with the shard identifier of the creator.
+func ComputeContractAddress(hasher HashComputer, creatorAddress []byte, salt []byte, codeHash []byte, vmType []byte) ([]byte, error) {
+	if len(creatorAddress) != AddressLen || len(codeHash) != HashLen || len(vmType) != core.VMTypeLen {
+		return nil, ErrInvalidContractAddressInput
+	}
+	if len(salt) > MaxContractAddressSaltLen {
+		return nil, ErrInvalidContractAddressSalt
+	}
+	if hasher.Size() < AddressLen {
+		return nil, ErrInvalidContractAddressInput
+	}
+
+	data := make([]byte, 0, AddressLen+HashLen+len(salt))
+	data = append(data, creatorAddress...)
+	data = append(data, codeHash...)
+	data = append(data, salt...)
+
+	address := make([]byte, AddressLen)
+	copy(address, hasher.Compute(string(data)))
+
+	prefixLen := core.NumInitCharactersForScAddress - core.VMTypeLen
+	for i := 0; i < prefixLen; i++ {
+		address[i] = 0
+	}
+	copy(address[prefixLen:core.NumInitCharactersForScAddress], vmType)
+	copy(address[AddressLen-core.ShardIdentiferLen:], creatorAddress[AddressLen-core.ShardIdentiferLen:])
+
+	return address, nil
+}
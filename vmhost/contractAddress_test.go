@@ -0,0 +1,39 @@
+package vmhost
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/hashing/blake2b"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComputeContractAddress(t *testing.T) {
+	hasher := blake2b.NewBlake2b()
+	creator := bytes.Repeat([]byte{0x07}, AddressLen)
+	codeHash := hasher.Compute("code")
+	vmType := []byte{5, 0}
+
+	address, err := ComputeContractAddress(hasher, creator, []byte("salt"), codeHash, vmType)
+	require.Nil(t, err)
+	require.Len(t, address, AddressLen)
+	require.True(t, core.IsSmartContractAddress(address))
+	require.Equal(t, vmType, address[8:10])
+	require.Equal(t, creator[30:], address[30:])
+
+	sameAddress, _ := ComputeContractAddress(hasher, creator, []byte("salt"), codeHash, vmType)
+	require.Equal(t, address, sameAddress)
+
+	otherSalt, _ := ComputeContractAddress(hasher, creator, []byte("other salt"), codeHash, vmType)
+	require.NotEqual(t, address, otherSalt)
+	otherCode, _ := ComputeContractAddress(hasher, creator, []byte("salt"), hasher.Compute("other code"), vmType)
+	require.NotEqual(t, address, otherCode)
+
+	_, err = ComputeContractAddress(hasher, creator, make([]byte, MaxContractAddressSaltLen+1), codeHash, vmType)
+	require.Equal(t, ErrInvalidContractAddressSalt, err)
+	_, err = ComputeContractAddress(hasher, creator[1:], nil, codeHash, vmType)
+	require.Equal(t, ErrInvalidContractAddressInput, err)
+	_, err = ComputeContractAddress(hasher, creator, nil, codeHash[1:], vmType)
+	require.Equal(t, ErrInvalidContractAddressInput, err)
+}
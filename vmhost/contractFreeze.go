@@ -0,0 +1,70 @@
+package vmhost
+
+import (
+	"encoding/binary"
+)
+
+// contractFreezeVersion starts the stored freeze, so that a freeze without allowed endpoints is not empty.
+const contractFreezeVersion = byte(1)
+
+// ContractFreeze records that the owner of a contract froze it, stored by the VM under FrozenContractKey.
+// While frozen, only the owner may call the contract, except for the allowed endpoints.
+type ContractFreeze struct {
+	AllowedEndpoints []string
+}
+
+// NewContractFreeze creates a freeze which lets anyone call the given endpoints.
+func NewContractFreeze(allowedEndpoints [][]byte) *ContractFreeze {
+	freeze := &ContractFreeze{AllowedEndpoints: make([]string, 0, len(allowedEndpoints))}
+	for _, endpoint := range allowedEndpoints {
+		freeze.AllowedEndpoints = append(freeze.AllowedEndpoints, string(endpoint))
+	}
+	return freeze
+}
+
+// IsEndpointAllowed returns true if anyone may call the given endpoint of the frozen contract.
+func (freeze *ContractFreeze) IsEndpointAllowed(functionName string) bool {
+	for _, endpoint := range freeze.AllowedEndpoints {
+		if endpoint == functionName {
+			return true
+		}
+	}
+	return false
+}
+
+// Serialize encodes the freeze to be stored.
+func (freeze *ContractFreeze) Serialize() []byte {
+	data := []byte{contractFreezeVersion}
+	for _, endpoint := range freeze.AllowedEndpoints {
+		data = binary.BigEndian.AppendUint32(data, uint32(len(endpoint)))
+		data = append(data, endpoint...)
+	}
+	return data
+}
+
+// DeserializeContractFreeze decodes a stored freeze; no data means that the contract is not frozen.
+func DeserializeContractFreeze(data []byte) (*ContractFreeze, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+	if data[0] != contractFreezeVersion {
+		return nil, ErrInvalidContractFreeze
+	}
+
+	freeze := &ContractFreeze{AllowedEndpoints: make([]string, 0)}
+	data = data[1:]
+	for len(data) > 0 {
+		if len(data) < 4 {
+			return nil, ErrInvalidContractFreeze
+		}
+		length := binary.BigEndian.Uint32(data)
+		data = data[4:]
+		if uint64(length) > uint64(len(data)) {
+			return nil, ErrInvalidContractFreeze
+		}
+		freeze.AllowedEndpoints = append(freeze.AllowedEndpoints, string(data[:length]))
+		data = data[length:]
+	}
+
+	return freeze, nil
+}
@@ -0,0 +1,33 @@
+package vmhost
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestContractFreeze_Serialize(t *testing.T) {
+	freeze := NewContractFreeze([][]byte{[]byte("unpause"), []byte("withdraw")})
+	deserialized, err := DeserializeContractFreeze(freeze.Serialize())
+	require.Nil(t, err)
+	require.Equal(t, freeze, deserialized)
+	require.True(t, deserialized.IsEndpointAllowed("withdraw"))
+	require.False(t, deserialized.IsEndpointAllowed("deposit"))
+
+	// a freeze without allowed endpoints is still stored
+	freeze = NewContractFreeze(nil)
+	require.NotEmpty(t, freeze.Serialize())
+	deserialized, err = DeserializeContractFreeze(freeze.Serialize())
+	require.Nil(t, err)
+	require.Equal(t, freeze, deserialized)
+
+	deserialized, err = DeserializeContractFreeze(nil)
+	require.Nil(t, err)
+	require.Nil(t, deserialized)
+
+	data := NewContractFreeze([][]byte{[]byte("unpause")}).Serialize()
+	_, err = DeserializeContractFreeze(data[:len(data)-1])
+	require.Equal(t, ErrInvalidContractFreeze, err)
+	_, err = DeserializeContractFreeze([]byte{0})
+	require.Equal(t, ErrInvalidContractFreeze, err)
+}
@@ -0,0 +1,39 @@
+package vmhost
+
+import (
+	"encoding/binary"
+)
+
+const deployInfoLen = AddressLen + 8 + 8
+
+// DeployInfo records who deployed a contract and in which block, stored by the VM under DeployInfoKey
+// when the contract is created.
+type DeployInfo struct {
+	Deployer       []byte
+	BlockNonce     uint64
+	BlockTimestamp uint64
+}
+
+// Serialize encodes the deploy info to be stored.
+func (info *DeployInfo) Serialize() []byte {
+	data := make([]byte, 0, deployInfoLen)
+	data = append(data, info.Deployer...)
+	data = binary.BigEndian.AppendUint64(data, info.BlockNonce)
+	return binary.BigEndian.AppendUint64(data, info.BlockTimestamp)
+}
+
+// DeserializeDeployInfo decodes a stored deploy info; no data means that the deployment was not recorded.
+func DeserializeDeployInfo(data []byte) (*DeployInfo, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+	if len(data) != deployInfoLen {
+		return nil, ErrInvalidDeployInfo
+	}
+
+	return &DeployInfo{
+		Deployer:       data[:AddressLen],
+		BlockNonce:     binary.BigEndian.Uint64(data[AddressLen : AddressLen+8]),
+		BlockTimestamp: binary.BigEndian.Uint64(data[AddressLen+8:]),
+	}, nil
+}
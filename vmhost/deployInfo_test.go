@@ -0,0 +1,26 @@
+package vmhost
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeployInfo_Serialize(t *testing.T) {
+	info := &DeployInfo{
+		Deployer:       bytes.Repeat([]byte{0x01}, AddressLen),
+		BlockNonce:     42,
+		BlockTimestamp: 1700000000,
+	}
+	deserialized, err := DeserializeDeployInfo(info.Serialize())
+	require.Nil(t, err)
+	require.Equal(t, info, deserialized)
+
+	deserialized, err = DeserializeDeployInfo(nil)
+	require.Nil(t, err)
+	require.Nil(t, deserialized)
+
+	_, err = DeserializeDeployInfo(info.Serialize()[1:])
+	require.Equal(t, ErrInvalidDeployInfo, err)
+}
@@ -257,6 +257,12 @@ var ErrNilEpochNotifier = errors.New("nil epoch notifier")
 // ErrNilEnableEpochsHandler signals that enable epochs handler is nil
 var ErrNilEnableEpochsHandler = errors.New("nil enable epochs handler")
 
+// ErrInvalidEpochGasSchedule signals that a gas schedule configured for an epoch cannot be applied
+var ErrInvalidEpochGasSchedule = errors.New("invalid epoch gas schedule")
+
+// ErrDuplicateEpochGasSchedule signals that more than one gas schedule was configured for the same start epoch
+var ErrDuplicateEpochGasSchedule = errors.New("duplicate epoch gas schedule")
+
 // ErrNoAsyncParentContext signals that load parent was called for an async call
 var ErrNoAsyncParentContext = errors.New("this should not be called for async calls (only callbacks and direct calls)")
 
@@ -304,3 +310,114 @@ var ErrOpcodeIsNotAllowed = errors.New("opcode is not allowed")
 
 // ErrInvalidSignature signals that a signature verification failed
 var ErrInvalidSignature = errors.New("signature is invalid")
+
+// ErrStorageDepositInsufficientFunds signals that the contract balance cannot cover the deposit for its storage growth
+var ErrStorageDepositInsufficientFunds = errors.New("insufficient funds for storage deposit")
+
+// ErrNilVMOutput signals that a nil VMOutput was provided
+var ErrNilVMOutput = errors.New("nil VMOutput")
+
+// ErrNoDecimalUnderThisHandle signals that there is no decimal for the given handle
+var ErrNoDecimalUnderThisHandle = errors.New("no decimal under the given handle")
+
+// ErrNegativeModulus signals that a negative modulus was provided to a modular operation
+var ErrNegativeModulus = errors.New("modulus is negative")
+
+// ErrNoModularInverse signals that the operand has no inverse for the given modulus
+var ErrNoModularInverse = errors.New("no modular inverse exists")
+
+// ErrGasEstimationFailed signals that the call does not succeed with the gas limit provided for the estimation
+var ErrGasEstimationFailed = errors.New("call fails with the gas provided for estimation")
+
+// ErrInvalidMaxGasRefundPercentage signals that the maximum gas refund percentage is above 100
+var ErrInvalidMaxGasRefundPercentage = errors.New("maximum gas refund percentage must not exceed 100")
+
+// ErrExecutionBudgetExceeded signals that the transaction executed more instructions than the instruction budget of the VM
+var ErrExecutionBudgetExceeded = errors.New("execution budget exceeded")
+
+// ErrInvalidExecutionWatchdog signals that the execution watchdog or its instruction budget are not valid
+var ErrInvalidExecutionWatchdog = errors.New("invalid execution watchdog")
+
+// ErrInvalidUpgradePolicy signals that the upgrade policy is malformed or cannot be met
+var ErrInvalidUpgradePolicy = errors.New("invalid upgrade policy")
+
+// ErrInvalidPendingUpgrade signals that the stored pending upgrade is malformed
+var ErrInvalidPendingUpgrade = errors.New("invalid pending upgrade")
+
+// ErrNoPendingUpgrade signals that the contract requires upgrades to be proposed, but none is pending
+var ErrNoPendingUpgrade = errors.New("no pending upgrade")
+
+// ErrPendingUpgradeExists signals that the upgrade policy cannot change while an upgrade is pending
+var ErrPendingUpgradeExists = errors.New("an upgrade is already pending")
+
+// ErrUpgradeCodeNotProposed signals that the code or the code metadata differ from the proposed upgrade
+var ErrUpgradeCodeNotProposed = errors.New("upgrade code was not proposed")
+
+// ErrUpgradeTimelocked signals that the activation round of the proposed upgrade has not been reached
+var ErrUpgradeTimelocked = errors.New("upgrade is timelocked")
+
+// ErrUpgradeTimelockWithoutDelay signals that the contract requires timelocked upgrades, but its upgrade policy has no delay
+var ErrUpgradeTimelockWithoutDelay = errors.New("timelocked upgrade requires an upgrade policy with a delay")
+
+// ErrUpgradeNotApproved signals that the proposed upgrade does not have enough approvals
+var ErrUpgradeNotApproved = errors.New("upgrade does not have enough approvals")
+
+// ErrUpgradeApproverNotAllowed signals that the caller is not an approver of the upgrade policy
+var ErrUpgradeApproverNotAllowed = errors.New("caller is not an upgrade approver")
+
+// ErrCodeSizeLimitExceeded signals that the contract code is larger than the configured limit
+var ErrCodeSizeLimitExceeded = fmt.Errorf("%w (code size limit exceeded)", ErrContractInvalid)
+
+// ErrFunctionCountLimitExceeded signals that the contract defines more functions than the configured limit
+var ErrFunctionCountLimitExceeded = fmt.Errorf("%w (function count limit exceeded)", ErrContractInvalid)
+
+// ErrLocalsCountLimitExceeded signals that a function of the contract declares more locals than the configured limit
+var ErrLocalsCountLimitExceeded = fmt.Errorf("%w (locals per function limit exceeded)", ErrContractInvalid)
+
+// ErrTableSizeLimitExceeded signals that a table of the contract is larger than the configured limit
+var ErrTableSizeLimitExceeded = fmt.Errorf("%w (table size limit exceeded)", ErrContractInvalid)
+
+// ErrDataSegmentCountLimitExceeded signals that the contract has more data segments than the configured limit
+var ErrDataSegmentCountLimitExceeded = fmt.Errorf("%w (data segment count limit exceeded)", ErrContractInvalid)
+
+// ErrImportCountLimitExceeded signals that the contract has more imports than the configured limit
+var ErrImportCountLimitExceeded = fmt.Errorf("%w (import count limit exceeded)", ErrContractInvalid)
+
+// ErrDuplicateEpochCodeLimits signals that more than one set of code limits was configured for the same start epoch
+var ErrDuplicateEpochCodeLimits = errors.New("duplicate epoch code limits")
+
+// ErrInvalidContractAddressSalt signals that the salt of a contract address is longer than allowed
+var ErrInvalidContractAddressSalt = errors.New("invalid contract address salt")
+
+// ErrInvalidContractAddressInput signals that the creator address or the code hash of a contract address have a wrong length
+var ErrInvalidContractAddressInput = errors.New("invalid contract address input")
+
+// ErrInvalidDeployInfo signals that the stored deploy info of a contract could not be decoded
+var ErrInvalidDeployInfo = errors.New("invalid deploy info")
+
+// ErrQueryGasLimitExceeded signals that a query was provided more gas than the gas limit configured for queries
+var ErrQueryGasLimitExceeded = errors.New("gas provided exceeds the query gas limit")
+
+// ErrTransferInQuery signals that a query attempted to transfer EGLD or ESDT tokens
+var ErrTransferInQuery = errors.New("transfers are not allowed in queries")
+
+// ErrAsyncCallInQuery signals that a query attempted to register an async call
+var ErrAsyncCallInQuery = errors.New("async calls are not allowed in queries")
+
+// ErrInvalidContractFreeze signals that the stored freeze of a contract could not be decoded
+var ErrInvalidContractFreeze = errors.New("invalid contract freeze")
+
+// ErrContractFrozen signals that a frozen contract was called by another address than its owner on an endpoint which is not allowed
+var ErrContractFrozen = errors.New("contract is frozen")
+
+// ErrContractNotFrozen signals that unfreezing was requested for a contract which is not frozen
+var ErrContractNotFrozen = errors.New("contract is not frozen")
+
+// ErrFreezeNotAllowed signals that freezing or unfreezing a contract was requested by another address than its owner
+var ErrFreezeNotAllowed = errors.New("freeze not allowed")
+
+// ErrCodeHashNotRegistered signals that a contract references a code hash which is not in the code registry
+var ErrCodeHashNotRegistered = errors.New("code hash not registered")
+
+// ErrInvalidEVMAbiValueHandles signals that the EVM ABI values are not given as a managed vector of handles
+var ErrInvalidEVMAbiValueHandles = errors.New("invalid managed vector of EVM ABI value handles")
@@ -11,4 +11,46 @@ const (
 
 	// UseGasBoundedShouldFailExecutionFlag defines the flag that activates failing of execution if gas bounded check fails
 	UseGasBoundedShouldFailExecutionFlag core.EnableEpochFlag = "UseGasBoundedShouldFailExecutionFlag"
+
+	// TransientStorageFlag defines the flag that allows contracts to import the transient storage hooks
+	TransientStorageFlag core.EnableEpochFlag = "TransientStorageFlag"
+
+	// StorageDepositFlag defines the flag that enables the storage deposit accounting and allows contracts to import the storage deposit hook
+	StorageDepositFlag core.EnableEpochFlag = "StorageDepositFlag"
+
+	// DecimalFlag defines the flag that allows contracts to import the fixed-point decimal hooks
+	DecimalFlag core.EnableEpochFlag = "DecimalFlag"
+
+	// ModularArithmeticFlag defines the flag that allows contracts to import the modular arithmetic big int hooks
+	ModularArithmeticFlag core.EnableEpochFlag = "ModularArithmeticFlag"
+
+	// GasRefundCapFlag defines the flag that activates the capped gas refunds for released storage
+	GasRefundCapFlag core.EnableEpochFlag = "GasRefundCapFlag"
+
+	// MemoryGrowthGasFlag defines the flag that activates charging gas for each newly allocated WASM memory page
+	MemoryGrowthGasFlag core.EnableEpochFlag = "MemoryGrowthGasFlag"
+
+	// EVMAbiFlag defines the flag that allows contracts to import the EVM ABI hooks
+	EVMAbiFlag core.EnableEpochFlag = "EVMAbiFlag"
+
+	// ContractABIArgumentsCheckFlag defines the flag that activates rejecting calls whose number of arguments does not match the embedded contract ABI
+	ContractABIArgumentsCheckFlag core.EnableEpochFlag = "ContractABIArgumentsCheckFlag"
+
+	// UpgradePolicyFlag defines the flag that activates the timelocked and multi-approval upgrade policies
+	UpgradePolicyFlag core.EnableEpochFlag = "UpgradePolicyFlag"
+
+	// SaltedDeployFlag defines the flag that allows contracts to import the salted deploy hooks
+	SaltedDeployFlag core.EnableEpochFlag = "SaltedDeployFlag"
+
+	// ContractDeployInfoFlag defines the flag that activates recording the deployer and the deploy block of new contracts
+	ContractDeployInfoFlag core.EnableEpochFlag = "ContractDeployInfoFlag"
+
+	// EndpointAccessControlFlag defines the flag that activates enforcing the endpoint attributes declared in the contract code
+	EndpointAccessControlFlag core.EnableEpochFlag = "EndpointAccessControlFlag"
+
+	// ContractFreezeFlag defines the flag that activates freezing contracts by their owners
+	ContractFreezeFlag core.EnableEpochFlag = "ContractFreezeFlag"
+
+	// CodeRegistryFlag defines the flag that activates registering the codes of contracts and deploying them by reference
+	CodeRegistryFlag core.EnableEpochFlag = "CodeRegistryFlag"
 )
@@ -0,0 +1,122 @@
+package vmhost
+
+import (
+	"reflect"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/multiversx/mx-chain-vm-go/executor"
+)
+
+// Categories of the gas consumed by VM hooks, as reported in GasBreakdown.VMHooks
+const (
+	GasCategoryStorage       = "storage"
+	GasCategoryCrypto        = "crypto"
+	GasCategoryManagedBuffer = "managedBuffer"
+	GasCategoryBigInt        = "bigInt"
+	GasCategoryTransfers     = "transfers"
+	GasCategoryMemory        = "memory"
+	GasCategoryOther         = "other"
+)
+
+// vmHookGroupGasCategories assigns a category to all the hooks of a group generated by eiGen
+// into executor.VMHooks. The hooks of the other groups are reported under GasCategoryOther.
+var vmHookGroupGasCategories = []struct {
+	group    reflect.Type
+	category string
+}{
+	{reflect.TypeOf((*executor.BigIntVMHooks)(nil)).Elem(), GasCategoryBigInt},
+	{reflect.TypeOf((*executor.ManagedBufferVMHooks)(nil)).Elem(), GasCategoryManagedBuffer},
+	{reflect.TypeOf((*executor.CryptoVMHooks)(nil)).Elem(), GasCategoryCrypto},
+}
+
+// vmHookGasCategories lists the hooks whose category differs from the one of their group,
+// mostly from the Main and Managed groups, which mix hooks of all kinds.
+var vmHookGasCategories = map[string]string{
+	"storageStore":                  GasCategoryStorage,
+	"storageLoadLength":             GasCategoryStorage,
+	"storageLoadFromAddress":        GasCategoryStorage,
+	"storageLoad":                   GasCategoryStorage,
+	"setStorageLock":                GasCategoryStorage,
+	"getStorageLock":                GasCategoryStorage,
+	"isStorageLocked":               GasCategoryStorage,
+	"clearStorageLock":              GasCategoryStorage,
+	"transientStore":                GasCategoryStorage,
+	"transientLoad":                 GasCategoryStorage,
+	"getStorageDeposit":             GasCategoryStorage,
+	"managedRegisterCode":           GasCategoryStorage,
+	"mBufferStorageStore":           GasCategoryStorage,
+	"mBufferStorageLoad":            GasCategoryStorage,
+	"mBufferStorageLoadFromAddress": GasCategoryStorage,
+	"smallIntStorageStoreUnsigned":  GasCategoryStorage,
+	"smallIntStorageStoreSigned":    GasCategoryStorage,
+	"smallIntStorageLoadUnsigned":   GasCategoryStorage,
+	"smallIntStorageLoadSigned":     GasCategoryStorage,
+	"int64storageStore":             GasCategoryStorage,
+	"int64storageLoad":              GasCategoryStorage,
+
+	"transferValue":                            GasCategoryTransfers,
+	"transferValueExecute":                     GasCategoryTransfers,
+	"transferESDTExecute":                      GasCategoryTransfers,
+	"transferESDTNFTExecute":                   GasCategoryTransfers,
+	"multiTransferESDTNFTExecute":              GasCategoryTransfers,
+	"createAsyncCall":                          GasCategoryTransfers,
+	"setAsyncContextCallback":                  GasCategoryTransfers,
+	"asyncCall":                                GasCategoryTransfers,
+	"upgradeContract":                          GasCategoryTransfers,
+	"upgradeFromSourceContract":                GasCategoryTransfers,
+	"deleteContract":                           GasCategoryTransfers,
+	"executeOnSameContext":                     GasCategoryTransfers,
+	"executeOnDestContext":                     GasCategoryTransfers,
+	"executeReadOnly":                          GasCategoryTransfers,
+	"createContract":                           GasCategoryTransfers,
+	"deployFromSourceContract":                 GasCategoryTransfers,
+	"managedAsyncCall":                         GasCategoryTransfers,
+	"managedCreateAsyncCall":                   GasCategoryTransfers,
+	"managedUpgradeFromSourceContract":         GasCategoryTransfers,
+	"managedUpgradeContract":                   GasCategoryTransfers,
+	"managedDeleteContract":                    GasCategoryTransfers,
+	"managedDeployFromSourceContract":          GasCategoryTransfers,
+	"managedDeployFromSourceContractWithSalt":  GasCategoryTransfers,
+	"managedDeployFromCodeHash":                GasCategoryTransfers,
+	"managedCreateContract":                    GasCategoryTransfers,
+	"managedCreateContractWithSalt":            GasCategoryTransfers,
+	"managedExecuteReadOnly":                   GasCategoryTransfers,
+	"managedExecuteOnSameContext":              GasCategoryTransfers,
+	"managedExecuteOnDestContext":              GasCategoryTransfers,
+	"managedMultiTransferESDTNFTExecute":       GasCategoryTransfers,
+	"managedMultiTransferESDTNFTExecuteByUser": GasCategoryTransfers,
+	"managedTransferValueExecute":              GasCategoryTransfers,
+
+	"managedBufferToHex": GasCategoryManagedBuffer,
+}
+
+var gasCategoriesOfVMHooks = makeGasCategoriesOfVMHooks()
+
+func makeGasCategoriesOfVMHooks() map[string]string {
+	categories := make(map[string]string)
+	for _, groupCategory := range vmHookGroupGasCategories {
+		for i := 0; i < groupCategory.group.NumMethod(); i++ {
+			categories[vmHookName(groupCategory.group.Method(i).Name)] = groupCategory.category
+		}
+	}
+	for hookName, category := range vmHookGasCategories {
+		categories[hookName] = category
+	}
+	return categories
+}
+
+// vmHookName reverts the capitalization eiGen applies to the hook names in the executor.VMHooks methods.
+func vmHookName(methodName string) string {
+	first, size := utf8.DecodeRuneInString(methodName)
+	return string(unicode.ToLower(first)) + methodName[size:]
+}
+
+// GasCategoryOfVMHook returns the category under which the gas consumed by the given VM hook is reported.
+func GasCategoryOfVMHook(hookName string) string {
+	category, ok := gasCategoriesOfVMHooks[hookName]
+	if !ok {
+		return GasCategoryOther
+	}
+	return category
+}
@@ -0,0 +1,54 @@
+package vmhost
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/multiversx/mx-chain-vm-go/executor"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGasCategoryOfVMHook(t *testing.T) {
+	t.Parallel()
+
+	expectedCategories := map[string]string{
+		"storageStore":                    GasCategoryStorage,
+		"mBufferStorageLoad":              GasCategoryStorage,
+		"transientStore":                  GasCategoryStorage,
+		"managedSha256":                   GasCategoryCrypto,
+		"verifyEd25519":                   GasCategoryCrypto,
+		"scalarMultEC":                    GasCategoryCrypto,
+		"mBufferAppend":                   GasCategoryManagedBuffer,
+		"managedBufferToHex":              GasCategoryManagedBuffer,
+		"bigIntAdd":                       GasCategoryBigInt,
+		"transferValueExecute":            GasCategoryTransfers,
+		"managedExecuteOnDestContext":     GasCategoryTransfers,
+		"createAsyncCall":                 GasCategoryTransfers,
+		"managedDeployFromSourceContract": GasCategoryTransfers,
+		"isSmartContract":                 GasCategoryOther,
+		"getBlockRandomSeed":              GasCategoryOther,
+		"bigFloatAdd":                     GasCategoryOther,
+		"int64storageStore":               GasCategoryStorage,
+		"managedVerifySecp256r1":          GasCategoryCrypto,
+		"managedEVMAbiEncode":             GasCategoryOther,
+		"unknownHook":                     GasCategoryOther,
+	}
+	for hookName, category := range expectedCategories {
+		require.Equal(t, category, GasCategoryOfVMHook(hookName), hookName)
+	}
+}
+
+func TestVMHookGasCategories_KnownHooks(t *testing.T) {
+	t.Parallel()
+
+	vmHooks := reflect.TypeOf((*executor.VMHooks)(nil)).Elem()
+	hookNames := make(map[string]struct{}, vmHooks.NumMethod())
+	for i := 0; i < vmHooks.NumMethod(); i++ {
+		hookNames[vmHookName(vmHooks.Method(i).Name)] = struct{}{}
+	}
+
+	for hookName := range vmHookGasCategories {
+		_, ok := hookNames[hookName]
+		require.True(t, ok, hookName)
+	}
+}
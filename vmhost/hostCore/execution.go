@@ -11,6 +11,7 @@ import (
 	"github.com/multiversx/mx-chain-core-go/core/check"
 	"github.com/multiversx/mx-chain-core-go/data/vm"
 	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	"github.com/multiversx/mx-chain-vm-go/contractabi"
 	"github.com/multiversx/mx-chain-vm-go/executor"
 	"github.com/multiversx/mx-chain-vm-go/math"
 	"github.com/multiversx/mx-chain-vm-go/vmhost"
@@ -76,7 +77,13 @@ func (host *vmHost) doRunSmartContractCreate(input *vmcommon.ContractCreateInput
 	return vmOutput
 }
 
-func (host *vmHost) performCodeDeployment(input vmhost.CodeDeployInput, initFunction func() error) (*vmcommon.VMOutput, error) {
+// performCodeDeployment deploys the given code and calls its init function. The prepareFunction runs on the new
+// instance before the init function, so that the gas it uses is paid from the gas left after the deployment cost.
+func (host *vmHost) performCodeDeployment(
+	input vmhost.CodeDeployInput,
+	prepareFunction func(vmhost.CodeDeployInput) error,
+	initFunction func() error,
+) (*vmcommon.VMOutput, error) {
 	log.Trace("performCodeDeployment", "address", input.ContractAddress, "len(code)", len(input.ContractCode), "metadata", input.ContractCodeMetadata)
 
 	_, _, metering, output, runtime, _, _ := host.GetContexts()
@@ -92,7 +99,12 @@ func (host *vmHost) performCodeDeployment(input vmhost.CodeDeployInput, initFunc
 	err = runtime.StartWasmerInstance(input.ContractCode, metering.GetGasForExecution(), true)
 	if err != nil {
 		log.Trace("performCodeDeployment/StartWasmerInstance", "err", err)
-		return nil, vmhost.ErrContractInvalid
+		return nil, contractInvalidError(err)
+	}
+
+	err = prepareFunction(input)
+	if err != nil {
+		return nil, err
 	}
 
 	err = initFunction()
@@ -108,11 +120,11 @@ func (host *vmHost) performCodeDeployment(input vmhost.CodeDeployInput, initFunc
 }
 
 func (host *vmHost) performCodeDeploymentAtContractCreate(input vmhost.CodeDeployInput) (*vmcommon.VMOutput, error) {
-	return host.performCodeDeployment(input, host.callInitFunction)
+	return host.performCodeDeployment(input, host.prepareContractCreate, host.callInitFunction)
 }
 
 func (host *vmHost) performCodeDeploymentAtContractUpgrade(input vmhost.CodeDeployInput) (*vmcommon.VMOutput, error) {
-	return host.performCodeDeployment(input, host.callUpgradeFunction)
+	return host.performCodeDeployment(input, host.prepareContractUpgrade, host.callUpgradeFunction)
 }
 
 // doRunSmartContractUpgrade upgrades a contract directly
@@ -169,6 +181,15 @@ func (host *vmHost) doRunSmartContractUpgrade(input *vmcommon.ContractCallInput)
 	return vmOutput
 }
 
+// contractInvalidError keeps the errors which already explain why the contract code is invalid, such as the
+// violated code limit, and replaces the other errors of the executor with ErrContractInvalid.
+func contractInvalidError(err error) error {
+	if errors.Is(err, vmhost.ErrContractInvalid) {
+		return err
+	}
+	return vmhost.ErrContractInvalid
+}
+
 func (host *vmHost) checkGasForGetCode(input *vmcommon.ContractCallInput, metering vmhost.MeteringContext) error {
 	getCodeBaseCost := metering.GasSchedule().BaseOperationCost.GetCode
 	if input.GasProvided < getCodeBaseCost {
@@ -208,6 +229,7 @@ func (host *vmHost) doRunSmartContractCall(input *vmcommon.ContractCallInput) *v
 		if vmOutput == nil || vmOutput.ReturnCode == vmcommon.ExecutionFailed {
 			host.Runtime().CleanInstance()
 		}
+		storage.ClearTransientStorage()
 	}()
 
 	runtime.InitStateFromContractCallInput(input)
@@ -243,6 +265,14 @@ func (host *vmHost) doRunSmartContractCall(input *vmcommon.ContractCallInput) *v
 		return vmOutput
 	}
 
+	// the freeze is read only once the gas provided covers the code of the contract
+	err = host.checkContractNotFrozen(input)
+	if err != nil {
+		log.Trace("doRunSmartContractCall", "error", err)
+		vmOutput = output.CreateVMOutputInCaseOfError(err)
+		return vmOutput
+	}
+
 	err = runtime.StartWasmerInstance(contract, metering.GetGasForExecution(), false)
 	if err != nil {
 		vmOutput = output.CreateVMOutputInCaseOfError(vmhost.ErrContractInvalid)
@@ -533,14 +563,15 @@ func (host *vmHost) finishExecuteOnDestContext(executeErr error) *vmcommon.VMOut
 
 	// Restore the previous context states
 	managedTypes.PopSetActiveState()
-	storage.PopSetActiveState()
 
 	if vmOutput.ReturnCode == vmcommon.Ok {
 		metering.PopMergeActiveState()
 		output.PopMergeActiveState()
+		storage.PopMergeActiveState()
 	} else {
 		metering.PopSetActiveState()
 		output.PopSetActiveState()
+		storage.PopSetActiveState()
 	}
 
 	log.Trace("ExecuteOnDestContext finished", "sc", string(runtime.GetContextAddress()), "function", runtime.FunctionName())
@@ -571,10 +602,10 @@ func (host *vmHost) ExecuteOnSameContext(input *vmcommon.ContractCallInput) erro
 		return vmhost.ErrBuiltinCallOnSameContextDisallowed
 	}
 
-	managedTypes, blockchain, metering, output, runtime, _, _ := host.GetContexts()
+	managedTypes, blockchain, metering, output, runtime, _, storage := host.GetContexts()
 
-	// Back up the states of the contexts (except Storage and Async, which aren't affected
-	// by ExecuteOnSameContext())
+	// Back up the states of the contexts (except Async, which isn't affected by
+	// ExecuteOnSameContext()); Storage is backed up only for its transient storage
 	managedTypes.PushState()
 	managedTypes.InitState()
 	output.PushState()
@@ -592,6 +623,7 @@ func (host *vmHost) ExecuteOnSameContext(input *vmcommon.ContractCallInput) erro
 	metering.InitStateFromContractCallInput(&input.VMInput)
 
 	blockchain.PushState()
+	storage.PushState()
 
 	var err error
 
@@ -617,7 +649,7 @@ func (host *vmHost) ExecuteOnSameContext(input *vmcommon.ContractCallInput) erro
 }
 
 func (host *vmHost) finishExecuteOnSameContext(executeErr error) {
-	managedTypes, blockchain, metering, output, runtime, _, _ := host.GetContexts()
+	managedTypes, blockchain, metering, output, runtime, _, storage := host.GetContexts()
 
 	if output.ReturnCode() != vmcommon.Ok || executeErr != nil {
 		// Execution failed: restore contexts as if the execution didn't happen.
@@ -625,6 +657,7 @@ func (host *vmHost) finishExecuteOnSameContext(executeErr error) {
 		metering.PopSetActiveState()
 		output.PopSetActiveState()
 		blockchain.PopSetActiveState()
+		storage.PopSetActiveState()
 		runtime.PopSetActiveState()
 		return
 	}
@@ -637,6 +670,7 @@ func (host *vmHost) finishExecuteOnSameContext(executeErr error) {
 	metering.PopMergeActiveState()
 	output.PopDiscard()
 	blockchain.PopDiscard()
+	storage.PopDiscard()
 	managedTypes.PopSetActiveState()
 	runtime.PopSetActiveState()
 	// Restore remaining gas to the caller (parent) Wasmer instance
@@ -679,8 +713,9 @@ func (host *vmHost) IsBuiltinFunctionCall(data []byte) bool {
 	return host.IsBuiltinFunctionName(functionName)
 }
 
-// CreateNewContract creates a new contract indirectly (from another Smart Contract)
-func (host *vmHost) CreateNewContract(input *vmcommon.ContractCreateInput, createContractCallType int) (newContractAddress []byte, err error) {
+// CreateNewContract creates a new contract indirectly (from another Smart Contract). Without a salt, the address of the
+// new contract is derived from the nonce of the creator, otherwise from the salt and the hash of the contract code.
+func (host *vmHost) CreateNewContract(input *vmcommon.ContractCreateInput, createContractCallType int, salt []byte) (newContractAddress []byte, err error) {
 	newContractAddress = nil
 	err = nil
 
@@ -698,7 +733,18 @@ func (host *vmHost) CreateNewContract(input *vmcommon.ContractCreateInput, creat
 		ContractAddress:      nil,
 		CodeDeployerAddress:  input.CallerAddr,
 	}
-	err = metering.DeductInitialGasForIndirectDeployment(codeDeployInput)
+	// a contract deployed by reference pays for compiling the whole registered code, like one uploading it,
+	// because whether a compiled artifact is already cached differs between nodes
+	compilationInput := codeDeployInput
+	referencedCodeHash, isCodeReference := vmhost.ParseCodeReference(input.ContractCode)
+	if isCodeReference {
+		compilationInput.ContractCode, err = host.getRegisteredCode(referencedCodeHash)
+		if err != nil {
+			return
+		}
+	}
+
+	err = metering.DeductInitialGasForIndirectDeployment(compilationInput)
 	if err != nil {
 		return
 	}
@@ -708,12 +754,20 @@ func (host *vmHost) CreateNewContract(input *vmcommon.ContractCreateInput, creat
 		return
 	}
 
-	newContractAddress, err = blockchain.NewAddress(input.CallerAddr)
+	if salt == nil {
+		newContractAddress, err = blockchain.NewAddress(input.CallerAddr)
+	} else {
+		codeHash := referencedCodeHash
+		if !isCodeReference {
+			codeHash = host.hasher.Compute(string(input.ContractCode))
+		}
+		newContractAddress, err = blockchain.NewSaltedAddress(input.CallerAddr, salt, codeHash)
+	}
 	if err != nil {
 		return
 	}
 
-	if blockchain.AccountExists(newContractAddress) {
+	if blockchain.AccountExists(newContractAddress) || output.HasDeployedCode(newContractAddress) {
 		err = vmhost.ErrDeploymentOverExistingAccount
 		return
 	}
@@ -727,7 +781,19 @@ func (host *vmHost) CreateNewContract(input *vmcommon.ContractCreateInput, creat
 		}
 	}()
 
-	runtime.MustVerifyNextContractCode()
+	err = host.recordDeployInfo(newContractAddress, input.CallerAddr)
+	if err != nil {
+		return
+	}
+	err = host.recordUpgradeConditions(newContractAddress, input.ContractCodeMetadata)
+	if err != nil {
+		return
+	}
+
+	// the registered code was verified when it was first uploaded
+	if !isCodeReference {
+		runtime.MustVerifyNextContractCode()
+	}
 
 	initCallInput := &vmcommon.ContractCallInput{
 		RecipientAddr:     newContractAddress,
@@ -781,6 +847,172 @@ func (host *vmHost) checkUpgradePermission(vmInput *vmcommon.ContractCallInput)
 	return vmhost.ErrUpgradeNotAllowed
 }
 
+// prepareContractCreate records the deploy info and the upgrade conditions of a contract deployed directly.
+func (host *vmHost) prepareContractCreate(input vmhost.CodeDeployInput) error {
+	err := host.recordDeployInfo(input.ContractAddress, input.CodeDeployerAddress)
+	if err != nil {
+		return err
+	}
+
+	return host.recordUpgradeConditions(input.ContractAddress, input.ContractCodeMetadata)
+}
+
+// prepareContractUpgrade checks the upgrade policy of a contract and records the upgrade conditions of its new code.
+func (host *vmHost) prepareContractUpgrade(input vmhost.CodeDeployInput) error {
+	err := host.checkUpgradePolicy(input.ContractAddress, input.ContractCode, input.ContractCodeMetadata)
+	if err != nil {
+		log.Trace("prepareContractUpgrade", "error", err)
+		return err
+	}
+
+	return host.recordUpgradeConditions(input.ContractAddress, input.ContractCodeMetadata)
+}
+
+// checkUpgradePolicy verifies that an upgrade of a contract whose code metadata
+// restricts upgrades applies exactly the proposed code and code metadata, after
+// the delay and with the approvals required by the upgrade policy of the
+// contract. The pending upgrade is consumed by the upgrade. The reads of the
+// policy are paid like storage loads, from the gas left after the deployment cost.
+func (host *vmHost) checkUpgradePolicy(contractAddress []byte, code []byte, codeMetadata []byte) error {
+	if !host.enableEpochsHandler.IsFlagEnabled(vmhost.UpgradePolicyFlag) {
+		return nil
+	}
+
+	contract, err := host.Blockchain().GetUserAccount(contractAddress)
+	if err != nil {
+		return err
+	}
+	if check.IfNilReflect(contract) {
+		return vmhost.ErrNilContract
+	}
+
+	storage := host.Storage()
+	recordedConditions, trieDepth, usedCache, err := storage.GetUpgradeConditions(contractAddress)
+	if err != nil {
+		return err
+	}
+	err = host.useGasForUpgradePolicyLoad(trieDepth, usedCache)
+	if err != nil {
+		return err
+	}
+	conditions := vmhost.UpgradeConditionsFromCodeMetadata(contract.GetCodeMetadata()).Union(recordedConditions)
+	if !conditions.IsRestricted() {
+		return nil
+	}
+
+	pending, trieDepth, usedCache, err := storage.GetPendingUpgrade(contractAddress)
+	if err != nil {
+		return err
+	}
+	err = host.useGasForUpgradePolicyLoad(trieDepth, usedCache)
+	if err != nil {
+		return err
+	}
+	if pending == nil {
+		return vmhost.ErrNoPendingUpgrade
+	}
+
+	codeHash := host.hasher.Compute(string(code))
+	if !bytes.Equal(pending.CodeHash, codeHash) || !bytes.Equal(pending.CodeMetadata, codeMetadata) {
+		return vmhost.ErrUpgradeCodeNotProposed
+	}
+
+	policy, trieDepth, usedCache, err := storage.GetUpgradePolicy(contractAddress)
+	if err != nil {
+		return err
+	}
+	err = host.useGasForUpgradePolicyLoad(trieDepth, usedCache)
+	if err != nil {
+		return err
+	}
+
+	if conditions.Timelocked {
+		if policy.DelayRounds == 0 {
+			return vmhost.ErrUpgradeTimelockWithoutDelay
+		}
+		if host.Blockchain().CurrentRound() < pending.ActivationRound {
+			return vmhost.ErrUpgradeTimelocked
+		}
+	}
+
+	if conditions.MultiApproval {
+		if policy.Threshold == 0 || uint32(len(pending.Approvals)) < policy.Threshold {
+			return vmhost.ErrUpgradeNotApproved
+		}
+	}
+
+	_, err = storage.SetProtectedStorageToAddressUnmetered(contractAddress, storage.GetVmProtectedPrefix(vmhost.PendingUpgradeKey), nil)
+	return err
+}
+
+func (host *vmHost) useGasForUpgradePolicyLoad(trieDepth uint32, usedCache bool) error {
+	return host.Storage().UseGasForStorageLoad(
+		vmhost.UpgradeFunctionName,
+		int64(trieDepth),
+		host.Metering().GasSchedule().BaseOpsAPICost.StorageLoad,
+		usedCache)
+}
+
+// recordUpgradeConditions stores the upgrade conditions of the code metadata deployed to a contract, which
+// checkUpgradePolicy keeps enforcing even if a later rewrite of the code metadata drops their bits.
+func (host *vmHost) recordUpgradeConditions(contractAddress []byte, codeMetadata []byte) error {
+	if !host.enableEpochsHandler.IsFlagEnabled(vmhost.UpgradePolicyFlag) {
+		return nil
+	}
+
+	storage := host.Storage()
+	conditions := vmhost.UpgradeConditionsFromCodeMetadata(codeMetadata)
+	_, err := storage.SetProtectedStorageToAddressUnmetered(contractAddress, storage.GetVmProtectedPrefix(vmhost.UpgradeConditionsKey), conditions.Serialize())
+	return err
+}
+
+// recordDeployInfo stores the deployer and the current block in the protected storage of a new contract,
+// to be read by other contracts through managedGetCodeDeployer and managedGetContractDeployInfo. The write is
+// paid per byte, like any storage write, by the running instance: the new contract for a direct deployment,
+// the deployer for an indirect one.
+func (host *vmHost) recordDeployInfo(contractAddress []byte, deployerAddress []byte) error {
+	if !host.enableEpochsHandler.IsFlagEnabled(vmhost.ContractDeployInfoFlag) {
+		return nil
+	}
+
+	blockchain := host.Blockchain()
+	storage := host.Storage()
+	deployInfo := &vmhost.DeployInfo{
+		Deployer:       deployerAddress,
+		BlockNonce:     blockchain.CurrentNonce(),
+		BlockTimestamp: blockchain.CurrentTimeStamp(),
+	}
+
+	serializedDeployInfo := deployInfo.Serialize()
+	metering := host.Metering()
+	gasToUse := math.MulUint64(metering.GasSchedule().BaseOperationCost.StorePerByte, uint64(len(serializedDeployInfo)))
+	err := metering.UseGasBounded(gasToUse)
+	if err != nil {
+		return err
+	}
+
+	_, err = storage.SetProtectedStorageToAddressUnmetered(contractAddress, storage.GetVmProtectedPrefix(vmhost.DeployInfoKey), serializedDeployInfo)
+	return err
+}
+
+// getRegisteredCode returns the code in the code registry which a contract deployed by reference to
+// the given code hash would run.
+func (host *vmHost) getRegisteredCode(codeHash []byte) ([]byte, error) {
+	if !host.enableEpochsHandler.IsFlagEnabled(vmhost.CodeRegistryFlag) {
+		return nil, vmhost.ErrCodeHashNotRegistered
+	}
+
+	registeredCode, _, err := host.Storage().GetRegisteredCode(codeHash)
+	if err != nil {
+		return nil, err
+	}
+	if len(registeredCode) == 0 {
+		return nil, vmhost.ErrCodeHashNotRegistered
+	}
+
+	return registeredCode, nil
+}
+
 // executeUpgrade upgrades a contract indirectly (from another contract). This
 // function follows the convention of executeSmartContractCall().
 func (host *vmHost) executeUpgrade(input *vmcommon.ContractCallInput) error {
@@ -814,7 +1046,12 @@ func (host *vmHost) executeUpgrade(input *vmcommon.ContractCallInput) error {
 	err = runtime.StartWasmerInstance(codeDeployInput.ContractCode, metering.GetGasForExecution(), true)
 	if err != nil {
 		log.Trace("performCodeDeployment/StartWasmerInstance", "err", err)
-		return vmhost.ErrContractInvalid
+		return contractInvalidError(err)
+	}
+
+	err = host.prepareContractUpgrade(codeDeployInput)
+	if err != nil {
+		return err
 	}
 
 	err = host.callUpgradeFunction()
@@ -870,6 +1107,10 @@ func (host *vmHost) execute(input *vmcommon.ContractCallInput) error {
 		return host.executeDelete(input)
 	}
 
+	if host.isContractFreezeCall(input) {
+		return host.applyContractFreeze(input, host.Storage().SetProtectedStorageToAddress)
+	}
+
 	contract, err := runtime.GetSCCode()
 	if err != nil {
 		return err
@@ -880,6 +1121,11 @@ func (host *vmHost) execute(input *vmcommon.ContractCallInput) error {
 		return err
 	}
 
+	err = host.checkContractNotFrozen(input)
+	if err != nil {
+		return err
+	}
+
 	// Replace the current Wasmer instance of the Runtime with a new one; this
 	// assumes that the instance was preserved on the Runtime instance stack
 	// before calling executeSmartContractCall().
@@ -910,12 +1156,28 @@ func (host *vmHost) callSCMethodIndirect() error {
 		return err
 	}
 
+	err = host.verifyEndpointAccess(functionName)
+	if err != nil {
+		return err
+	}
+
+	err = host.verifyArgumentsAgainstContractABI(functionName)
+	if err != nil {
+		return err
+	}
+
 	err = host.Runtime().CallSCFunction(functionName)
 	if err != nil {
-		err = host.handleBreakpointIfAny(err)
+		return host.handleBreakpointIfAny(err)
 	}
 
-	return err
+	// The instructions are reported in chunks, so a contract which ignores the failure of a nested call over
+	// the budget may finish without reporting its own instructions again.
+	if host.Runtime().IsExecutionBudgetExceeded() {
+		return vmhost.ErrExecutionBudgetExceeded
+	}
+
+	return host.Runtime().UseGasForMemoryGrowth()
 }
 
 // ExecuteESDTTransfer calls the process built in function with the given transfer for ESDT/ESDTNFT if nonce > 0
@@ -924,6 +1186,9 @@ func (host *vmHost) ExecuteESDTTransfer(transfersArgs *vmhost.ESDTTransfersArgs,
 	if len(transfersArgs.Transfers) == 0 {
 		return nil, 0, vmhost.ErrFailedTransfer
 	}
+	if host.queryExecution {
+		return nil, 0, vmhost.ErrTransferInQuery
+	}
 
 	if host.Runtime().ReadOnly() {
 		return nil, 0, vmhost.ErrInvalidCallOnReadOnlyMode
@@ -1007,7 +1272,7 @@ func (host *vmHost) ExecuteESDTTransfer(transfersArgs *vmhost.ESDTTransfersArgs,
 			log.Trace("ESDT transfer", "error", vmhost.ErrNotEnoughGas)
 			return vmOutput, esdtTransferInput.GasProvided, vmhost.ErrNotEnoughGas
 		}
-		err = metering.UseGasBounded(gasConsumed)
+		err = metering.UseGasBoundedInCategory(vmhost.GasCategoryTransfers, gasConsumed)
 		if err != nil {
 			log.Trace("ESDT transfer", "error", vmhost.ErrNotEnoughGas)
 			return vmOutput, esdtTransferInput.GasProvided, vmhost.ErrNotEnoughGas
@@ -1022,7 +1287,7 @@ func (host *vmHost) callFunctionOnOtherVM(input *vmcommon.ContractCallInput) (*v
 
 	vmOutput, err := host.Blockchain().ExecuteSmartContractCallOnOtherVM(input)
 	if err != nil {
-		_ = metering.UseGasBounded(input.GasProvided)
+		_ = metering.UseGasBoundedInCategory(vmhost.GasCategoryOther, input.GasProvided)
 		return nil, err
 	}
 
@@ -1047,13 +1312,13 @@ func (host *vmHost) callBuiltinFunction(input *vmcommon.ContractCallInput) (*vmc
 
 	vmOutput, err := host.Blockchain().ProcessBuiltInFunction(input)
 	if err != nil {
-		_ = metering.UseGasBounded(input.GasProvided)
+		_ = metering.UseGasBoundedInCategory(vmhost.GasCategoryTransfers, input.GasProvided)
 		return nil, nil, err
 	}
 
 	newVMInput, err := host.isSCExecutionAfterBuiltInFunc(input, vmOutput)
 	if err != nil {
-		_ = metering.UseGasBounded(input.GasProvided)
+		_ = metering.UseGasBoundedInCategory(vmhost.GasCategoryTransfers, input.GasProvided)
 		return nil, nil, err
 	}
 
@@ -1132,12 +1397,23 @@ func (host *vmHost) addOutputTransferToVMOutput(
 }
 
 func (host *vmHost) checkFinalGasAfterExit() error {
+	err := host.Runtime().UseGasForMemoryGrowth()
+	if err != nil {
+		log.Trace("checkFinalGasAfterExit", "failed", "memory growth")
+		return vmhost.ErrNotEnoughGas
+	}
+
 	totalUsedPoints := host.Runtime().GetPointsUsed()
 	if totalUsedPoints > host.Metering().GetGasForExecution() {
 		log.Trace("checkFinalGasAfterExit", "failed")
 		return vmhost.ErrNotEnoughGas
 	}
 
+	if host.Runtime().IsExecutionBudgetExceeded() {
+		log.Trace("checkFinalGasAfterExit", "failed", "execution budget")
+		return vmhost.ErrExecutionBudgetExceeded
+	}
+
 	log.Trace("checkFinalGasAfterExit", "ok")
 	return nil
 }
@@ -1156,7 +1432,12 @@ func (host *vmHost) callSCFunction(functionName string) error {
 		return executor.ErrFuncNotFound
 	}
 
-	err := runtime.CallSCFunction(functionName)
+	err := host.verifyArgumentsAgainstContractABI(functionName)
+	if err != nil {
+		return err
+	}
+
+	err = runtime.CallSCFunction(functionName)
 	if err != nil {
 		err = host.handleBreakpointIfAny(err)
 	}
@@ -1230,7 +1511,7 @@ func (host *vmHost) callSCMethodAsynchronousCallBack() error {
 
 		if callbackErr != nil {
 			metering := host.Metering()
-			_ = metering.UseGasBounded(metering.GasLeft())
+			_ = metering.UseGasBoundedInCategory(vmhost.GasCategoryOther, metering.GasLeft())
 		}
 
 		// TODO matei-p R2 Returning an error here will cause the VMOutput to be
@@ -1275,6 +1556,12 @@ func (host *vmHost) callFunctionAndExecuteAsync() (bool, error) {
 			return false, err
 		}
 
+		err = host.verifyArgumentsAgainstContractABI(functionName)
+		if err != nil {
+			log.Trace("call SC method failed", "error", err, "src", "verifyArgumentsAgainstContractABI")
+			return false, err
+		}
+
 		err = runtime.CallSCFunction(functionName)
 		if err != nil {
 			err = host.handleBreakpointIfAny(err)
@@ -1325,9 +1612,89 @@ func (host *vmHost) verifyAllowedFunctionCall() error {
 		return vmhost.ErrCallBackFuncCalledInRun
 	}
 
+	return host.verifyEndpointAccess(functionName)
+}
+
+// verifyEndpointAccess enforces the attributes which the contract code declares for the called endpoint,
+// before the contract starts executing; callbacks are not checked, being called by the VM and not by the caller.
+func (host *vmHost) verifyEndpointAccess(functionName string) error {
+	if !host.enableEpochsHandler.IsFlagEnabled(vmhost.EndpointAccessControlFlag) {
+		return nil
+	}
+
+	runtime := host.Runtime()
+	accessControl := runtime.GetAccessControl()
+	if accessControl == nil {
+		return nil
+	}
+	endpoint := accessControl.Endpoint(functionName)
+	if endpoint == nil {
+		return nil
+	}
+
+	vmInput := runtime.GetVMInput()
+	if vmInput.CallType == vm.AsynchronousCallBack {
+		return nil
+	}
+
+	if endpoint.Deprecated {
+		return contractabi.ErrEndpointDeprecated
+	}
+
+	if endpoint.OnlyOwner {
+		owner, err := host.Blockchain().GetOwnerAddress()
+		if err != nil {
+			return err
+		}
+		err = endpoint.CheckCaller(vmInput.CallerAddr, owner)
+		if err != nil {
+			return err
+		}
+	}
+
+	err := endpoint.CheckPayment(transferredTokenIdentifiers(vmInput))
+	if err != nil {
+		return err
+	}
+
+	if endpoint.ReadOnly {
+		runtime.SetReadOnly(true)
+	}
+
 	return nil
 }
 
+func transferredTokenIdentifiers(vmInput *vmcommon.ContractCallInput) [][]byte {
+	tokenIdentifiers := make([][]byte, 0, len(vmInput.ESDTTransfers)+1)
+	if vmInput.CallValue != nil && vmInput.CallValue.Sign() > 0 {
+		tokenIdentifiers = append(tokenIdentifiers, []byte(contractabi.EGLDTokenIdentifier))
+	}
+	for _, transfer := range vmInput.ESDTTransfers {
+		tokenIdentifiers = append(tokenIdentifiers, transfer.ESDTTokenName)
+	}
+	return tokenIdentifiers
+}
+
+// verifyArgumentsAgainstContractABI rejects the call if the contract embeds an ABI describing the called function
+// and the number of arguments does not fit its inputs; functions missing from the ABI are not checked.
+func (host *vmHost) verifyArgumentsAgainstContractABI(functionName string) error {
+	if !host.enableEpochsHandler.IsFlagEnabled(vmhost.ContractABIArgumentsCheckFlag) {
+		return nil
+	}
+
+	runtime := host.Runtime()
+	contractABI := runtime.GetContractABI()
+	if contractABI == nil {
+		return nil
+	}
+	endpoint := contractABI.Endpoint(functionName)
+	if endpoint == nil {
+		return nil
+	}
+
+	return endpoint.CheckArgumentCount(len(runtime.Arguments()))
+}
+
 func (host *vmHost) isSCExecutionAfterBuiltInFunc(
 	vmInput *vmcommon.ContractCallInput,
 	vmOutput *vmcommon.VMOutput,
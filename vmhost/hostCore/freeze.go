@@ -0,0 +1,135 @@
+package hostCore
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-core-go/data/vm"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	"github.com/multiversx/mx-chain-vm-go/vmhost"
+)
+
+type protectedStorageSetter func(address []byte, key []byte, value []byte) (vmhost.StorageStatus, error)
+
+func (host *vmHost) isContractFreezeCall(input *vmcommon.ContractCallInput) bool {
+	if !host.enableEpochsHandler.IsFlagEnabled(vmhost.ContractFreezeFlag) {
+		return false
+	}
+	return input.Function == vmhost.FreezeFunctionName || input.Function == vmhost.UnfreezeFunctionName
+}
+
+// doRunSmartContractFreeze freezes or unfreezes a contract directly, without instantiating it
+func (host *vmHost) doRunSmartContractFreeze(input *vmcommon.ContractCallInput) *vmcommon.VMOutput {
+	host.InitState()
+
+	_, _, metering, output, runtime, _, storage := host.GetContexts()
+
+	runtime.InitStateFromContractCallInput(input)
+	metering.InitStateFromContractCallInput(&input.VMInput)
+	output.AddTxValueToAccount(input.RecipientAddr, input.CallValue)
+	storage.SetAddress(runtime.GetContextAddress())
+
+	// no instance runs to be charged for the write, so it is paid up front, as the code of a call
+	setProtectedStorage := func(address []byte, key []byte, value []byte) (vmhost.StorageStatus, error) {
+		err := metering.DeductInitialGasForStorageWrite(value)
+		if err != nil {
+			return vmhost.StorageUnchanged, err
+		}
+		return storage.SetProtectedStorageToAddressUnmetered(address, key, value)
+	}
+
+	err := host.applyContractFreeze(input, setProtectedStorage)
+	if err != nil {
+		log.Trace("doRunSmartContractFreeze", "function", input.Function, "error", err)
+		return output.CreateVMOutputInCaseOfError(err)
+	}
+
+	vmOutput := output.GetVMOutput()
+	host.CompleteLogEntriesWithCallType(vmOutput, vmhost.DirectCallString)
+	return vmOutput
+}
+
+// applyContractFreeze lets the owner of a contract freeze it, with the arguments as the endpoints which anyone
+// may still call, or unfreeze it. The change is recorded under the protected FrozenContractKey and logged
+// with the name of the operation as identifier.
+func (host *vmHost) applyContractFreeze(input *vmcommon.ContractCallInput, setProtectedStorage protectedStorageSetter) error {
+	if host.Runtime().ReadOnly() {
+		return fmt.Errorf("%w (%s)", vmhost.ErrInvalidCallOnReadOnlyMode, input.Function)
+	}
+
+	owner, err := host.contractOwner(input.RecipientAddr)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(input.CallerAddr, owner) {
+		return vmhost.ErrFreezeNotAllowed
+	}
+
+	storage := host.Storage()
+	var value []byte
+	switch input.Function {
+	case vmhost.FreezeFunctionName:
+		value = vmhost.NewContractFreeze(input.Arguments).Serialize()
+	case vmhost.UnfreezeFunctionName:
+		if len(input.Arguments) > 0 {
+			return vmhost.ErrInvalidArgument
+		}
+		freeze, _, _, err := storage.GetContractFreeze(input.RecipientAddr)
+		if err != nil {
+			return err
+		}
+		if freeze == nil {
+			return vmhost.ErrContractNotFrozen
+		}
+	}
+
+	_, err = setProtectedStorage(input.RecipientAddr, storage.GetVmProtectedPrefix(vmhost.FrozenContractKey), value)
+	if err != nil {
+		return err
+	}
+
+	host.Output().WriteLogWithIdentifier(input.RecipientAddr, input.Arguments, [][]byte{input.CallerAddr}, []byte(input.Function))
+	return nil
+}
+
+// checkContractNotFrozen rejects the calls to a frozen contract, unless they are made by its owner or
+// target one of the endpoints allowed by the freeze. Callbacks are always delivered, so that the
+// async calls started before the freeze can complete.
+func (host *vmHost) checkContractNotFrozen(input *vmcommon.ContractCallInput) error {
+	if !host.enableEpochsHandler.IsFlagEnabled(vmhost.ContractFreezeFlag) {
+		return nil
+	}
+	if input.CallType == vm.AsynchronousCallBack {
+		return nil
+	}
+
+	freeze, _, _, err := host.Storage().GetContractFreeze(input.RecipientAddr)
+	if err != nil {
+		return err
+	}
+	if freeze == nil || freeze.IsEndpointAllowed(input.Function) {
+		return nil
+	}
+
+	owner, err := host.contractOwner(input.RecipientAddr)
+	if err != nil {
+		return err
+	}
+	if bytes.Equal(input.CallerAddr, owner) {
+		return nil
+	}
+
+	return fmt.Errorf("%w (%s)", vmhost.ErrContractFrozen, input.Function)
+}
+
+func (host *vmHost) contractOwner(address []byte) ([]byte, error) {
+	contract, err := host.Blockchain().GetUserAccount(address)
+	if err != nil {
+		return nil, err
+	}
+	if check.IfNilReflect(contract) {
+		return nil, vmhost.ErrNilContract
+	}
+	return contract.GetOwnerAddress(), nil
+}
@@ -0,0 +1,295 @@
+package hostCore
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/data/vm"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	"github.com/multiversx/mx-chain-vm-go/math"
+	"github.com/multiversx/mx-chain-vm-go/vmhost"
+)
+
+// gasEstimationRun is the outcome of executing the call once, with a given gas limit
+type gasEstimationRun struct {
+	gasLimit    uint64
+	initialCost uint64
+	vmOutput    *vmcommon.VMOutput
+}
+
+// EstimateGas finds the smallest gas limit with which the call has the same successful outcome as with
+// input.GasProvided, which acts as the upper bound of the search. The call is executed repeatedly, but none
+// of its outputs is applied, so the state is left untouched. Because the outcome is compared for every
+// candidate gas limit, contracts which branch on the gas left or which lock gas for async callbacks
+// are estimated correctly, unlike when adding a margin to the gas used. The gas forwarded by the output
+// transfers, e.g. by async calls, is not compared with the reference run, because it usually depends on
+// the gas left; instead, it must cover the gas which the destination consumes.
+func (host *vmHost) EstimateGas(input *vmcommon.ContractCallInput) (*vmhost.GasEstimation, error) {
+	reference, err := host.runForGasEstimation(input, input.GasProvided)
+	if err != nil {
+		return nil, err
+	}
+	if reference.vmOutput.ReturnCode != vmcommon.Ok {
+		return nil, fmt.Errorf("%w: %s: %s",
+			vmhost.ErrGasEstimationFailed, reference.vmOutput.ReturnCode, reference.vmOutput.ReturnMessage)
+	}
+
+	requirements := host.computeTransferGasRequirements(reference.vmOutput)
+
+	// the gas used with the upper bound is usually the answer, so it is tried first to shorten the search
+	best := reference
+	lowerBound := uint64(0)
+	gasUsed := math.SubUint64(input.GasProvided, reference.vmOutput.GasRemaining)
+	if gasUsed < best.gasLimit {
+		run, err := host.runForGasEstimation(input, gasUsed)
+		if err != nil {
+			return nil, err
+		}
+		if haveSameOutcome(reference.vmOutput, run.vmOutput, requirements) {
+			best = run
+		} else {
+			lowerBound = gasUsed
+		}
+	}
+
+	for best.gasLimit-lowerBound > 1 {
+		gasLimit := lowerBound + (best.gasLimit-lowerBound)/2
+		run, err := host.runForGasEstimation(input, gasLimit)
+		if err != nil {
+			return nil, err
+		}
+		if haveSameOutcome(reference.vmOutput, run.vmOutput, requirements) {
+			best = run
+		} else {
+			lowerBound = gasLimit
+		}
+	}
+
+	return createGasEstimation(best), nil
+}
+
+// runForGasEstimation executes a copy of the input with the given gas limit
+func (host *vmHost) runForGasEstimation(input *vmcommon.ContractCallInput, gasLimit uint64) (*gasEstimationRun, error) {
+	estimationInput := *input
+	estimationInput.GasProvided = gasLimit
+
+	vmOutput, err := host.RunSmartContractCall(&estimationInput)
+	if err != nil {
+		return nil, err
+	}
+
+	return &gasEstimationRun{
+		gasLimit:    gasLimit,
+		initialCost: host.Metering().GetSCPrepareInitialCost(),
+		vmOutput:    vmOutput,
+	}, nil
+}
+
+// transferGasRequirement is the gas which an output transfer of the reference run must at least forward
+// and lock in every other run
+type transferGasRequirement struct {
+	gasLimit  uint64
+	gasLocked uint64
+}
+
+// computeTransferGasRequirements finds, for each output transfer of the reference run, the gas which its
+// destination consumes. The destination is executed with the gas forwarded by the reference run, on the
+// current state; when it cannot be executed here, e.g. because it belongs to another shard, the gas
+// forwarded by the reference run stays the requirement. Transfers to user accounts require no gas.
+func (host *vmHost) computeTransferGasRequirements(vmOutput *vmcommon.VMOutput) map[string][]transferGasRequirement {
+	requirements := make(map[string][]transferGasRequirement, len(vmOutput.OutputAccounts))
+	for address, outputAccount := range vmOutput.OutputAccounts {
+		accountRequirements := make([]transferGasRequirement, len(outputAccount.OutputTransfers))
+		for i, transfer := range outputAccount.OutputTransfers {
+			accountRequirements[i] = transferGasRequirement{
+				gasLimit:  host.computeDestinationGasConsumed(outputAccount.Address, transfer),
+				gasLocked: transfer.GasLocked,
+			}
+		}
+		requirements[address] = accountRequirements
+	}
+	return requirements
+}
+
+func (host *vmHost) computeDestinationGasConsumed(destination []byte, transfer vmcommon.OutputTransfer) uint64 {
+	if transfer.GasLimit == 0 || !core.IsSmartContractAddress(destination) {
+		return 0
+	}
+
+	function, arguments, err := host.callArgsParser.ParseData(string(transfer.Data))
+	if err != nil {
+		return transfer.GasLimit
+	}
+
+	callValue := big.NewInt(0)
+	if transfer.Value != nil {
+		callValue.Set(transfer.Value)
+	}
+
+	destinationInput := &vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			CallerAddr:  transfer.SenderAddress,
+			Arguments:   arguments,
+			CallValue:   callValue,
+			CallType:    transfer.CallType,
+			GasProvided: transfer.GasLimit,
+			GasLocked:   transfer.GasLocked,
+		},
+		RecipientAddr: destination,
+		Function:      function,
+	}
+
+	if transfer.CallType == vm.AsynchronousCall {
+		// the async data of the transfer holds the identifiers of the call and of its caller
+		_, asyncData, err := host.callArgsParser.ParseData(string(transfer.AsyncData))
+		if err != nil || len(asyncData) < 2 {
+			return transfer.GasLimit
+		}
+		destinationInput.AsyncArguments = &vmcommon.AsyncArguments{
+			CallID:       asyncData[0],
+			CallerCallID: asyncData[1],
+		}
+	}
+
+	vmOutput, err := host.RunSmartContractCall(destinationInput)
+	if err != nil || vmOutput.ReturnCode != vmcommon.Ok {
+		return transfer.GasLimit
+	}
+
+	return math.SubUint64(transfer.GasLimit, vmOutput.GasRemaining)
+}
+
+func createGasEstimation(run *gasEstimationRun) *vmhost.GasEstimation {
+	asyncLocked := uint64(0)
+	for _, outputAccount := range run.vmOutput.OutputAccounts {
+		for _, transfer := range outputAccount.OutputTransfers {
+			asyncLocked = math.AddUint64(asyncLocked, transfer.GasLocked)
+		}
+	}
+
+	gasUsed := math.SubUint64(run.gasLimit, run.vmOutput.GasRemaining)
+	execution := math.SubUint64(math.SubUint64(gasUsed, run.initialCost), asyncLocked)
+
+	refund := big.NewInt(0)
+	if run.vmOutput.GasRefund != nil {
+		refund.Set(run.vmOutput.GasRefund)
+	}
+
+	return &vmhost.GasEstimation{
+		GasLimit:    run.gasLimit,
+		Compilation: run.initialCost,
+		Execution:   execution,
+		AsyncLocked: asyncLocked,
+		Refund:      refund,
+		VMOutput:    run.vmOutput,
+	}
+}
+
+// haveSameOutcome compares everything in the outputs except the gas remaining and the gas refund, which are
+// expected to differ; the logs are part of the outcome, while the gas given to the output transfers only has
+// to meet the requirements computed from the reference run
+func haveSameOutcome(
+	reference *vmcommon.VMOutput,
+	vmOutput *vmcommon.VMOutput,
+	requirements map[string][]transferGasRequirement,
+) bool {
+	if vmOutput.ReturnCode != reference.ReturnCode {
+		return false
+	}
+	if !areByteSlicesEqual(reference.ReturnData, vmOutput.ReturnData) {
+		return false
+	}
+	if !haveSameLogs(reference.Logs, vmOutput.Logs) {
+		return false
+	}
+	if len(reference.OutputAccounts) != len(vmOutput.OutputAccounts) {
+		return false
+	}
+
+	for address, referenceAccount := range reference.OutputAccounts {
+		outputAccount, ok := vmOutput.OutputAccounts[address]
+		if !ok || !haveSameAccountOutcome(referenceAccount, outputAccount, requirements[address]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func haveSameAccountOutcome(
+	reference *vmcommon.OutputAccount,
+	outputAccount *vmcommon.OutputAccount,
+	requirements []transferGasRequirement,
+) bool {
+	if !areBigIntsEqual(reference.BalanceDelta, outputAccount.BalanceDelta) {
+		return false
+	}
+	if !bytes.Equal(reference.Code, outputAccount.Code) {
+		return false
+	}
+
+	if len(reference.StorageUpdates) != len(outputAccount.StorageUpdates) {
+		return false
+	}
+	for key, referenceUpdate := range reference.StorageUpdates {
+		update, ok := outputAccount.StorageUpdates[key]
+		if !ok || !bytes.Equal(referenceUpdate.Data, update.Data) {
+			return false
+		}
+	}
+
+	if len(reference.OutputTransfers) != len(outputAccount.OutputTransfers) {
+		return false
+	}
+	for i, referenceTransfer := range reference.OutputTransfers {
+		transfer := outputAccount.OutputTransfers[i]
+		if !areBigIntsEqual(referenceTransfer.Value, transfer.Value) ||
+			!bytes.Equal(referenceTransfer.Data, transfer.Data) ||
+			!bytes.Equal(referenceTransfer.SenderAddress, transfer.SenderAddress) ||
+			referenceTransfer.CallType != transfer.CallType {
+			return false
+		}
+		if transfer.GasLimit < requirements[i].gasLimit || transfer.GasLocked < requirements[i].gasLocked {
+			return false
+		}
+	}
+
+	return true
+}
+
+func haveSameLogs(reference []*vmcommon.LogEntry, logs []*vmcommon.LogEntry) bool {
+	if len(reference) != len(logs) {
+		return false
+	}
+	for i, referenceLog := range reference {
+		logEntry := logs[i]
+		if !bytes.Equal(referenceLog.Identifier, logEntry.Identifier) ||
+			!bytes.Equal(referenceLog.Address, logEntry.Address) ||
+			!areByteSlicesEqual(referenceLog.Topics, logEntry.Topics) ||
+			!areByteSlicesEqual(referenceLog.Data, logEntry.Data) {
+			return false
+		}
+	}
+	return true
+}
+
+func areByteSlicesEqual(first [][]byte, second [][]byte) bool {
+	if len(first) != len(second) {
+		return false
+	}
+	for i := range first {
+		if !bytes.Equal(first[i], second[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func areBigIntsEqual(first *big.Int, second *big.Int) bool {
+	if first == nil || second == nil {
+		return first == second
+	}
+	return first.Cmp(second) == 0
+}
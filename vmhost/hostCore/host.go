@@ -2,8 +2,11 @@ package hostCore
 
 import (
 	"context"
+	"fmt"
 	"math"
+	"math/big"
 	"runtime/debug"
+	"sort"
 	"sync"
 	"time"
 
@@ -33,23 +36,52 @@ var MaximumRuntimeInstanceStackSize = uint64(10)
 
 var _ vmhost.VMHost = (*vmHost)(nil)
 var _ scenexec.VMInterface = (*vmHost)(nil)
+var _ vmhost.GasEstimator = (*vmHost)(nil)
+var _ vmhost.GasBreakdownProvider = (*vmHost)(nil)
 
 const minExecutionTimeout = time.Second
 const internalVMErrors = "internalVMErrors"
+const gasRefundIdentifier = "gasRefund"
+
+// noEpochGasSchedule marks that none of the gas schedules configured per epoch is active
+const noEpochGasSchedule = -1
+
+// noEpochCodeLimits marks that none of the code limits configured per epoch is active
+const noEpochCodeLimits = -1
 
 // allFlags must have all flags used by mx-chain-vm-go in the current version
 var allFlags = []core.EnableEpochFlag{
 	vmhost.CryptoOpcodesV2Flag,
 	vmhost.MultiESDTNFTTransferAndExecuteByUserFlag,
 	vmhost.UseGasBoundedShouldFailExecutionFlag,
+	vmhost.TransientStorageFlag,
+	vmhost.StorageDepositFlag,
+	vmhost.DecimalFlag,
+	vmhost.ModularArithmeticFlag,
+	vmhost.GasRefundCapFlag,
+	vmhost.MemoryGrowthGasFlag,
+	vmhost.EVMAbiFlag,
+	vmhost.ContractABIArgumentsCheckFlag,
+	vmhost.UpgradePolicyFlag,
+	vmhost.SaltedDeployFlag,
+	vmhost.ContractDeployInfoFlag,
+	vmhost.EndpointAccessControlFlag,
+	vmhost.ContractFreezeFlag,
+	vmhost.CodeRegistryFlag,
 }
 
 // vmHost implements HostContext interface.
 type vmHost struct {
-	cryptoHook       crypto.VMCrypto
-	mutExecution     sync.RWMutex
-	closingInstance  bool
-	executionTimeout time.Duration
+	cryptoHook        crypto.VMCrypto
+	mutExecution      sync.RWMutex
+	closingInstance   bool
+	executionTimeout  time.Duration
+	executionWatchdog vmhost.ExecutionWatchdog
+	executionBudget   uint64
+
+	queryExecution bool
+	queryTimeout   time.Duration
+	queryGasLimit  uint64
 
 	ethInput []byte
 
@@ -64,12 +96,23 @@ type vmHost struct {
 	gasSchedule          config.GasScheduleMap
 	builtInFuncContainer vmcommon.BuiltInFunctionContainer
 	esdtTransferParser   vmcommon.ESDTTransferParser
+	hasher               vmhost.HashComputer
 	callArgsParser       vmhost.CallArgsParser
 	enableEpochsHandler  vmhost.EnableEpochsHandler
 	activationEpochMap   map[uint32]struct{}
 
+	baseGasSchedule        config.GasScheduleMap
+	epochGasSchedules      []vmhost.EpochGasSchedule
+	activeEpochGasSchedule int
+
+	baseCodeLimits        vmhost.CodeLimits
+	epochCodeLimits       []vmhost.EpochCodeLimits
+	activeEpochCodeLimits int
+
 	transferLogIdentifiers    map[string]bool
 	mapOpcodeAddressIsAllowed map[string]map[string]struct{}
+
+	gasBreakdownEnabled bool
 }
 
 // NewVMHost creates a new VM vmHost
@@ -108,6 +151,18 @@ func NewVMHost(
 	if hostParameters.MapOpcodeAddressIsAllowed == nil {
 		return nil, vmhost.ErrNilMapOpcodeAddress
 	}
+	epochGasSchedules, err := sortEpochGasSchedules(hostParameters.EpochGasSchedules)
+	if err != nil {
+		return nil, err
+	}
+	epochCodeLimits, err := sortEpochCodeLimits(hostParameters.EpochCodeLimits)
+	if err != nil {
+		return nil, err
+	}
+	executionBudget, err := executionBudgetFromParameters(hostParameters)
+	if err != nil {
+		return nil, err
+	}
 
 	cryptoHook, err := factory.NewVMCrypto()
 	if err != nil {
@@ -125,15 +180,30 @@ func NewVMHost(
 		gasSchedule:               hostParameters.GasSchedule,
 		builtInFuncContainer:      hostParameters.BuiltInFuncContainer,
 		esdtTransferParser:        hostParameters.ESDTTransferParser,
+		hasher:                    hostParameters.Hasher,
 		callArgsParser:            parsers.NewCallArgsParser(),
 		executionTimeout:          minExecutionTimeout,
+		executionWatchdog:         hostParameters.ExecutionWatchdog,
+		executionBudget:           executionBudget,
+		queryGasLimit:             hostParameters.QueryGasLimit,
 		enableEpochsHandler:       hostParameters.EnableEpochsHandler,
 		mapOpcodeAddressIsAllowed: hostParameters.MapOpcodeAddressIsAllowed,
+		baseGasSchedule:           hostParameters.GasSchedule,
+		epochGasSchedules:         epochGasSchedules,
+		activeEpochGasSchedule:    noEpochGasSchedule,
+		baseCodeLimits:            hostParameters.CodeLimits,
+		epochCodeLimits:           epochCodeLimits,
+		activeEpochCodeLimits:     noEpochCodeLimits,
 	}
 	newExecutionTimeout := time.Duration(hostParameters.TimeOutForSCExecutionInMilliseconds) * time.Millisecond
 	if newExecutionTimeout > minExecutionTimeout {
 		host.executionTimeout = newExecutionTimeout
 	}
+	host.queryTimeout = host.executionTimeout
+	newQueryTimeout := time.Duration(hostParameters.TimeOutForSCQueryInMilliseconds) * time.Millisecond
+	if newQueryTimeout > host.queryTimeout {
+		host.queryTimeout = newQueryTimeout
+	}
 
 	host.blockchainContext, err = contexts.NewBlockchainContext(host, blockChainHook)
 	if err != nil {
@@ -144,6 +214,11 @@ func NewVMHost(
 	if err != nil {
 		return nil, err
 	}
+	_, countsInstructions := vmExecutor.FunctionNames()[vmhost.CountInstructionsHookName]
+	if executionBudget > 0 && !countsInstructions {
+		return nil, fmt.Errorf("%w: the executor does not provide the %s hook required by the instruction budget",
+			vmhost.ErrInvalidExecutionWatchdog, vmhost.CountInstructionsHookName)
+	}
 
 	host.runtimeContext, err = contexts.NewRuntimeContext(
 		host,
@@ -156,10 +231,16 @@ func NewVMHost(
 		return nil, err
 	}
 
-	host.meteringContext, err = contexts.NewMeteringContext(host, hostParameters.GasSchedule, hostParameters.BlockGasLimit)
+	maxGasRefundPercentage := hostParameters.MaxGasRefundPercentage
+	if maxGasRefundPercentage == 0 {
+		maxGasRefundPercentage = vmhost.DefaultMaxGasRefundPercentage
+	}
+	host.meteringContext, err = contexts.NewMeteringContext(host, hostParameters.GasSchedule, hostParameters.BlockGasLimit, maxGasRefundPercentage)
 	if err != nil {
 		return nil, err
 	}
+	host.gasBreakdownEnabled = hostParameters.EnableGasBreakdown
+	host.meteringContext.SetGasBreakdown(host.gasBreakdownEnabled)
 
 	host.outputContext, err = contexts.NewOutputContext(host)
 	if err != nil {
@@ -170,6 +251,7 @@ func NewVMHost(
 		host,
 		blockChainHook,
 		hostParameters.ProtectedKeyPrefix,
+		hostParameters.StorageDepositPerByte,
 	)
 	if err != nil {
 		return nil, err
@@ -186,6 +268,8 @@ func NewVMHost(
 	}
 
 	host.runtimeContext.SetMaxInstanceStackSize(MaximumRuntimeInstanceStackSize)
+	host.runtimeContext.SetExecutionBudget(executionBudget)
+	host.runtimeContext.SetCodeLimits(hostParameters.CodeLimits)
 
 	host.initContexts()
 	hostParameters.EpochNotifier.RegisterNotifyHandler(host)
@@ -233,6 +317,11 @@ func (host *vmHost) Crypto() crypto.VMCrypto {
 	return host.cryptoHook
 }
 
+// Hasher returns the hasher of the host, used for code hashes and contract addresses
+func (host *vmHost) Hasher() vmhost.HashComputer {
+	return host.hasher
+}
+
 // Blockchain returns the BlockchainContext instance of the host
 func (host *vmHost) Blockchain() vmhost.BlockchainContext {
 	return host.blockchainContext
@@ -342,11 +431,22 @@ func (host *vmHost) ClearContextStateStack() {
 	host.blockchainContext.ClearStateStack()
 }
 
-// GasScheduleChange applies a new gas schedule to the host
+// GasScheduleChange applies a new gas schedule to the host. It replaces the gas schedule from the host
+// parameters, so the gas schedules configured per epoch still take precedence from their start epoch.
 func (host *vmHost) GasScheduleChange(newGasSchedule config.GasScheduleMap) {
 	host.mutExecution.Lock()
 	defer host.mutExecution.Unlock()
 
+	host.baseGasSchedule = newGasSchedule
+	if host.activeEpochGasSchedule != noEpochGasSchedule {
+		log.Debug("gas schedule change deferred, a gas schedule configured per epoch is active")
+		return
+	}
+
+	host.applyGasSchedule(newGasSchedule)
+}
+
+func (host *vmHost) applyGasSchedule(newGasSchedule config.GasScheduleMap) {
 	host.gasSchedule = newGasSchedule
 	gasCostConfig, err := config.CreateGasConfig(newGasSchedule)
 	if err != nil {
@@ -370,23 +470,45 @@ func (host *vmHost) GetGasTrace() map[string]map[string][]uint64 {
 	return host.meteringContext.GetGasTrace()
 }
 
+// gasBreakdownOf returns the gas breakdown per account of the execution which produced the given output, if it
+// was successful and VMHostParameters.EnableGasBreakdown is set. It must be called before the next execution starts.
+func (host *vmHost) gasBreakdownOf(vmOutput *vmcommon.VMOutput) map[string]*vmhost.GasBreakdown {
+	if !host.gasBreakdownEnabled {
+		return nil
+	}
+
+	return host.meteringContext.GetGasBreakdown(vmOutput)
+}
+
 // SetGasTracing configures the gas tracing flag, used in scenario tests
 func (host *vmHost) SetGasTracing(enableGasTracing bool) {
 	host.meteringContext.SetGasTracing(enableGasTracing)
 }
 
 // RunSmartContractCreate executes the deployment of a new contract
-func (host *vmHost) RunSmartContractCreate(input *vmcommon.ContractCreateInput) (vmOutput *vmcommon.VMOutput, err error) {
+func (host *vmHost) RunSmartContractCreate(input *vmcommon.ContractCreateInput) (*vmcommon.VMOutput, error) {
+	vmOutput, _, err := host.runSmartContractCreate(input)
+	return vmOutput, err
+}
+
+// RunSmartContractCreateWithGasBreakdown executes the deployment of a new contract, and also returns the gas
+// breakdown per account, which is not part of the VMOutput. The breakdown is empty if the deployment failed, and
+// nil if VMHostParameters.EnableGasBreakdown is not set.
+func (host *vmHost) RunSmartContractCreateWithGasBreakdown(input *vmcommon.ContractCreateInput) (*vmcommon.VMOutput, map[string]*vmhost.GasBreakdown, error) {
+	return host.runSmartContractCreate(input)
+}
+
+func (host *vmHost) runSmartContractCreate(input *vmcommon.ContractCreateInput) (vmOutput *vmcommon.VMOutput, gasBreakdown map[string]*vmhost.GasBreakdown, err error) {
 	err = validateVMInput(&input.VMInput)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	host.mutExecution.RLock()
 	defer host.mutExecution.RUnlock()
 
 	if host.closingInstance {
-		return nil, vmhost.ErrVMIsClosing
+		return nil, nil, vmhost.ErrVMIsClosing
 	}
 
 	host.setGasTracerEnabledIfLogIsTrace()
@@ -415,7 +537,9 @@ func (host *vmHost) RunSmartContractCreate(input *vmcommon.ContractCreateInput)
 		}()
 
 		vmOutput = host.doRunSmartContractCreate(input)
+		gasBreakdown = host.gasBreakdownOf(vmOutput)
 		host.CompleteLogEntriesWithCallType(vmOutput, vmhost.DeploySmartContractString)
+		host.applyGasRefundCap(input.CallerAddr, vmOutput)
 
 		logsFromErrors := host.createLogEntryFromErrors(input.CallerAddr, input.CallerAddr, "_init")
 		if logsFromErrors != nil {
@@ -433,6 +557,7 @@ func (host *vmHost) RunSmartContractCreate(input *vmcommon.ContractCreateInput)
 	case <-done:
 		return
 	case <-ctx.Done():
+		host.warnIfTimeoutWithExecutionBudget()
 		host.Runtime().FailExecution(vmhost.ErrExecutionFailedWithTimeout)
 		<-done
 		err = vmhost.ErrExecutionFailedWithTimeout
@@ -442,21 +567,56 @@ func (host *vmHost) RunSmartContractCreate(input *vmcommon.ContractCreateInput)
 }
 
 // RunSmartContractCall executes the call of an existing contract
-func (host *vmHost) RunSmartContractCall(input *vmcommon.ContractCallInput) (vmOutput *vmcommon.VMOutput, err error) {
-	err = validateVMInput(&input.VMInput)
+func (host *vmHost) RunSmartContractCall(input *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+	err := validateVMInput(&input.VMInput)
 	if err != nil {
 		return nil, err
 	}
 
-	host.mutExecution.RLock()
-	defer host.mutExecution.RUnlock()
+	vmOutput, _, err := host.runSmartContractCall(input, host.executionTimeout, false)
+	return vmOutput, err
+}
+
+// RunSmartContractCallWithGasBreakdown executes the call of an existing contract, and also returns the gas
+// breakdown per account, which is not part of the VMOutput. The breakdown is empty if the call failed, and
+// nil if VMHostParameters.EnableGasBreakdown is not set.
+func (host *vmHost) RunSmartContractCallWithGasBreakdown(input *vmcommon.ContractCallInput) (*vmcommon.VMOutput, map[string]*vmhost.GasBreakdown, error) {
+	err := validateVMInput(&input.VMInput)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return host.runSmartContractCall(input, host.executionTimeout, false)
+}
+
+// runSmartContractCall executes the call, as a query if requested. Queries hold mutExecution exclusively, so that
+// the query mode, which the contexts pick up when they are initialized, is never seen by a concurrent call, and is
+// only written while no other call runs.
+func (host *vmHost) runSmartContractCall(
+	input *vmcommon.ContractCallInput,
+	timeout time.Duration,
+	query bool,
+) (vmOutput *vmcommon.VMOutput, gasBreakdown map[string]*vmhost.GasBreakdown, err error) {
+	if query {
+		host.mutExecution.Lock()
+		defer host.mutExecution.Unlock()
+
+		// written only under the exclusive lock, so that no concurrent call reads it
+		host.queryExecution = true
+		defer func() {
+			host.queryExecution = false
+		}()
+	} else {
+		host.mutExecution.RLock()
+		defer host.mutExecution.RUnlock()
+	}
 
 	if host.closingInstance {
-		return nil, vmhost.ErrVMIsClosing
+		return nil, nil, vmhost.ErrVMIsClosing
 	}
 
 	host.setGasTracerEnabledIfLogIsTrace()
-	ctx, cancel := context.WithTimeout(context.Background(), host.executionTimeout)
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
 	defer cancel()
 
 	log.Trace("RunSmartContractCall begin",
@@ -485,8 +645,14 @@ func (host *vmHost) RunSmartContractCall(input *vmcommon.ContractCallInput) (vmO
 		case vmhost.DeleteFunctionName:
 			vmOutput = host.doRunSmartContractDelete(input)
 		default:
-			vmOutput = host.doRunSmartContractCall(input)
+			if host.isContractFreezeCall(input) {
+				vmOutput = host.doRunSmartContractFreeze(input)
+			} else {
+				vmOutput = host.doRunSmartContractCall(input)
+			}
 		}
+		gasBreakdown = host.gasBreakdownOf(vmOutput)
+		host.applyGasRefundCap(input.CallerAddr, vmOutput)
 
 		logsFromErrors := host.createLogEntryFromErrors(input.CallerAddr, input.RecipientAddr, input.Function)
 		if logsFromErrors != nil {
@@ -511,6 +677,7 @@ func (host *vmHost) RunSmartContractCall(input *vmcommon.ContractCallInput) (vmO
 		// basic block in order to close the WASM instance cleanly. This is done by
 		// reading the `done` channel once more, awaiting the call to `close(done)`
 		// from above.
+		host.warnIfTimeoutWithExecutionBudget()
 		host.Runtime().FailExecution(vmhost.ErrExecutionFailedWithTimeout)
 		<-done
 		err = vmhost.ErrExecutionFailedWithTimeout
@@ -535,6 +702,30 @@ func (host *vmHost) createLogEntryFromErrors(sndAddress, rcvAddress []byte, func
 	return logFromError
 }
 
+// applyGasRefundCap caps the gas refund of a successful transaction. When the cap limits the refund,
+// a log entry with the accumulated, cap and applied values reports how the refund was computed;
+// otherwise the accumulated refund is the one set in VMOutput.GasRefund.
+func (host *vmHost) applyGasRefundCap(sndAddress []byte, vmOutput *vmcommon.VMOutput) {
+	if !host.enableEpochsHandler.IsFlagEnabled(vmhost.GasRefundCapFlag) || vmOutput.ReturnCode != vmcommon.Ok {
+		return
+	}
+
+	breakdown := host.meteringContext.ApplyGasRefundCap(vmOutput)
+	if breakdown.Applied == breakdown.Accumulated {
+		return
+	}
+
+	vmOutput.Logs = append(vmOutput.Logs, &vmcommon.LogEntry{
+		Identifier: []byte(gasRefundIdentifier),
+		Address:    sndAddress,
+		Topics: [][]byte{
+			big.NewInt(0).SetUint64(breakdown.Accumulated).Bytes(),
+			big.NewInt(0).SetUint64(breakdown.Cap).Bytes(),
+			big.NewInt(0).SetUint64(breakdown.Applied).Bytes(),
+		},
+	})
+}
+
 // AreInSameShard returns true if the provided addresses are part of the same shard
 func (host *vmHost) AreInSameShard(leftAddress []byte, rightAddress []byte) bool {
 	blockchain := host.Blockchain()
@@ -592,6 +783,127 @@ func (host *vmHost) EpochConfirmed(epoch uint32, _ uint64) {
 		host.Runtime().ClearWarmInstanceCache()
 		host.Blockchain().ClearCompiledCodes()
 	}
+
+	host.mutExecution.Lock()
+	defer host.mutExecution.Unlock()
+
+	host.switchEpochGasSchedule(epoch)
+	host.switchEpochCodeLimits(epoch)
+}
+
+// switchEpochGasSchedule applies the gas schedule configured for the given epoch, if it is not already active.
+// Before the first configured start epoch, the gas schedule from the host parameters applies.
+// The compiled codes are also cleared, because they are metered with the opcode costs of the old gas schedule.
+// It must be called with mutExecution locked.
+func (host *vmHost) switchEpochGasSchedule(epoch uint32) {
+	index := activeEpochIndex(len(host.epochGasSchedules), func(i int) uint32 {
+		return host.epochGasSchedules[i].StartEpoch
+	}, epoch)
+	if index == host.activeEpochGasSchedule {
+		return
+	}
+
+	gasSchedule := host.baseGasSchedule
+	if index != noEpochGasSchedule {
+		gasSchedule = host.epochGasSchedules[index].GasSchedule
+	}
+
+	log.Debug("switching gas schedule", "epoch", epoch)
+	host.activeEpochGasSchedule = index
+	host.applyGasSchedule(gasSchedule)
+	host.Blockchain().ClearCompiledCodes()
+}
+
+// switchEpochCodeLimits applies the code limits configured for the given epoch, if they are not already active.
+// Before the first configured start epoch, the code limits from the host parameters apply.
+// It must be called with mutExecution locked.
+func (host *vmHost) switchEpochCodeLimits(epoch uint32) {
+	index := activeEpochIndex(len(host.epochCodeLimits), func(i int) uint32 {
+		return host.epochCodeLimits[i].StartEpoch
+	}, epoch)
+	if index == host.activeEpochCodeLimits {
+		return
+	}
+
+	codeLimits := host.baseCodeLimits
+	if index != noEpochCodeLimits {
+		codeLimits = host.epochCodeLimits[index].CodeLimits
+	}
+
+	log.Debug("switching code limits", "epoch", epoch)
+	host.activeEpochCodeLimits = index
+	host.runtimeContext.SetCodeLimits(codeLimits)
+}
+
+// activeEpochIndex returns the index of the last entry, out of entries sorted by start epoch,
+// which started at or before the given epoch, or -1 if none did
+func activeEpochIndex(numEntries int, startEpochOf func(i int) uint32, epoch uint32) int {
+	return sort.Search(numEntries, func(i int) bool {
+		return startEpochOf(i) > epoch
+	}) - 1
+}
+
+// executionBudgetFromParameters returns the instruction budget of the executions, or 0 for the wall-clock watchdog
+func executionBudgetFromParameters(hostParameters *vmhost.VMHostParameters) (uint64, error) {
+	switch hostParameters.ExecutionWatchdog {
+	case vmhost.WallClockWatchdog:
+		return 0, nil
+	case vmhost.InstructionBudgetWatchdog:
+		if hostParameters.ExecutionInstructionBudget == 0 {
+			return 0, fmt.Errorf("%w: the instruction budget must be positive", vmhost.ErrInvalidExecutionWatchdog)
+		}
+		return hostParameters.ExecutionInstructionBudget, nil
+	default:
+		return 0, fmt.Errorf("%w: unknown watchdog %d", vmhost.ErrInvalidExecutionWatchdog, hostParameters.ExecutionWatchdog)
+	}
+}
+
+// warnIfTimeoutWithExecutionBudget signals that the wall-clock safety net fired although the executions
+// are bounded by the instruction budget, which makes the outcome depend on the speed of the machine.
+func (host *vmHost) warnIfTimeoutWithExecutionBudget() {
+	if host.executionWatchdog != vmhost.InstructionBudgetWatchdog || host.queryExecution {
+		return
+	}
+	log.Warn("execution timeout reached before the instruction budget; the timeout should be increased",
+		"timeout", host.executionTimeout)
+}
+
+// sortEpochGasSchedules validates the gas schedules configured per epoch and sorts them by start epoch
+func sortEpochGasSchedules(epochGasSchedules []vmhost.EpochGasSchedule) ([]vmhost.EpochGasSchedule, error) {
+	sorted := make([]vmhost.EpochGasSchedule, len(epochGasSchedules))
+	copy(sorted, epochGasSchedules)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].StartEpoch < sorted[j].StartEpoch
+	})
+
+	for i, epochGasSchedule := range sorted {
+		if i > 0 && sorted[i-1].StartEpoch == epochGasSchedule.StartEpoch {
+			return nil, fmt.Errorf("%w: epoch %d", vmhost.ErrDuplicateEpochGasSchedule, epochGasSchedule.StartEpoch)
+		}
+		_, err := config.CreateGasConfig(epochGasSchedule.GasSchedule)
+		if err != nil {
+			return nil, fmt.Errorf("%w: epoch %d: %s", vmhost.ErrInvalidEpochGasSchedule, epochGasSchedule.StartEpoch, err)
+		}
+	}
+
+	return sorted, nil
+}
+
+// sortEpochCodeLimits sorts the code limits configured per epoch by start epoch and rejects duplicated start epochs
+func sortEpochCodeLimits(epochCodeLimits []vmhost.EpochCodeLimits) ([]vmhost.EpochCodeLimits, error) {
+	sorted := make([]vmhost.EpochCodeLimits, len(epochCodeLimits))
+	copy(sorted, epochCodeLimits)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].StartEpoch < sorted[j].StartEpoch
+	})
+
+	for i := 1; i < len(sorted); i++ {
+		if sorted[i-1].StartEpoch == sorted[i].StartEpoch {
+			return nil, fmt.Errorf("%w: epoch %d", vmhost.ErrDuplicateEpochCodeLimits, sorted[i].StartEpoch)
+		}
+	}
+
+	return sorted, nil
 }
 
 func validateVMInput(vmInput *vmcommon.VMInput) error {
@@ -9,8 +9,11 @@ import (
 	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
 	"github.com/multiversx/mx-chain-vm-common-go/builtInFunctions"
 	"github.com/multiversx/mx-chain-vm-common-go/parsers"
+	"github.com/multiversx/mx-chain-vm-go/config"
+	contextmock "github.com/multiversx/mx-chain-vm-go/mock/context"
 	"github.com/multiversx/mx-chain-vm-go/vmhost"
 	"github.com/multiversx/mx-chain-vm-go/vmhost/mock"
+	"github.com/multiversx/mx-chain-vm-go/wasmer2"
 	"github.com/stretchr/testify/require"
 )
 
@@ -98,6 +101,128 @@ func TestNewVMHost(t *testing.T) {
 		require.Nil(t, host)
 		require.ErrorIs(t, err, vmhost.ErrNilVMType)
 	})
+	t.Run("InstructionBudgetWatchdogWithoutBudget", func(t *testing.T) {
+		hostParameters := makeHostParameters()
+		hostParameters.ExecutionWatchdog = vmhost.InstructionBudgetWatchdog
+		host, err := NewVMHost(blockchainHook, hostParameters)
+		require.Nil(t, host)
+		require.ErrorIs(t, err, vmhost.ErrInvalidExecutionWatchdog)
+	})
+	t.Run("UnknownExecutionWatchdog", func(t *testing.T) {
+		hostParameters := makeHostParameters()
+		hostParameters.ExecutionWatchdog = vmhost.InstructionBudgetWatchdog + 1
+		hostParameters.ExecutionInstructionBudget = 1000
+		host, err := NewVMHost(blockchainHook, hostParameters)
+		require.Nil(t, host)
+		require.ErrorIs(t, err, vmhost.ErrInvalidExecutionWatchdog)
+	})
+	t.Run("InstructionBudgetWatchdogWithoutCountInstructionsHook", func(t *testing.T) {
+		hostParameters := makeHostParameters()
+		hostParameters.GasSchedule = config.MakeGasMapForTests()
+		hostParameters.OverrideVMExecutor = wasmer2.ExecutorFactory()
+		hostParameters.ExecutionWatchdog = vmhost.InstructionBudgetWatchdog
+		hostParameters.ExecutionInstructionBudget = 1000
+		host, err := NewVMHost(blockchainHook, hostParameters)
+		require.Nil(t, host)
+		require.ErrorIs(t, err, vmhost.ErrInvalidExecutionWatchdog)
+	})
+}
+
+func TestVMHost_EpochGasSchedules(t *testing.T) {
+	esdtTransferParser, err := parsers.NewESDTTransferParser(worldmock.WorldMarshalizer)
+	require.Nil(t, err)
+
+	world := worldmock.NewMockWorld()
+	makeHostParameters := func(epochGasSchedules []vmhost.EpochGasSchedule) *vmhost.VMHostParameters {
+		return &vmhost.VMHostParameters{
+			VMType:                    []byte("vmType"),
+			OverrideVMExecutor:        contextmock.NewExecutorMockFactory(world),
+			ESDTTransferParser:        esdtTransferParser,
+			BuiltInFuncContainer:      builtInFunctions.NewBuiltInFunctionContainer(),
+			EpochNotifier:             &mock.EpochNotifierStub{},
+			EnableEpochsHandler:       &worldmock.EnableEpochsHandlerStub{},
+			Hasher:                    worldmock.DefaultHasher,
+			MapOpcodeAddressIsAllowed: map[string]map[string]struct{}{},
+			ProtectedKeyPrefix:        []byte(core.ProtectedKeyPrefix),
+			BlockGasLimit:             uint64(math.MaxUint64),
+			GasSchedule:               config.MakeGasMapForTests(),
+			EpochGasSchedules:         epochGasSchedules,
+		}
+	}
+
+	t.Run("SwitchOnEpochConfirmed", func(t *testing.T) {
+		epochGasSchedules := []vmhost.EpochGasSchedule{
+			{StartEpoch: 10, GasSchedule: config.MakeGasMap(3, 1)},
+			{StartEpoch: 5, GasSchedule: config.MakeGasMap(2, 1)},
+		}
+		host, err := NewVMHost(world, makeHostParameters(epochGasSchedules))
+		require.Nil(t, err)
+		vmHost := host.(*vmHost)
+		defer vmHost.Reset()
+
+		requireGetSCAddressCost := func(expected uint64) {
+			require.Equal(t, expected, vmHost.GetGasScheduleMap()["BaseOpsAPICost"]["GetSCAddress"])
+			require.Equal(t, expected, vmHost.Metering().GasSchedule().BaseOpsAPICost.GetSCAddress)
+		}
+		requireGetSCAddressCost(1)
+
+		vmHost.EpochConfirmed(7, 0)
+		requireGetSCAddressCost(2)
+
+		vmHost.EpochConfirmed(12, 0)
+		requireGetSCAddressCost(3)
+
+		vmHost.EpochConfirmed(3, 0)
+		requireGetSCAddressCost(1)
+
+		vmHost.EpochConfirmed(5, 0)
+		requireGetSCAddressCost(2)
+	})
+	t.Run("GasScheduleChangeReplacesBase", func(t *testing.T) {
+		epochGasSchedules := []vmhost.EpochGasSchedule{
+			{StartEpoch: 5, GasSchedule: config.MakeGasMap(2, 1)},
+		}
+		host, err := NewVMHost(world, makeHostParameters(epochGasSchedules))
+		require.Nil(t, err)
+		vmHost := host.(*vmHost)
+		defer vmHost.Reset()
+
+		requireGetSCAddressCost := func(expected uint64) {
+			require.Equal(t, expected, vmHost.GetGasScheduleMap()["BaseOpsAPICost"]["GetSCAddress"])
+			require.Equal(t, expected, vmHost.Metering().GasSchedule().BaseOpsAPICost.GetSCAddress)
+		}
+
+		vmHost.GasScheduleChange(config.MakeGasMap(4, 1))
+		requireGetSCAddressCost(4)
+
+		vmHost.EpochConfirmed(7, 0)
+		requireGetSCAddressCost(2)
+
+		vmHost.GasScheduleChange(config.MakeGasMap(6, 1))
+		requireGetSCAddressCost(2)
+
+		vmHost.EpochConfirmed(3, 0)
+		requireGetSCAddressCost(6)
+	})
+	t.Run("DuplicateStartEpoch", func(t *testing.T) {
+		epochGasSchedules := []vmhost.EpochGasSchedule{
+			{StartEpoch: 5, GasSchedule: config.MakeGasMap(2, 1)},
+			{StartEpoch: 5, GasSchedule: config.MakeGasMap(3, 1)},
+		}
+		host, err := NewVMHost(world, makeHostParameters(epochGasSchedules))
+		require.Nil(t, host)
+		require.ErrorIs(t, err, vmhost.ErrDuplicateEpochGasSchedule)
+	})
+	t.Run("InvalidGasSchedule", func(t *testing.T) {
+		gasSchedule := config.MakeGasMap(2, 1)
+		delete(gasSchedule, "BaseOpsAPICost")
+		epochGasSchedules := []vmhost.EpochGasSchedule{
+			{StartEpoch: 5, GasSchedule: gasSchedule},
+		}
+		host, err := NewVMHost(world, makeHostParameters(epochGasSchedules))
+		require.Nil(t, host)
+		require.ErrorIs(t, err, vmhost.ErrInvalidEpochGasSchedule)
+	})
 }
 
 func TestValidateVMInput(t *testing.T) {
@@ -113,3 +238,81 @@ func TestValidateVMInput(t *testing.T) {
 	err = validateVMInput(vmInput)
 	require.Nil(t, err)
 }
+
+func TestVMHost_EpochCodeLimits(t *testing.T) {
+	esdtTransferParser, err := parsers.NewESDTTransferParser(worldmock.WorldMarshalizer)
+	require.Nil(t, err)
+
+	world := worldmock.NewMockWorld()
+	baseCodeLimits := vmhost.CodeLimits{MaxCodeSize: 1000}
+	makeHostParameters := func(epochCodeLimits []vmhost.EpochCodeLimits) *vmhost.VMHostParameters {
+		return &vmhost.VMHostParameters{
+			VMType:                    []byte("vmType"),
+			OverrideVMExecutor:        contextmock.NewExecutorMockFactory(world),
+			ESDTTransferParser:        esdtTransferParser,
+			BuiltInFuncContainer:      builtInFunctions.NewBuiltInFunctionContainer(),
+			EpochNotifier:             &mock.EpochNotifierStub{},
+			EnableEpochsHandler:       &worldmock.EnableEpochsHandlerStub{},
+			Hasher:                    worldmock.DefaultHasher,
+			MapOpcodeAddressIsAllowed: map[string]map[string]struct{}{},
+			ProtectedKeyPrefix:        []byte(core.ProtectedKeyPrefix),
+			BlockGasLimit:             uint64(math.MaxUint64),
+			GasSchedule:               config.MakeGasMapForTests(),
+			CodeLimits:                baseCodeLimits,
+			EpochCodeLimits:           epochCodeLimits,
+		}
+	}
+
+	t.Run("SwitchOnEpochConfirmed", func(t *testing.T) {
+		epochCodeLimits := []vmhost.EpochCodeLimits{
+			{StartEpoch: 10, CodeLimits: vmhost.CodeLimits{MaxCodeSize: 3000, MaxImports: 30}},
+			{StartEpoch: 5, CodeLimits: vmhost.CodeLimits{MaxCodeSize: 2000, MaxImports: 20}},
+		}
+		host, err := NewVMHost(world, makeHostParameters(epochCodeLimits))
+		require.Nil(t, err)
+		vmHost := host.(*vmHost)
+		defer vmHost.Reset()
+
+		var appliedCodeLimits []vmhost.CodeLimits
+		runtimeWrapper := contextmock.NewRuntimeContextWrapper(&vmHost.runtimeContext)
+		runtimeWrapper.SetCodeLimitsFunc = func(limits vmhost.CodeLimits) {
+			appliedCodeLimits = append(appliedCodeLimits, limits)
+		}
+		vmHost.runtimeContext = runtimeWrapper
+
+		vmHost.EpochConfirmed(7, 0)
+		vmHost.EpochConfirmed(8, 0)
+		vmHost.EpochConfirmed(12, 0)
+		vmHost.EpochConfirmed(3, 0)
+
+		// confirming epoch 8 keeps the limits of epoch 5 active, without applying them again
+		require.Equal(t, []vmhost.CodeLimits{
+			epochCodeLimits[1].CodeLimits,
+			epochCodeLimits[0].CodeLimits,
+			baseCodeLimits,
+		}, appliedCodeLimits)
+	})
+	t.Run("DuplicateStartEpoch", func(t *testing.T) {
+		epochCodeLimits := []vmhost.EpochCodeLimits{
+			{StartEpoch: 5, CodeLimits: vmhost.CodeLimits{MaxImports: 20}},
+			{StartEpoch: 5, CodeLimits: vmhost.CodeLimits{MaxImports: 30}},
+		}
+		host, err := NewVMHost(world, makeHostParameters(epochCodeLimits))
+		require.Nil(t, host)
+		require.ErrorIs(t, err, vmhost.ErrDuplicateEpochCodeLimits)
+	})
+}
+
+func TestActiveEpochIndex(t *testing.T) {
+	startEpochs := []uint32{10, 20, 30}
+	startEpochOf := func(i int) uint32 {
+		return startEpochs[i]
+	}
+
+	require.Equal(t, -1, activeEpochIndex(0, startEpochOf, 15))
+	require.Equal(t, -1, activeEpochIndex(len(startEpochs), startEpochOf, 9))
+	require.Equal(t, 0, activeEpochIndex(len(startEpochs), startEpochOf, 10))
+	require.Equal(t, 0, activeEpochIndex(len(startEpochs), startEpochOf, 19))
+	require.Equal(t, 1, activeEpochIndex(len(startEpochs), startEpochOf, 20))
+	require.Equal(t, 2, activeEpochIndex(len(startEpochs), startEpochOf, 1000))
+}
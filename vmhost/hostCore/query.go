@@ -0,0 +1,49 @@
+package hostCore
+
+import (
+	"fmt"
+
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	"github.com/multiversx/mx-chain-vm-go/vmhost"
+)
+
+var _ vmhost.QueryExecutor = (*vmHost)(nil)
+
+// RunSmartContractQuery executes a call on behalf of an off-chain client, in read-only mode for the whole call tree.
+// Storage writes, transfers and async calls are rejected, the async context is never persisted and the warm
+// instance cache is only read, so that queries do not influence the executions of transactions. Queries are
+// bounded by QueryGasLimit and TimeOutForSCQueryInMilliseconds instead of the instruction budget, which is
+// meant to keep the outcome of transactions identical on all machines.
+func (host *vmHost) RunSmartContractQuery(input *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+	err := host.validateQueryInput(input)
+	if err != nil {
+		return nil, err
+	}
+
+	vmOutput, _, err := host.runSmartContractCall(input, host.queryTimeout, true)
+	return vmOutput, err
+}
+
+// IsQueryExecution returns true while the host executes a query started by RunSmartContractQuery.
+func (host *vmHost) IsQueryExecution() bool {
+	return host.queryExecution
+}
+
+func (host *vmHost) validateQueryInput(input *vmcommon.ContractCallInput) error {
+	err := validateVMInput(&input.VMInput)
+	if err != nil {
+		return err
+	}
+
+	if host.queryGasLimit > 0 && input.GasProvided > host.queryGasLimit {
+		return fmt.Errorf("%w: provided %d, limit %d", vmhost.ErrQueryGasLimitExceeded, input.GasProvided, host.queryGasLimit)
+	}
+	if (input.CallValue != nil && input.CallValue.Sign() > 0) || len(input.ESDTTransfers) > 0 {
+		return vmhost.ErrTransferInQuery
+	}
+	if input.Function == vmhost.UpgradeFunctionName || input.Function == vmhost.DeleteFunctionName || host.isContractFreezeCall(input) {
+		return fmt.Errorf("%w (%s)", vmhost.ErrInvalidCallOnReadOnlyMode, input.Function)
+	}
+
+	return nil
+}
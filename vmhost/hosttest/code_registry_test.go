@@ -0,0 +1,272 @@
+package hostCoretest
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/hashing/blake2b"
+	"github.com/multiversx/mx-chain-scenario-go/worldmock"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	contextmock "github.com/multiversx/mx-chain-vm-go/mock/context"
+	"github.com/multiversx/mx-chain-vm-go/mock/contracts"
+	test "github.com/multiversx/mx-chain-vm-go/testcommon"
+	"github.com/multiversx/mx-chain-vm-go/vmhost"
+	"github.com/multiversx/mx-chain-vm-go/vmhost/vmhooks"
+	"github.com/stretchr/testify/require"
+)
+
+const codeRegistryGasProvided = 1_000_000
+
+// sharedCode is larger than a code reference, as the code of the contracts deployed by the factories.
+var sharedCode = bytes.Repeat([]byte("shared"), 200)
+
+// codeFactoryMock deploys contracts from a source contract, uploading its code, or by the hash of a registered code,
+// and registers the code of a source contract.
+func codeFactoryMock(instanceMock *contextmock.InstanceMock, _ interface{}) {
+	deploy := func(name string, deployHook func(hooks *vmhooks.VMHooksImpl, argumentHandle, resultAddressHandle int32) int32) {
+		instanceMock.AddMockMethod(name, func() *contextmock.InstanceMock {
+			host := instanceMock.Host
+			instance := contextmock.GetMockInstance(host)
+			managedTypes := host.ManagedTypes()
+
+			argumentHandle := managedTypes.NewManagedBufferFromBytes(host.Runtime().Arguments()[0])
+			resultAddressHandle := managedTypes.NewManagedBuffer()
+			result := deployHook(vmhooks.NewVMHooksImpl(host), argumentHandle, resultAddressHandle)
+			if result != 0 {
+				return instance
+			}
+
+			newAddress, _ := managedTypes.GetBytes(resultAddressHandle)
+			host.Output().Finish(newAddress)
+			return instance
+		})
+	}
+
+	deploy("deployFromSource", func(hooks *vmhooks.VMHooksImpl, sourceHandle, resultAddressHandle int32) int32 {
+		managedTypes := instanceMock.Host.ManagedTypes()
+		return hooks.ManagedDeployFromSourceContract(
+			codeRegistryGasProvided/10,
+			managedTypes.NewBigIntFromInt64(0),
+			sourceHandle,
+			managedTypes.NewManagedBufferFromBytes([]byte{0, 0}),
+			managedTypes.NewManagedBuffer(),
+			resultAddressHandle,
+			managedTypes.NewManagedBuffer(),
+		)
+	})
+	instanceMock.AddMockMethod("registerCode", func() *contextmock.InstanceMock {
+		host := instanceMock.Host
+		instance := contextmock.GetMockInstance(host)
+		managedTypes := host.ManagedTypes()
+
+		sourceHandle := managedTypes.NewManagedBufferFromBytes(host.Runtime().Arguments()[0])
+		codeHashHandle := managedTypes.NewManagedBuffer()
+		result := vmhooks.NewVMHooksImpl(host).ManagedRegisterCode(sourceHandle, codeHashHandle)
+		if result != 0 {
+			return instance
+		}
+
+		codeHash, _ := managedTypes.GetBytes(codeHashHandle)
+		host.Output().Finish(codeHash)
+		return instance
+	})
+	instanceMock.AddMockMethod("getCodeHash", func() *contextmock.InstanceMock {
+		host := instanceMock.Host
+		instance := contextmock.GetMockInstance(host)
+		managedTypes := host.ManagedTypes()
+
+		addressHandle := managedTypes.NewManagedBufferFromBytes(host.Runtime().Arguments()[0])
+		codeHashHandle := managedTypes.NewManagedBuffer()
+		vmhooks.NewVMHooksImpl(host).ManagedGetCodeHash(addressHandle, codeHashHandle)
+
+		codeHash, _ := managedTypes.GetBytes(codeHashHandle)
+		host.Output().Finish(codeHash)
+		return instance
+	})
+	deploy("deployFromCodeHash", func(hooks *vmhooks.VMHooksImpl, codeHashHandle, resultAddressHandle int32) int32 {
+		managedTypes := instanceMock.Host.ManagedTypes()
+		return hooks.ManagedDeployFromCodeHash(
+			codeRegistryGasProvided/10,
+			managedTypes.NewBigIntFromInt64(0),
+			codeHashHandle,
+			managedTypes.NewManagedBufferFromBytes([]byte{0, 0}),
+			managedTypes.NewManagedBuffer(),
+			resultAddressHandle,
+			managedTypes.NewManagedBuffer(),
+		)
+	})
+}
+
+// sharedCodeMock is the contract deployed by the factory.
+func sharedCodeMock(instanceMock *contextmock.InstanceMock, config interface{}) {
+	contracts.InitMockMethod(instanceMock, config)
+	instanceMock.AddMockMethod("ping", func() *contextmock.InstanceMock {
+		host := instanceMock.Host
+		host.Output().Finish([]byte("pong"))
+		return contextmock.GetMockInstance(host)
+	})
+}
+
+func newCodeRegistrySequence(t *testing.T) *mockCallSequence {
+	testConfig := makeTestConfig()
+	return newMockCallSequence(t,
+		test.CreateMockContract(sc1Address).
+			WithConfig(testConfig).
+			WithCode(sharedCode).
+			WithMethods(sharedCodeMock),
+		test.CreateMockContract(test.ParentAddress).
+			WithBalance(testConfig.ParentBalance).
+			WithConfig(testConfig).
+			WithMethods(codeFactoryMock))
+}
+
+func codeRegistryInput(recipient []byte, function string, arguments ...[]byte) *vmcommon.ContractCallInput {
+	return test.CreateTestContractCallInputBuilder().
+		WithCallerAddr(test.UserAddress).
+		WithRecipientAddr(recipient).
+		WithGasProvided(codeRegistryGasProvided).
+		WithFunction(function).
+		WithArguments(arguments...).
+		Build()
+}
+
+func factoryInput(function string, arguments ...[]byte) *vmcommon.ContractCallInput {
+	return codeRegistryInput(test.ParentAddress, function, arguments...)
+}
+
+func codeRegistryOk(_ *worldmock.MockWorld, verify *test.VMOutputVerifier) {
+	verify.Ok()
+}
+
+func codeHashNotRegistered(_ *worldmock.MockWorld, verify *test.VMOutputVerifier) {
+	verify.ExecutionFailed().
+		ReturnMessageContains(vmhost.ErrCodeHashNotRegistered.Error())
+}
+
+func systemAccountUntouched(verify *test.VMOutputVerifier) {
+	require.NotContains(verify.T, verify.VmOutput.OutputAccounts, string(core.SystemAccountAddress))
+}
+
+func sharedCodeHash() []byte {
+	return blake2b.NewBlake2b().Compute(string(sharedCode))
+}
+
+func registryKey() []byte {
+	return append(vmProtectedKey(vmhost.CodeRegistryKey), sharedCodeHash()...)
+}
+
+func referenceKey() []byte {
+	referenceCodeHash := blake2b.NewBlake2b().Compute(string(vmhost.NewCodeReference(sharedCodeHash())))
+	return append(vmProtectedKey(vmhost.CodeReferenceKey), referenceCodeHash...)
+}
+
+func TestCodeRegistry_DeployByCodeHash(t *testing.T) {
+	sequence := newCodeRegistrySequence(t)
+
+	// uploading a code does not register it
+	sequence.call(factoryInput("deployFromSource", sc1Address),
+		func(_ *worldmock.MockWorld, verify *test.VMOutputVerifier) {
+			verify.Ok()
+			systemAccountUntouched(verify)
+		})
+
+	sequence.call(factoryInput("registerCode", sc1Address),
+		func(_ *worldmock.MockWorld, verify *test.VMOutputVerifier) {
+			verify.Ok().
+				ReturnData(sharedCodeHash()).
+				Storage(
+					test.CreateStoreEntry(core.SystemAccountAddress).WithKey(registryKey()).WithValue(sharedCode),
+					test.CreateStoreEntry(core.SystemAccountAddress).WithKey(referenceKey()).WithValue(sharedCodeHash()),
+				)
+		})
+
+	vmOutput := sequence.call(factoryInput("deployFromCodeHash", sharedCodeHash()),
+		func(_ *worldmock.MockWorld, verify *test.VMOutputVerifier) {
+			verify.Ok()
+			require.Len(t, verify.VmOutput.ReturnData, 1)
+			verify.Code(verify.VmOutput.ReturnData[0], vmhost.NewCodeReference(sharedCodeHash()))
+			systemAccountUntouched(verify)
+		})
+	childAddress := vmOutput.ReturnData[0]
+
+	sequence.call(codeRegistryInput(childAddress, "ping"),
+		func(_ *worldmock.MockWorld, verify *test.VMOutputVerifier) {
+			verify.Ok().
+				ReturnData([]byte("pong"))
+		})
+}
+
+func TestCodeRegistry_CodeHashOfCommittedReference(t *testing.T) {
+	sequence := newCodeRegistrySequence(t)
+	sequence.call(factoryInput("registerCode", sc1Address), codeRegistryOk)
+	childAddress := sequence.call(factoryInput("deployFromCodeHash", sharedCodeHash()), codeRegistryOk).ReturnData[0]
+
+	// the child is committed and not touched by the transaction reading its code hash
+	sequence.withSetup(func(host vmhost.VMHost, _ *worldmock.MockWorld) {
+		require.Equal(t, sharedCodeHash(), host.Blockchain().GetCodeHash(childAddress))
+	})
+	sequence.call(factoryInput("getCodeHash", childAddress),
+		func(_ *worldmock.MockWorld, verify *test.VMOutputVerifier) {
+			verify.Ok().
+				ReturnData(sharedCodeHash())
+		})
+}
+
+func TestCodeRegistry_GasRules(t *testing.T) {
+	const (
+		storePerByteCost = uint64(10)
+		getCodeCost      = uint64(1000)
+	)
+	sequence := newCodeRegistrySequence(t).
+		withSetup(func(host vmhost.VMHost, _ *worldmock.MockWorld) {
+			gasSchedule := host.Metering().GasSchedule()
+			gasSchedule.BaseOperationCost.StorePerByte = storePerByteCost
+			gasSchedule.BaseOperationCost.GetCode = getCodeCost
+		})
+	gasUsed := func(input *vmcommon.ContractCallInput) uint64 {
+		return codeRegistryGasProvided - sequence.call(input, codeRegistryOk).GasRemaining
+	}
+
+	// the first registration pays for storing the code and its hash, the next ones only for reading it
+	firstRegistration := gasUsed(factoryInput("registerCode", sc1Address))
+	registration := gasUsed(factoryInput("registerCode", sc1Address))
+	storedLength := uint64(len(sharedCode) + len(sharedCodeHash()))
+	require.Equal(t, storePerByteCost*storedLength, firstRegistration-registration)
+
+	// a deployment by reference pays for compiling the whole registered code, like an upload,
+	// and for reading the code from the registry
+	upload := gasUsed(factoryInput("deployFromSource", sc1Address))
+	reference := gasUsed(factoryInput("deployFromCodeHash", sharedCodeHash()))
+	require.Equal(t, getCodeCost, reference-upload)
+}
+
+func TestCodeRegistry_RegisterWithoutCode(t *testing.T) {
+	newCodeRegistrySequence(t).
+		call(factoryInput("registerCode", test.UserAddress),
+			func(_ *worldmock.MockWorld, verify *test.VMOutputVerifier) {
+				verify.ExecutionFailed()
+				systemAccountUntouched(verify)
+			})
+}
+
+func TestCodeRegistry_NotRegistered(t *testing.T) {
+	sequence := newCodeRegistrySequence(t)
+	sequence.call(factoryInput("deployFromSource", sc1Address), codeRegistryOk)
+
+	sequence.call(factoryInput("deployFromCodeHash", sharedCodeHash()), codeHashNotRegistered)
+	sequence.call(factoryInput("deployFromCodeHash", []byte("short")), codeHashNotRegistered)
+}
+
+func TestCodeRegistry_FlagDisabled(t *testing.T) {
+	sequence := newCodeRegistrySequence(t)
+	sequence.call(factoryInput("registerCode", sc1Address), codeRegistryOk)
+
+	sequence.withSetup(func(host vmhost.VMHost, _ *worldmock.MockWorld) {
+		enableEpochsHandler, _ := host.EnableEpochsHandler().(*worldmock.EnableEpochsHandlerStub)
+		enableEpochsHandler.IsFlagEnabledCalled = func(flag core.EnableEpochFlag) bool {
+			return flag != vmhost.CodeRegistryFlag
+		}
+	})
+	sequence.call(factoryInput("deployFromCodeHash", sharedCodeHash()), codeHashNotRegistered)
+}
@@ -0,0 +1,138 @@
+package hostCoretest
+
+import (
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-scenario-go/worldmock"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	"github.com/multiversx/mx-chain-vm-go/contractabi"
+	contextmock "github.com/multiversx/mx-chain-vm-go/mock/context"
+	test "github.com/multiversx/mx-chain-vm-go/testcommon"
+	"github.com/multiversx/mx-chain-vm-go/vmhost"
+	"github.com/stretchr/testify/require"
+)
+
+const adderABIJSON = `{
+	"name": "Adder",
+	"constructor": {"inputs": [{"name": "initial_value", "type": "BigUint"}], "outputs": []},
+	"endpoints": [
+		{"name": "add", "mutability": "mutable", "inputs": [{"name": "value", "type": "BigUint"}], "outputs": []},
+		{"name": "addMany", "mutability": "mutable", "inputs": [{"name": "values", "type": "variadic<BigUint>"}], "outputs": []}
+	]
+}`
+
+var wasmHeader = []byte{0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00}
+
+// adderMock records the name of the ABI seen by its endpoints, including the ones missing from the ABI.
+func adderMock(seenABIName *string) func(*contextmock.InstanceMock, interface{}) {
+	return func(instanceMock *contextmock.InstanceMock, _ interface{}) {
+		for _, name := range []string{"add", "addMany", "notInABI"} {
+			instanceMock.AddMockMethod(name, func() *contextmock.InstanceMock {
+				host := instanceMock.Host
+				*seenABIName = ""
+				contractABI := host.Runtime().GetContractABI()
+				if contractABI != nil {
+					*seenABIName = contractABI.Name
+				}
+				return contextmock.GetMockInstance(host)
+			})
+		}
+	}
+}
+
+func runContractABITest(t *testing.T, code []byte, flagEnabled bool, function string, numArgs int) (*vmcommon.VMOutput, string) {
+	testConfig := makeTestConfig()
+	world := worldmock.NewMockWorld()
+	world.AcctMap.CreateAccount(test.UserAddress, world)
+
+	executorFactory := contextmock.NewExecutorMockFactory(world)
+	host := test.NewTestHostBuilder(t).
+		WithExecutorFactory(executorFactory).
+		WithBlockchainHook(world).
+		Build()
+	defer host.Reset()
+
+	if !flagEnabled {
+		enableEpochsHandler, _ := host.EnableEpochsHandler().(*worldmock.EnableEpochsHandlerStub)
+		enableEpochsHandler.IsFlagEnabledCalled = func(flag core.EnableEpochFlag) bool {
+			return flag != vmhost.ContractABIArgumentsCheckFlag
+		}
+	}
+
+	seenABIName := "not called"
+	parent := test.CreateMockContract(test.ParentAddress).
+		WithBalance(testConfig.ParentBalance).
+		WithConfig(testConfig).
+		WithCode(code).
+		WithMethods(adderMock(&seenABIName))
+	parent.Initialize(t, host, executorFactory.LastCreatedExecutor, true)
+
+	arguments := make([][]byte, numArgs)
+	for i := range arguments {
+		arguments[i] = []byte{byte(i + 1)}
+	}
+	input := test.CreateTestContractCallInputBuilder().
+		WithRecipientAddr(test.ParentAddress).
+		WithGasProvided(testConfig.GasProvided).
+		WithFunction(function).
+		WithArguments(arguments...).
+		Build()
+
+	vmOutput, err := host.RunSmartContractCall(input)
+	require.Nil(t, err)
+	return vmOutput, seenABIName
+}
+
+func TestContractABI_ExposedToEndpoints(t *testing.T) {
+	code := contractabi.AppendToCode(wasmHeader, []byte(adderABIJSON))
+	vmOutput, seenABIName := runContractABITest(t, code, true, "add", 1)
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+	require.Equal(t, "Adder", seenABIName)
+
+	vmOutput, seenABIName = runContractABITest(t, wasmHeader, true, "add", 1)
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+	require.Empty(t, seenABIName)
+
+	// a malformed ABI is ignored, like a missing one
+	code = contractabi.AppendToCode(wasmHeader, []byte("{"))
+	vmOutput, seenABIName = runContractABITest(t, code, true, "add", 1)
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+	require.Empty(t, seenABIName)
+}
+
+func TestContractABI_ArgumentCountMismatch(t *testing.T) {
+	code := contractabi.AppendToCode(wasmHeader, []byte(adderABIJSON))
+
+	vmOutput, seenABIName := runContractABITest(t, code, true, "add", 2)
+	require.Equal(t, vmcommon.UserError, vmOutput.ReturnCode)
+	require.Equal(t, "wrong number of arguments for endpoint add: expected 1, received 2", vmOutput.ReturnMessage)
+	require.Equal(t, "not called", seenABIName)
+
+	vmOutput, _ = runContractABITest(t, code, true, "add", 0)
+	require.Equal(t, vmcommon.UserError, vmOutput.ReturnCode)
+}
+
+func TestContractABI_ArgumentCountAccepted(t *testing.T) {
+	code := contractabi.AppendToCode(wasmHeader, []byte(adderABIJSON))
+	testCases := []struct {
+		function string
+		numArgs  int
+	}{
+		{"addMany", 0},
+		{"addMany", 5},
+		{"notInABI", 3},
+	}
+
+	for _, testCase := range testCases {
+		vmOutput, _ := runContractABITest(t, code, true, testCase.function, testCase.numArgs)
+		require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode, testCase.function)
+	}
+}
+
+func TestContractABI_ArgumentCountNotCheckedWithoutFlag(t *testing.T) {
+	code := contractabi.AppendToCode(wasmHeader, []byte(adderABIJSON))
+	vmOutput, seenABIName := runContractABITest(t, code, false, "add", 2)
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+	require.Equal(t, "Adder", seenABIName)
+}
@@ -0,0 +1,204 @@
+package hostCoretest
+
+import (
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-scenario-go/worldmock"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	contextmock "github.com/multiversx/mx-chain-vm-go/mock/context"
+	"github.com/multiversx/mx-chain-vm-go/mock/contracts"
+	test "github.com/multiversx/mx-chain-vm-go/testcommon"
+	"github.com/multiversx/mx-chain-vm-go/vmhost"
+	"github.com/stretchr/testify/require"
+)
+
+var freezableEndpoints = []string{"deposit", "withdraw"}
+
+var freezeOwnerAddress = test.MakeTestSCAddressWithDefaultVM("freezeOwner")
+
+// freezableMock counts the calls of its endpoints.
+func freezableMock(calls map[string]int) func(*contextmock.InstanceMock, interface{}) {
+	return func(instanceMock *contextmock.InstanceMock, _ interface{}) {
+		for _, name := range freezableEndpoints {
+			endpointName := name
+			instanceMock.AddMockMethod(endpointName, func() *contextmock.InstanceMock {
+				calls[endpointName]++
+				return contextmock.GetMockInstance(instanceMock.Host)
+			})
+		}
+	}
+}
+
+// newContractFreezeSequence deploys the freezable contract at ChildAddress, owned by freezeOwnerAddress,
+// and a parent which calls it on the destination context.
+func newContractFreezeSequence(t *testing.T, calls map[string]int) *mockCallSequence {
+	testConfig := makeTestConfig()
+	sequence := newMockCallSequence(t,
+		test.CreateMockContract(test.ChildAddress).
+			WithBalance(testConfig.ChildBalance).
+			WithConfig(testConfig).
+			WithOwnerAddress(freezeOwnerAddress).
+			WithMethods(freezableMock(calls)),
+		test.CreateMockContract(test.ParentAddress).
+			WithBalance(testConfig.ParentBalance).
+			WithConfig(testConfig).
+			WithMethods(contracts.ExecOnDestCtxSingleCallParentMock))
+	sequence.world.AcctMap.CreateAccount(freezeOwnerAddress, sequence.world)
+	return sequence
+}
+
+func freezeInput(caller []byte, function string, arguments ...[]byte) *vmcommon.ContractCallInput {
+	return test.CreateTestContractCallInputBuilder().
+		WithCallerAddr(caller).
+		WithRecipientAddr(test.ChildAddress).
+		WithGasProvided(makeTestConfig().GasProvided).
+		WithFunction(function).
+		WithArguments(arguments...).
+		Build()
+}
+
+func freezeOk(_ *worldmock.MockWorld, verify *test.VMOutputVerifier) {
+	verify.Ok()
+}
+
+func freezeFailed(message string) test.AssertResultsFunc {
+	return func(_ *worldmock.MockWorld, verify *test.VMOutputVerifier) {
+		verify.UserError().
+			ReturnMessage(message)
+	}
+}
+
+func freezeLogged(identifier string, topics ...[]byte) test.AssertResultsFunc {
+	return func(_ *worldmock.MockWorld, verify *test.VMOutputVerifier) {
+		verify.Ok()
+		require.Len(verify.T, verify.VmOutput.Logs, 1)
+		require.Equal(verify.T, []byte(identifier), verify.VmOutput.Logs[0].Identifier)
+		if len(topics) > 0 {
+			require.Equal(verify.T, topics, verify.VmOutput.Logs[0].Topics)
+		}
+	}
+}
+
+func TestContractFreeze_RejectsNonOwnerCalls(t *testing.T) {
+	calls := make(map[string]int)
+	sequence := newContractFreezeSequence(t, calls)
+
+	sequence.call(freezeInput(freezeOwnerAddress, vmhost.FreezeFunctionName, []byte("withdraw")),
+		freezeLogged(vmhost.FreezeFunctionName, []byte("withdraw")))
+
+	sequence.call(freezeInput(test.UserAddress, "deposit"),
+		func(_ *worldmock.MockWorld, verify *test.VMOutputVerifier) {
+			verify.UserError().
+				ReturnMessageContains(vmhost.ErrContractFrozen.Error())
+		})
+	require.Zero(t, calls["deposit"])
+
+	// the allowed endpoints stay open, and the owner may still call the contract
+	sequence.call(freezeInput(test.UserAddress, "withdraw"), freezeOk)
+	require.Equal(t, 1, calls["withdraw"])
+
+	sequence.call(freezeInput(freezeOwnerAddress, "deposit"), freezeOk)
+	require.Equal(t, 1, calls["deposit"])
+}
+
+func TestContractFreeze_NestedCall(t *testing.T) {
+	calls := make(map[string]int)
+	sequence := newContractFreezeSequence(t, calls)
+	sequence.call(freezeInput(freezeOwnerAddress, vmhost.FreezeFunctionName), freezeOk)
+
+	input := test.CreateTestContractCallInputBuilder().
+		WithCallerAddr(test.UserAddress).
+		WithRecipientAddr(test.ParentAddress).
+		WithGasProvided(makeTestConfig().GasProvided).
+		WithFunction("execOnDestCtxSingleCall").
+		WithArguments(test.ChildAddress, []byte("deposit")).
+		Build()
+	sequence.call(input, func(_ *worldmock.MockWorld, verify *test.VMOutputVerifier) {
+		require.NotEqual(t, vmcommon.Ok, verify.VmOutput.ReturnCode)
+	})
+	require.Zero(t, calls["deposit"])
+}
+
+func TestContractFreeze_Unfreeze(t *testing.T) {
+	calls := make(map[string]int)
+	sequence := newContractFreezeSequence(t, calls)
+
+	sequence.call(freezeInput(freezeOwnerAddress, vmhost.UnfreezeFunctionName), freezeFailed(vmhost.ErrContractNotFrozen.Error()))
+
+	sequence.call(freezeInput(freezeOwnerAddress, vmhost.FreezeFunctionName), freezeOk)
+	sequence.call(freezeInput(test.UserAddress, vmhost.UnfreezeFunctionName), freezeFailed(vmhost.ErrFreezeNotAllowed.Error()))
+	sequence.call(freezeInput(freezeOwnerAddress, vmhost.UnfreezeFunctionName), freezeLogged(vmhost.UnfreezeFunctionName))
+
+	sequence.call(freezeInput(test.UserAddress, "deposit"), freezeOk)
+	require.Equal(t, 1, calls["deposit"])
+}
+
+func TestContractFreeze_NotAllowed(t *testing.T) {
+	sequence := newContractFreezeSequence(t, make(map[string]int))
+
+	sequence.call(freezeInput(test.UserAddress, vmhost.FreezeFunctionName), freezeFailed(vmhost.ErrFreezeNotAllowed.Error()))
+	sequence.call(freezeInput(test.UserAddress, "deposit"), freezeOk)
+}
+
+func TestContractFreeze_FlagDisabled(t *testing.T) {
+	calls := make(map[string]int)
+	sequence := newContractFreezeSequence(t, calls)
+	sequence.call(freezeInput(freezeOwnerAddress, vmhost.FreezeFunctionName), freezeOk)
+
+	sequence.withSetup(func(host vmhost.VMHost, _ *worldmock.MockWorld) {
+		enableEpochsHandler, _ := host.EnableEpochsHandler().(*worldmock.EnableEpochsHandlerStub)
+		enableEpochsHandler.IsFlagEnabledCalled = func(flag core.EnableEpochFlag) bool {
+			return flag != vmhost.ContractFreezeFlag
+		}
+	})
+	sequence.call(freezeInput(test.UserAddress, "deposit"), freezeOk)
+	require.Equal(t, 1, calls["deposit"])
+}
+
+func TestContractFreeze_ChargesTheWrite(t *testing.T) {
+	const (
+		storageStoreCost = uint64(1000)
+		storePerByteCost = uint64(10)
+	)
+	value := vmhost.NewContractFreeze([][]byte{[]byte("withdraw")}).Serialize()
+	writeCost := storageStoreCost + uint64(len(value))*storePerByteCost
+
+	freezeWithGas := func(gasProvided uint64, assertResults test.AssertResultsFunc) {
+		input := freezeInput(freezeOwnerAddress, vmhost.FreezeFunctionName, []byte("withdraw"))
+		input.GasProvided = gasProvided
+		newContractFreezeSequence(t, make(map[string]int)).
+			withSetup(func(host vmhost.VMHost, _ *worldmock.MockWorld) {
+				gasSchedule := host.Metering().GasSchedule()
+				gasSchedule.BaseOpsAPICost.StorageStore = storageStoreCost
+				gasSchedule.BaseOperationCost.StorePerByte = storePerByteCost
+			}).
+			call(input, assertResults)
+	}
+
+	freezeWithGas(writeCost-1, func(_ *worldmock.MockWorld, verify *test.VMOutputVerifier) {
+		verify.OutOfGas()
+	})
+
+	gasProvided := makeTestConfig().GasProvided
+	freezeWithGas(gasProvided, func(_ *worldmock.MockWorld, verify *test.VMOutputVerifier) {
+		verify.Ok().
+			GasRemaining(gasProvided - writeCost)
+	})
+}
+
+func TestContractFreeze_CheckedAfterTheGasForCode(t *testing.T) {
+	const getCodeCost = uint64(1000)
+	sequence := newContractFreezeSequence(t, make(map[string]int)).
+		withSetup(func(host vmhost.VMHost, _ *worldmock.MockWorld) {
+			host.Metering().GasSchedule().BaseOperationCost.GetCode = getCodeCost
+		})
+	sequence.call(freezeInput(freezeOwnerAddress, vmhost.FreezeFunctionName), freezeOk)
+
+	input := freezeInput(test.UserAddress, "deposit")
+	input.GasProvided = getCodeCost - 1
+	sequence.call(input, func(_ *worldmock.MockWorld, verify *test.VMOutputVerifier) {
+		verify.OutOfGas()
+		require.NotContains(t, verify.VmOutput.ReturnMessage, vmhost.ErrContractFrozen.Error())
+	})
+}
@@ -0,0 +1,175 @@
+package hostCoretest
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-scenario-go/worldmock"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	contextmock "github.com/multiversx/mx-chain-vm-go/mock/context"
+	"github.com/multiversx/mx-chain-vm-go/mock/contracts"
+	test "github.com/multiversx/mx-chain-vm-go/testcommon"
+	"github.com/multiversx/mx-chain-vm-go/vmhost"
+	"github.com/multiversx/mx-chain-vm-go/vmhost/vmhooks"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	deployInfoGasProvided    = 1_000_000
+	deployInfoBlockNonce     = 7
+	deployInfoBlockTimestamp = 1234
+)
+
+// deployInfoMock finishes the code hash, the deployer and the deploy info of the contract given as argument.
+func deployInfoMock(instanceMock *contextmock.InstanceMock, _ interface{}) {
+	instanceMock.AddMockMethod("introspect", func() *contextmock.InstanceMock {
+		host := instanceMock.Host
+		instance := contextmock.GetMockInstance(host)
+		managedTypes := host.ManagedTypes()
+		hooks := vmhooks.NewVMHooksImpl(host)
+
+		addressHandle := managedTypes.NewManagedBufferFromBytes(host.Runtime().Arguments()[0])
+
+		codeHashHandle := managedTypes.NewManagedBuffer()
+		hooks.ManagedGetCodeHash(addressHandle, codeHashHandle)
+		codeDeployerHandle := managedTypes.NewManagedBuffer()
+		hooks.ManagedGetCodeDeployer(addressHandle, codeDeployerHandle)
+
+		deployerHandle := managedTypes.NewManagedBuffer()
+		blockNonceHandle := managedTypes.NewBigIntFromInt64(0)
+		blockTimestampHandle := managedTypes.NewBigIntFromInt64(0)
+		result := hooks.ManagedGetContractDeployInfo(addressHandle, deployerHandle, blockNonceHandle, blockTimestampHandle)
+		if result < 0 {
+			return instance
+		}
+
+		for _, handle := range []int32{codeHashHandle, codeDeployerHandle, deployerHandle} {
+			value, _ := managedTypes.GetBytes(handle)
+			host.Output().Finish(value)
+		}
+		for _, handle := range []int32{blockNonceHandle, blockTimestampHandle} {
+			value, _ := managedTypes.GetBigInt(handle)
+			host.Output().Finish(value.Bytes())
+		}
+		host.Output().Finish(big.NewInt(int64(result)).Bytes())
+		return instance
+	})
+}
+
+func newDeployInfoSequence(t *testing.T) *mockCallSequence {
+	testConfig := makeTestConfig()
+	sequence := newMockCallSequence(t,
+		test.CreateMockContract(sc1Address).
+			WithConfig(testConfig).
+			WithMethods(contracts.InitMockMethod),
+		test.CreateMockContract(test.ParentAddress).
+			WithBalance(testConfig.ParentBalance).
+			WithConfig(testConfig).
+			WithMethods(contracts.DeployContractFromSourceMock, deployInfoMock))
+	sequence.world.CurrentBlockInfo.BlockNonce = deployInfoBlockNonce
+	sequence.world.CurrentBlockInfo.BlockTimestamp = deployInfoBlockTimestamp
+	return sequence
+}
+
+func deployInfoInput(function string, arguments ...[]byte) *vmcommon.ContractCallInput {
+	return test.CreateTestContractCallInputBuilder().
+		WithCallerAddr(test.UserAddress).
+		WithRecipientAddr(test.ParentAddress).
+		WithGasProvided(deployInfoGasProvided).
+		WithFunction(function).
+		WithArguments(arguments...).
+		Build()
+}
+
+func deployFromSourceInput() *vmcommon.ContractCallInput {
+	return deployInfoInput("deployContractFromSource", sc1Address, []byte{0, 0}, big.NewInt(deployInfoGasProvided/10).Bytes())
+}
+
+func deployInfoOk(_ *worldmock.MockWorld, verify *test.VMOutputVerifier) {
+	verify.Ok()
+}
+
+func deployedAddress(t *testing.T, vmOutput *vmcommon.VMOutput) []byte {
+	require.Len(t, vmOutput.ReturnData, 1)
+	return vmOutput.ReturnData[0]
+}
+
+func withDeployInfo(enabled bool) test.SetupFunction {
+	return func(host vmhost.VMHost, _ *worldmock.MockWorld) {
+		enableEpochsHandler, _ := host.EnableEpochsHandler().(*worldmock.EnableEpochsHandlerStub)
+		enableEpochsHandler.IsFlagEnabledCalled = func(flag core.EnableEpochFlag) bool {
+			return enabled || flag != vmhost.ContractDeployInfoFlag
+		}
+	}
+}
+
+func TestDeployInfo_RecordedAtDeploy(t *testing.T) {
+	sequence := newDeployInfoSequence(t)
+	newAddress := deployedAddress(t, sequence.call(deployFromSourceInput(), deployInfoOk))
+
+	codeHash := sequence.world.AcctMap.GetAccount(newAddress).CodeHash
+	require.NotEmpty(t, codeHash)
+
+	sequence.call(deployInfoInput("introspect", newAddress),
+		func(_ *worldmock.MockWorld, verify *test.VMOutputVerifier) {
+			verify.Ok().
+				ReturnData(
+					codeHash,
+					test.ParentAddress,
+					test.ParentAddress,
+					[]byte{deployInfoBlockNonce},
+					big.NewInt(deployInfoBlockTimestamp).Bytes(),
+					[]byte{1},
+				)
+		})
+}
+
+func TestDeployInfo_NotRecorded(t *testing.T) {
+	sequence := newDeployInfoSequence(t)
+
+	// contracts created before the deploy info was recorded only expose their code hash
+	sequence.call(deployInfoInput("introspect", sc1Address),
+		func(world *worldmock.MockWorld, verify *test.VMOutputVerifier) {
+			verify.Ok().
+				ReturnData(
+					world.AcctMap.GetAccount(sc1Address).CodeHash,
+					[]byte{},
+					[]byte{},
+					[]byte{},
+					[]byte{},
+					[]byte{},
+				)
+		})
+
+	sequence.withSetup(withDeployInfo(false))
+	newAddress := deployedAddress(t, sequence.call(deployFromSourceInput(), deployInfoOk))
+
+	sequence.withSetup(withDeployInfo(true))
+	sequence.call(deployInfoInput("introspect", newAddress),
+		func(_ *worldmock.MockWorld, verify *test.VMOutputVerifier) {
+			verify.Ok()
+			require.Equal(t, []byte{}, verify.VmOutput.ReturnData[1])
+			require.Equal(t, []byte{}, verify.VmOutput.ReturnData[5])
+		})
+}
+
+func TestDeployInfo_WriteIsPaid(t *testing.T) {
+	const storePerByte = uint64(100)
+	runDeploy := func(deployInfoEnabled bool) uint64 {
+		vmOutput := newDeployInfoSequence(t).
+			withSetup(func(host vmhost.VMHost, world *worldmock.MockWorld) {
+				host.Metering().GasSchedule().BaseOperationCost.StorePerByte = storePerByte
+				withDeployInfo(deployInfoEnabled)(host, world)
+			}).
+			call(deployFromSourceInput(), deployInfoOk)
+		return vmOutput.GasRemaining
+	}
+
+	serializedDeployInfo := (&vmhost.DeployInfo{
+		Deployer:       test.ParentAddress,
+		BlockNonce:     deployInfoBlockNonce,
+		BlockTimestamp: deployInfoBlockTimestamp,
+	}).Serialize()
+	require.Equal(t, runDeploy(false)-storePerByte*uint64(len(serializedDeployInfo)), runDeploy(true))
+}
@@ -0,0 +1,176 @@
+package hostCoretest
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-scenario-go/worldmock"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	"github.com/multiversx/mx-chain-vm-go/contractabi"
+	contextmock "github.com/multiversx/mx-chain-vm-go/mock/context"
+	"github.com/multiversx/mx-chain-vm-go/mock/contracts"
+	test "github.com/multiversx/mx-chain-vm-go/testcommon"
+	"github.com/multiversx/mx-chain-vm-go/vmhost"
+	"github.com/stretchr/testify/require"
+)
+
+const vaultAccessControlJSON = `{
+	"endpoints": {
+		"setFee": {"onlyOwner": true, "payable": []},
+		"deposit": {"payable": ["EGLD", "WEGLD-abcdef"]},
+		"getFee": {"readOnly": true},
+		"claimOld": {"deprecated": true}
+	}
+}`
+
+var vaultEndpoints = []string{"setFee", "deposit", "getFee", "claimOld"}
+
+// vaultMock counts the calls of its endpoints and writes to the storage, which fails for read-only endpoints.
+func vaultMock(calls map[string]int) func(*contextmock.InstanceMock, interface{}) {
+	return func(instanceMock *contextmock.InstanceMock, _ interface{}) {
+		for _, name := range vaultEndpoints {
+			endpointName := name
+			instanceMock.AddMockMethod(endpointName, func() *contextmock.InstanceMock {
+				host := instanceMock.Host
+				instance := contextmock.GetMockInstance(host)
+				calls[endpointName]++
+				_, err := host.Storage().SetStorage([]byte("fee"), []byte{1})
+				if err != nil {
+					host.Runtime().FailExecution(err)
+				}
+				return instance
+			})
+		}
+	}
+}
+
+// runEndpointAccessTest runs the given call against a vault owned by vaultOwner, returning the calls which
+// reached the endpoints of the vault.
+func runEndpointAccessTest(
+	t *testing.T,
+	vaultOwner []byte,
+	input *vmcommon.ContractCallInput,
+	setup test.SetupFunction,
+	assertResults test.AssertResultsFunc,
+) map[string]int {
+	testConfig := makeTestConfig()
+	calls := make(map[string]int)
+	_, err := test.BuildMockInstanceCallTest(t).
+		WithContracts(
+			test.CreateMockContract(test.ChildAddress).
+				WithBalance(testConfig.ChildBalance).
+				WithConfig(testConfig).
+				WithOwnerAddress(vaultOwner).
+				WithCode(contractabi.AppendAccessControlToCode(wasmHeader, []byte(vaultAccessControlJSON))).
+				WithMethods(vaultMock(calls)),
+			test.CreateMockContract(test.ParentAddress).
+				WithBalance(testConfig.ParentBalance).
+				WithConfig(testConfig).
+				WithMethods(contracts.ExecOnDestCtxSingleCallParentMock)).
+		WithInput(input).
+		WithSetup(setup).
+		AndAssertResults(assertResults)
+	require.Nil(t, err)
+	return calls
+}
+
+func vaultCallInput(function string) *vmcommon.ContractCallInput {
+	return test.CreateTestContractCallInputBuilder().
+		WithCallerAddr(test.UserAddress).
+		WithRecipientAddr(test.ChildAddress).
+		WithGasProvided(makeTestConfig().GasProvided).
+		WithFunction(function).
+		Build()
+}
+
+func noEndpointAccessSetup(vmhost.VMHost, *worldmock.MockWorld) {}
+
+func endpointAccessOk(_ *worldmock.MockWorld, verify *test.VMOutputVerifier) {
+	verify.Ok()
+}
+
+func endpointAccessDenied(message string) test.AssertResultsFunc {
+	return func(_ *worldmock.MockWorld, verify *test.VMOutputVerifier) {
+		verify.UserError().
+			ReturnMessage(message)
+	}
+}
+
+func TestEndpointAccess_OnlyOwner(t *testing.T) {
+	calls := runEndpointAccessTest(t, test.UserAddress, vaultCallInput("setFee"), noEndpointAccessSetup, endpointAccessOk)
+	require.Equal(t, 1, calls["setFee"])
+
+	calls = runEndpointAccessTest(t, test.ParentAddress, vaultCallInput("setFee"), noEndpointAccessSetup,
+		endpointAccessDenied(contractabi.ErrEndpointOnlyOwner.Error()))
+	require.Zero(t, calls["setFee"])
+}
+
+func TestEndpointAccess_OnlyOwner_ContractCaller(t *testing.T) {
+	execOnDestCtxInput := test.CreateTestContractCallInputBuilder().
+		WithCallerAddr(test.UserAddress).
+		WithRecipientAddr(test.ParentAddress).
+		WithGasProvided(makeTestConfig().GasProvided).
+		WithFunction("execOnDestCtxSingleCall").
+		WithArguments(test.ChildAddress, []byte("setFee")).
+		Build()
+
+	// the owner of the parent is not the owner of the vault
+	calls := runEndpointAccessTest(t, test.UserAddress, execOnDestCtxInput, noEndpointAccessSetup,
+		func(_ *worldmock.MockWorld, verify *test.VMOutputVerifier) {
+			require.NotEqual(t, vmcommon.Ok, verify.VmOutput.ReturnCode)
+		})
+	require.Zero(t, calls["setFee"])
+
+	calls = runEndpointAccessTest(t, test.ParentAddress, execOnDestCtxInput, noEndpointAccessSetup, endpointAccessOk)
+	require.Equal(t, 1, calls["setFee"])
+}
+
+func TestEndpointAccess_Payable(t *testing.T) {
+	input := vaultCallInput("setFee")
+	input.CallValue = big.NewInt(1)
+	runEndpointAccessTest(t, test.UserAddress, input, noEndpointAccessSetup,
+		func(_ *worldmock.MockWorld, verify *test.VMOutputVerifier) {
+			verify.UserError().
+				ReturnMessageContains(contractabi.ErrEndpointNotPayable.Error())
+		})
+
+	input = vaultCallInput("deposit")
+	input.CallValue = big.NewInt(1)
+	input.ESDTTransfers = []*vmcommon.ESDTTransfer{{ESDTTokenName: []byte("WEGLD-abcdef"), ESDTValue: big.NewInt(1)}}
+	calls := runEndpointAccessTest(t, test.UserAddress, input, noEndpointAccessSetup, endpointAccessOk)
+	require.Equal(t, 1, calls["deposit"])
+
+	input = vaultCallInput("deposit")
+	input.ESDTTransfers = []*vmcommon.ESDTTransfer{{ESDTTokenName: []byte("OTHER-123456"), ESDTValue: big.NewInt(1)}}
+	calls = runEndpointAccessTest(t, test.UserAddress, input, noEndpointAccessSetup,
+		endpointAccessDenied(contractabi.ErrEndpointNotPayable.Error()+" (OTHER-123456)"))
+	require.Zero(t, calls["deposit"])
+}
+
+func TestEndpointAccess_ReadOnlyAndDeprecated(t *testing.T) {
+	calls := runEndpointAccessTest(t, test.UserAddress, vaultCallInput("getFee"), noEndpointAccessSetup,
+		func(_ *worldmock.MockWorld, verify *test.VMOutputVerifier) {
+			require.NotEqual(t, vmcommon.Ok, verify.VmOutput.ReturnCode)
+			verify.ReturnMessageContains(vmhost.ErrCannotWriteOnReadOnly.Error())
+		})
+	require.Equal(t, 1, calls["getFee"])
+
+	calls = runEndpointAccessTest(t, test.UserAddress, vaultCallInput("claimOld"), noEndpointAccessSetup,
+		endpointAccessDenied(contractabi.ErrEndpointDeprecated.Error()))
+	require.Zero(t, calls["claimOld"])
+}
+
+func TestEndpointAccess_FlagDisabled(t *testing.T) {
+	disableEndpointAccessControl := func(host vmhost.VMHost, _ *worldmock.MockWorld) {
+		enableEpochsHandler, _ := host.EnableEpochsHandler().(*worldmock.EnableEpochsHandlerStub)
+		enableEpochsHandler.IsFlagEnabledCalled = func(flag core.EnableEpochFlag) bool {
+			return flag != vmhost.EndpointAccessControlFlag
+		}
+	}
+
+	for _, function := range vaultEndpoints {
+		calls := runEndpointAccessTest(t, test.ParentAddress, vaultCallInput(function), disableEndpointAccessControl, endpointAccessOk)
+		require.Equal(t, 1, calls[function])
+	}
+}
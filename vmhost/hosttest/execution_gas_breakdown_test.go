@@ -0,0 +1,232 @@
+package hostCoretest
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/multiversx/mx-chain-scenario-go/worldmock"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	contextmock "github.com/multiversx/mx-chain-vm-go/mock/context"
+	"github.com/multiversx/mx-chain-vm-go/mock/contracts"
+	test "github.com/multiversx/mx-chain-vm-go/testcommon"
+	"github.com/multiversx/mx-chain-vm-go/vmhost"
+	"github.com/multiversx/mx-chain-vm-go/vmhost/vmhooks"
+	"github.com/stretchr/testify/require"
+)
+
+func runParentChildCallWithGasBreakdown(t *testing.T, enableGasBreakdown bool) (*vmcommon.VMOutput, map[string]*vmhost.GasBreakdown) {
+	testConfig := makeTestConfig()
+	world := worldmock.NewMockWorld()
+	world.AcctMap.CreateAccount(test.UserAddress, world)
+
+	executorFactory := contextmock.NewExecutorMockFactory(world)
+	host := test.NewTestHostBuilder(t).
+		WithExecutorFactory(executorFactory).
+		WithBlockchainHook(world).
+		WithGasBreakdown(enableGasBreakdown).
+		Build()
+	defer host.Reset()
+
+	parent := test.CreateMockContract(test.ParentAddress).
+		WithBalance(testConfig.ParentBalance).
+		WithConfig(testConfig).
+		WithMethods(contracts.ExecOnDestCtxSingleCallParentMock)
+	parent.Initialize(t, host, executorFactory.LastCreatedExecutor, true)
+	child := test.CreateMockContract(test.ChildAddress).
+		WithBalance(testConfig.ChildBalance).
+		WithConfig(testConfig).
+		WithMethods(contracts.WasteGasChildMock)
+	child.Initialize(t, host, executorFactory.LastCreatedExecutor, true)
+
+	input := test.CreateTestContractCallInputBuilder().
+		WithRecipientAddr(test.ParentAddress).
+		WithGasProvided(testConfig.GasProvided).
+		WithFunction("execOnDestCtxSingleCall").
+		WithArguments(test.ChildAddress, []byte("wasteGas")).
+		Build()
+
+	provider, ok := host.(vmhost.GasBreakdownProvider)
+	require.True(t, ok)
+	vmOutput, gasBreakdown, err := provider.RunSmartContractCallWithGasBreakdown(input)
+	require.Nil(t, err)
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+
+	// the breakdown belongs to the call which returned it, not to the host
+	failingInput := *input
+	failingInput.Function = "missingFunction"
+	vmOutputOfFailure, _, err := provider.RunSmartContractCallWithGasBreakdown(&failingInput)
+	require.Nil(t, err)
+	require.NotEqual(t, vmcommon.Ok, vmOutputOfFailure.ReturnCode)
+
+	return vmOutput, gasBreakdown
+}
+
+func TestGasBreakdown_ParentChild(t *testing.T) {
+	testConfig := makeTestConfig()
+
+	vmOutput, gasBreakdown := runParentChildCallWithGasBreakdown(t, true)
+	require.Len(t, gasBreakdown, 2)
+
+	for address, breakdown := range gasBreakdown {
+		require.Equal(t, vmOutput.OutputAccounts[address].GasUsed, breakdown.GasUsed)
+
+		sum := breakdown.Compilation + breakdown.Opcodes
+		for _, gas := range breakdown.VMHooks {
+			sum += gas
+		}
+		require.Equal(t, breakdown.GasUsed, sum)
+	}
+
+	childBreakdown := gasBreakdown[string(test.ChildAddress)]
+	require.Equal(t, testConfig.GasUsedByChild, childBreakdown.VMHooks[vmhost.GasCategoryOther])
+	require.Equal(t, uint64(0), childBreakdown.Forwarded)
+
+	parentBreakdown := gasBreakdown[string(test.ParentAddress)]
+	require.Equal(t, childBreakdown.GasUsed, parentBreakdown.Forwarded)
+	require.Equal(t, uint64(0), parentBreakdown.LockedForCallbacks)
+}
+
+func TestGasBreakdown_Disabled(t *testing.T) {
+	_, gasBreakdown := runParentChildCallWithGasBreakdown(t, false)
+	require.Nil(t, gasBreakdown)
+}
+
+// forwardMock exposes the "forward" endpoint, which calls its second argument with the gas given
+// by its first argument, the function given by its third argument and the remaining arguments.
+func forwardMock(instanceMock *contextmock.InstanceMock, _ interface{}) {
+	instanceMock.AddMockMethod("forward", func() *contextmock.InstanceMock {
+		host := instanceMock.Host
+		instance := contextmock.GetMockInstance(host)
+		arguments := host.Runtime().Arguments()
+
+		gasLimit := big.NewInt(0).SetBytes(arguments[0]).Int64()
+		returnValue := vmhooks.ExecuteOnDestContextWithTypedArgs(host, gasLimit, big.NewInt(0), arguments[2], arguments[1], arguments[3:])
+		if returnValue != 0 {
+			host.Runtime().SignalUserError("forward failed")
+		}
+		return instance
+	})
+}
+
+func TestGasBreakdown_ReenteredAccount(t *testing.T) {
+	testConfig := makeTestConfig()
+	world := worldmock.NewMockWorld()
+	world.AcctMap.CreateAccount(test.UserAddress, world)
+
+	executorFactory := contextmock.NewExecutorMockFactory(world)
+	host := test.NewTestHostBuilder(t).
+		WithExecutorFactory(executorFactory).
+		WithBlockchainHook(world).
+		WithGasBreakdown(true).
+		Build()
+	defer host.Reset()
+
+	for _, address := range [][]byte{test.ParentAddress, test.ChildAddress, test.NephewAddress} {
+		contract := test.CreateMockContract(address).
+			WithBalance(testConfig.ParentBalance).
+			WithConfig(testConfig).
+			WithMethods(forwardMock, contracts.WasteGasChildMock)
+		contract.Initialize(t, host, executorFactory.LastCreatedExecutor, true)
+	}
+
+	// parent -> child -> parent -> nephew
+	input := test.CreateTestContractCallInputBuilder().
+		WithRecipientAddr(test.ParentAddress).
+		WithGasProvided(testConfig.GasProvided).
+		WithFunction("forward").
+		WithArguments(
+			big.NewInt(1200).Bytes(), test.ChildAddress, []byte("forward"),
+			big.NewInt(800).Bytes(), test.ParentAddress, []byte("forward"),
+			big.NewInt(400).Bytes(), test.NephewAddress, []byte("wasteGas")).
+		Build()
+
+	provider, ok := host.(vmhost.GasBreakdownProvider)
+	require.True(t, ok)
+	vmOutput, gasBreakdown, err := provider.RunSmartContractCallWithGasBreakdown(input)
+	require.Nil(t, err)
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode, vmOutput.ReturnMessage)
+	require.Len(t, gasBreakdown, 3)
+
+	childGasUsed := vmOutput.OutputAccounts[string(test.ChildAddress)].GasUsed
+	nephewGasUsed := vmOutput.OutputAccounts[string(test.NephewAddress)].GasUsed
+
+	// the nephew is reached by both executions of the parent, but its gas is forwarded only once
+	parentBreakdown := gasBreakdown[string(test.ParentAddress)]
+	require.Equal(t, childGasUsed+nephewGasUsed, parentBreakdown.Forwarded)
+	require.Equal(t, uint64(0), parentBreakdown.LockedForCallbacks)
+	require.Equal(t, uint64(0), gasBreakdown[string(test.NephewAddress)].Forwarded)
+}
+
+// storeAndGrowMemoryMock calls the storageStore hook and then grows its memory by the number of pages given as
+// argument, which the VM charges outside of any VM hook.
+func storeAndGrowMemoryMock(instanceMock *contextmock.InstanceMock, _ interface{}) {
+	instanceMock.AddMockMethod("storeAndGrowMemory", func() *contextmock.InstanceMock {
+		host := instanceMock.Host
+		instance := contextmock.GetMockInstance(host)
+		arguments := host.Runtime().Arguments()
+
+		if vmhooks.StorageStoreWithTypedArgs(host, []byte("key"), []byte("value")) < 0 {
+			return instance
+		}
+
+		previousPages := int32(instance.MemLength() / vmhost.WASMPageSize)
+		err := instance.MemGrow(uint32(big.NewInt(0).SetBytes(arguments[0]).Uint64()))
+		if err != nil {
+			host.Runtime().FailExecution(err)
+			return instance
+		}
+		vmhooks.NewVMHooksImpl(host).ChargeMemoryGrowth(previousPages)
+		return instance
+	})
+}
+
+func runStoreAndGrowMemoryWithGasBreakdown(t *testing.T, numPages uint32) *vmhost.GasBreakdown {
+	testConfig := makeTestConfig()
+	world := worldmock.NewMockWorld()
+	world.AcctMap.CreateAccount(test.UserAddress, world)
+
+	executorFactory := contextmock.NewExecutorMockFactory(world)
+	host := test.NewTestHostBuilder(t).
+		WithExecutorFactory(executorFactory).
+		WithBlockchainHook(world).
+		WithGasBreakdown(true).
+		Build()
+	defer host.Reset()
+	host.Metering().GasSchedule().BaseOperationCost.MemoryGrowPerPage = memoryGrowPerPage
+
+	parent := test.CreateMockContract(test.ParentAddress).
+		WithBalance(testConfig.ParentBalance).
+		WithConfig(testConfig).
+		WithMethods(storeAndGrowMemoryMock)
+	parent.Initialize(t, host, executorFactory.LastCreatedExecutor, true)
+
+	input := test.CreateTestContractCallInputBuilder().
+		WithRecipientAddr(test.ParentAddress).
+		WithGasProvided(100_000).
+		WithFunction("storeAndGrowMemory").
+		WithArguments(big.NewInt(int64(numPages)).Bytes()).
+		Build()
+
+	provider, ok := host.(vmhost.GasBreakdownProvider)
+	require.True(t, ok)
+	vmOutput, gasBreakdown, err := provider.RunSmartContractCallWithGasBreakdown(input)
+	require.Nil(t, err)
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode, vmOutput.ReturnMessage)
+
+	parentBreakdown := gasBreakdown[string(test.ParentAddress)]
+	require.NotNil(t, parentBreakdown)
+	return parentBreakdown
+}
+
+func TestGasBreakdown_MemoryGrowthAfterStorageHook(t *testing.T) {
+	withoutGrowth := runStoreAndGrowMemoryWithGasBreakdown(t, 0)
+	require.Greater(t, withoutGrowth.VMHooks[vmhost.GasCategoryStorage], uint64(0))
+	require.Zero(t, withoutGrowth.VMHooks[vmhost.GasCategoryMemory])
+
+	// the pages are not reported under the storage category, although the storage hook was the last one called
+	numPages := uint32(3)
+	withGrowth := runStoreAndGrowMemoryWithGasBreakdown(t, numPages)
+	require.Equal(t, withoutGrowth.VMHooks[vmhost.GasCategoryStorage], withGrowth.VMHooks[vmhost.GasCategoryStorage])
+	require.Equal(t, uint64(numPages)*memoryGrowPerPage, withGrowth.VMHooks[vmhost.GasCategoryMemory])
+	require.Equal(t, withoutGrowth.GasUsed+uint64(numPages)*memoryGrowPerPage, withGrowth.GasUsed)
+}
@@ -0,0 +1,226 @@
+package hostCoretest
+
+import (
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/data/vm"
+	"github.com/multiversx/mx-chain-scenario-go/worldmock"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	contextmock "github.com/multiversx/mx-chain-vm-go/mock/context"
+	"github.com/multiversx/mx-chain-vm-go/mock/contracts"
+	test "github.com/multiversx/mx-chain-vm-go/testcommon"
+	"github.com/multiversx/mx-chain-vm-go/vmhost"
+	"github.com/stretchr/testify/require"
+)
+
+const gasLeftRequiredByParent = uint64(1000)
+
+func requireGasLeftParentMock(instanceMock *contextmock.InstanceMock, config interface{}) {
+	testConfig := config.(*test.TestConfig)
+	instanceMock.AddMockMethod("requireGasLeft", func() *contextmock.InstanceMock {
+		host := instanceMock.Host
+		instance := contextmock.GetMockInstance(host)
+
+		if host.Metering().GasLeft() < gasLeftRequiredByParent {
+			host.Runtime().SignalUserError("not enough gas left")
+			return instance
+		}
+
+		err := host.Metering().UseGasBounded(testConfig.GasUsedByParent)
+		if err != nil {
+			host.Runtime().SetRuntimeBreakpointValue(vmhost.BreakpointOutOfGas)
+		}
+		return instance
+	})
+}
+
+// lowGasEventParentMock emits an event only when little gas is left after its work, so that the event
+// tells apart the executions with too little gas, which otherwise succeed with the same output
+func lowGasEventParentMock(instanceMock *contextmock.InstanceMock, config interface{}) {
+	testConfig := config.(*test.TestConfig)
+	instanceMock.AddMockMethod("emitEventOnLowGas", func() *contextmock.InstanceMock {
+		host := instanceMock.Host
+		instance := contextmock.GetMockInstance(host)
+
+		err := host.Metering().UseGasBounded(testConfig.GasUsedByParent)
+		if err != nil {
+			host.Runtime().SetRuntimeBreakpointValue(vmhost.BreakpointOutOfGas)
+			return instance
+		}
+
+		if host.Metering().GasLeft() < gasLeftRequiredByParent {
+			host.Output().WriteLog(test.ParentAddress, [][]byte{[]byte("lowGas")}, nil)
+		}
+		return instance
+	})
+}
+
+func estimateGasWithMockContract(
+	t *testing.T,
+	method func(*contextmock.InstanceMock, interface{}),
+	function string,
+) (*vmhost.GasEstimation, error) {
+	testConfig := makeTestConfig()
+	world := worldmock.NewMockWorld()
+	world.AcctMap.CreateAccount(test.UserAddress, world)
+
+	executorFactory := contextmock.NewExecutorMockFactory(world)
+	host := test.NewTestHostBuilder(t).
+		WithExecutorFactory(executorFactory).
+		WithBlockchainHook(world).
+		Build()
+	defer host.Reset()
+
+	parent := test.CreateMockContract(test.ParentAddress).
+		WithBalance(testConfig.ParentBalance).
+		WithConfig(testConfig).
+		WithMethods(method)
+	parent.Initialize(t, host, executorFactory.LastCreatedExecutor, true)
+
+	input := test.CreateTestContractCallInputBuilder().
+		WithRecipientAddr(test.ParentAddress).
+		WithGasProvided(100_000).
+		WithFunction(function).
+		Build()
+
+	estimator, ok := host.(vmhost.GasEstimator)
+	require.True(t, ok)
+	return estimator.EstimateGas(input)
+}
+
+func TestEstimateGas_SingleContract(t *testing.T) {
+	testConfig := makeTestConfig()
+
+	estimation, err := estimateGasWithMockContract(t, contracts.WasteGasParentMock, "wasteGas")
+	require.Nil(t, err)
+	require.Equal(t, vmcommon.Ok, estimation.VMOutput.ReturnCode)
+	require.Greater(t, estimation.Compilation, uint64(0))
+	require.Equal(t, testConfig.GasUsedByParent, estimation.Execution)
+	require.Equal(t, estimation.Compilation+testConfig.GasUsedByParent, estimation.GasLimit)
+	require.Equal(t, uint64(0), estimation.AsyncLocked)
+	require.Equal(t, int64(0), estimation.Refund.Int64())
+}
+
+func TestEstimateGas_DependsOnGasLeft(t *testing.T) {
+	testConfig := makeTestConfig()
+
+	estimation, err := estimateGasWithMockContract(t, requireGasLeftParentMock, "requireGasLeft")
+	require.Nil(t, err)
+	require.Equal(t, vmcommon.Ok, estimation.VMOutput.ReturnCode)
+	require.Equal(t, estimation.Compilation+gasLeftRequiredByParent, estimation.GasLimit)
+	require.Equal(t, testConfig.GasUsedByParent, estimation.Execution)
+	require.Equal(t, gasLeftRequiredByParent-testConfig.GasUsedByParent, estimation.VMOutput.GasRemaining)
+}
+
+func TestEstimateGas_ComparesLogs(t *testing.T) {
+	testConfig := makeTestConfig()
+
+	estimation, err := estimateGasWithMockContract(t, lowGasEventParentMock, "emitEventOnLowGas")
+	require.Nil(t, err)
+	require.Equal(t, vmcommon.Ok, estimation.VMOutput.ReturnCode)
+	require.Empty(t, estimation.VMOutput.Logs)
+	require.Equal(t, estimation.Compilation+testConfig.GasUsedByParent+gasLeftRequiredByParent, estimation.GasLimit)
+	require.Equal(t, gasLeftRequiredByParent, estimation.VMOutput.GasRemaining)
+}
+
+func TestEstimateGas_CallFails(t *testing.T) {
+	estimation, err := estimateGasWithMockContract(t, contracts.WasteGasParentMock, "missingFunction")
+	require.Nil(t, estimation)
+	require.ErrorIs(t, err, vmhost.ErrGasEstimationFailed)
+}
+
+// asyncCallChildParentMock forwards all its gas left to the child through a legacy async call
+func asyncCallChildParentMock(instanceMock *contextmock.InstanceMock, config interface{}) {
+	testConfig := config.(*test.TestConfig)
+	instanceMock.AddMockMethod("asyncCallChild", func() *contextmock.InstanceMock {
+		host := instanceMock.Host
+		instance := contextmock.GetMockInstance(host)
+
+		err := host.Metering().UseGasBounded(testConfig.GasUsedByParent)
+		if err != nil {
+			host.Runtime().SetRuntimeBreakpointValue(vmhost.BreakpointOutOfGas)
+			return instance
+		}
+
+		err = host.Async().RegisterLegacyAsyncCall(test.ChildAddress, []byte("useGas"), nil)
+		if err != nil {
+			host.Runtime().SignalUserError(err.Error())
+		}
+		return instance
+	})
+}
+
+func useGasChildMock(instanceMock *contextmock.InstanceMock, config interface{}) {
+	testConfig := config.(*test.TestConfig)
+	instanceMock.AddMockMethod("useGas", func() *contextmock.InstanceMock {
+		host := instanceMock.Host
+		instance := contextmock.GetMockInstance(host)
+
+		err := host.Metering().UseGasBounded(testConfig.GasUsedByChild)
+		if err != nil {
+			host.Runtime().SetRuntimeBreakpointValue(vmhost.BreakpointOutOfGas)
+		}
+		return instance
+	})
+}
+
+func TestEstimateGas_CrossShardAsyncCall(t *testing.T) {
+	testConfig := makeTestConfig()
+	world := worldmock.NewMockWorld()
+	world.AcctMap.CreateAccount(test.UserAddress, world)
+
+	executorFactory := contextmock.NewExecutorMockFactory(world)
+	host := test.NewTestHostBuilder(t).
+		WithExecutorFactory(executorFactory).
+		WithBlockchainHook(world).
+		Build()
+	defer host.Reset()
+
+	parent := test.CreateMockContract(test.ParentAddress).
+		WithBalance(testConfig.ParentBalance).
+		WithConfig(testConfig).
+		WithMethods(asyncCallChildParentMock)
+	parent.Initialize(t, host, executorFactory.LastCreatedExecutor, true)
+	child := test.CreateMockContract(test.ChildAddress).
+		WithBalance(testConfig.ChildBalance).
+		WithConfig(testConfig).
+		WithShardID(1).
+		WithMethods(useGasChildMock)
+	child.Initialize(t, host, executorFactory.LastCreatedExecutor, true)
+
+	// the gas which the child consumes when it receives the async call
+	childInput := test.CreateTestContractCallInputBuilder().
+		WithCallerAddr(test.ParentAddress).
+		WithRecipientAddr(test.ChildAddress).
+		WithCallType(vm.AsynchronousCall).
+		WithAsyncArguments(&vmcommon.AsyncArguments{
+			CallID:       []byte("callID"),
+			CallerCallID: []byte("callerCallID"),
+		}).
+		WithGasProvided(100_000).
+		WithFunction("useGas").
+		Build()
+	childOutput, err := host.RunSmartContractCall(childInput)
+	require.Nil(t, err)
+	require.Equal(t, vmcommon.Ok, childOutput.ReturnCode)
+	gasConsumedByChild := childInput.GasProvided - childOutput.GasRemaining
+
+	input := test.CreateTestContractCallInputBuilder().
+		WithRecipientAddr(test.ParentAddress).
+		WithGasProvided(100_000).
+		WithFunction("asyncCallChild").
+		Build()
+
+	estimator, ok := host.(vmhost.GasEstimator)
+	require.True(t, ok)
+	estimation, err := estimator.EstimateGas(input)
+	require.Nil(t, err)
+	require.Equal(t, vmcommon.Ok, estimation.VMOutput.ReturnCode)
+	require.Less(t, estimation.GasLimit, input.GasProvided)
+
+	childAccount := estimation.VMOutput.OutputAccounts[string(test.ChildAddress)]
+	require.NotNil(t, childAccount)
+	require.Len(t, childAccount.OutputTransfers, 1)
+	require.Equal(t, gasConsumedByChild, childAccount.OutputTransfers[0].GasLimit)
+	require.Equal(t, childAccount.OutputTransfers[0].GasLocked, estimation.AsyncLocked)
+}
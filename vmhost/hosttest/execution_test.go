@@ -3717,3 +3717,32 @@ func runNContractsForHostAndVerify(tb testing.TB, host vmhost.VMHost, input *vmc
 		verify.Ok()
 	}
 }
+
+func TestExecution_UpgradeContract_CodeLimitExceeded(t *testing.T) {
+	testConfig := makeTestConfig()
+
+	codeMetadata := []byte{vmcommon.MetadataUpgradeable, 0}
+
+	_, err := test.BuildMockInstanceCallTest(t).
+		WithContracts(
+			test.CreateMockContract(test.ParentAddress).
+				WithBalance(testConfig.ParentBalance).
+				WithConfig(testConfig).
+				WithOwnerAddress(test.UserAddress).
+				WithCodeMetadata(codeMetadata).
+				WithMethods(contracts.UpgradeFunctionMock)).
+		WithInput(test.CreateTestContractCallInputBuilder().
+			WithRecipientAddr(test.ParentAddress).
+			WithGasProvided(testConfig.GasProvided).
+			WithFunction(vmhost.UpgradeFunctionName).
+			WithArguments(test.ParentAddress, codeMetadata).
+			Build()).
+		WithSetup(func(host vmhost.VMHost, world *worldmock.MockWorld) {
+			host.Runtime().SetCodeLimits(vmhost.CodeLimits{MaxCodeSize: uint64(len(test.ParentAddress)) - 1})
+		}).
+		AndAssertResults(func(world *worldmock.MockWorld, verify *test.VMOutputVerifier) {
+			verify.ContractInvalid().
+				HasRuntimeErrors(vmhost.ErrCodeSizeLimitExceeded.Error())
+		})
+	assert.Nil(t, err)
+}
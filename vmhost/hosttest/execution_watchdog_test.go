@@ -0,0 +1,292 @@
+package hostCoretest
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/multiversx/mx-chain-scenario-go/worldmock"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	contextmock "github.com/multiversx/mx-chain-vm-go/mock/context"
+	"github.com/multiversx/mx-chain-vm-go/mock/contracts"
+	test "github.com/multiversx/mx-chain-vm-go/testcommon"
+	"github.com/multiversx/mx-chain-vm-go/testcommon/testexecutor"
+	"github.com/multiversx/mx-chain-vm-go/vmhost"
+	"github.com/multiversx/mx-chain-vm-go/vmhost/vmhooks"
+	"github.com/multiversx/mx-chain-vm-go/wasmer"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	pointsPerBasicBlock       = uint64(7)
+	instructionsPerBasicBlock = uint64(5)
+)
+
+// executeBasicBlocks emulates an instrumented contract run by the executor, which consumes the points of each
+// basic block and stops the execution at the first block over the gas limit of the instance, while the injected
+// code reports the instructions of each block to the VM; 0 blocks means forever.
+func executeBasicBlocks(host vmhost.VMHost, numBlocks int) {
+	instance := contextmock.GetMockInstance(host)
+	hooks := vmhooks.NewVMHooksImpl(host)
+	for i := 0; numBlocks == 0 || i < numBlocks; i++ {
+		if host.Runtime().GetRuntimeBreakpointValue() != vmhost.BreakpointNone {
+			return
+		}
+
+		instance.SetPointsUsed(instance.GetPointsUsed() + pointsPerBasicBlock)
+		if instance.GetPointsUsed() > instance.GasLimit {
+			host.Runtime().SetRuntimeBreakpointValue(vmhost.BreakpointOutOfGas)
+			return
+		}
+		hooks.CountInstructions(int64(instructionsPerBasicBlock))
+	}
+}
+
+func basicBlocksMock(numBlocks int) func(*contextmock.InstanceMock, interface{}) {
+	return func(instanceMock *contextmock.InstanceMock, _ interface{}) {
+		instanceMock.AddMockMethod("loop", func() *contextmock.InstanceMock {
+			host := instanceMock.Host
+			executeBasicBlocks(host, numBlocks)
+			return contextmock.GetMockInstance(host)
+		})
+	}
+}
+
+// loopTwiceParentMock runs the loop of the child twice, in two nested calls of the same transaction.
+func loopTwiceParentMock(instanceMock *contextmock.InstanceMock, config interface{}) {
+	instanceMock.AddMockMethod("loopTwice", func() *contextmock.InstanceMock {
+		testConfig := config.(*test.TestConfig)
+		host := instanceMock.Host
+		for i := 0; i < 2; i++ {
+			input := test.CreateTestContractCallInputBuilder().
+				WithCallerAddr(test.ParentAddress).
+				WithRecipientAddr(test.ChildAddress).
+				WithGasProvided(testConfig.GasProvidedToChild).
+				WithFunction("loop").
+				Build()
+			returnValue := contracts.ExecuteOnDestContextInMockContracts(host, input)
+			if returnValue != 0 {
+				host.Runtime().FailExecution(fmt.Errorf("return value %d", returnValue))
+				break
+			}
+		}
+		return contextmock.GetMockInstance(host)
+	})
+}
+
+type watchdogTestCase struct {
+	watchdog          vmhost.ExecutionWatchdog
+	instructionBudget uint64
+	timeout           uint32
+	parentMethods     func(*contextmock.InstanceMock, interface{})
+	childMethods      func(*contextmock.InstanceMock, interface{})
+	input             *vmcommon.ContractCallInput
+}
+
+func runWatchdogTest(t *testing.T, testCase *watchdogTestCase) *vmcommon.VMOutput {
+	testConfig := makeTestConfig()
+	world := worldmock.NewMockWorld()
+	world.AcctMap.CreateAccount(test.UserAddress, world)
+
+	executorFactory := contextmock.NewExecutorMockFactory(world)
+	host := test.NewTestHostBuilder(t).
+		WithExecutorFactory(executorFactory).
+		WithBlockchainHook(world).
+		WithExecutionWatchdog(testCase.watchdog, testCase.instructionBudget).
+		WithExecutionTimeout(testCase.timeout).
+		Build()
+	defer host.Reset()
+
+	parent := test.CreateMockContract(test.ParentAddress).
+		WithBalance(testConfig.ParentBalance).
+		WithConfig(testConfig).
+		WithMethods(testCase.parentMethods)
+	parent.Initialize(t, host, executorFactory.LastCreatedExecutor, true)
+	if testCase.childMethods != nil {
+		child := test.CreateMockContract(test.ChildAddress).
+			WithBalance(testConfig.ChildBalance).
+			WithConfig(testConfig).
+			WithMethods(testCase.childMethods)
+		child.Initialize(t, host, executorFactory.LastCreatedExecutor, true)
+	}
+
+	vmOutput, err := host.RunSmartContractCall(testCase.input)
+	require.Nil(t, err)
+	return vmOutput
+}
+
+func loopCallInput(gasProvided uint64) *vmcommon.ContractCallInput {
+	return test.CreateTestContractCallInputBuilder().
+		WithRecipientAddr(test.ParentAddress).
+		WithGasProvided(gasProvided).
+		WithFunction("loop").
+		Build()
+}
+
+func nestedLoopsCallInput() *vmcommon.ContractCallInput {
+	return test.CreateTestContractCallInputBuilder().
+		WithRecipientAddr(test.ParentAddress).
+		WithGasProvided(makeTestConfig().GasProvided).
+		WithFunction("loopTwice").
+		Build()
+}
+
+func requireRuntimeErrorLogged(t *testing.T, vmOutput *vmcommon.VMOutput, expectedErr error) {
+	for _, logEntry := range vmOutput.Logs {
+		if bytes.Contains(logEntry.Data[0], []byte(expectedErr.Error())) {
+			return
+		}
+	}
+	require.Fail(t, "runtime error not logged", expectedErr.Error())
+}
+
+func TestExecutionWatchdog_InstructionBudget_InfiniteLoop(t *testing.T) {
+	testCase := &watchdogTestCase{
+		watchdog:          vmhost.InstructionBudgetWatchdog,
+		instructionBudget: 500,
+		parentMethods:     basicBlocksMock(0),
+		input:             loopCallInput(100000),
+	}
+
+	vmOutput := runWatchdogTest(t, testCase)
+	require.Equal(t, vmcommon.ExecutionFailed, vmOutput.ReturnCode)
+	require.Equal(t, vmhost.ErrExecutionBudgetExceeded.Error(), vmOutput.ReturnMessage)
+	require.Zero(t, vmOutput.GasRemaining)
+
+	// the outcome does not depend on the wall-clock timeout, which stands for the speed of the machine
+	for _, timeout := range []uint32{1000, 5000, 60000} {
+		testCase.timeout = timeout
+		require.Equal(t, vmOutput, runWatchdogTest(t, testCase))
+	}
+}
+
+func TestExecutionWatchdog_InstructionBudget_GasStillApplies(t *testing.T) {
+	vmOutput := runWatchdogTest(t, &watchdogTestCase{
+		watchdog:          vmhost.InstructionBudgetWatchdog,
+		instructionBudget: 1000000,
+		parentMethods:     basicBlocksMock(0),
+		input:             loopCallInput(1000),
+	})
+	require.Equal(t, vmcommon.OutOfGas, vmOutput.ReturnCode)
+	require.Equal(t, vmhost.ErrNotEnoughGas.Error(), vmOutput.ReturnMessage)
+}
+
+func TestExecutionWatchdog_InstructionBudget_WithinBudget(t *testing.T) {
+	numBlocks := 50
+	vmOutput := runWatchdogTest(t, &watchdogTestCase{
+		watchdog:          vmhost.InstructionBudgetWatchdog,
+		instructionBudget: uint64(numBlocks) * instructionsPerBasicBlock,
+		parentMethods:     basicBlocksMock(numBlocks),
+		input:             loopCallInput(100000),
+	})
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+}
+
+func TestExecutionWatchdog_InstructionBudget_SharedWithNestedCalls(t *testing.T) {
+	testConfig := makeTestConfig()
+
+	// each call of the child fits in the gas forwarded to it and in the budget, but not both calls
+	childBlocks := int(testConfig.GasProvidedToChild / pointsPerBasicBlock / 2)
+	childInstructions := uint64(childBlocks) * instructionsPerBasicBlock
+	testCase := &watchdogTestCase{
+		watchdog:          vmhost.InstructionBudgetWatchdog,
+		instructionBudget: 2*childInstructions - 1,
+		parentMethods:     loopTwiceParentMock,
+		childMethods:      basicBlocksMock(childBlocks),
+		input:             nestedLoopsCallInput(),
+	}
+
+	vmOutput := runWatchdogTest(t, testCase)
+	require.Equal(t, vmcommon.ExecutionFailed, vmOutput.ReturnCode)
+	requireRuntimeErrorLogged(t, vmOutput, vmhost.ErrExecutionBudgetExceeded)
+	require.Equal(t, vmOutput, runWatchdogTest(t, testCase))
+
+	testCase.instructionBudget = 2 * childInstructions
+	vmOutput = runWatchdogTest(t, testCase)
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode, vmOutput.ReturnMessage)
+}
+
+func TestExecutionWatchdog_WallClock_IgnoresBudget(t *testing.T) {
+	numBlocks := 50
+	vmOutput := runWatchdogTest(t, &watchdogTestCase{
+		watchdog:          vmhost.WallClockWatchdog,
+		instructionBudget: 1,
+		parentMethods:     basicBlocksMock(numBlocks),
+		input:             loopCallInput(100000),
+	})
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+}
+
+// runInstructionBudgetContract calls the instruction-budget test contract with Wasmer 1, the executor which provides
+// the hook of the instrumented code.
+func runInstructionBudgetContract(
+	t *testing.T,
+	function string,
+	gasProvided uint64,
+	watchdog vmhost.ExecutionWatchdog,
+	instructionBudget uint64,
+	timeout uint32,
+) *vmcommon.VMOutput {
+	contract := test.CreateInstanceContract(test.ParentAddress).
+		WithCode(test.GetTestSCCode("instruction-budget", "../../"))
+	host := test.NewTestHostBuilder(t).
+		WithExecutorFactory(wasmer.ExecutorFactory()).
+		WithBlockchainHook(test.BlockchainHookStubForContracts([]*test.InstanceTestSmartContract{contract})).
+		WithExecutionWatchdog(watchdog, instructionBudget).
+		WithExecutionTimeout(timeout).
+		Build()
+	defer host.Reset()
+
+	vmOutput, err := host.RunSmartContractCall(test.CreateTestContractCallInputBuilder().
+		WithRecipientAddr(test.ParentAddress).
+		WithGasProvided(gasProvided).
+		WithFunction(function).
+		Build())
+	require.Nil(t, err)
+	return vmOutput
+}
+
+func TestExecutionWatchdog_InstructionBudget_TightLoop(t *testing.T) {
+	if !testexecutor.IsWasmer1Allowed() {
+		t.Skip("run exclusively with wasmer1")
+	}
+
+	// the loop executes 7 instructions per iteration, far fewer than its gas
+	vmOutput := runInstructionBudgetContract(t, "boundedLoop", 100_000_000, vmhost.InstructionBudgetWatchdog, 10_000, 0)
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode, vmOutput.ReturnMessage)
+	require.Equal(t, [][]byte{big.NewInt(1000).Bytes()}, vmOutput.ReturnData)
+
+	vmOutput = runInstructionBudgetContract(t, "boundedLoop", 100_000_000, vmhost.InstructionBudgetWatchdog, 5_000, 0)
+	require.Equal(t, vmcommon.ExecutionFailed, vmOutput.ReturnCode)
+	require.Equal(t, vmhost.ErrExecutionBudgetExceeded.Error(), vmOutput.ReturnMessage)
+
+	vmOutput = runInstructionBudgetContract(t, "infiniteLoop", 100_000_000, vmhost.InstructionBudgetWatchdog, 100_000, 0)
+	require.Equal(t, vmcommon.ExecutionFailed, vmOutput.ReturnCode)
+	require.Equal(t, vmhost.ErrExecutionBudgetExceeded.Error(), vmOutput.ReturnMessage)
+	require.Zero(t, vmOutput.GasRemaining)
+
+	// the outcome does not depend on the wall-clock timeout, which stands for the speed of the machine
+	for _, timeout := range []uint32{1000, 5000, 60000} {
+		require.Equal(t, vmOutput,
+			runInstructionBudgetContract(t, "infiniteLoop", 100_000_000, vmhost.InstructionBudgetWatchdog, 100_000, timeout))
+	}
+}
+
+func TestExecutionWatchdog_InstructionBudget_InjectedCodeIsMetered(t *testing.T) {
+	if !testexecutor.IsWasmer1Allowed() {
+		t.Skip("run exclusively with wasmer1")
+	}
+
+	vmOutput := runInstructionBudgetContract(t, "boundedLoop", 100_000_000, vmhost.WallClockWatchdog, 0, 0)
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode, vmOutput.ReturnMessage)
+	gasUsed := 100_000_000 - vmOutput.GasRemaining
+
+	vmOutput = runInstructionBudgetContract(t, "boundedLoop", gasUsed, vmhost.WallClockWatchdog, 0, 0)
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode, vmOutput.ReturnMessage)
+	require.Zero(t, vmOutput.GasRemaining)
+
+	// the code which counts the instructions for the budget is metered like the code of the contract
+	vmOutput = runInstructionBudgetContract(t, "boundedLoop", gasUsed, vmhost.InstructionBudgetWatchdog, 10_000, 0)
+	require.Equal(t, vmcommon.OutOfGas, vmOutput.ReturnCode)
+}
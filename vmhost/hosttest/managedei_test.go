@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"crypto/ed25519"
 	"crypto/elliptic"
+	"encoding/binary"
 	"encoding/hex"
 	"fmt"
 	"math/big"
@@ -12,6 +13,7 @@ import (
 
 	"github.com/multiversx/mx-chain-vm-go/crypto/hashing"
 	"github.com/multiversx/mx-chain-vm-go/crypto/signing/secp256"
+	"github.com/multiversx/mx-chain-vm-go/evmabi"
 	mock "github.com/multiversx/mx-chain-vm-go/mock/context"
 	"github.com/multiversx/mx-chain-vm-go/mock/contracts"
 	"github.com/multiversx/mx-chain-vm-go/testcommon"
@@ -1921,3 +1923,315 @@ func Test_ManagedMultiTransferESDTNFTExecuteByUser_ReturnOnFail(t *testing.T) {
 		})
 	assert.Nil(t, err)
 }
+
+func Test_BigIntModularArithmetic(t *testing.T) {
+	testConfig := baseTestConfig
+
+	_, err := test.BuildMockInstanceCallTest(t).
+		WithContracts(
+			test.CreateMockContract(test.ParentAddress).
+				WithBalance(testConfig.ParentBalance).
+				WithConfig(testConfig).
+				WithMethods(func(parentInstance *mock.InstanceMock, config interface{}) {
+					parentInstance.AddMockMethod("testFunction", func() *mock.InstanceMock {
+						vmHooksImpl := vmhooks.NewVMHooksImpl(parentInstance.Host)
+						managedType := parentInstance.Host.ManagedTypes()
+						result := managedType.NewBigIntFromInt64(0)
+
+						vmHooksImpl.BigIntModPow(result,
+							managedType.NewBigIntFromInt64(-4),
+							managedType.NewBigIntFromInt64(13),
+							managedType.NewBigIntFromInt64(497))
+						vmHooksImpl.BigIntFinishUnsigned(result)
+
+						vmHooksImpl.BigIntModInverse(result,
+							managedType.NewBigIntFromInt64(3),
+							managedType.NewBigIntFromInt64(11))
+						vmHooksImpl.BigIntFinishUnsigned(result)
+
+						vmHooksImpl.BigIntMulMod(result,
+							managedType.NewBigIntFromInt64(7),
+							managedType.NewBigIntFromInt64(-8),
+							managedType.NewBigIntFromInt64(5))
+						vmHooksImpl.BigIntFinishUnsigned(result)
+
+						vmHooksImpl.BigIntGCD(result,
+							managedType.NewBigIntFromInt64(-12),
+							managedType.NewBigIntFromInt64(18))
+						vmHooksImpl.BigIntFinishUnsigned(result)
+
+						return parentInstance
+					})
+				}),
+		).
+		WithInput(test.CreateTestContractCallInputBuilder().
+			WithRecipientAddr(test.ParentAddress).
+			WithGasProvided(testConfig.GasProvided).
+			WithFunction("testFunction").
+			Build()).
+		AndAssertResults(func(world *worldmock.MockWorld, verify *test.VMOutputVerifier) {
+			verify.Ok().
+				ReturnData([]byte{0x34}, []byte{4}, []byte{4}, []byte{6})
+		})
+	assert.Nil(t, err)
+}
+
+func Test_BigIntModularArithmetic_Errors(t *testing.T) {
+	testCases := []struct {
+		name      string
+		operation func(vmHooksImpl *vmhooks.VMHooksImpl, managedType vmhost.ManagedTypesContext)
+		err       error
+	}{
+		{
+			name: "modInverse without inverse",
+			operation: func(vmHooksImpl *vmhooks.VMHooksImpl, managedType vmhost.ManagedTypesContext) {
+				vmHooksImpl.BigIntModInverse(0, managedType.NewBigIntFromInt64(2), managedType.NewBigIntFromInt64(4))
+			},
+			err: vmhost.ErrNoModularInverse,
+		},
+		{
+			name: "modPow with zero modulus",
+			operation: func(vmHooksImpl *vmhooks.VMHooksImpl, managedType vmhost.ManagedTypesContext) {
+				vmHooksImpl.BigIntModPow(0, managedType.NewBigIntFromInt64(2), managedType.NewBigIntFromInt64(4), managedType.NewBigIntFromInt64(0))
+			},
+			err: vmhost.ErrDivZero,
+		},
+		{
+			name: "mulMod with negative modulus",
+			operation: func(vmHooksImpl *vmhooks.VMHooksImpl, managedType vmhost.ManagedTypesContext) {
+				vmHooksImpl.BigIntMulMod(0, managedType.NewBigIntFromInt64(2), managedType.NewBigIntFromInt64(4), managedType.NewBigIntFromInt64(-3))
+			},
+			err: vmhost.ErrNegativeModulus,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			testConfig := baseTestConfig
+			operation := testCase.operation
+
+			_, err := test.BuildMockInstanceCallTest(t).
+				WithContracts(
+					test.CreateMockContract(test.ParentAddress).
+						WithBalance(testConfig.ParentBalance).
+						WithConfig(testConfig).
+						WithMethods(func(parentInstance *mock.InstanceMock, config interface{}) {
+							parentInstance.AddMockMethod("testFunction", func() *mock.InstanceMock {
+								operation(vmhooks.NewVMHooksImpl(parentInstance.Host), parentInstance.Host.ManagedTypes())
+								return parentInstance
+							})
+						}),
+				).
+				WithInput(test.CreateTestContractCallInputBuilder().
+					WithRecipientAddr(test.ParentAddress).
+					WithGasProvided(testConfig.GasProvided).
+					WithFunction("testFunction").
+					Build()).
+				AndAssertResults(func(world *worldmock.MockWorld, verify *test.VMOutputVerifier) {
+					verify.ExecutionFailed().
+						HasRuntimeErrors(testCase.err.Error())
+				})
+			assert.Nil(t, err)
+		})
+	}
+}
+
+func Test_ManagedEVMAbi(t *testing.T) {
+	testConfig := baseTestConfig
+	address := bytes.Repeat([]byte{0x11}, 20)
+
+	_, err := test.BuildMockInstanceCallTest(t).
+		WithContracts(
+			test.CreateMockContract(test.ParentAddress).
+				WithBalance(testConfig.ParentBalance).
+				WithConfig(testConfig).
+				WithMethods(func(parentInstance *mock.InstanceMock, config interface{}) {
+					parentInstance.AddMockMethod("testFunction", func() *mock.InstanceMock {
+						vmHooksImpl := vmhooks.NewVMHooksImpl(parentInstance.Host)
+						managedType := parentInstance.Host.ManagedTypes()
+
+						selectorHandle := managedType.NewManagedBuffer()
+						vmHooksImpl.ManagedEVMFunctionSelector(
+							managedType.NewManagedBufferFromBytes([]byte("transfer(address, uint)")),
+							selectorHandle)
+						vmHooksImpl.MBufferFinish(selectorHandle)
+
+						typesHandle := managedType.NewManagedBufferFromBytes([]byte("address,uint256"))
+						valuesHandle := managedType.NewManagedBuffer()
+						_ = managedType.WriteManagedVecOfManagedBuffers([][]byte{address, {0x01, 0x00}}, valuesHandle)
+
+						encodedHandle := managedType.NewManagedBuffer()
+						vmHooksImpl.ManagedEVMAbiEncode(typesHandle, valuesHandle, encodedHandle)
+						vmHooksImpl.MBufferFinish(encodedHandle)
+
+						decodedHandle := managedType.NewManagedBuffer()
+						vmHooksImpl.ManagedEVMAbiDecode(typesHandle, encodedHandle, decodedHandle)
+						decoded, _, _ := managedType.ReadManagedVecOfManagedBuffers(decodedHandle)
+						for _, value := range decoded {
+							vmHooksImpl.MBufferFinish(managedType.NewManagedBufferFromBytes(value))
+						}
+
+						return parentInstance
+					})
+				}),
+		).
+		WithInput(test.CreateTestContractCallInputBuilder().
+			WithRecipientAddr(test.ParentAddress).
+			WithGasProvided(testConfig.GasProvided).
+			WithFunction("testFunction").
+			Build()).
+		AndAssertResults(func(world *worldmock.MockWorld, verify *test.VMOutputVerifier) {
+			expectedEncoded := append(make([]byte, 12), address...)
+			expectedEncoded = append(expectedEncoded, make([]byte, 30)...)
+			expectedEncoded = append(expectedEncoded, 0x01, 0x00)
+
+			verify.Ok().
+				ReturnData([]byte{0xa9, 0x05, 0x9c, 0xbb}, expectedEncoded, address, []byte{0x01, 0x00})
+		})
+	assert.Nil(t, err)
+}
+
+func Test_ManagedEVMAbi_NestedValues(t *testing.T) {
+	testConfig := baseTestConfig
+
+	_, err := test.BuildMockInstanceCallTest(t).
+		WithContracts(
+			test.CreateMockContract(test.ParentAddress).
+				WithBalance(testConfig.ParentBalance).
+				WithConfig(testConfig).
+				WithMethods(func(parentInstance *mock.InstanceMock, config interface{}) {
+					parentInstance.AddMockMethod("testFunction", func() *mock.InstanceMock {
+						vmHooksImpl := vmhooks.NewVMHooksImpl(parentInstance.Host)
+						managedType := parentInstance.Host.ManagedTypes()
+
+						// the values of "(bool,bytes)[]" are a vec holding the array, which holds the tuple
+						typesHandle := managedType.NewManagedBufferFromBytes([]byte("(bool,bytes)[]"))
+						tupleHandle := managedType.NewManagedBuffer()
+						_ = managedType.WriteManagedVecOfManagedBuffers([][]byte{{0x01}, []byte("abc")}, tupleHandle)
+						arrayHandle := managedVecOfHandles(managedType, tupleHandle)
+						valuesHandle := managedVecOfHandles(managedType, arrayHandle)
+
+						encodedHandle := managedType.NewManagedBuffer()
+						vmHooksImpl.ManagedEVMAbiEncode(typesHandle, valuesHandle, encodedHandle)
+						vmHooksImpl.MBufferFinish(encodedHandle)
+
+						decodedHandle := managedType.NewManagedBuffer()
+						vmHooksImpl.ManagedEVMAbiDecode(typesHandle, encodedHandle, decodedHandle)
+						for _, handleOf := range []string{"array", "tuple"} {
+							decodedBytes, _ := managedType.GetBytes(decodedHandle)
+							require.Len(t, decodedBytes, 4, handleOf)
+							decodedHandle = int32(binary.BigEndian.Uint32(decodedBytes))
+						}
+						tuple, _, _ := managedType.ReadManagedVecOfManagedBuffers(decodedHandle)
+						for _, value := range tuple {
+							vmHooksImpl.MBufferFinish(managedType.NewManagedBufferFromBytes(value))
+						}
+
+						return parentInstance
+					})
+				}),
+		).
+		WithInput(test.CreateTestContractCallInputBuilder().
+			WithRecipientAddr(test.ParentAddress).
+			WithGasProvided(testConfig.GasProvided).
+			WithFunction("testFunction").
+			Build()).
+		AndAssertResults(func(world *worldmock.MockWorld, verify *test.VMOutputVerifier) {
+			expectedEncoded := bytes.Join([][]byte{
+				evmWord(0x20), evmWord(1), evmWord(0x20),
+				evmWord(1), evmWord(0x40), evmWord(3), append([]byte("abc"), make([]byte, 29)...),
+			}, nil)
+
+			verify.Ok().
+				ReturnData(expectedEncoded, []byte{0x01}, []byte("abc"))
+		})
+	assert.Nil(t, err)
+}
+
+func Test_ManagedEVMAbi_Errors(t *testing.T) {
+	testCases := []struct {
+		name      string
+		operation func(vmHooksImpl *vmhooks.VMHooksImpl, managedType vmhost.ManagedTypesContext)
+		err       error
+	}{
+		{
+			name: "encode with unknown type",
+			operation: func(vmHooksImpl *vmhooks.VMHooksImpl, managedType vmhost.ManagedTypesContext) {
+				vmHooksImpl.ManagedEVMAbiEncode(managedType.NewManagedBufferFromBytes([]byte("uint7")), managedType.NewManagedBuffer(), 0)
+			},
+			err: evmabi.ErrInvalidType,
+		},
+		{
+			name: "encode with missing value",
+			operation: func(vmHooksImpl *vmhooks.VMHooksImpl, managedType vmhost.ManagedTypesContext) {
+				vmHooksImpl.ManagedEVMAbiEncode(managedType.NewManagedBufferFromBytes([]byte("uint8,bool")), managedType.NewManagedBuffer(), 0)
+			},
+			err: evmabi.ErrValueCountMismatch,
+		},
+		{
+			name: "encode with truncated value handles",
+			operation: func(vmHooksImpl *vmhooks.VMHooksImpl, managedType vmhost.ManagedTypesContext) {
+				vmHooksImpl.ManagedEVMAbiEncode(managedType.NewManagedBufferFromBytes([]byte("uint8")), managedType.NewManagedBufferFromBytes([]byte{0, 0, 1}), 0)
+			},
+			err: vmhost.ErrInvalidEVMAbiValueHandles,
+		},
+		{
+			name: "decode with invalid padding",
+			operation: func(vmHooksImpl *vmhooks.VMHooksImpl, managedType vmhost.ManagedTypesContext) {
+				vmHooksImpl.ManagedEVMAbiDecode(managedType.NewManagedBufferFromBytes([]byte("uint8")), managedType.NewManagedBufferFromBytes(evmWord(0x100)), 0)
+			},
+			err: evmabi.ErrInvalidPadding,
+		},
+		{
+			name: "selector of invalid signature",
+			operation: func(vmHooksImpl *vmhooks.VMHooksImpl, managedType vmhost.ManagedTypesContext) {
+				vmHooksImpl.ManagedEVMFunctionSelector(managedType.NewManagedBufferFromBytes([]byte("transfer")), 0)
+			},
+			err: evmabi.ErrInvalidType,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			testConfig := baseTestConfig
+			operation := testCase.operation
+
+			_, err := test.BuildMockInstanceCallTest(t).
+				WithContracts(
+					test.CreateMockContract(test.ParentAddress).
+						WithBalance(testConfig.ParentBalance).
+						WithConfig(testConfig).
+						WithMethods(func(parentInstance *mock.InstanceMock, config interface{}) {
+							parentInstance.AddMockMethod("testFunction", func() *mock.InstanceMock {
+								operation(vmhooks.NewVMHooksImpl(parentInstance.Host), parentInstance.Host.ManagedTypes())
+								return parentInstance
+							})
+						}),
+				).
+				WithInput(test.CreateTestContractCallInputBuilder().
+					WithRecipientAddr(test.ParentAddress).
+					WithGasProvided(testConfig.GasProvided).
+					WithFunction("testFunction").
+					Build()).
+				AndAssertResults(func(world *worldmock.MockWorld, verify *test.VMOutputVerifier) {
+					verify.ExecutionFailed().
+						HasRuntimeErrors(testCase.err.Error())
+				})
+			assert.Nil(t, err)
+		})
+	}
+}
+
+func managedVecOfHandles(managedType vmhost.ManagedTypesContext, handles ...int32) int32 {
+	vecBytes := make([]byte, 4*len(handles))
+	for i, handle := range handles {
+		binary.BigEndian.PutUint32(vecBytes[4*i:], uint32(handle))
+	}
+	return managedType.NewManagedBufferFromBytes(vecBytes)
+}
+
+func evmWord(value uint64) []byte {
+	word := make([]byte, 32)
+	binary.BigEndian.PutUint64(word[24:], value)
+	return word
+}
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/multiversx/mx-chain-scenario-go/worldmock"
+	"github.com/multiversx/mx-chain-vm-go/config"
 	mock "github.com/multiversx/mx-chain-vm-go/mock/context"
 	test "github.com/multiversx/mx-chain-vm-go/testcommon"
 	"github.com/multiversx/mx-chain-vm-go/vmhost"
@@ -146,3 +147,54 @@ func TestManaged_CopyByteSlice_SameBuffer(t *testing.T) {
 		})
 	assert.Nil(t, err)
 }
+
+func TestManaged_DecimalLnExpGasScalesWithScale(t *testing.T) {
+	type series func(hooks *vmhooks.VMHooksImpl, destinationHandle, opHandle, scale int32)
+	testCases := []struct {
+		name         string
+		run          series
+		costPerDigit func(costs config.DecimalAPICost) uint64
+	}{
+		{"ln", (*vmhooks.VMHooksImpl).DecimalLn, func(costs config.DecimalAPICost) uint64 { return costs.DecimalLnPerScaleDigit }},
+		{"exp", (*vmhooks.VMHooksImpl).DecimalExp, func(costs config.DecimalAPICost) uint64 { return costs.DecimalExpPerScaleDigit }},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			var gasUsedAtScale10, gasUsedAtScale40, costPerDigit uint64
+			_, err := test.BuildMockInstanceCallTest(t).
+				WithContracts(
+					test.CreateMockContract(test.ParentAddress).
+						WithBalance(1000).
+						WithMethods(func(parentInstance *mock.InstanceMock, config interface{}) {
+							parentInstance.AddMockMethod("testFunction", func() *mock.InstanceMock {
+								host := parentInstance.Host
+								hooks := vmhooks.NewVMHooksImpl(host)
+								costPerDigit = testCase.costPerDigit(host.Metering().GasSchedule().DecimalAPICost)
+
+								opHandle := hooks.DecimalNew(2, 0)
+								gasUsedAt := func(scale int32) uint64 {
+									gasLeft := host.Metering().GasLeft()
+									testCase.run(hooks, host.ManagedTypes().NewManagedBuffer(), opHandle, scale)
+									return gasLeft - host.Metering().GasLeft()
+								}
+								gasUsedAtScale10 = gasUsedAt(10)
+								gasUsedAtScale40 = gasUsedAt(40)
+								return parentInstance
+							})
+						}),
+				).
+				WithInput(test.CreateTestContractCallInputBuilder().
+					WithRecipientAddr(test.ParentAddress).
+					WithGasProvided(1_000_000).
+					WithFunction("testFunction").
+					Build()).
+				AndAssertResults(func(world *worldmock.MockWorld, verify *test.VMOutputVerifier) {
+					verify.Ok()
+				})
+			assert.Nil(t, err)
+			assert.NotZero(t, costPerDigit)
+			assert.Equal(t, 30*costPerDigit, gasUsedAtScale40-gasUsedAtScale10)
+		})
+	}
+}
@@ -0,0 +1,255 @@
+package hostCoretest
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-scenario-go/worldmock"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	contextmock "github.com/multiversx/mx-chain-vm-go/mock/context"
+	test "github.com/multiversx/mx-chain-vm-go/testcommon"
+	"github.com/multiversx/mx-chain-vm-go/testcommon/testexecutor"
+	"github.com/multiversx/mx-chain-vm-go/vmhost"
+	"github.com/multiversx/mx-chain-vm-go/vmhost/vmhooks"
+	"github.com/multiversx/mx-chain-vm-go/wasmer"
+	"github.com/stretchr/testify/require"
+)
+
+const memoryGrowPerPage = uint64(7)
+
+func growMemoryParentMock(numPages uint32) func(*contextmock.InstanceMock, interface{}) {
+	return func(instanceMock *contextmock.InstanceMock, config interface{}) {
+		testConfig := config.(*test.TestConfig)
+		instanceMock.AddMockMethod("growMemory", func() *contextmock.InstanceMock {
+			host := instanceMock.Host
+			instance := contextmock.GetMockInstance(host)
+
+			err := host.Metering().UseGasBounded(testConfig.GasUsedByParent)
+			if err != nil {
+				host.Runtime().SetRuntimeBreakpointValue(vmhost.BreakpointOutOfGas)
+				return instance
+			}
+
+			err = instance.MemGrow(numPages)
+			if err != nil {
+				host.Runtime().FailExecution(err)
+				return instance
+			}
+
+			memoryUsage := vmhooks.NewVMHooksImpl(host).GetMemoryUsage()
+			host.Output().Finish(big.NewInt(memoryUsage).Bytes())
+			return instance
+		})
+	}
+}
+
+// growMemoryInstrumentedMock emulates an instrumented contract, which calls the memory growth hook right after
+// memory.grow, and then returns the gas left.
+func growMemoryInstrumentedMock(numPages uint32) func(*contextmock.InstanceMock, interface{}) {
+	return func(instanceMock *contextmock.InstanceMock, _ interface{}) {
+		instanceMock.AddMockMethod("growMemory", func() *contextmock.InstanceMock {
+			host := instanceMock.Host
+			instance := contextmock.GetMockInstance(host)
+			hooks := vmhooks.NewVMHooksImpl(host)
+
+			previousPages := int32(instance.MemLength() / vmhost.WASMPageSize)
+			err := instance.MemGrow(numPages)
+			if err != nil {
+				host.Runtime().FailExecution(err)
+				return instance
+			}
+			hooks.ChargeMemoryGrowth(previousPages)
+			if host.Runtime().GetRuntimeBreakpointValue() != vmhost.BreakpointNone {
+				return instance
+			}
+
+			host.Output().Finish(big.NewInt(hooks.GetGasLeft()).Bytes())
+			return instance
+		})
+	}
+}
+
+func runGrowMemory(t *testing.T, methods func(*contextmock.InstanceMock, interface{}), gasProvided uint64) *vmcommon.VMOutput {
+	testConfig := makeTestConfig()
+
+	vmOutput, err := test.BuildMockInstanceCallTest(t).
+		WithContracts(
+			test.CreateMockContract(test.ParentAddress).
+				WithBalance(0).
+				WithConfig(testConfig).
+				WithMethods(methods)).
+		WithInput(test.CreateTestContractCallInputBuilder().
+			WithRecipientAddr(test.ParentAddress).
+			WithGasProvided(gasProvided).
+			WithFunction("growMemory").
+			Build()).
+		WithSetup(func(host vmhost.VMHost, _ *worldmock.MockWorld) {
+			setZeroCodeCosts(host)
+			host.Metering().GasSchedule().BaseOperationCost.MemoryGrowPerPage = memoryGrowPerPage
+		}).
+		AndAssertResults(func(_ *worldmock.MockWorld, _ *test.VMOutputVerifier) {
+		})
+	require.Nil(t, err)
+
+	return vmOutput
+}
+
+func TestGasUsed_MemoryGrowth_ChargedPerPage(t *testing.T) {
+	testConfig := makeTestConfig()
+
+	noGrowthOutput := runGrowMemory(t, growMemoryParentMock(0), testConfig.GasProvided)
+	require.Equal(t, vmcommon.Ok, noGrowthOutput.ReturnCode)
+
+	numPages := uint32(3)
+	growthOutput := runGrowMemory(t, growMemoryParentMock(numPages), testConfig.GasProvided)
+	require.Equal(t, vmcommon.Ok, growthOutput.ReturnCode)
+
+	expectedGrowthGas := uint64(numPages) * memoryGrowPerPage
+	require.Equal(t, noGrowthOutput.GasRemaining-expectedGrowthGas, growthOutput.GasRemaining)
+
+	initialMemory := contextmock.NewMemoryMock().Length()
+	require.Equal(t, int64(initialMemory), big.NewInt(0).SetBytes(noGrowthOutput.ReturnData[0]).Int64())
+	require.Equal(t,
+		int64(initialMemory)+int64(numPages)*int64(vmhost.WASMPageSize),
+		big.NewInt(0).SetBytes(growthOutput.ReturnData[0]).Int64())
+}
+
+func TestGasUsed_MemoryGrowth_NotEnoughGas(t *testing.T) {
+	testConfig := makeTestConfig()
+
+	noGrowthOutput := runGrowMemory(t, growMemoryParentMock(0), testConfig.GasProvided)
+	require.Equal(t, vmcommon.Ok, noGrowthOutput.ReturnCode)
+	gasNeededWithoutGrowth := testConfig.GasProvided - noGrowthOutput.GasRemaining
+
+	numPages := uint32(10)
+	vmOutput := runGrowMemory(t, growMemoryParentMock(numPages), gasNeededWithoutGrowth+uint64(numPages)*memoryGrowPerPage-1)
+	require.Equal(t, vmcommon.OutOfGas, vmOutput.ReturnCode)
+}
+
+func TestGasUsed_MemoryGrowth_ChargedAtGrowTime(t *testing.T) {
+	testConfig := makeTestConfig()
+
+	noGrowthOutput := runGrowMemory(t, growMemoryInstrumentedMock(0), testConfig.GasProvided)
+	require.Equal(t, vmcommon.Ok, noGrowthOutput.ReturnCode, noGrowthOutput.ReturnMessage)
+
+	// the pages are charged before the contract continues, not only when the execution returns to the VM
+	numPages := uint32(3)
+	growthOutput := runGrowMemory(t, growMemoryInstrumentedMock(numPages), testConfig.GasProvided)
+	require.Equal(t, vmcommon.Ok, growthOutput.ReturnCode, growthOutput.ReturnMessage)
+	gasLeftWithoutGrowth := big.NewInt(0).SetBytes(noGrowthOutput.ReturnData[0]).Uint64()
+	gasLeftAfterGrowth := big.NewInt(0).SetBytes(growthOutput.ReturnData[0]).Uint64()
+	require.Equal(t, gasLeftWithoutGrowth-uint64(numPages)*memoryGrowPerPage, gasLeftAfterGrowth)
+
+	// the execution stops at the growth which the gas does not cover
+	numPages = uint32(testConfig.GasProvided/memoryGrowPerPage) + 1
+	vmOutput := runGrowMemory(t, growMemoryInstrumentedMock(numPages), testConfig.GasProvided)
+	require.Equal(t, vmcommon.OutOfGas, vmOutput.ReturnCode)
+	require.Empty(t, vmOutput.ReturnData)
+}
+
+// runMemoryGrowContract calls growMemory repeatedly on the same host, so that the later calls use a warm instance.
+func runMemoryGrowContract(t *testing.T, memoryGrowPerPage uint64, numCalls int) []*vmcommon.VMOutput {
+	vmOutputs := make([]*vmcommon.VMOutput, 0, numCalls)
+	testCase := test.BuildInstanceCallTest(t).
+		WithContracts(
+			test.CreateInstanceContract(test.ParentAddress).
+				WithCode(test.GetTestSCCode("memory-grow", "../../")).
+				WithBalance(1000)).
+		WithSetup(func(host vmhost.VMHost, _ *contextmock.BlockchainHookStub) {
+			host.Metering().GasSchedule().BaseOperationCost.MemoryGrowPerPage = memoryGrowPerPage
+		}).
+		WithInput(test.CreateTestContractCallInputBuilder().
+			WithRecipientAddr(test.ParentAddress).
+			WithGasProvided(100000).
+			WithFunction("growMemory").
+			Build())
+
+	for i := 0; i < numCalls; i++ {
+		testCase.AndAssertResultsWithoutReset(func(_ vmhost.VMHost, _ *contextmock.BlockchainHookStub, verify *test.VMOutputVerifier) {
+			// the module declares 2 pages and grows by 3
+			verify.Ok().ReturnData([]byte{2}, []byte{5})
+			vmOutputs = append(vmOutputs, verify.VmOutput)
+		})
+	}
+	testCase.GetVMHost().Reset()
+
+	return vmOutputs
+}
+
+func TestGasUsed_MemoryGrowth_RealMemoryGrow(t *testing.T) {
+	withoutGrowthGas := runMemoryGrowContract(t, 0, 1)
+	withGrowthGas := runMemoryGrowContract(t, memoryGrowPerPage, 2)
+
+	// only the 3 pages added by memory.grow are charged, not the 2 declared by the module,
+	// and the charge does not depend on the instance being cold or warm
+	expectedGrowthGas := 3 * memoryGrowPerPage
+	require.Equal(t, withoutGrowthGas[0].GasRemaining-expectedGrowthGas, withGrowthGas[0].GasRemaining)
+	require.Equal(t, withGrowthGas[0].GasRemaining, withGrowthGas[1].GasRemaining)
+}
+
+// runGrowMemoryAndGetGasLeft calls the memory-grow test contract with Wasmer 1, the executor which provides the
+// memory growth hook, injected into the contract only when the gas for memory growth is enabled.
+func runGrowMemoryAndGetGasLeft(t *testing.T, memoryGrowPerPage uint64, growthGasEnabled bool) *vmcommon.VMOutput {
+	var vmOutput *vmcommon.VMOutput
+	test.BuildInstanceCallTest(t).
+		WithContracts(
+			test.CreateInstanceContract(test.ParentAddress).
+				WithCode(test.GetTestSCCode("memory-grow", "../../"))).
+		WithExecutorFactory(wasmer.ExecutorFactory()).
+		WithSetup(func(host vmhost.VMHost, _ *contextmock.BlockchainHookStub) {
+			host.Metering().GasSchedule().BaseOperationCost.MemoryGrowPerPage = memoryGrowPerPage
+			if !growthGasEnabled {
+				enableEpochsHandler, _ := host.EnableEpochsHandler().(*worldmock.EnableEpochsHandlerStub)
+				enableEpochsHandler.IsFlagEnabledCalled = func(flag core.EnableEpochFlag) bool {
+					return flag != vmhost.MemoryGrowthGasFlag
+				}
+			}
+		}).
+		WithInput(test.CreateTestContractCallInputBuilder().
+			WithRecipientAddr(test.ParentAddress).
+			WithGasProvided(100000).
+			WithFunction("growMemoryAndGetGasLeft").
+			Build()).
+		AndAssertResults(func(_ vmhost.VMHost, _ *contextmock.BlockchainHookStub, verify *test.VMOutputVerifier) {
+			vmOutput = verify.VmOutput
+		})
+	return vmOutput
+}
+
+func TestGasUsed_MemoryGrowth_RealMemoryGrowChargedAtGrowTime(t *testing.T) {
+	if !testexecutor.IsWasmer1Allowed() {
+		t.Skip("run exclusively with wasmer1")
+	}
+
+	withoutGrowthGas := runGrowMemoryAndGetGasLeft(t, 0, true)
+	require.Equal(t, vmcommon.Ok, withoutGrowthGas.ReturnCode, withoutGrowthGas.ReturnMessage)
+	withGrowthGas := runGrowMemoryAndGetGasLeft(t, memoryGrowPerPage, true)
+	require.Equal(t, vmcommon.Ok, withGrowthGas.ReturnCode, withGrowthGas.ReturnMessage)
+
+	// the contract reads the gas left right after growing by 3 pages, which are already charged
+	gasLeftWithoutGrowthGas := big.NewInt(0).SetBytes(withoutGrowthGas.ReturnData[0]).Uint64()
+	gasLeftWithGrowthGas := big.NewInt(0).SetBytes(withGrowthGas.ReturnData[0]).Uint64()
+	require.Equal(t, gasLeftWithoutGrowthGas-3*memoryGrowPerPage, gasLeftWithGrowthGas)
+	require.Equal(t, withoutGrowthGas.GasRemaining-3*memoryGrowPerPage, withGrowthGas.GasRemaining)
+
+	vmOutput := runGrowMemoryAndGetGasLeft(t, 1_000_000, true)
+	require.Equal(t, vmcommon.OutOfGas, vmOutput.ReturnCode)
+	require.Equal(t, vmhost.ErrNotEnoughGas.Error(), vmOutput.ReturnMessage)
+}
+
+func TestGasUsed_MemoryGrowth_HookCostsNoOpcodeGas(t *testing.T) {
+	if !testexecutor.IsWasmer1Allowed() {
+		t.Skip("run exclusively with wasmer1")
+	}
+
+	// the executors meter only the opcodes of the contract, so the call injected after memory.grow must not change
+	// the gas, which is then the same as on Wasmer 2, where the growth is charged when the execution returns
+	uninstrumented := runGrowMemoryAndGetGasLeft(t, 0, false)
+	require.Equal(t, vmcommon.Ok, uninstrumented.ReturnCode, uninstrumented.ReturnMessage)
+	instrumented := runGrowMemoryAndGetGasLeft(t, 0, true)
+	require.Equal(t, vmcommon.Ok, instrumented.ReturnCode, instrumented.ReturnMessage)
+
+	require.Equal(t, uninstrumented.ReturnData, instrumented.ReturnData)
+	require.Equal(t, uninstrumented.GasRemaining, instrumented.GasRemaining)
+}
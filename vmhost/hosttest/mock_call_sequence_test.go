@@ -0,0 +1,58 @@
+package hostCoretest
+
+import (
+	"testing"
+
+	"github.com/multiversx/mx-chain-scenario-go/worldmock"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	test "github.com/multiversx/mx-chain-vm-go/testcommon"
+	"github.com/multiversx/mx-chain-vm-go/vmhost"
+	"github.com/stretchr/testify/require"
+)
+
+// mockCallSequence runs successive mock contract call tests on the same world, applying the output of each
+// successful call to the world before the next one, as the protocol does between transactions.
+type mockCallSequence struct {
+	t                    *testing.T
+	world                *worldmock.MockWorld
+	contracts            []test.MockTestSmartContract
+	setup                test.SetupFunction
+	contractsInitialized bool
+}
+
+func newMockCallSequence(t *testing.T, contracts ...test.MockTestSmartContract) *mockCallSequence {
+	world := worldmock.NewMockWorld()
+	world.CurrentBlockInfo = &worldmock.BlockInfo{}
+	return &mockCallSequence{
+		t:         t,
+		world:     world,
+		contracts: contracts,
+		setup:     func(vmhost.VMHost, *worldmock.MockWorld) {},
+	}
+}
+
+// withSetup provides the setup function which runs on the new host of each call.
+func (sequence *mockCallSequence) withSetup(setup test.SetupFunction) *mockCallSequence {
+	sequence.setup = setup
+	return sequence
+}
+
+// call runs the next call of the sequence and asserts its results.
+func (sequence *mockCallSequence) call(input *vmcommon.ContractCallInput, assertResults test.AssertResultsFunc) *vmcommon.VMOutput {
+	vmOutput, err := test.BuildMockInstanceCallTest(sequence.t).
+		WithContracts(sequence.contracts...).
+		WithInput(input).
+		WithSetup(sequence.setup).
+		AndAssertResultsWithWorld(sequence.world, !sequence.contractsInitialized, nil, nil,
+			func(_ *test.TestCallNode, world *worldmock.MockWorld, verify *test.VMOutputVerifier, _ []string) {
+				assertResults(world, verify)
+			})
+	sequence.contractsInitialized = true
+	require.Nil(sequence.t, err)
+
+	if vmOutput.ReturnCode == vmcommon.Ok {
+		err = sequence.world.UpdateAccounts(vmOutput.OutputAccounts, vmOutput.DeletedAccounts)
+		require.Nil(sequence.t, err)
+	}
+	return vmOutput
+}
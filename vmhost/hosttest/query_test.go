@@ -0,0 +1,272 @@
+package hostCoretest
+
+import (
+	"math/big"
+	"sync"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/data/vm"
+	"github.com/multiversx/mx-chain-scenario-go/worldmock"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	contextmock "github.com/multiversx/mx-chain-vm-go/mock/context"
+	test "github.com/multiversx/mx-chain-vm-go/testcommon"
+	"github.com/multiversx/mx-chain-vm-go/vmhost"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	queryGasLimit    = 1_000_000
+	queryBudget      = 500
+	queryLoopBlocks  = 200
+	queryStorageKey  = "value"
+	queryStoredValue = "stored"
+)
+
+// queryMock exposes a view and the operations which queries must reject.
+func queryMock(instanceMock *contextmock.InstanceMock, _ interface{}) {
+	failOnError := func(host vmhost.VMHost, err error) {
+		if err != nil {
+			host.Runtime().FailExecution(err)
+		}
+	}
+
+	instanceMock.AddMockMethod("getValue", func() *contextmock.InstanceMock {
+		host := instanceMock.Host
+		value, _, _, err := host.Storage().GetStorage([]byte(queryStorageKey))
+		failOnError(host, err)
+		host.Output().Finish(value)
+		return contextmock.GetMockInstance(host)
+	})
+	instanceMock.AddMockMethod("setValue", func() *contextmock.InstanceMock {
+		host := instanceMock.Host
+		_, err := host.Storage().SetStorage([]byte(queryStorageKey), []byte("changed"))
+		failOnError(host, err)
+		return contextmock.GetMockInstance(host)
+	})
+	instanceMock.AddMockMethod("sendEGLD", func() *contextmock.InstanceMock {
+		host := instanceMock.Host
+		err := host.Output().Transfer(test.UserAddress, test.ParentAddress, 0, 0, big.NewInt(1), nil, nil, vm.DirectCall)
+		failOnError(host, err)
+		return contextmock.GetMockInstance(host)
+	})
+	instanceMock.AddMockMethod("sendESDT", func() *contextmock.InstanceMock {
+		host := instanceMock.Host
+		_, _, err := host.ExecuteESDTTransfer(&vmhost.ESDTTransfersArgs{
+			Destination: test.UserAddress,
+			Sender:      test.ParentAddress,
+			Transfers:   []*vmcommon.ESDTTransfer{{ESDTTokenName: test.ESDTTestTokenName, ESDTValue: big.NewInt(1)}},
+		}, vm.DirectCall)
+		failOnError(host, err)
+		return contextmock.GetMockInstance(host)
+	})
+	instanceMock.AddMockMethod("callAsync", func() *contextmock.InstanceMock {
+		host := instanceMock.Host
+		err := host.Async().RegisterAsyncCall("", &vmhost.AsyncCall{
+			Destination: test.ChildAddress,
+			Data:        []byte("f"),
+			GasLimit:    1000,
+		})
+		failOnError(host, err)
+		return contextmock.GetMockInstance(host)
+	})
+	instanceMock.AddMockMethod("loop", func() *contextmock.InstanceMock {
+		host := instanceMock.Host
+		executeBasicBlocks(host, queryLoopBlocks)
+		return contextmock.GetMockInstance(host)
+	})
+}
+
+// queryTest builds a mock contract call test of the given input against the query mock, on a host which
+// bounds the transactions by an instruction budget.
+func queryTest(t *testing.T, input *vmcommon.ContractCallInput) *test.MockInstancesTestTemplate {
+	testConfig := makeTestConfig()
+	return test.BuildMockInstanceCallTest(t).
+		WithContracts(
+			test.CreateMockContract(test.ParentAddress).
+				WithBalance(testConfig.ParentBalance).
+				WithConfig(testConfig).
+				WithMethods(queryMock)).
+		WithInput(input).
+		WithExecutionWatchdog(vmhost.InstructionBudgetWatchdog, queryBudget).
+		WithQueryGasLimit(queryGasLimit).
+		WithSetup(setQueryStoredValue)
+}
+
+func setQueryStoredValue(_ vmhost.VMHost, world *worldmock.MockWorld) {
+	world.AcctMap.GetAccount(test.ParentAddress).Storage[queryStorageKey] = []byte(queryStoredValue)
+}
+
+func queryInput(function string) *vmcommon.ContractCallInput {
+	return test.CreateTestContractCallInputBuilder().
+		WithCallerAddr(test.UserAddress).
+		WithRecipientAddr(test.ParentAddress).
+		WithGasProvided(queryGasLimit).
+		WithFunction(function).
+		Build()
+}
+
+func runQuery(t *testing.T, host vmhost.VMHost, function string) *vmcommon.VMOutput {
+	vmOutput, err := host.(vmhost.QueryExecutor).RunSmartContractQuery(queryInput(function))
+	require.Nil(t, err)
+	return vmOutput
+}
+
+func runCall(t *testing.T, host vmhost.VMHost, function string) *vmcommon.VMOutput {
+	vmOutput, err := host.RunSmartContractCall(queryInput(function))
+	require.Nil(t, err)
+	return vmOutput
+}
+
+func queryOk(_ *worldmock.MockWorld, verify *test.VMOutputVerifier) {
+	verify.Ok()
+}
+
+func budgetExceeded(_ *worldmock.MockWorld, verify *test.VMOutputVerifier) {
+	verify.ExecutionFailed().
+		ReturnMessageContains(vmhost.ErrExecutionBudgetExceeded.Error())
+}
+
+func requireWarmInstances(t *testing.T, host vmhost.VMHost, expectedWarm int) {
+	tracker, ok := host.Runtime().GetInstanceTracker().(interface{ NumRunningInstances() (int, int) })
+	require.True(t, ok)
+	warm, cold := tracker.NumRunningInstances()
+	require.Equal(t, expectedWarm, warm)
+	require.Zero(t, cold)
+	require.Nil(t, host.Runtime().ValidateInstances())
+}
+
+func TestQuery_View(t *testing.T) {
+	var host vmhost.VMHost
+	_, err := queryTest(t, queryInput("getValue")).
+		WithSetup(func(testHost vmhost.VMHost, world *worldmock.MockWorld) {
+			host = testHost
+			setQueryStoredValue(testHost, world)
+		}).
+		AndQueryAndAssertResults(func(_ *worldmock.MockWorld, verify *test.VMOutputVerifier) {
+			verify.Ok().
+				ReturnData([]byte(queryStoredValue))
+			require.False(t, host.IsQueryExecution())
+		})
+	require.Nil(t, err)
+}
+
+func TestQuery_RejectsWrites(t *testing.T) {
+	testCases := []struct {
+		function    string
+		expectedErr error
+	}{
+		{"setValue", vmhost.ErrCannotWriteOnReadOnly},
+		{"sendEGLD", vmhost.ErrInvalidCallOnReadOnlyMode},
+		{"sendESDT", vmhost.ErrTransferInQuery},
+		{"callAsync", vmhost.ErrAsyncCallInQuery},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.function, func(t *testing.T) {
+			_, err := queryTest(t, queryInput(testCase.function)).
+				AndQueryAndAssertResults(func(_ *worldmock.MockWorld, verify *test.VMOutputVerifier) {
+					verify.ExecutionFailed().
+						ReturnMessageContains(testCase.expectedErr.Error())
+				})
+			require.Nil(t, err)
+
+			// the same operations are allowed in transactions
+			_, err = queryTest(t, queryInput(testCase.function)).
+				AndAssertResults(func(_ *worldmock.MockWorld, verify *test.VMOutputVerifier) {
+					require.NotContains(t, verify.VmOutput.ReturnMessage, testCase.expectedErr.Error())
+				})
+			require.Nil(t, err)
+		})
+	}
+}
+
+func TestQuery_InvalidInput(t *testing.T) {
+	requireInvalidQuery := func(input *vmcommon.ContractCallInput, expectedErr error) {
+		_, _, err := queryTest(t, input).RunTest(nil, true, test.QueryTest)
+		require.ErrorIs(t, err, expectedErr)
+	}
+
+	input := queryInput("getValue")
+	input.GasProvided = queryGasLimit + 1
+	requireInvalidQuery(input, vmhost.ErrQueryGasLimitExceeded)
+
+	input = queryInput("getValue")
+	input.CallValue = big.NewInt(1)
+	requireInvalidQuery(input, vmhost.ErrTransferInQuery)
+
+	input = queryInput("getValue")
+	input.ESDTTransfers = []*vmcommon.ESDTTransfer{{ESDTTokenName: test.ESDTTestTokenName, ESDTValue: big.NewInt(1)}}
+	requireInvalidQuery(input, vmhost.ErrTransferInQuery)
+
+	requireInvalidQuery(queryInput(vmhost.UpgradeFunctionName), vmhost.ErrInvalidCallOnReadOnlyMode)
+}
+
+func TestQuery_NotBoundedByInstructionBudget(t *testing.T) {
+	_, err := queryTest(t, queryInput("loop")).
+		AndAssertResults(budgetExceeded)
+	require.Nil(t, err)
+
+	_, err = queryTest(t, queryInput("loop")).
+		AndQueryAndAssertResults(queryOk)
+	require.Nil(t, err)
+
+	// the budget applies again to the transactions after the query
+	_, err = queryTest(t, queryInput("loop")).
+		WithSetup(func(host vmhost.VMHost, world *worldmock.MockWorld) {
+			setQueryStoredValue(host, world)
+			require.Equal(t, vmcommon.Ok, runQuery(t, host, "loop").ReturnCode)
+		}).
+		AndAssertResults(budgetExceeded)
+	require.Nil(t, err)
+}
+
+func TestQuery_WarmInstanceCacheUnchanged(t *testing.T) {
+	_, err := queryTest(t, queryInput("getValue")).
+		WithSetup(func(host vmhost.VMHost, world *worldmock.MockWorld) {
+			setQueryStoredValue(host, world)
+
+			runQuery(t, host, "getValue")
+			requireWarmInstances(t, host, 0)
+
+			vmOutput := runCall(t, host, "getValue")
+			require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode, vmOutput.ReturnMessage)
+			requireWarmInstances(t, host, 1)
+
+			// the warm instance is used by the query, but stays in the cache
+			vmOutput = runQuery(t, host, "getValue")
+			require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode, vmOutput.ReturnMessage)
+			requireWarmInstances(t, host, 1)
+		}).
+		AndQueryAndAssertResults(queryOk)
+	require.Nil(t, err)
+}
+
+func TestQuery_ConcurrentWithCall(t *testing.T) {
+	const numExecutions = 20
+	_, err := queryTest(t, queryInput("loop")).
+		WithSetup(func(host vmhost.VMHost, world *worldmock.MockWorld) {
+			setQueryStoredValue(host, world)
+
+			wg := sync.WaitGroup{}
+			wg.Add(2)
+			go func() {
+				defer wg.Done()
+				for i := 0; i < numExecutions; i++ {
+					vmOutput := runQuery(t, host, "loop")
+					require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode, vmOutput.ReturnMessage)
+				}
+			}()
+			go func() {
+				defer wg.Done()
+				for i := 0; i < numExecutions; i++ {
+					// the query mode of a concurrent query must not lift the instruction budget of the call
+					vmOutput := runCall(t, host, "loop")
+					require.Equal(t, vmcommon.ExecutionFailed, vmOutput.ReturnCode)
+					require.Contains(t, vmOutput.ReturnMessage, vmhost.ErrExecutionBudgetExceeded.Error())
+				}
+			}()
+			wg.Wait()
+			require.False(t, host.IsQueryExecution())
+		}).
+		AndAssertResults(budgetExceeded)
+	require.Nil(t, err)
+}
@@ -0,0 +1,182 @@
+package hostCoretest
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/hashing/blake2b"
+	"github.com/multiversx/mx-chain-scenario-go/worldmock"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	contextmock "github.com/multiversx/mx-chain-vm-go/mock/context"
+	"github.com/multiversx/mx-chain-vm-go/mock/contracts"
+	test "github.com/multiversx/mx-chain-vm-go/testcommon"
+	"github.com/multiversx/mx-chain-vm-go/vmhost"
+	"github.com/multiversx/mx-chain-vm-go/vmhost/vmhooks"
+	"github.com/stretchr/testify/require"
+)
+
+const saltedDeployGasProvided = 1_000_000
+
+// saltedDeployMock deploys copies of a source contract with a salt and computes their addresses.
+func saltedDeployMock(instanceMock *contextmock.InstanceMock, _ interface{}) {
+	instanceMock.AddMockMethod("deployWithSalt", func() *contextmock.InstanceMock {
+		host := instanceMock.Host
+		instance := contextmock.GetMockInstance(host)
+		managedTypes := host.ManagedTypes()
+		hooks := vmhooks.NewVMHooksImpl(host)
+
+		arguments := host.Runtime().Arguments()
+		sourceHandle := managedTypes.NewManagedBufferFromBytes(arguments[0])
+		saltHandle := managedTypes.NewManagedBufferFromBytes(arguments[1])
+		times := int(big.NewInt(0).SetBytes(arguments[2]).Int64())
+
+		for i := 0; i < times; i++ {
+			resultAddressHandle := managedTypes.NewManagedBuffer()
+			result := hooks.ManagedDeployFromSourceContractWithSalt(
+				saltedDeployGasProvided/10,
+				managedTypes.NewBigIntFromInt64(0),
+				sourceHandle,
+				managedTypes.NewManagedBufferFromBytes([]byte{0, 0}),
+				managedTypes.NewManagedBuffer(),
+				saltHandle,
+				resultAddressHandle,
+				managedTypes.NewManagedBuffer(),
+			)
+			if result != 0 {
+				return instance
+			}
+
+			newAddress, _ := managedTypes.GetBytes(resultAddressHandle)
+			host.Output().Finish(newAddress)
+		}
+		return instance
+	})
+	instanceMock.AddMockMethod("computeAddress", func() *contextmock.InstanceMock {
+		host := instanceMock.Host
+		instance := contextmock.GetMockInstance(host)
+		managedTypes := host.ManagedTypes()
+
+		arguments := host.Runtime().Arguments()
+		resultHandle := managedTypes.NewManagedBuffer()
+		result := vmhooks.NewVMHooksImpl(host).ManagedComputeContractAddress(
+			managedTypes.NewManagedBufferFromBytes(arguments[0]),
+			managedTypes.NewManagedBufferFromBytes(arguments[1]),
+			managedTypes.NewManagedBufferFromBytes(arguments[2]),
+			resultHandle,
+		)
+		if result != 0 {
+			return instance
+		}
+
+		address, _ := managedTypes.GetBytes(resultHandle)
+		host.Output().Finish(address)
+		return instance
+	})
+}
+
+func saltedDeployContracts() []test.MockTestSmartContract {
+	testConfig := makeTestConfig()
+	return []test.MockTestSmartContract{
+		test.CreateMockContract(sc1Address).
+			WithConfig(testConfig).
+			WithMethods(contracts.InitMockMethod),
+		test.CreateMockContract(test.ParentAddress).
+			WithBalance(testConfig.ParentBalance).
+			WithConfig(testConfig).
+			WithMethods(saltedDeployMock),
+	}
+}
+
+func saltedDeployInput(function string, arguments ...[]byte) *vmcommon.ContractCallInput {
+	return test.CreateTestContractCallInputBuilder().
+		WithCallerAddr(test.UserAddress).
+		WithRecipientAddr(test.ParentAddress).
+		WithGasProvided(saltedDeployGasProvided).
+		WithFunction(function).
+		WithArguments(arguments...).
+		Build()
+}
+
+func runSaltedDeployTest(t *testing.T, input *vmcommon.ContractCallInput, assertResults test.AssertResultsFunc) {
+	_, err := test.BuildMockInstanceCallTest(t).
+		WithContracts(saltedDeployContracts()...).
+		WithInput(input).
+		AndAssertResults(assertResults)
+	require.Nil(t, err)
+}
+
+func sourceCodeHash() []byte {
+	return blake2b.NewBlake2b().Compute(string(sc1Address))
+}
+
+func expectedSaltedAddress(t *testing.T, salt []byte) []byte {
+	address, err := vmhost.ComputeContractAddress(blake2b.NewBlake2b(), test.ParentAddress, salt, sourceCodeHash(), test.DefaultVMType)
+	require.Nil(t, err)
+	return address
+}
+
+func saltedDeployFailed(expectedErr error) test.AssertResultsFunc {
+	return func(_ *worldmock.MockWorld, verify *test.VMOutputVerifier) {
+		require.NotEqual(verify.T, vmcommon.Ok, verify.VmOutput.ReturnCode)
+		verify.ReturnMessageContains(expectedErr.Error())
+	}
+}
+
+func TestSaltedDeploy_PredictableAddress(t *testing.T) {
+	salt := []byte("salt")
+	expectedAddress := expectedSaltedAddress(t, salt)
+
+	runSaltedDeployTest(t, saltedDeployInput("computeAddress", test.ParentAddress, salt, sourceCodeHash()),
+		func(_ *worldmock.MockWorld, verify *test.VMOutputVerifier) {
+			verify.Ok().
+				ReturnData(expectedAddress)
+		})
+
+	runSaltedDeployTest(t, saltedDeployInput("deployWithSalt", sc1Address, salt, []byte{1}),
+		func(_ *worldmock.MockWorld, verify *test.VMOutputVerifier) {
+			verify.Ok().
+				ReturnData(expectedAddress).
+				Code(expectedAddress, sc1Address)
+		})
+
+	// an empty salt still derives the address from the salt, not from the nonce of the creator
+	runSaltedDeployTest(t, saltedDeployInput("deployWithSalt", sc1Address, []byte{}, []byte{1}),
+		func(_ *worldmock.MockWorld, verify *test.VMOutputVerifier) {
+			verify.Ok().
+				ReturnData(expectedSaltedAddress(t, []byte{}))
+		})
+}
+
+func TestSaltedDeploy_Collision(t *testing.T) {
+	salt := []byte("salt")
+
+	runSaltedDeployTest(t, saltedDeployInput("deployWithSalt", sc1Address, salt, []byte{2}),
+		func(_ *worldmock.MockWorld, verify *test.VMOutputVerifier) {
+			verify.ExecutionFailed().
+				ReturnMessageContains(vmhost.ErrDeploymentOverExistingAccount.Error())
+		})
+
+	sequence := newMockCallSequence(t, saltedDeployContracts()...)
+	sequence.call(saltedDeployInput("deployWithSalt", sc1Address, salt, []byte{1}),
+		func(_ *worldmock.MockWorld, verify *test.VMOutputVerifier) {
+			verify.Ok()
+		})
+	sequence.call(saltedDeployInput("deployWithSalt", sc1Address, salt, []byte{1}),
+		func(_ *worldmock.MockWorld, verify *test.VMOutputVerifier) {
+			verify.ExecutionFailed().
+				ReturnMessageContains(vmhost.ErrDeploymentOverExistingAccount.Error())
+		})
+	sequence.call(saltedDeployInput("deployWithSalt", sc1Address, []byte("other salt"), []byte{1}),
+		func(_ *worldmock.MockWorld, verify *test.VMOutputVerifier) {
+			verify.Ok()
+		})
+}
+
+func TestSaltedDeploy_InvalidSalt(t *testing.T) {
+	longSalt := make([]byte, vmhost.MaxContractAddressSaltLen+1)
+
+	runSaltedDeployTest(t, saltedDeployInput("deployWithSalt", sc1Address, longSalt, []byte{1}),
+		saltedDeployFailed(vmhost.ErrInvalidContractAddressSalt))
+	runSaltedDeployTest(t, saltedDeployInput("computeAddress", test.ParentAddress, longSalt, sourceCodeHash()),
+		saltedDeployFailed(vmhost.ErrInvalidContractAddressSalt))
+}
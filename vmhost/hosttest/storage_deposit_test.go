@@ -0,0 +1,160 @@
+package hostCoretest
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-scenario-go/worldmock"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	contextmock "github.com/multiversx/mx-chain-vm-go/mock/context"
+	test "github.com/multiversx/mx-chain-vm-go/testcommon"
+	"github.com/multiversx/mx-chain-vm-go/vmhost"
+	"github.com/stretchr/testify/require"
+)
+
+const storageDepositGasProvided = 1_000_000
+
+var storageDepositPerByte = big.NewInt(10)
+
+// storageDepositMock exposes the storage writes of the contract as endpoints: "store" writes its second
+// argument under its first, "storeProtected" writes it under a VM protected key, and "storeAndTransfer"
+// also sends the value given as third argument to the user.
+func storageDepositMock(instanceMock *contextmock.InstanceMock, _ interface{}) {
+	instanceMock.AddMockMethod("store", func() *contextmock.InstanceMock {
+		host := instanceMock.Host
+		instance := contextmock.GetMockInstance(host)
+		arguments := host.Runtime().Arguments()
+		_, err := host.Storage().SetStorage(arguments[0], arguments[1])
+		if err != nil {
+			host.Runtime().SignalUserError(err.Error())
+		}
+		return instance
+	})
+
+	instanceMock.AddMockMethod("storeProtected", func() *contextmock.InstanceMock {
+		host := instanceMock.Host
+		instance := contextmock.GetMockInstance(host)
+		arguments := host.Runtime().Arguments()
+		key := host.Storage().GetVmProtectedPrefix(string(arguments[0]))
+		_, err := host.Storage().SetProtectedStorage(key, arguments[1])
+		if err != nil {
+			host.Runtime().SignalUserError(err.Error())
+		}
+		return instance
+	})
+
+	instanceMock.AddMockMethod("storeAndTransfer", func() *contextmock.InstanceMock {
+		host := instanceMock.Host
+		instance := contextmock.GetMockInstance(host)
+		arguments := host.Runtime().Arguments()
+		_, err := host.Storage().SetStorage(arguments[0], arguments[1])
+		if err != nil {
+			host.Runtime().SignalUserError(err.Error())
+			return instance
+		}
+
+		value := big.NewInt(0).SetBytes(arguments[2])
+		err = host.Output().TransferValueOnly(test.UserAddress, host.Runtime().GetContextAddress(), value, false)
+		if err != nil {
+			host.Runtime().SignalUserError(err.Error())
+		}
+		return instance
+	})
+}
+
+func storageDepositTest(t *testing.T, function string, arguments ...[]byte) *test.MockInstancesTestTemplate {
+	testConfig := makeTestConfig()
+	return test.BuildMockInstanceCallTest(t).
+		WithContracts(
+			test.CreateMockContract(test.ParentAddress).
+				WithBalance(testConfig.ParentBalance).
+				WithConfig(testConfig).
+				WithMethods(storageDepositMock)).
+		WithInput(test.CreateTestContractCallInputBuilder().
+			WithCallerAddr(test.UserAddress).
+			WithRecipientAddr(test.ParentAddress).
+			WithGasProvided(storageDepositGasProvided).
+			WithFunction(function).
+			WithArguments(arguments...).
+			Build()).
+		WithStorageDeposit(storageDepositPerByte)
+}
+
+// vmProtectedKey returns the key under which the VM keeps the given key in the storage of an account.
+func vmProtectedKey(key string) []byte {
+	return []byte(core.ProtectedKeyPrefix + vmhost.VMStoragePrefix + key)
+}
+
+func storageDepositOf(value []byte) int64 {
+	return storageDepositPerByte.Int64() * int64(len(value))
+}
+
+func requireBalanceDeltasSumToZero(t *testing.T, vmOutput *vmcommon.VMOutput) {
+	sum := big.NewInt(0)
+	for _, account := range vmOutput.OutputAccounts {
+		if account.BalanceDelta != nil {
+			sum.Add(sum, account.BalanceDelta)
+		}
+	}
+	require.Zero(t, sum.Sign(), "balance deltas sum up to %s", sum)
+}
+
+func TestStorageDeposit_ContractStorageLocksDeposit(t *testing.T) {
+	key := []byte("key")
+	value := []byte("value")
+	deposit := storageDepositOf(value)
+
+	_, err := storageDepositTest(t, "store", key, value).
+		AndAssertResults(func(world *worldmock.MockWorld, verify *test.VMOutputVerifier) {
+			// the deposit is moved out of the balance of the contract, to the system account
+			verify.Ok().
+				BalanceDelta(test.ParentAddress, -deposit).
+				BalanceDelta(core.SystemAccountAddress, deposit).
+				Storage(
+					test.CreateStoreEntry(test.ParentAddress).WithKey(key).WithValue(value),
+					test.CreateStoreEntry(test.ParentAddress).WithKey(vmProtectedKey(vmhost.StorageDepositKey)).WithValue(big.NewInt(deposit).Bytes()))
+			requireBalanceDeltasSumToZero(t, verify.VmOutput)
+		})
+	require.Nil(t, err)
+}
+
+func TestStorageDeposit_VMProtectedStorageIsExempt(t *testing.T) {
+	value := []byte("value")
+
+	_, err := storageDepositTest(t, "storeProtected", []byte("vmKey"), value).
+		AndAssertResults(func(world *worldmock.MockWorld, verify *test.VMOutputVerifier) {
+			verify.Ok().
+				Storage(test.CreateStoreEntry(test.ParentAddress).WithKey(vmProtectedKey("vmKey")).WithValue(value))
+			require.NotContains(t, verify.VmOutput.OutputAccounts, string(core.SystemAccountAddress))
+		})
+	require.Nil(t, err)
+}
+
+func TestStorageDeposit_LockedDepositCannotBeTransferred(t *testing.T) {
+	key := []byte("key")
+	value := make([]byte, 50)
+	balance := makeTestConfig().ParentBalance
+	deposit := storageDepositOf(value)
+
+	_, err := storageDepositTest(t, "storeAndTransfer", key, value, big.NewInt(balance).Bytes()).
+		AndAssertResults(func(world *worldmock.MockWorld, verify *test.VMOutputVerifier) {
+			verify.UserError().
+				ReturnMessage(vmhost.ErrTransferInsufficientFunds.Error())
+		})
+	require.Nil(t, err)
+
+	unlockedBalance := balance - deposit
+	_, err = storageDepositTest(t, "storeAndTransfer", key, value, big.NewInt(unlockedBalance).Bytes()).
+		AndAssertResults(func(world *worldmock.MockWorld, verify *test.VMOutputVerifier) {
+			verify.Ok().
+				BalanceDelta(test.ParentAddress, -balance).
+				BalanceDelta(test.UserAddress, unlockedBalance).
+				BalanceDelta(core.SystemAccountAddress, deposit).
+				Storage(
+					test.CreateStoreEntry(test.ParentAddress).WithKey(key).WithValue(value),
+					test.CreateStoreEntry(test.ParentAddress).WithKey(vmProtectedKey(vmhost.StorageDepositKey)).WithValue(big.NewInt(deposit).Bytes()))
+			requireBalanceDeltasSumToZero(t, verify.VmOutput)
+		})
+	require.Nil(t, err)
+}
@@ -1,14 +1,17 @@
 package hostCoretest
 
 import (
+	"math/big"
 	"testing"
 
 	"github.com/multiversx/mx-chain-scenario-go/worldmock"
 	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	contextmock "github.com/multiversx/mx-chain-vm-go/mock/context"
 	"github.com/multiversx/mx-chain-vm-go/mock/contracts"
 	test "github.com/multiversx/mx-chain-vm-go/testcommon"
 	"github.com/multiversx/mx-chain-vm-go/vmhost"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 var smallKey = []byte("testKey")
@@ -265,3 +268,66 @@ func TestBytesCount_SetStorage_ExecuteOnDestCtx(t *testing.T) {
 		})
 	assert.Nil(t, err)
 }
+
+func deleteStorageParentMock(instanceMock *contextmock.InstanceMock, config interface{}) {
+	testConfig := config.(*test.TestConfig)
+	instanceMock.AddMockMethod("deleteStorage", func() *contextmock.InstanceMock {
+		host := instanceMock.Host
+		instance := contextmock.GetMockInstance(host)
+
+		err := host.Metering().UseGasBounded(testConfig.GasUsedByParent)
+		if err != nil {
+			host.Runtime().SetRuntimeBreakpointValue(vmhost.BreakpointOutOfGas)
+			return instance
+		}
+
+		_, err = host.Storage().SetStorage(smallKey, nil)
+		if err != nil {
+			host.Runtime().FailExecution(err)
+		}
+		return instance
+	})
+}
+
+func TestGasRefund_DeleteStorage_RefundIsCapped(t *testing.T) {
+	testConfig := makeTestConfig()
+	value := make([]byte, 100)
+	releasePerByte := uint64(10)
+
+	vmOutput, err := test.BuildMockInstanceCallTest(t).
+		WithContracts(
+			test.CreateMockContract(test.ParentAddress).
+				WithBalance(0).
+				WithConfig(testConfig).
+				WithMethods(deleteStorageParentMock)).
+		WithInput(test.CreateTestContractCallInputBuilder().
+			WithRecipientAddr(test.ParentAddress).
+			WithGasProvided(testConfig.GasProvided).
+			WithFunction("deleteStorage").
+			Build()).
+		WithSetup(func(host vmhost.VMHost, world *worldmock.MockWorld) {
+			setZeroCodeCosts(host)
+			host.Metering().GasSchedule().BaseOperationCost.ReleasePerByte = releasePerByte
+
+			accountHandler, _ := world.GetUserAccount(test.ParentAddress)
+			(accountHandler.(*worldmock.Account)).Storage[string(smallKey)] = value
+		}).
+		AndAssertResults(func(world *worldmock.MockWorld, verify *test.VMOutputVerifier) {
+			verify.Ok()
+		})
+	require.Nil(t, err)
+
+	gasUsed := testConfig.GasProvided - vmOutput.GasRemaining
+	refundCap := gasUsed * vmhost.DefaultMaxGasRefundPercentage / 100
+	accumulated := releasePerByte * uint64(len(value))
+	require.Less(t, refundCap, accumulated)
+	require.Equal(t, big.NewInt(0).SetUint64(refundCap), vmOutput.GasRefund)
+
+	refundLog := vmOutput.Logs[len(vmOutput.Logs)-1]
+	require.Equal(t, []byte("gasRefund"), refundLog.Identifier)
+	require.Equal(t, [][]byte{
+		big.NewInt(0).SetUint64(accumulated).Bytes(),
+		big.NewInt(0).SetUint64(refundCap).Bytes(),
+		big.NewInt(0).SetUint64(refundCap).Bytes(),
+	}, refundLog.Topics)
+}
@@ -0,0 +1,91 @@
+package hostCoretest
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/multiversx/mx-chain-scenario-go/worldmock"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	contextmock "github.com/multiversx/mx-chain-vm-go/mock/context"
+	test "github.com/multiversx/mx-chain-vm-go/testcommon"
+	"github.com/multiversx/mx-chain-vm-go/vmhost/vmhooks"
+	"github.com/stretchr/testify/require"
+)
+
+const transientStorageGasProvided = 1_000_000
+
+// transientStorageMock exposes "storeAndLoad", which writes its second argument in the transient storage
+// under its first argument and reads it back.
+func transientStorageMock(instanceMock *contextmock.InstanceMock, _ interface{}) {
+	instanceMock.AddMockMethod("storeAndLoad", func() *contextmock.InstanceMock {
+		host := instanceMock.Host
+		instance := contextmock.GetMockInstance(host)
+		managedTypes := host.ManagedTypes()
+		hooks := vmhooks.NewVMHooksImpl(host)
+
+		arguments := host.Runtime().Arguments()
+		keyHandle := managedTypes.NewManagedBufferFromBytes(arguments[0])
+		valueHandle := managedTypes.NewManagedBufferFromBytes(arguments[1])
+		if hooks.TransientStore(keyHandle, valueHandle) != 0 {
+			return instance
+		}
+
+		resultHandle := managedTypes.NewManagedBuffer()
+		if hooks.TransientLoad(keyHandle, resultHandle) < 0 {
+			return instance
+		}
+		result, _ := managedTypes.GetBytes(resultHandle)
+		host.Output().Finish(result)
+		return instance
+	})
+}
+
+// runTransientStorageTest returns the gas used by "storeAndLoad" and the cost of copying a byte
+func runTransientStorageTest(t *testing.T, key []byte, value []byte) (uint64, uint64) {
+	testConfig := makeTestConfig()
+	world := worldmock.NewMockWorld()
+	world.AcctMap.CreateAccount(test.UserAddress, world)
+
+	executorFactory := contextmock.NewExecutorMockFactory(world)
+	host := test.NewTestHostBuilder(t).
+		WithExecutorFactory(executorFactory).
+		WithBlockchainHook(world).
+		Build()
+	t.Cleanup(host.Reset)
+
+	parent := test.CreateMockContract(test.ParentAddress).
+		WithBalance(testConfig.ParentBalance).
+		WithConfig(testConfig).
+		WithMethods(transientStorageMock)
+	parent.Initialize(t, host, executorFactory.LastCreatedExecutor, true)
+
+	input := test.CreateTestContractCallInputBuilder().
+		WithCallerAddr(test.UserAddress).
+		WithRecipientAddr(test.ParentAddress).
+		WithGasProvided(transientStorageGasProvided).
+		WithFunction("storeAndLoad").
+		WithArguments(key, value).
+		Build()
+
+	vmOutput, err := host.RunSmartContractCall(input)
+	require.Nil(t, err)
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode, vmOutput.ReturnMessage)
+	require.Equal(t, [][]byte{value}, vmOutput.ReturnData)
+
+	return transientStorageGasProvided - vmOutput.GasRemaining, host.Metering().GasSchedule().BaseOperationCost.DataCopyPerByte
+}
+
+func TestTransientStorage_GasCoversKeyAndValue(t *testing.T) {
+	shortKey, longKey := []byte("k"), bytes.Repeat([]byte("k"), 101)
+	shortValue, longValue := []byte("value"), bytes.Repeat([]byte("v"), 55)
+
+	baseGasUsed, dataCopyPerByte := runTransientStorageTest(t, shortKey, shortValue)
+	require.NotZero(t, dataCopyPerByte)
+
+	// both the store and the load copy the key and the value
+	gasUsed, _ := runTransientStorageTest(t, longKey, shortValue)
+	require.Equal(t, 2*dataCopyPerByte*uint64(len(longKey)-len(shortKey)), gasUsed-baseGasUsed)
+
+	gasUsed, _ = runTransientStorageTest(t, shortKey, longValue)
+	require.Equal(t, 2*dataCopyPerByte*uint64(len(longValue)-len(shortValue)), gasUsed-baseGasUsed)
+}
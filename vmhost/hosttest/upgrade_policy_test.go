@@ -0,0 +1,275 @@
+package hostCoretest
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/hashing/blake2b"
+	"github.com/multiversx/mx-chain-scenario-go/worldmock"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	"github.com/multiversx/mx-chain-vm-go/config"
+	contextmock "github.com/multiversx/mx-chain-vm-go/mock/context"
+	"github.com/multiversx/mx-chain-vm-go/mock/contracts"
+	test "github.com/multiversx/mx-chain-vm-go/testcommon"
+	"github.com/multiversx/mx-chain-vm-go/vmhost"
+	"github.com/multiversx/mx-chain-vm-go/vmhost/vmhooks"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	upgradeApprover1 = test.UserAddress2
+	upgradeApprover2 = test.ChildAddress
+)
+
+const upgradePolicyGasProvided = 1_000_000
+
+var restrictedUpgradeMetadata = []byte{vmcommon.MetadataUpgradeable | vmhost.MetadataUpgradeTimelocked | vmhost.MetadataUpgradeMultiApproval, 0}
+
+// upgradePolicyMock exposes the upgrade policy hooks as endpoints, finishing their result.
+func upgradePolicyMock(instanceMock *contextmock.InstanceMock, _ interface{}) {
+	methods := map[string]func(hooks *vmhooks.VMHooksImpl, host vmhost.VMHost, arguments [][]byte) int32{
+		"setPolicy": func(hooks *vmhooks.VMHooksImpl, host vmhost.VMHost, arguments [][]byte) int32 {
+			delayRounds := big.NewInt(0).SetBytes(arguments[0]).Int64()
+			threshold := int32(big.NewInt(0).SetBytes(arguments[1]).Int64())
+			approversHandle := host.ManagedTypes().NewManagedBufferFromBytes(arguments[2])
+			return hooks.ManagedSetUpgradePolicy(delayRounds, threshold, approversHandle)
+		},
+		"propose": func(hooks *vmhooks.VMHooksImpl, host vmhost.VMHost, arguments [][]byte) int32 {
+			codeHashHandle := host.ManagedTypes().NewManagedBufferFromBytes(arguments[0])
+			codeMetadataHandle := host.ManagedTypes().NewManagedBufferFromBytes(arguments[1])
+			return hooks.ManagedProposeUpgrade(codeHashHandle, codeMetadataHandle)
+		},
+		"approve": func(hooks *vmhooks.VMHooksImpl, _ vmhost.VMHost, _ [][]byte) int32 {
+			return hooks.ManagedApproveUpgrade()
+		},
+		"cancel": func(hooks *vmhooks.VMHooksImpl, _ vmhost.VMHost, _ [][]byte) int32 {
+			return hooks.ManagedCancelUpgrade()
+		},
+	}
+
+	for name, method := range methods {
+		method := method
+		instanceMock.AddMockMethod(name, func() *contextmock.InstanceMock {
+			host := instanceMock.Host
+			instance := contextmock.GetMockInstance(host)
+			result := method(vmhooks.NewVMHooksImpl(host), host, host.Runtime().Arguments())
+			host.Output().Finish(big.NewInt(int64(result)).Bytes())
+			return instance
+		})
+	}
+}
+
+func newUpgradePolicySequence(t *testing.T, codeMetadata []byte) *mockCallSequence {
+	testConfig := makeTestConfig()
+	sequence := newMockCallSequence(t,
+		test.CreateMockContract(test.ParentAddress).
+			WithBalance(testConfig.ParentBalance).
+			WithConfig(testConfig).
+			WithOwnerAddress(test.UserAddress).
+			WithCodeMetadata(codeMetadata).
+			WithMethods(upgradePolicyMock, contracts.UpgradeFunctionMock))
+	sequence.world.CurrentBlockInfo.BlockRound = 5
+	return sequence
+}
+
+func upgradePolicyInput(caller []byte, function string, arguments ...[]byte) *vmcommon.ContractCallInput {
+	return test.CreateTestContractCallInputBuilder().
+		WithCallerAddr(caller).
+		WithRecipientAddr(test.ParentAddress).
+		WithGasProvided(upgradePolicyGasProvided).
+		WithFunction(function).
+		WithArguments(arguments...).
+		Build()
+}
+
+func upgradeInput(codeMetadata []byte) *vmcommon.ContractCallInput {
+	return upgradePolicyInput(test.UserAddress, vmhost.UpgradeFunctionName, test.ParentAddress, codeMetadata)
+}
+
+func upgradePolicyOk(returnData ...[]byte) test.AssertResultsFunc {
+	return func(_ *worldmock.MockWorld, verify *test.VMOutputVerifier) {
+		verify.Ok()
+		if len(returnData) > 0 {
+			verify.ReturnData(returnData...)
+		}
+	}
+}
+
+func upgradePolicyFailed(expectedErr error) test.AssertResultsFunc {
+	return func(_ *worldmock.MockWorld, verify *test.VMOutputVerifier) {
+		require.NotEqual(verify.T, vmcommon.Ok, verify.VmOutput.ReturnCode)
+		verify.ReturnMessageContains(expectedErr.Error())
+	}
+}
+
+func pendingUpgrade(world *worldmock.MockWorld) []byte {
+	return world.AcctMap.GetAccount(test.ParentAddress).Storage[string(vmProtectedKey(vmhost.PendingUpgradeKey))]
+}
+
+func proposedCodeHash() []byte {
+	return blake2b.NewBlake2b().Compute(string(test.ParentAddress))
+}
+
+func approvers(addresses ...[]byte) []byte {
+	result := make([]byte, 0, len(addresses)*vmhost.AddressLen)
+	for _, address := range addresses {
+		result = append(result, address...)
+	}
+	return result
+}
+
+func TestUpgradePolicy_TimelockedAndApproved(t *testing.T) {
+	sequence := newUpgradePolicySequence(t, restrictedUpgradeMetadata)
+
+	sequence.call(upgradePolicyInput(test.UserAddress, "setPolicy", []byte{10}, []byte{2}, approvers(upgradeApprover1, upgradeApprover2)), upgradePolicyOk())
+	sequence.call(upgradeInput(restrictedUpgradeMetadata), upgradePolicyFailed(vmhost.ErrNoPendingUpgrade))
+
+	sequence.call(upgradePolicyInput(test.UserAddress, "propose", proposedCodeHash(), restrictedUpgradeMetadata), upgradePolicyOk())
+	require.NotEmpty(t, pendingUpgrade(sequence.world))
+
+	sequence.call(upgradePolicyInput(test.UserAddress, "approve"), upgradePolicyFailed(vmhost.ErrUpgradeApproverNotAllowed))
+	sequence.call(upgradePolicyInput(upgradeApprover1, "approve"), upgradePolicyOk([]byte{1}))
+	// approving twice does not count twice
+	sequence.call(upgradePolicyInput(upgradeApprover1, "approve"), upgradePolicyOk([]byte{1}))
+
+	sequence.call(upgradeInput(restrictedUpgradeMetadata), upgradePolicyFailed(vmhost.ErrUpgradeTimelocked))
+
+	sequence.world.CurrentBlockInfo.BlockRound = 15
+	sequence.call(upgradeInput(restrictedUpgradeMetadata), upgradePolicyFailed(vmhost.ErrUpgradeNotApproved))
+	sequence.call(upgradePolicyInput(upgradeApprover2, "approve"), upgradePolicyOk([]byte{2}))
+
+	// the code metadata is part of the proposal, so that the restrictions cannot be dropped by the upgrade
+	sequence.call(upgradeInput([]byte{vmcommon.MetadataUpgradeable, 0}), upgradePolicyFailed(vmhost.ErrUpgradeCodeNotProposed))
+
+	sequence.call(upgradeInput(restrictedUpgradeMetadata), func(_ *worldmock.MockWorld, verify *test.VMOutputVerifier) {
+		verify.Ok().
+			CodeMetadata(test.ParentAddress, restrictedUpgradeMetadata)
+	})
+	require.Empty(t, pendingUpgrade(sequence.world))
+}
+
+func TestUpgradePolicy_Cancel(t *testing.T) {
+	sequence := newUpgradePolicySequence(t, restrictedUpgradeMetadata)
+
+	sequence.call(upgradePolicyInput(test.UserAddress, "setPolicy", []byte{1}, []byte{1}, approvers(upgradeApprover1)), upgradePolicyOk())
+	sequence.call(upgradePolicyInput(test.UserAddress, "propose", proposedCodeHash(), restrictedUpgradeMetadata), upgradePolicyOk())
+
+	// the policy cannot change under a pending upgrade
+	sequence.call(upgradePolicyInput(test.UserAddress, "setPolicy", []byte{}, []byte{}, []byte{}), upgradePolicyFailed(vmhost.ErrPendingUpgradeExists))
+
+	sequence.call(upgradePolicyInput(test.UserAddress, "cancel"), upgradePolicyOk())
+	require.Empty(t, pendingUpgrade(sequence.world))
+
+	sequence.call(upgradePolicyInput(upgradeApprover1, "approve"), upgradePolicyFailed(vmhost.ErrNoPendingUpgrade))
+	sequence.call(upgradePolicyInput(test.UserAddress, "cancel"), upgradePolicyFailed(vmhost.ErrNoPendingUpgrade))
+	sequence.call(upgradeInput(restrictedUpgradeMetadata), upgradePolicyFailed(vmhost.ErrNoPendingUpgrade))
+}
+
+func TestUpgradePolicy_InvalidArguments(t *testing.T) {
+	sequence := newUpgradePolicySequence(t, restrictedUpgradeMetadata)
+
+	sequence.call(upgradePolicyInput(test.UserAddress, "setPolicy", []byte{}, []byte{2}, approvers(upgradeApprover1)), upgradePolicyFailed(vmhost.ErrInvalidUpgradePolicy))
+	sequence.call(upgradePolicyInput(test.UserAddress, "propose", []byte("short"), restrictedUpgradeMetadata), upgradePolicyFailed(vmhost.ErrInvalidArgument))
+}
+
+func TestUpgradePolicy_MultiApprovalWithoutPolicy(t *testing.T) {
+	multiApprovalMetadata := []byte{vmcommon.MetadataUpgradeable | vmhost.MetadataUpgradeMultiApproval, 0}
+	sequence := newUpgradePolicySequence(t, multiApprovalMetadata)
+
+	sequence.call(upgradePolicyInput(test.UserAddress, "propose", proposedCodeHash(), multiApprovalMetadata), upgradePolicyOk())
+	sequence.call(upgradeInput(multiApprovalMetadata), upgradePolicyFailed(vmhost.ErrUpgradeNotApproved))
+}
+
+func TestUpgradePolicy_TimelockedWithoutDelay(t *testing.T) {
+	timelockedMetadata := []byte{vmcommon.MetadataUpgradeable | vmhost.MetadataUpgradeTimelocked, 0}
+	sequence := newUpgradePolicySequence(t, timelockedMetadata)
+
+	sequence.call(upgradePolicyInput(test.UserAddress, "propose", proposedCodeHash(), timelockedMetadata), upgradePolicyFailed(vmhost.ErrUpgradeTimelockWithoutDelay))
+
+	sequence.call(upgradePolicyInput(test.UserAddress, "setPolicy", []byte{}, []byte{}, []byte{}), upgradePolicyOk())
+	sequence.call(upgradePolicyInput(test.UserAddress, "propose", proposedCodeHash(), timelockedMetadata), upgradePolicyFailed(vmhost.ErrUpgradeTimelockWithoutDelay))
+
+	// a proposal stored without a delay cannot be applied
+	pending := &vmhost.PendingUpgrade{
+		CodeHash:        proposedCodeHash(),
+		CodeMetadata:    timelockedMetadata,
+		ActivationRound: sequence.world.CurrentBlockInfo.BlockRound,
+	}
+	sequence.world.AcctMap.GetAccount(test.ParentAddress).Storage[string(vmProtectedKey(vmhost.PendingUpgradeKey))] = pending.Serialize()
+	sequence.call(upgradeInput(timelockedMetadata), upgradePolicyFailed(vmhost.ErrUpgradeTimelockWithoutDelay))
+}
+
+func TestUpgradePolicy_RestrictionSurvivesCodeMetadataRewrite(t *testing.T) {
+	sequence := newUpgradePolicySequence(t, restrictedUpgradeMetadata)
+
+	sequence.call(upgradePolicyInput(test.UserAddress, "setPolicy", []byte{1}, []byte{1}, approvers(upgradeApprover1)), upgradePolicyOk())
+	sequence.call(upgradePolicyInput(test.UserAddress, "propose", proposedCodeHash(), restrictedUpgradeMetadata), upgradePolicyOk())
+	sequence.call(upgradePolicyInput(upgradeApprover1, "approve"), upgradePolicyOk([]byte{1}))
+	sequence.world.CurrentBlockInfo.BlockRound++
+	sequence.call(upgradeInput(restrictedUpgradeMetadata), upgradePolicyOk())
+
+	// rewriting the code metadata through vmcommon.CodeMetadata, as guarding the account does, drops the upgrade bits
+	account := sequence.world.AcctMap.GetAccount(test.ParentAddress)
+	codeMetadata := vmcommon.CodeMetadataFromBytes(account.CodeMetadata)
+	account.CodeMetadata = codeMetadata.ToBytes()
+	require.False(t, vmhost.UpgradeConditionsFromCodeMetadata(account.CodeMetadata).IsRestricted())
+
+	sequence.call(upgradeInput([]byte{vmcommon.MetadataUpgradeable, 0}), upgradePolicyFailed(vmhost.ErrNoPendingUpgrade))
+}
+
+func TestUpgradePolicy_NotRestricted(t *testing.T) {
+	_, err := test.BuildMockInstanceCallTest(t).
+		WithContracts(
+			test.CreateMockContract(test.ParentAddress).
+				WithOwnerAddress(test.UserAddress).
+				WithCodeMetadata([]byte{vmcommon.MetadataUpgradeable, 0}).
+				WithMethods(contracts.UpgradeFunctionMock)).
+		WithInput(upgradeInput([]byte{vmcommon.MetadataUpgradeable, 0})).
+		AndAssertResults(upgradePolicyOk())
+	require.Nil(t, err)
+
+	_, err = test.BuildMockInstanceCallTest(t).
+		WithContracts(
+			test.CreateMockContract(test.ParentAddress).
+				WithOwnerAddress(test.UserAddress).
+				WithCodeMetadata(restrictedUpgradeMetadata).
+				WithMethods(contracts.UpgradeFunctionMock)).
+		WithInput(upgradeInput(restrictedUpgradeMetadata)).
+		WithSetup(func(host vmhost.VMHost, _ *worldmock.MockWorld) {
+			enableEpochsHandler, _ := host.EnableEpochsHandler().(*worldmock.EnableEpochsHandlerStub)
+			enableEpochsHandler.IsFlagEnabledCalled = func(flag core.EnableEpochFlag) bool {
+				return flag != vmhost.UpgradePolicyFlag
+			}
+		}).
+		AndAssertResults(upgradePolicyOk())
+	require.Nil(t, err)
+}
+
+func TestUpgradePolicy_PolicyReadsArePaid(t *testing.T) {
+	const storageLoadCost = uint64(1000)
+	runUpgrade := func(upgradePolicyEnabled bool) uint64 {
+		vmOutput, err := test.BuildMockInstanceCallTest(t).
+			WithContracts(
+				test.CreateMockContract(test.ParentAddress).
+					WithOwnerAddress(test.UserAddress).
+					WithCodeMetadata([]byte{vmcommon.MetadataUpgradeable, 0}).
+					WithMethods(contracts.UpgradeFunctionMock)).
+			WithInput(upgradeInput([]byte{vmcommon.MetadataUpgradeable, 0})).
+			WithSetup(func(host vmhost.VMHost, _ *worldmock.MockWorld) {
+				gasSchedule := host.Metering().GasSchedule()
+				gasSchedule.BaseOpsAPICost.StorageLoad = storageLoadCost
+				gasSchedule.DynamicStorageLoad = config.DynamicStorageLoadCostCoefficients{MinGasCost: 1}
+				enableEpochsHandler, _ := host.EnableEpochsHandler().(*worldmock.EnableEpochsHandlerStub)
+				enableEpochsHandler.IsFlagEnabledCalled = func(flag core.EnableEpochFlag) bool {
+					return upgradePolicyEnabled || flag != vmhost.UpgradePolicyFlag
+				}
+			}).
+			AndAssertResults(upgradePolicyOk())
+		require.Nil(t, err)
+		return vmOutput.GasRemaining
+	}
+
+	// the upgrade of a contract which is not restricted only reads its recorded upgrade conditions
+	require.Equal(t, runUpgrade(false)-storageLoadCost, runUpgrade(true))
+}
@@ -10,8 +10,10 @@ import (
 	"github.com/multiversx/mx-chain-core-go/data/vm"
 	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
 	"github.com/multiversx/mx-chain-vm-go/config"
+	"github.com/multiversx/mx-chain-vm-go/contractabi"
 	"github.com/multiversx/mx-chain-vm-go/crypto"
 	"github.com/multiversx/mx-chain-vm-go/executor"
+	"github.com/multiversx/mx-chain-vm-go/math"
 )
 
 // StateStack defines the functionality for working with a state stack
@@ -33,6 +35,8 @@ type CallArgsParser interface {
 type VMHost interface {
 	vmcommon.VMExecutionHandler
 	Crypto() crypto.VMCrypto
+	Hasher() HashComputer
+	IsQueryExecution() bool
 	Blockchain() BlockchainContext
 	Runtime() RuntimeContext
 	Async() AsyncContext
@@ -43,7 +47,7 @@ type VMHost interface {
 	EnableEpochsHandler() EnableEpochsHandler
 
 	ExecuteESDTTransfer(transfersArgs *ESDTTransfersArgs, callType vm.CallType) (*vmcommon.VMOutput, uint64, error)
-	CreateNewContract(input *vmcommon.ContractCreateInput, createContractCallType int) ([]byte, error)
+	CreateNewContract(input *vmcommon.ContractCreateInput, createContractCallType int, salt []byte) ([]byte, error)
 	ExecuteOnSameContext(input *vmcommon.ContractCallInput) error
 	ExecuteOnDestContext(input *vmcommon.ContractCallInput) (*vmcommon.VMOutput, bool, error)
 	IsBuiltinFunctionName(functionName string) bool
@@ -65,11 +69,29 @@ type VMHost interface {
 	GetGasTrace() map[string]map[string][]uint64
 }
 
+// GasEstimator defines the functionality for estimating the gas limit needed by a smart contract call
+type GasEstimator interface {
+	EstimateGas(input *vmcommon.ContractCallInput) (*GasEstimation, error)
+}
+
+// QueryExecutor defines the functionality for executing read-only calls on behalf of off-chain clients
+type QueryExecutor interface {
+	RunSmartContractQuery(input *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error)
+}
+
+// GasBreakdownProvider defines the functionality for executing calls and deployments which also return the gas
+// breakdown per account of their execution
+type GasBreakdownProvider interface {
+	RunSmartContractCallWithGasBreakdown(input *vmcommon.ContractCallInput) (*vmcommon.VMOutput, map[string]*GasBreakdown, error)
+	RunSmartContractCreateWithGasBreakdown(input *vmcommon.ContractCreateInput) (*vmcommon.VMOutput, map[string]*GasBreakdown, error)
+}
+
 // BlockchainContext defines the functionality needed for interacting with the blockchain context
 type BlockchainContext interface {
 	StateStack
 
 	NewAddress(creatorAddress []byte) ([]byte, error)
+	NewSaltedAddress(creatorAddress []byte, salt []byte, codeHash []byte) ([]byte, error)
 	AccountExists(addr []byte) bool
 	GetBalance(addr []byte) []byte
 	GetBalanceBigInt(addr []byte) *big.Int
@@ -122,6 +144,8 @@ type RuntimeContext interface {
 	SetCodeAddress(scAddress []byte)
 	GetSCCode() ([]byte, error)
 	GetSCCodeSize() uint64
+	GetContractABI() *contractabi.ABI
+	GetAccessControl() *contractabi.AccessControl
 	GetVMType() []byte
 	FunctionName() string
 	Arguments() [][]byte
@@ -141,18 +165,24 @@ type RuntimeContext interface {
 	StartWasmerInstance(contract []byte, gasLimit uint64, newCode bool) error
 	ClearWarmInstanceCache()
 	SetMaxInstanceStackSize(uint64)
-	VerifyContractCode() error
+	SetExecutionBudget(budget uint64)
+	UseInstructions(count uint64) error
+	IsExecutionBudgetExceeded() bool
+	VerifyContractCode(code []byte) error
+	SetCodeLimits(limits CodeLimits)
 	GetInstance() executor.Instance
 	GetInstanceTracker() InstanceTracker
 	FunctionNameChecked() (string, error)
 	CallSCFunction(functionName string) error
 	GetPointsUsed() uint64
 	SetPointsUsed(gasPoints uint64)
+	UseGasForMemoryGrowth() error
 	BaseOpsErrorShouldFailExecution() bool
 	SyncExecAPIErrorShouldFailExecution() bool
 	CryptoAPIErrorShouldFailExecution() bool
 	BigIntAPIErrorShouldFailExecution() bool
 	BigFloatAPIErrorShouldFailExecution() bool
+	DecimalAPIErrorShouldFailExecution() bool
 	ManagedBufferAPIErrorShouldFailExecution() bool
 	ManagedMapAPIErrorShouldFailExecution() bool
 	UseGasBoundedShouldFailExecution() bool
@@ -162,7 +192,7 @@ type RuntimeContext interface {
 	GetAllErrors() error
 
 	ValidateCallbackName(callbackName string) error
-	
+
 	IsReservedFunctionName(functionName string) bool
 
 	HasFunction(functionName string) bool
@@ -200,6 +230,11 @@ type ManagedTypesContext interface {
 	GetBigFloatOrCreate(handle int32) (*big.Float, error)
 	GetBigFloat(handle int32) (*big.Float, error)
 	GetTwoBigFloats(handle1 int32, handle2 int32) (*big.Float, *big.Float, error)
+	ConsumeGasForDecimalCopy(values ...*math.Decimal) error
+	PutDecimal(value *math.Decimal) int32
+	GetDecimalOrCreate(handle int32) *math.Decimal
+	GetDecimal(handle int32) (*math.Decimal, error)
+	GetTwoDecimals(handle1 int32, handle2 int32) (*math.Decimal, *math.Decimal, error)
 	PutEllipticCurve(ec *elliptic.CurveParams) int32
 	GetEllipticCurve(handle int32) (*elliptic.CurveParams, error)
 	GetEllipticCurveSizeOfField(ecHandle int32) int32
@@ -239,6 +274,7 @@ type OutputContext interface {
 	GetOutputAccount(address []byte) (*vmcommon.OutputAccount, bool)
 	GetOutputAccounts() map[string]*vmcommon.OutputAccount
 	DeleteOutputAccount(address []byte)
+	HasDeployedCode(address []byte) bool
 	WriteLog(address []byte, topics [][]byte, data [][]byte)
 	WriteLogWithIdentifier(address []byte, topics [][]byte, data [][]byte, identifier []byte)
 	TransferValueOnly(destination []byte, sender []byte, value *big.Int, checkPayable bool) error
@@ -277,6 +313,8 @@ type MeteringContext interface {
 	GasSchedule() *config.GasCost
 	UseGasBoundedAndAddTracedGas(functionName string, gas uint64) error
 	FreeGas(gas uint64)
+	GetGasRefund() uint64
+	ApplyGasRefundCap(vmOutput *vmcommon.VMOutput) *GasRefundBreakdown
 	RestoreGas(gas uint64)
 	GasLeft() uint64
 	GasUsedForExecution() uint64
@@ -289,9 +327,12 @@ type MeteringContext interface {
 	DeductInitialGasForExecution(contract []byte) error
 	DeductInitialGasForDirectDeployment(input CodeDeployInput) error
 	DeductInitialGasForIndirectDeployment(input CodeDeployInput) error
+	DeductInitialGasForStorageWrite(value []byte) error
 	ComputeExtraGasLockedForAsync() uint64
 	UseGasForAsyncStep() error
 	UseGasBounded(gasToUse uint64) error
+	UseGasBoundedInCategory(category string, gasToUse uint64) error
+	UseGasBoundedForChildExecution(gasToUse uint64) error
 	UseGasForContractInit(gasToUse uint64)
 	GetGasLocked() uint64
 	UpdateGasStateOnSuccess(vmOutput *vmcommon.VMOutput) error
@@ -302,6 +343,8 @@ type MeteringContext interface {
 	StartGasTracing(functionName string)
 	SetGasTracing(enableGasTracing bool)
 	GetGasTrace() map[string]map[string][]uint64
+	SetGasBreakdown(enableGasBreakdown bool)
+	GetGasBreakdown(vmOutput *vmcommon.VMOutput) map[string]*GasBreakdown
 }
 
 // StorageStatus defines the states the storage can be in
@@ -324,6 +367,7 @@ const (
 // StorageContext defines the functionality needed for interacting with the storage context
 type StorageContext interface {
 	StateStack
+	PopMergeActiveState()
 
 	SetAddress(address []byte)
 	GetStorageUpdates(address []byte) map[string]*vmcommon.StorageUpdate
@@ -337,6 +381,17 @@ type StorageContext interface {
 	SetProtectedStorageToAddressUnmetered(address []byte, key []byte, value []byte) (StorageStatus, error)
 	UseGasForStorageLoad(tracedFunctionName string, trieDepth int64, blockchainLoadCost uint64, usedCache bool) error
 	GetVmProtectedPrefix(prefix string) []byte
+	GetStorageDeposit(address []byte) (*big.Int, uint32, bool, error)
+	GetUpgradePolicy(address []byte) (*UpgradePolicy, uint32, bool, error)
+	GetUpgradeConditions(address []byte) (UpgradeConditions, uint32, bool, error)
+	GetPendingUpgrade(address []byte) (*PendingUpgrade, uint32, bool, error)
+	GetDeployInfo(address []byte) (*DeployInfo, uint32, bool, error)
+	GetContractFreeze(address []byte) (*ContractFreeze, uint32, bool, error)
+	GetRegisteredCode(codeHash []byte) ([]byte, uint32, error)
+	GetReferencedCodeHash(referenceCodeHash []byte) ([]byte, uint32, error)
+	GetTransientStorage(key []byte) []byte
+	SetTransientStorage(key []byte, value []byte) error
+	ClearTransientStorage()
 }
 
 // AsyncCallInfoHandler defines the functionality for working with AsyncCallInfo
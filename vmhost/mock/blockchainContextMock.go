@@ -40,6 +40,11 @@ func (b *BlockchainContextMock) NewAddress(creatorAddress []byte) ([]byte, error
 	return creatorAddress, nil
 }
 
+// NewSaltedAddress -
+func (b *BlockchainContextMock) NewSaltedAddress(creatorAddress []byte, _ []byte, _ []byte) ([]byte, error) {
+	return creatorAddress, nil
+}
+
 // AccountExists -
 func (b *BlockchainContextMock) AccountExists(_ []byte) bool {
 	return true
@@ -0,0 +1,202 @@
+package vmhost
+
+import (
+	"bytes"
+	"encoding/binary"
+)
+
+const (
+	// MetadataUpgradeTimelocked is the bit of the first code metadata byte requiring
+	// upgrades to be proposed and to wait for the delay of the upgrade policy
+	MetadataUpgradeTimelocked = 16
+
+	// MetadataUpgradeMultiApproval is the bit of the first code metadata byte requiring
+	// upgrades to be proposed and approved by the approvers of the upgrade policy
+	MetadataUpgradeMultiApproval = 32
+)
+
+// UpgradeConditions holds the conditions an upgrade must meet, as required by the code metadata of a contract.
+type UpgradeConditions struct {
+	Timelocked    bool
+	MultiApproval bool
+}
+
+// UpgradeConditionsFromCodeMetadata reads the upgrade conditions from the code metadata of a contract.
+func UpgradeConditionsFromCodeMetadata(codeMetadata []byte) UpgradeConditions {
+	if len(codeMetadata) == 0 {
+		return UpgradeConditions{}
+	}
+
+	return UpgradeConditions{
+		Timelocked:    codeMetadata[0]&MetadataUpgradeTimelocked != 0,
+		MultiApproval: codeMetadata[0]&MetadataUpgradeMultiApproval != 0,
+	}
+}
+
+// IsRestricted returns true if the upgrades must be proposed before being applied.
+func (conditions UpgradeConditions) IsRestricted() bool {
+	return conditions.Timelocked || conditions.MultiApproval
+}
+
+// Union returns the conditions required by either the receiver or the given conditions.
+func (conditions UpgradeConditions) Union(other UpgradeConditions) UpgradeConditions {
+	return UpgradeConditions{
+		Timelocked:    conditions.Timelocked || other.Timelocked,
+		MultiApproval: conditions.MultiApproval || other.MultiApproval,
+	}
+}
+
+// Serialize encodes the conditions to be stored under UpgradeConditionsKey, with the bits of the
+// first code metadata byte; no conditions are encoded as no data.
+func (conditions UpgradeConditions) Serialize() []byte {
+	var flags byte
+	if conditions.Timelocked {
+		flags |= MetadataUpgradeTimelocked
+	}
+	if conditions.MultiApproval {
+		flags |= MetadataUpgradeMultiApproval
+	}
+	if flags == 0 {
+		return nil
+	}
+	return []byte{flags}
+}
+
+// UpgradePolicy holds the delay and the approvers of the upgrades of a contract,
+// stored by the contract itself under UpgradePolicyKey.
+type UpgradePolicy struct {
+	DelayRounds uint64
+	Threshold   uint32
+	Approvers   [][]byte
+}
+
+// NewUpgradePolicy creates an upgrade policy from the concatenated addresses of the approvers.
+// The threshold cannot exceed the number of approvers, who must be distinct.
+func NewUpgradePolicy(delayRounds uint64, threshold uint32, approvers []byte) (*UpgradePolicy, error) {
+	approverAddresses, err := readAddresses(approvers)
+	if err != nil {
+		return nil, err
+	}
+	if uint64(threshold) > uint64(len(approverAddresses)) {
+		return nil, ErrInvalidUpgradePolicy
+	}
+	for i, approver := range approverAddresses {
+		if containsAddress(approverAddresses[:i], approver) {
+			return nil, ErrInvalidUpgradePolicy
+		}
+	}
+
+	return &UpgradePolicy{
+		DelayRounds: delayRounds,
+		Threshold:   threshold,
+		Approvers:   approverAddresses,
+	}, nil
+}
+
+// IsApprover returns true if the given address may approve upgrades.
+func (policy *UpgradePolicy) IsApprover(address []byte) bool {
+	return containsAddress(policy.Approvers, address)
+}
+
+// Serialize encodes the policy to be stored.
+func (policy *UpgradePolicy) Serialize() []byte {
+	data := binary.BigEndian.AppendUint64(nil, policy.DelayRounds)
+	data = binary.BigEndian.AppendUint32(data, policy.Threshold)
+	return appendAddresses(data, policy.Approvers)
+}
+
+// DeserializeUpgradePolicy decodes a stored policy; no data means no policy.
+func DeserializeUpgradePolicy(data []byte) (*UpgradePolicy, error) {
+	if len(data) == 0 {
+		return &UpgradePolicy{}, nil
+	}
+	if len(data) < 12 {
+		return nil, ErrInvalidUpgradePolicy
+	}
+
+	approvers, err := readAddresses(data[12:])
+	if err != nil {
+		return nil, ErrInvalidUpgradePolicy
+	}
+
+	return &UpgradePolicy{
+		DelayRounds: binary.BigEndian.Uint64(data[:8]),
+		Threshold:   binary.BigEndian.Uint32(data[8:12]),
+		Approvers:   approvers,
+	}, nil
+}
+
+// PendingUpgrade is an upgrade proposed by a contract, stored under PendingUpgradeKey
+// until it is applied or cancelled.
+type PendingUpgrade struct {
+	CodeHash        []byte
+	CodeMetadata    []byte
+	ActivationRound uint64
+	Approvals       [][]byte
+}
+
+// IsApprovedBy returns true if the given address already approved the upgrade.
+func (pending *PendingUpgrade) IsApprovedBy(address []byte) bool {
+	return containsAddress(pending.Approvals, address)
+}
+
+// Serialize encodes the pending upgrade to be stored.
+func (pending *PendingUpgrade) Serialize() []byte {
+	data := make([]byte, 0, HashLen+CodeMetadataLen+8+len(pending.Approvals)*AddressLen)
+	data = append(data, pending.CodeHash...)
+	data = append(data, pending.CodeMetadata...)
+	data = binary.BigEndian.AppendUint64(data, pending.ActivationRound)
+	return appendAddresses(data, pending.Approvals)
+}
+
+// DeserializePendingUpgrade decodes a stored pending upgrade; no data means no pending upgrade.
+func DeserializePendingUpgrade(data []byte) (*PendingUpgrade, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+
+	headerLength := HashLen + CodeMetadataLen + 8
+	if len(data) < headerLength {
+		return nil, ErrInvalidPendingUpgrade
+	}
+
+	approvals, err := readAddresses(data[headerLength:])
+	if err != nil {
+		return nil, ErrInvalidPendingUpgrade
+	}
+
+	return &PendingUpgrade{
+		CodeHash:        data[:HashLen],
+		CodeMetadata:    data[HashLen : HashLen+CodeMetadataLen],
+		ActivationRound: binary.BigEndian.Uint64(data[HashLen+CodeMetadataLen : headerLength]),
+		Approvals:       approvals,
+	}, nil
+}
+
+func appendAddresses(data []byte, addresses [][]byte) []byte {
+	for _, address := range addresses {
+		data = append(data, address...)
+	}
+	return data
+}
+
+func readAddresses(data []byte) ([][]byte, error) {
+	if len(data)%AddressLen != 0 {
+		return nil, ErrInvalidUpgradePolicy
+	}
+
+	addresses := make([][]byte, 0, len(data)/AddressLen)
+	for start := 0; start < len(data); start += AddressLen {
+		addresses = append(addresses, data[start:start+AddressLen])
+	}
+	return addresses, nil
+}
+
+func containsAddress(addresses [][]byte, address []byte) bool {
+	for _, existing := range addresses {
+		if bytes.Equal(existing, address) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,88 @@
+package vmhost
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testApprover1 = bytes.Repeat([]byte{0x01}, AddressLen)
+	testApprover2 = bytes.Repeat([]byte{0x02}, AddressLen)
+)
+
+func TestUpgradeConditionsFromCodeMetadata(t *testing.T) {
+	require.False(t, UpgradeConditionsFromCodeMetadata(nil).IsRestricted())
+	require.False(t, UpgradeConditionsFromCodeMetadata([]byte{1, 0}).IsRestricted())
+
+	conditions := UpgradeConditionsFromCodeMetadata([]byte{1 | MetadataUpgradeTimelocked, 0})
+	require.Equal(t, UpgradeConditions{Timelocked: true}, conditions)
+	require.True(t, conditions.IsRestricted())
+
+	conditions = UpgradeConditionsFromCodeMetadata([]byte{MetadataUpgradeTimelocked | MetadataUpgradeMultiApproval, 0})
+	require.Equal(t, UpgradeConditions{Timelocked: true, MultiApproval: true}, conditions)
+}
+
+func TestUpgradeConditions_SerializeAndUnion(t *testing.T) {
+	require.Nil(t, UpgradeConditions{}.Serialize())
+
+	conditions := UpgradeConditions{Timelocked: true, MultiApproval: true}
+	require.Equal(t, conditions, UpgradeConditionsFromCodeMetadata(conditions.Serialize()))
+
+	union := UpgradeConditions{Timelocked: true}.Union(UpgradeConditions{MultiApproval: true})
+	require.Equal(t, conditions, union)
+}
+
+func TestNewUpgradePolicy(t *testing.T) {
+	approvers := append(append([]byte{}, testApprover1...), testApprover2...)
+	policy, err := NewUpgradePolicy(100, 2, approvers)
+	require.Nil(t, err)
+	require.Equal(t, [][]byte{testApprover1, testApprover2}, policy.Approvers)
+	require.True(t, policy.IsApprover(testApprover2))
+	require.False(t, policy.IsApprover(bytes.Repeat([]byte{0x03}, AddressLen)))
+
+	_, err = NewUpgradePolicy(100, 3, approvers)
+	require.Equal(t, ErrInvalidUpgradePolicy, err)
+	_, err = NewUpgradePolicy(100, 1, approvers[:AddressLen+1])
+	require.Equal(t, ErrInvalidUpgradePolicy, err)
+	_, err = NewUpgradePolicy(100, 1, append(append([]byte{}, testApprover1...), testApprover1...))
+	require.Equal(t, ErrInvalidUpgradePolicy, err)
+}
+
+func TestUpgradePolicy_Serialize(t *testing.T) {
+	policy := &UpgradePolicy{DelayRounds: 1000, Threshold: 1, Approvers: [][]byte{testApprover1, testApprover2}}
+	deserialized, err := DeserializeUpgradePolicy(policy.Serialize())
+	require.Nil(t, err)
+	require.Equal(t, policy, deserialized)
+
+	deserialized, err = DeserializeUpgradePolicy(nil)
+	require.Nil(t, err)
+	require.Equal(t, &UpgradePolicy{}, deserialized)
+
+	_, err = DeserializeUpgradePolicy(policy.Serialize()[:20])
+	require.Equal(t, ErrInvalidUpgradePolicy, err)
+}
+
+func TestPendingUpgrade_Serialize(t *testing.T) {
+	pending := &PendingUpgrade{
+		CodeHash:        bytes.Repeat([]byte{0xab}, HashLen),
+		CodeMetadata:    []byte{1 | MetadataUpgradeMultiApproval, 0},
+		ActivationRound: 42,
+		Approvals:       [][]byte{testApprover2},
+	}
+	deserialized, err := DeserializePendingUpgrade(pending.Serialize())
+	require.Nil(t, err)
+	require.Equal(t, pending, deserialized)
+	require.True(t, deserialized.IsApprovedBy(testApprover2))
+	require.False(t, deserialized.IsApprovedBy(testApprover1))
+
+	deserialized, err = DeserializePendingUpgrade(nil)
+	require.Nil(t, err)
+	require.Nil(t, deserialized)
+
+	_, err = DeserializePendingUpgrade(pending.Serialize()[:HashLen])
+	require.Equal(t, ErrInvalidPendingUpgrade, err)
+	_, err = DeserializePendingUpgrade(pending.Serialize()[:HashLen+CodeMetadataLen+8+1])
+	require.Equal(t, ErrInvalidPendingUpgrade, err)
+}
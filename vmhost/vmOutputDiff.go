@@ -0,0 +1,385 @@
+package vmhost
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"sort"
+	"unicode"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-core-go/data/esdt"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+)
+
+// StorageKeyKind classifies a storage key touched by a VMOutput.
+type StorageKeyKind string
+
+const (
+	// UserStorageKey is a key written by the contract itself
+	UserStorageKey StorageKeyKind = "user"
+	// ProtectedStorageKey is a protocol key which is not owned by the VM
+	ProtectedStorageKey StorageKeyKind = "protected"
+	// ESDTStorageKey is a key holding the ESDT balance of an account
+	ESDTStorageKey StorageKeyKind = "esdt"
+	// AsyncContextStorageKey is a VM key holding a persisted AsyncContext
+	AsyncContextStorageKey StorageKeyKind = "vm-async"
+	// TimeLockStorageKey is a VM key holding a storage lock
+	TimeLockStorageKey StorageKeyKind = "vm-timelock"
+	// StorageDepositStorageKey is a VM key holding the deposit locked for storage
+	StorageDepositStorageKey StorageKeyKind = "vm-storage-deposit"
+	// UpgradePolicyStorageKey is a VM key holding the upgrade policy of a contract
+	UpgradePolicyStorageKey StorageKeyKind = "vm-upgrade-policy"
+	// PendingUpgradeStorageKey is a VM key holding the upgrade proposed by a contract
+	PendingUpgradeStorageKey StorageKeyKind = "vm-pending-upgrade"
+	// DeployInfoStorageKey is a VM key holding the deployer and the deploy block of a contract
+	DeployInfoStorageKey StorageKeyKind = "vm-deploy-info"
+	// FrozenContractStorageKey is a VM key holding the freeze of a contract
+	FrozenContractStorageKey StorageKeyKind = "vm-frozen-contract"
+	// CodeRegistryStorageKey is a VM key of the system account holding a registered code
+	CodeRegistryStorageKey StorageKeyKind = "vm-code-registry"
+	// CodeReferenceStorageKey is a VM key of the system account holding the code hash referenced by a contract code
+	CodeReferenceStorageKey StorageKeyKind = "vm-code-reference"
+	// VMInternalStorageKey is any other VM-internal key
+	VMInternalStorageKey StorageKeyKind = "vm-internal"
+)
+
+var vmStorageKeyKinds = []struct {
+	prefix string
+	kind   StorageKeyKind
+}{
+	{AsyncDataPrefix, AsyncContextStorageKey},
+	{TimeLockKeyPrefix, TimeLockStorageKey},
+	{StorageDepositKey, StorageDepositStorageKey},
+	{UpgradePolicyKey, UpgradePolicyStorageKey},
+	{PendingUpgradeKey, PendingUpgradeStorageKey},
+	{DeployInfoKey, DeployInfoStorageKey},
+	{FrozenContractKey, FrozenContractStorageKey},
+	{CodeRegistryKey, CodeRegistryStorageKey},
+	{CodeReferenceKey, CodeReferenceStorageKey},
+}
+
+const esdtTokenRandomSequenceLength = 6
+
+// VMOutputDiff is a human-readable representation of the changes a VMOutput applies to a world state.
+type VMOutputDiff struct {
+	ReturnCode    string         `json:"returnCode"`
+	ReturnMessage string         `json:"returnMessage,omitempty"`
+	GasRemaining  uint64         `json:"gasRemaining"`
+	Accounts      []*AccountDiff `json:"accounts"`
+}
+
+// AccountDiff holds the changes applied to a single account.
+type AccountDiff struct {
+	Address      string              `json:"address"`
+	BalanceOld   string              `json:"balanceOld"`
+	BalanceNew   string              `json:"balanceNew"`
+	BalanceDelta string              `json:"balanceDelta"`
+	CodeUpdated  bool                `json:"codeUpdated,omitempty"`
+	Storage      []*StorageEntryDiff `json:"storage,omitempty"`
+	ESDT         []*ESDTDiff         `json:"esdt,omitempty"`
+}
+
+// StorageEntryDiff holds the old and new value of a storage key.
+type StorageEntryDiff struct {
+	Key        string         `json:"key"`
+	Kind       StorageKeyKind `json:"kind"`
+	DecodedKey string         `json:"decodedKey"`
+	Old        string         `json:"old"`
+	New        string         `json:"new"`
+}
+
+// ESDTDiff holds the balance change of an ESDT token, decoded from the storage updates.
+type ESDTDiff struct {
+	TokenIdentifier string `json:"tokenIdentifier"`
+	Nonce           uint64 `json:"nonce,omitempty"`
+	Old             string `json:"old"`
+	New             string `json:"new"`
+	Delta           string `json:"delta"`
+}
+
+// VMOutputDiffer computes the diff of a VMOutput against the world state exposed by a BlockchainHook.
+type VMOutputDiffer struct {
+	worldState           vmcommon.BlockchainHook
+	protectedKeyPrefix   []byte
+	vmProtectedKeyPrefix []byte
+	esdtKeyPrefix        []byte
+}
+
+// NewVMOutputDiffer creates a new VMOutputDiffer
+func NewVMOutputDiffer(worldState vmcommon.BlockchainHook, protectedKeyPrefix []byte) (*VMOutputDiffer, error) {
+	if check.IfNil(worldState) {
+		return nil, ErrNilBlockChainHook
+	}
+	if len(protectedKeyPrefix) == 0 {
+		return nil, ErrEmptyProtectedKeyPrefix
+	}
+
+	return &VMOutputDiffer{
+		worldState:           worldState,
+		protectedKeyPrefix:   protectedKeyPrefix,
+		vmProtectedKeyPrefix: append(append([]byte{}, protectedKeyPrefix...), []byte(VMStoragePrefix)...),
+		esdtKeyPrefix:        append(append([]byte{}, protectedKeyPrefix...), []byte(core.ESDTKeyIdentifier)...),
+	}, nil
+}
+
+// Diff computes the changes the given VMOutput applies to the world state.
+func (differ *VMOutputDiffer) Diff(vmOutput *vmcommon.VMOutput) (*VMOutputDiff, error) {
+	if vmOutput == nil {
+		return nil, ErrNilVMOutput
+	}
+
+	diff := &VMOutputDiff{
+		ReturnCode:    vmOutput.ReturnCode.String(),
+		ReturnMessage: vmOutput.ReturnMessage,
+		GasRemaining:  vmOutput.GasRemaining,
+		Accounts:      make([]*AccountDiff, 0, len(vmOutput.OutputAccounts)),
+	}
+
+	for _, outputAccount := range SortVMOutputAccounts(vmOutput) {
+		accountDiff, err := differ.diffAccount(outputAccount)
+		if err != nil {
+			return nil, err
+		}
+		diff.Accounts = append(diff.Accounts, accountDiff)
+	}
+
+	return diff, nil
+}
+
+// SortVMOutputAccounts returns the output accounts of a VMOutput, ordered by address.
+func SortVMOutputAccounts(vmOutput *vmcommon.VMOutput) []*vmcommon.OutputAccount {
+	outputAccounts := make([]*vmcommon.OutputAccount, 0, len(vmOutput.OutputAccounts))
+	for _, outputAccount := range vmOutput.OutputAccounts {
+		outputAccounts = append(outputAccounts, outputAccount)
+	}
+	sort.Slice(outputAccounts, func(i, j int) bool {
+		return bytes.Compare(outputAccounts[i].Address, outputAccounts[j].Address) < 0
+	})
+
+	return outputAccounts
+}
+
+func (differ *VMOutputDiffer) diffAccount(outputAccount *vmcommon.OutputAccount) (*AccountDiff, error) {
+	oldBalance := big.NewInt(0)
+	account, err := differ.worldState.GetUserAccount(outputAccount.Address)
+	if err == nil && !check.IfNil(account) && account.GetBalance() != nil {
+		oldBalance.Set(account.GetBalance())
+	}
+
+	balanceDelta := big.NewInt(0)
+	if outputAccount.BalanceDelta != nil {
+		balanceDelta.Set(outputAccount.BalanceDelta)
+	}
+
+	accountDiff := &AccountDiff{
+		Address:      hex.EncodeToString(outputAccount.Address),
+		BalanceOld:   oldBalance.String(),
+		BalanceNew:   big.NewInt(0).Add(oldBalance, balanceDelta).String(),
+		BalanceDelta: balanceDelta.String(),
+		CodeUpdated:  len(outputAccount.Code) > 0,
+		Storage:      make([]*StorageEntryDiff, 0, len(outputAccount.StorageUpdates)),
+		ESDT:         make([]*ESDTDiff, 0),
+	}
+
+	keys := make([]string, 0, len(outputAccount.StorageUpdates))
+	for key := range outputAccount.StorageUpdates {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		storageUpdate := outputAccount.StorageUpdates[key]
+		if !storageUpdate.Written {
+			continue
+		}
+
+		oldValue, _, err := differ.worldState.GetStorageData(outputAccount.Address, storageUpdate.Offset)
+		if err != nil {
+			return nil, err
+		}
+
+		kind, decodedKey := differ.ClassifyStorageKey(storageUpdate.Offset)
+		accountDiff.Storage = append(accountDiff.Storage, &StorageEntryDiff{
+			Key:        hex.EncodeToString(storageUpdate.Offset),
+			Kind:       kind,
+			DecodedKey: decodedKey,
+			Old:        hex.EncodeToString(oldValue),
+			New:        hex.EncodeToString(storageUpdate.Data),
+		})
+
+		if kind != ESDTStorageKey {
+			continue
+		}
+		esdtDiff, err := differ.diffESDT(storageUpdate.Offset, oldValue, storageUpdate.Data)
+		if err != nil {
+			return nil, err
+		}
+		accountDiff.ESDT = append(accountDiff.ESDT, esdtDiff)
+	}
+
+	return accountDiff, nil
+}
+
+// ClassifyStorageKey returns the kind of the given storage key, together with a readable form of it.
+func (differ *VMOutputDiffer) ClassifyStorageKey(key []byte) (StorageKeyKind, string) {
+	if bytes.HasPrefix(key, differ.vmProtectedKeyPrefix) {
+		vmKey := key[len(differ.vmProtectedKeyPrefix):]
+		for _, vmKeyKind := range vmStorageKeyKinds {
+			if bytes.HasPrefix(vmKey, []byte(vmKeyKind.prefix)) {
+				return vmKeyKind.kind, VMStoragePrefix + vmKeyKind.prefix + readableKey(vmKey[len(vmKeyKind.prefix):])
+			}
+		}
+		return VMInternalStorageKey, VMStoragePrefix + readableKey(vmKey)
+	}
+
+	if bytes.HasPrefix(key, differ.esdtKeyPrefix) {
+		tokenIdentifier, nonce := splitESDTTokenKey(key[len(differ.esdtKeyPrefix):])
+		if nonce > 0 {
+			return ESDTStorageKey, fmt.Sprintf("%s%s-%d", core.ESDTKeyIdentifier, tokenIdentifier, nonce)
+		}
+		return ESDTStorageKey, core.ESDTKeyIdentifier + string(tokenIdentifier)
+	}
+
+	if bytes.HasPrefix(key, differ.protectedKeyPrefix) {
+		return ProtectedStorageKey, readableKey(key[len(differ.protectedKeyPrefix):])
+	}
+
+	return UserStorageKey, readableKey(key)
+}
+
+func (differ *VMOutputDiffer) diffESDT(key []byte, oldValue []byte, newValue []byte) (*ESDTDiff, error) {
+	tokenIdentifier, nonce := splitESDTTokenKey(key[len(differ.esdtKeyPrefix):])
+
+	oldBalance, err := esdtBalanceFromStorageValue(oldValue)
+	if err != nil {
+		return nil, err
+	}
+	newBalance, err := esdtBalanceFromStorageValue(newValue)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ESDTDiff{
+		TokenIdentifier: string(tokenIdentifier),
+		Nonce:           nonce,
+		Old:             oldBalance.String(),
+		New:             newBalance.String(),
+		Delta:           big.NewInt(0).Sub(newBalance, oldBalance).String(),
+	}, nil
+}
+
+func esdtBalanceFromStorageValue(value []byte) (*big.Int, error) {
+	if len(value) == 0 {
+		return big.NewInt(0), nil
+	}
+
+	token := &esdt.ESDigitalToken{}
+	err := token.Unmarshal(value)
+	if err != nil {
+		return nil, err
+	}
+	if token.Value == nil {
+		return big.NewInt(0), nil
+	}
+
+	return token.Value, nil
+}
+
+// splitESDTTokenKey separates the token identifier (TICKER-abcdef) from the NFT nonce that may follow it.
+func splitESDTTokenKey(tokenKey []byte) ([]byte, uint64) {
+	separatorIndex := bytes.IndexByte(tokenKey, '-')
+	if separatorIndex < 0 {
+		return tokenKey, 0
+	}
+
+	identifierLength := separatorIndex + 1 + esdtTokenRandomSequenceLength
+	if identifierLength >= len(tokenKey) {
+		return tokenKey, 0
+	}
+
+	return tokenKey[:identifierLength], big.NewInt(0).SetBytes(tokenKey[identifierLength:]).Uint64()
+}
+
+// readableKey returns the key as text when it is printable, or hex-encoded otherwise.
+func readableKey(key []byte) string {
+	for _, r := range string(key) {
+		if r == unicode.ReplacementChar || !unicode.IsPrint(r) {
+			return "0x" + hex.EncodeToString(key)
+		}
+	}
+
+	return string(key)
+}
+
+// ToJSON serializes the diff as indented JSON.
+func (diff *VMOutputDiff) ToJSON() ([]byte, error) {
+	return json.MarshalIndent(diff, "", "  ")
+}
+
+const (
+	ansiReset  = "\033[0m"
+	ansiRed    = "\033[31m"
+	ansiGreen  = "\033[32m"
+	ansiYellow = "\033[33m"
+	ansiCyan   = "\033[36m"
+)
+
+// WriteText renders the diff as text, optionally using ANSI terminal colors.
+func (diff *VMOutputDiff) WriteText(writer io.Writer, colored bool) error {
+	paint := func(color string, text string) string {
+		if !colored {
+			return text
+		}
+		return color + text + ansiReset
+	}
+
+	var buffer bytes.Buffer
+	fmt.Fprintf(&buffer, "return code: %s\n", diff.ReturnCode)
+	if len(diff.ReturnMessage) > 0 {
+		fmt.Fprintf(&buffer, "return message: %s\n", diff.ReturnMessage)
+	}
+	fmt.Fprintf(&buffer, "gas remaining: %d\n", diff.GasRemaining)
+
+	for _, account := range diff.Accounts {
+		fmt.Fprintf(&buffer, "\n%s\n", paint(ansiCyan, "account "+account.Address))
+		if account.BalanceDelta != "0" {
+			fmt.Fprintf(&buffer, "  balance: %s -> %s (%s)\n",
+				paint(ansiRed, account.BalanceOld),
+				paint(ansiGreen, account.BalanceNew),
+				account.BalanceDelta)
+		}
+		if account.CodeUpdated {
+			fmt.Fprintf(&buffer, "  %s\n", paint(ansiYellow, "code updated"))
+		}
+		for _, esdtDiff := range account.ESDT {
+			fmt.Fprintf(&buffer, "  esdt %s", esdtDiff.TokenIdentifier)
+			if esdtDiff.Nonce > 0 {
+				fmt.Fprintf(&buffer, " nonce %d", esdtDiff.Nonce)
+			}
+			fmt.Fprintf(&buffer, ": %s -> %s (%s)\n",
+				paint(ansiRed, esdtDiff.Old),
+				paint(ansiGreen, esdtDiff.New),
+				esdtDiff.Delta)
+		}
+		for _, entry := range account.Storage {
+			fmt.Fprintf(&buffer, "  [%s] %s\n", paint(ansiYellow, string(entry.Kind)), entry.DecodedKey)
+			fmt.Fprintf(&buffer, "    %s\n", paint(ansiRed, "- "+displayValue(entry.Old)))
+			fmt.Fprintf(&buffer, "    %s\n", paint(ansiGreen, "+ "+displayValue(entry.New)))
+		}
+	}
+
+	_, err := writer.Write(buffer.Bytes())
+	return err
+}
+
+func displayValue(hexValue string) string {
+	if len(hexValue) == 0 {
+		return "(empty)"
+	}
+	return "0x" + hexValue
+}
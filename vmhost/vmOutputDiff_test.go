@@ -0,0 +1,128 @@
+package vmhost
+
+import (
+	"bytes"
+	"encoding/hex"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/data/esdt"
+	worldmock "github.com/multiversx/mx-chain-scenario-go/worldmock"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVMOutputDiffer_NilArguments(t *testing.T) {
+	differ, err := NewVMOutputDiffer(nil, []byte(core.ProtectedKeyPrefix))
+	require.Nil(t, differ)
+	require.Equal(t, ErrNilBlockChainHook, err)
+
+	differ, err = NewVMOutputDiffer(worldmock.NewMockWorld(), nil)
+	require.Nil(t, differ)
+	require.Equal(t, ErrEmptyProtectedKeyPrefix, err)
+
+	differ, err = NewVMOutputDiffer(worldmock.NewMockWorld(), []byte(core.ProtectedKeyPrefix))
+	require.Nil(t, err)
+	diff, err := differ.Diff(nil)
+	require.Nil(t, diff)
+	require.Equal(t, ErrNilVMOutput, err)
+}
+
+func TestVMOutputDiffer_ClassifyStorageKey(t *testing.T) {
+	differ, err := NewVMOutputDiffer(worldmock.NewMockWorld(), []byte(core.ProtectedKeyPrefix))
+	require.Nil(t, err)
+
+	vmPrefix := core.ProtectedKeyPrefix + VMStoragePrefix
+	testCases := []struct {
+		key        string
+		kind       StorageKeyKind
+		decodedKey string
+	}{
+		{"counter", UserStorageKey, "counter"},
+		{"\x00\x01", UserStorageKey, "0x0001"},
+		{vmPrefix + AsyncDataPrefix + "\xab", AsyncContextStorageKey, "VM@ASYNC0xab"},
+		{vmPrefix + TimeLockKeyPrefix + "key", TimeLockStorageKey, "VM@TIMELOCKkey"},
+		{vmPrefix + StorageDepositKey, StorageDepositStorageKey, "VM@STORAGEDEPOSIT"},
+		{vmPrefix + "OTHER", VMInternalStorageKey, "VM@OTHER"},
+		{core.ProtectedKeyPrefix + core.ESDTKeyIdentifier + "TOKEN-abcdef", ESDTStorageKey, "esdtTOKEN-abcdef"},
+		{core.ProtectedKeyPrefix + core.ESDTKeyIdentifier + "NFT-abcdef\x05", ESDTStorageKey, "esdtNFT-abcdef-5"},
+		{core.ProtectedKeyPrefix + "something", ProtectedStorageKey, "something"},
+	}
+
+	for _, testCase := range testCases {
+		kind, decodedKey := differ.ClassifyStorageKey([]byte(testCase.key))
+		require.Equal(t, testCase.kind, kind, testCase.key)
+		require.Equal(t, testCase.decodedKey, decodedKey, testCase.key)
+	}
+}
+
+func TestVMOutputDiffer_Diff(t *testing.T) {
+	address := bytes.Repeat([]byte{1}, 32)
+	esdtKey := []byte(core.ProtectedKeyPrefix + core.ESDTKeyIdentifier + "TOKEN-abcdef")
+	oldToken, err := (&esdt.ESDigitalToken{Value: big.NewInt(100)}).Marshal()
+	require.Nil(t, err)
+	newToken, err := (&esdt.ESDigitalToken{Value: big.NewInt(70)}).Marshal()
+	require.Nil(t, err)
+
+	world := worldmock.NewMockWorld()
+	world.AcctMap.PutAccount(&worldmock.Account{
+		Address: address,
+		Balance: big.NewInt(1000),
+		Storage: map[string][]byte{
+			"counter":       {1},
+			"owner":         {3},
+			string(esdtKey): oldToken,
+		},
+	})
+
+	differ, err := NewVMOutputDiffer(world, []byte(core.ProtectedKeyPrefix))
+	require.Nil(t, err)
+
+	vmOutput := &vmcommon.VMOutput{
+		ReturnCode:   vmcommon.Ok,
+		GasRemaining: 42,
+		OutputAccounts: map[string]*vmcommon.OutputAccount{
+			string(address): {
+				Address:      address,
+				BalanceDelta: big.NewInt(-10),
+				StorageUpdates: map[string]*vmcommon.StorageUpdate{
+					"counter":       {Offset: []byte("counter"), Data: []byte{2}, Written: true},
+					"owner":         {Offset: []byte("owner"), Data: []byte{3}, Written: false},
+					string(esdtKey): {Offset: esdtKey, Data: newToken, Written: true},
+				},
+			},
+		},
+	}
+
+	diff, err := differ.Diff(vmOutput)
+	require.Nil(t, err)
+	require.Equal(t, "ok", diff.ReturnCode)
+	require.Equal(t, uint64(42), diff.GasRemaining)
+	require.Len(t, diff.Accounts, 1)
+
+	account := diff.Accounts[0]
+	require.Equal(t, "1000", account.BalanceOld)
+	require.Equal(t, "990", account.BalanceNew)
+	require.Equal(t, "-10", account.BalanceDelta)
+	require.Len(t, account.Storage, 2)
+	require.Equal(t, UserStorageKey, account.Storage[1].Kind)
+	require.Equal(t, "01", account.Storage[1].Old)
+	require.Equal(t, "02", account.Storage[1].New)
+	require.NotContains(t, account.Storage[0].Key+account.Storage[1].Key, hex.EncodeToString([]byte("owner")))
+	require.Len(t, account.ESDT, 1)
+	require.Equal(t, "TOKEN-abcdef", account.ESDT[0].TokenIdentifier)
+	require.Equal(t, "-30", account.ESDT[0].Delta)
+
+	jsonOutput, err := diff.ToJSON()
+	require.Nil(t, err)
+	require.Contains(t, string(jsonOutput), `"kind": "esdt"`)
+
+	var text strings.Builder
+	err = diff.WriteText(&text, false)
+	require.Nil(t, err)
+	require.Contains(t, text.String(), "balance: 1000 -> 990 (-10)")
+	require.Contains(t, text.String(), "esdt TOKEN-abcdef: 100 -> 70 (-30)")
+	require.NotContains(t, text.String(), ansiReset)
+}
@@ -8,6 +8,7 @@ import (
 	"math/big"
 
 	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/core/check"
 	"github.com/multiversx/mx-chain-core-go/data/esdt"
 	"github.com/multiversx/mx-chain-core-go/data/vm"
 	logger "github.com/multiversx/mx-chain-logger-go"
@@ -61,6 +62,7 @@ const (
 	returnDataName                   = "returnData"
 	signalErrorName                  = "signalError"
 	getGasLeftName                   = "getGasLeft"
+	getMemoryUsageName               = "getMemoryUsage"
 	getESDTBalanceName               = "getESDTBalance"
 	getESDTNFTNameLengthName         = "getESDTNFTNameLength"
 	getESDTNFTAttributeLengthName    = "getESDTNFTAttributeLength"
@@ -86,6 +88,9 @@ const (
 	getStorageLockName               = "getStorageLock"
 	isStorageLockedName              = "isStorageLocked"
 	clearStorageLockName             = "clearStorageLock"
+	transientStoreName               = "transientStore"
+	transientLoadName                = "transientLoad"
+	getStorageDepositName            = "getStorageDeposit"
 	getBlockTimestampName            = "getBlockTimestamp"
 	getBlockNonceName                = "getBlockNonce"
 	getBlockRoundName                = "getBlockRound"
@@ -142,6 +147,68 @@ func (context *VMHooksImpl) GetGasLeft() int64 {
 	return int64(metering.GasLeft())
 }
 
+// GetMemoryUsage VMHooks implementation.
+// @autogenerate(VMHooks)
+func (context *VMHooksImpl) GetMemoryUsage() int64 {
+	runtime := context.GetRuntimeContext()
+	metering := context.GetMeteringContext()
+
+	gasToUse := metering.GasSchedule().BaseOpsAPICost.GetMemoryUsage
+	err := metering.UseGasBoundedAndAddTracedGas(getMemoryUsageName, gasToUse)
+	if context.WithFault(err, runtime.BaseOpsErrorShouldFailExecution()) {
+		return -1
+	}
+
+	instance := runtime.GetInstance()
+	if check.IfNil(instance) || !instance.HasMemory() {
+		return 0
+	}
+
+	return int64(instance.MemLength())
+}
+
+// CountInstructions VMHooks implementation.
+// It is only called by the code which the VM injects into the contracts to report the instructions they execute
+// to the instruction budget, and it costs no gas because the executor already meters these instructions.
+// @autogenerate(VMHooks)
+func (context *VMHooksImpl) CountInstructions(count int64) {
+	if count < 0 {
+		_ = context.WithFault(vmhost.ErrArgOutOfRange, true)
+		return
+	}
+
+	err := context.GetRuntimeContext().UseInstructions(uint64(count))
+	_ = context.WithFault(err, true)
+}
+
+// ChargeMemoryGrowth VMHooks implementation.
+// It is only called by the code which the VM injects into the contracts right after each memory.grow, with the
+// result of memory.grow, which it returns, so that the pages are charged when they are allocated and the execution
+// stops if the gas does not cover them. The injected call is refunded, so the gas does not depend on the executor.
+// @autogenerate(VMHooks)
+func (context *VMHooksImpl) ChargeMemoryGrowth(previousPages int32) int32 {
+	opcodeCost := context.GetMeteringContext().GasSchedule().WASMOpcodeCost
+	context.refundInjectedOpcodes(uint64(opcodeCost.Call))
+
+	err := context.GetRuntimeContext().UseGasForMemoryGrowth()
+	if context.WithFault(err, true) {
+		return -1
+	}
+
+	return previousPages
+}
+
+// refundInjectedOpcodes gives back the gas which the executor metered for the opcodes injected by the VM into the
+// contract before calling one of its hooks; the executors charge the opcodes of a block before each call.
+func (context *VMHooksImpl) refundInjectedOpcodes(gas uint64) {
+	runtime := context.GetRuntimeContext()
+	pointsUsed := runtime.GetPointsUsed()
+	if gas > pointsUsed {
+		gas = pointsUsed
+	}
+	runtime.SetPointsUsed(pointsUsed - gas)
+}
+
 // GetSCAddress VMHooks implementation.
 // @autogenerate(VMHooks)
 func (context *VMHooksImpl) GetSCAddress(resultOffset executor.MemPtr) {
@@ -2173,6 +2240,94 @@ func (context *VMHooksImpl) ClearStorageLock(keyOffset executor.MemPtr, keyLengt
 	return context.SetStorageLock(keyOffset, keyLength, 0)
 }
 
+// TransientStore VMHooks implementation.
+// @autogenerate(VMHooks)
+func (context *VMHooksImpl) TransientStore(keyHandle int32, valueHandle int32) int32 {
+	managedType := context.GetManagedTypesContext()
+	runtime := context.GetRuntimeContext()
+	storage := context.GetStorageContext()
+	metering := context.GetMeteringContext()
+
+	key, err := managedType.GetBytes(keyHandle)
+	if context.WithFault(err, runtime.BaseOpsErrorShouldFailExecution()) {
+		return -1
+	}
+
+	value, err := managedType.GetBytes(valueHandle)
+	if context.WithFault(err, runtime.BaseOpsErrorShouldFailExecution()) {
+		return -1
+	}
+
+	gasToUse := metering.GasSchedule().BaseOpsAPICost.TransientStore
+	copiedBytes := uint64(len(key) + len(value))
+	gasToUse = math.AddUint64(gasToUse, math.MulUint64(metering.GasSchedule().BaseOperationCost.DataCopyPerByte, copiedBytes))
+	err = metering.UseGasBoundedAndAddTracedGas(transientStoreName, gasToUse)
+	if context.WithFault(err, runtime.BaseOpsErrorShouldFailExecution()) {
+		return -1
+	}
+
+	err = storage.SetTransientStorage(key, value)
+	if context.WithFault(err, runtime.BaseOpsErrorShouldFailExecution()) {
+		return -1
+	}
+
+	return 0
+}
+
+// TransientLoad VMHooks implementation.
+// @autogenerate(VMHooks)
+func (context *VMHooksImpl) TransientLoad(keyHandle int32, destinationHandle int32) int32 {
+	managedType := context.GetManagedTypesContext()
+	runtime := context.GetRuntimeContext()
+	storage := context.GetStorageContext()
+	metering := context.GetMeteringContext()
+
+	key, err := managedType.GetBytes(keyHandle)
+	if context.WithFault(err, runtime.BaseOpsErrorShouldFailExecution()) {
+		return -1
+	}
+
+	value := storage.GetTransientStorage(key)
+
+	gasToUse := metering.GasSchedule().BaseOpsAPICost.TransientLoad
+	copiedBytes := uint64(len(key) + len(value))
+	gasToUse = math.AddUint64(gasToUse, math.MulUint64(metering.GasSchedule().BaseOperationCost.DataCopyPerByte, copiedBytes))
+	err = metering.UseGasBoundedAndAddTracedGas(transientLoadName, gasToUse)
+	if context.WithFault(err, runtime.BaseOpsErrorShouldFailExecution()) {
+		return -1
+	}
+
+	managedType.SetBytes(destinationHandle, value)
+
+	return int32(len(value))
+}
+
+// GetStorageDeposit VMHooks implementation.
+// @autogenerate(VMHooks)
+func (context *VMHooksImpl) GetStorageDeposit(resultHandle int32) {
+	managedType := context.GetManagedTypesContext()
+	runtime := context.GetRuntimeContext()
+	storage := context.GetStorageContext()
+	metering := context.GetMeteringContext()
+
+	deposit, trieDepth, usedCache, err := storage.GetStorageDeposit(runtime.GetContextAddress())
+	if context.WithFault(err, runtime.BaseOpsErrorShouldFailExecution()) {
+		return
+	}
+
+	err = storage.UseGasForStorageLoad(
+		getStorageDepositName,
+		int64(trieDepth),
+		metering.GasSchedule().BaseOpsAPICost.GetStorageDeposit,
+		usedCache)
+	if context.WithFault(err, runtime.BaseOpsErrorShouldFailExecution()) {
+		return
+	}
+
+	result := managedType.GetBigIntOrCreate(resultHandle)
+	result.Set(deposit)
+}
+
 // GetCaller VMHooks implementation.
 // @autogenerate(VMHooks)
 func (context *VMHooksImpl) GetCaller(resultOffset executor.MemPtr) {
@@ -3188,7 +3343,7 @@ func (context *VMHooksImpl) createContractWithHost(
 	}
 
 	valueAsInt := big.NewInt(0).SetBytes(value)
-	newAddress, err := createContract(sender, data, valueAsInt, gasLimit, code, codeMetadata, host, CreateContract)
+	newAddress, err := createContract(sender, data, valueAsInt, gasLimit, code, codeMetadata, nil, host, CreateContract)
 
 	if WithFaultAndHost(host, err, runtime.BaseOpsErrorShouldFailExecution()) {
 		return 1
@@ -3285,6 +3440,18 @@ func DeployFromSourceContractWithTypedArgs(
 	value *big.Int,
 	data [][]byte,
 	gasLimit int64,
+) ([]byte, error) {
+	return deployFromSourceContract(host, sourceContractAddress, codeMetadata, value, data, gasLimit, nil)
+}
+
+func deployFromSourceContract(
+	host vmhost.VMHost,
+	sourceContractAddress []byte,
+	codeMetadata []byte,
+	value *big.Int,
+	data [][]byte,
+	gasLimit int64,
+	salt []byte,
 ) ([]byte, error) {
 	runtime := host.Runtime()
 	sender := runtime.GetContextAddress()
@@ -3295,7 +3462,7 @@ func DeployFromSourceContractWithTypedArgs(
 		return nil, err
 	}
 
-	return createContract(sender, data, value, gasLimit, code, codeMetadata, host, DeployContract)
+	return createContract(sender, data, value, gasLimit, code, codeMetadata, salt, host, DeployContract)
 }
 
 func createContract(
@@ -3305,6 +3472,7 @@ func createContract(
 	gasLimit int64,
 	code []byte,
 	codeMetadata []byte,
+	salt []byte,
 	host vmhost.VMHost,
 	createContractCallType CreateContractCallType,
 ) ([]byte, error) {
@@ -3329,7 +3497,7 @@ func createContract(
 		copy(contractCreate.RelayerAddr, currentVMInput.RelayerAddr)
 	}
 
-	return host.CreateNewContract(contractCreate, int(createContractCallType))
+	return host.CreateNewContract(contractCreate, int(createContractCallType), salt)
 }
 
 // GetNumReturnData VMHooks implementation.
@@ -28,6 +28,10 @@ const (
 	bigIntEDivName                    = "bigIntEDiv"
 	bigIntEModName                    = "bigIntEMod"
 	bigIntPowName                     = "bigIntPow"
+	bigIntModPowName                  = "bigIntModPow"
+	bigIntModInverseName              = "bigIntModInverse"
+	bigIntMulModName                  = "bigIntMulMod"
+	bigIntGCDName                     = "bigIntGCD"
 	bigIntLog2Name                    = "bigIntLog2"
 	bigIntSqrtName                    = "bigIntSqrt"
 	bigIntAbsName                     = "bigIntAbs"
@@ -798,6 +802,187 @@ func (context *VMHooksImpl) BigIntPow(destinationHandle, op1Handle, op2Handle in
 	dest.Exp(a, b, nil)
 }
 
+// modularWordBits is the word size used to express the complexity of modular operations
+const modularWordBits = 64
+
+// modularMultiplicationComplexity returns the squared number of words of the largest operand,
+// following the multiplication complexity of EIP-2565
+func modularMultiplicationComplexity(operands ...*big.Int) uint64 {
+	maxBitLen := 0
+	for _, operand := range operands {
+		if operand.BitLen() > maxBitLen {
+			maxBitLen = operand.BitLen()
+		}
+	}
+
+	words := uint64((maxBitLen + modularWordBits - 1) / modularWordBits)
+	if words == 0 {
+		words = 1
+	}
+	return math.MulUint64(words, words)
+}
+
+// modPowIterationCount returns the number of squarings needed for the given exponent, following EIP-2565
+func modPowIterationCount(exponent *big.Int) uint64 {
+	if exponent.BitLen() <= 1 {
+		return 1
+	}
+	return uint64(exponent.BitLen() - 1)
+}
+
+func (context *VMHooksImpl) useGasForModularOperation(operationName string, complexity uint64) error {
+	metering := context.GetMeteringContext()
+	gasToUse := math.MulUint64(complexity, metering.GasSchedule().BigIntAPICost.ModularArithmeticPerWordSquared)
+	return metering.UseGasBoundedAndAddTracedGas(operationName, gasToUse)
+}
+
+func (context *VMHooksImpl) getPositiveModulus(modulusHandle int32) (*big.Int, error) {
+	modulus, err := context.GetManagedTypesContext().GetBigInt(modulusHandle)
+	if err != nil {
+		return nil, err
+	}
+	if modulus.Sign() == 0 {
+		return nil, vmhost.ErrDivZero
+	}
+	if modulus.Sign() < 0 {
+		return nil, vmhost.ErrNegativeModulus
+	}
+	return modulus, nil
+}
+
+// BigIntModPow VMHooks implementation.
+// @autogenerate(VMHooks)
+func (context *VMHooksImpl) BigIntModPow(destinationHandle, baseHandle, exponentHandle, modulusHandle int32) {
+	managedType := context.GetManagedTypesContext()
+	metering := context.GetMeteringContext()
+	runtime := context.GetRuntimeContext()
+
+	gasToUse := metering.GasSchedule().BigIntAPICost.BigIntModPow
+	err := metering.UseGasBoundedAndAddTracedGas(bigIntModPowName, gasToUse)
+	if context.WithFault(err, runtime.BigIntAPIErrorShouldFailExecution()) {
+		return
+	}
+
+	base, exponent, err := managedType.GetTwoBigInt(baseHandle, exponentHandle)
+	if context.WithFault(err, runtime.BigIntAPIErrorShouldFailExecution()) {
+		return
+	}
+	modulus, err := context.getPositiveModulus(modulusHandle)
+	if context.WithFault(err, runtime.BigIntAPIErrorShouldFailExecution()) {
+		return
+	}
+	if exponent.Sign() < 0 {
+		_ = context.WithFault(vmhost.ErrBadLowerBounds, runtime.BigIntAPIErrorShouldFailExecution())
+		return
+	}
+
+	complexity := math.MulUint64(modularMultiplicationComplexity(base, modulus), modPowIterationCount(exponent))
+	err = context.useGasForModularOperation(bigIntModPowName, complexity)
+	if context.WithFault(err, runtime.BigIntAPIErrorShouldFailExecution()) {
+		return
+	}
+
+	reducedBase := big.NewInt(0).Mod(base, modulus)
+	dest := managedType.GetBigIntOrCreate(destinationHandle)
+	dest.Exp(reducedBase, exponent, modulus)
+}
+
+// BigIntModInverse VMHooks implementation.
+// @autogenerate(VMHooks)
+func (context *VMHooksImpl) BigIntModInverse(destinationHandle, opHandle, modulusHandle int32) {
+	managedType := context.GetManagedTypesContext()
+	metering := context.GetMeteringContext()
+	runtime := context.GetRuntimeContext()
+
+	gasToUse := metering.GasSchedule().BigIntAPICost.BigIntModInverse
+	err := metering.UseGasBoundedAndAddTracedGas(bigIntModInverseName, gasToUse)
+	if context.WithFault(err, runtime.BigIntAPIErrorShouldFailExecution()) {
+		return
+	}
+
+	op, err := managedType.GetBigInt(opHandle)
+	if context.WithFault(err, runtime.BigIntAPIErrorShouldFailExecution()) {
+		return
+	}
+	modulus, err := context.getPositiveModulus(modulusHandle)
+	if context.WithFault(err, runtime.BigIntAPIErrorShouldFailExecution()) {
+		return
+	}
+
+	err = context.useGasForModularOperation(bigIntModInverseName, modularMultiplicationComplexity(op, modulus))
+	if context.WithFault(err, runtime.BigIntAPIErrorShouldFailExecution()) {
+		return
+	}
+
+	inverse := big.NewInt(0).ModInverse(big.NewInt(0).Mod(op, modulus), modulus)
+	if inverse == nil {
+		_ = context.WithFault(vmhost.ErrNoModularInverse, runtime.BigIntAPIErrorShouldFailExecution())
+		return
+	}
+
+	dest := managedType.GetBigIntOrCreate(destinationHandle)
+	dest.Set(inverse)
+}
+
+// BigIntMulMod VMHooks implementation.
+// @autogenerate(VMHooks)
+func (context *VMHooksImpl) BigIntMulMod(destinationHandle, op1Handle, op2Handle, modulusHandle int32) {
+	managedType := context.GetManagedTypesContext()
+	metering := context.GetMeteringContext()
+	runtime := context.GetRuntimeContext()
+
+	gasToUse := metering.GasSchedule().BigIntAPICost.BigIntMulMod
+	err := metering.UseGasBoundedAndAddTracedGas(bigIntMulModName, gasToUse)
+	if context.WithFault(err, runtime.BigIntAPIErrorShouldFailExecution()) {
+		return
+	}
+
+	a, b, err := managedType.GetTwoBigInt(op1Handle, op2Handle)
+	if context.WithFault(err, runtime.BigIntAPIErrorShouldFailExecution()) {
+		return
+	}
+	modulus, err := context.getPositiveModulus(modulusHandle)
+	if context.WithFault(err, runtime.BigIntAPIErrorShouldFailExecution()) {
+		return
+	}
+
+	err = context.useGasForModularOperation(bigIntMulModName, modularMultiplicationComplexity(a, b, modulus))
+	if context.WithFault(err, runtime.BigIntAPIErrorShouldFailExecution()) {
+		return
+	}
+
+	product := big.NewInt(0).Mul(a, b)
+	dest := managedType.GetBigIntOrCreate(destinationHandle)
+	dest.Mod(product, modulus)
+}
+
+// BigIntGCD VMHooks implementation.
+// @autogenerate(VMHooks)
+func (context *VMHooksImpl) BigIntGCD(destinationHandle, op1Handle, op2Handle int32) {
+	managedType := context.GetManagedTypesContext()
+	metering := context.GetMeteringContext()
+	runtime := context.GetRuntimeContext()
+
+	gasToUse := metering.GasSchedule().BigIntAPICost.BigIntGCD
+	err := metering.UseGasBoundedAndAddTracedGas(bigIntGCDName, gasToUse)
+	if context.WithFault(err, runtime.BigIntAPIErrorShouldFailExecution()) {
+		return
+	}
+
+	a, b, err := managedType.GetTwoBigInt(op1Handle, op2Handle)
+	if context.WithFault(err, runtime.BigIntAPIErrorShouldFailExecution()) {
+		return
+	}
+
+	err = context.useGasForModularOperation(bigIntGCDName, modularMultiplicationComplexity(a, b))
+	if context.WithFault(err, runtime.BigIntAPIErrorShouldFailExecution()) {
+		return
+	}
+
+	dest := managedType.GetBigIntOrCreate(destinationHandle)
+	dest.GCD(nil, nil, a, b)
+}
+
 // BigIntLog2 VMHooks implementation.
 // @autogenerate(VMHooks)
 func (context *VMHooksImpl) BigIntLog2(op1Handle int32) int32 {
@@ -4,6 +4,7 @@ import (
 	"crypto/elliptic"
 
 	"github.com/multiversx/mx-chain-vm-go/crypto/signing/secp256"
+	"github.com/multiversx/mx-chain-vm-go/evmabi"
 	"github.com/multiversx/mx-chain-vm-go/executor"
 	"github.com/multiversx/mx-chain-vm-go/math"
 	"github.com/multiversx/mx-chain-vm-go/vmhost"
@@ -16,6 +17,7 @@ const ed25519SignatureLength = 64
 const secp256k1CompressedPublicKeyLength = 33
 const secp256k1UncompressedPublicKeyLength = 65
 const curveNameLength = 4
+const evmFunctionSelectorLength = 4
 
 const (
 	sha256Name                      = "sha256"
@@ -42,6 +44,7 @@ const (
 	verifyBLSSignatureShare         = "verifyBLSSignatureShare"
 	verifyBLSAggregatedSignature    = "verifyBLSAggregatedSignature"
 	verifySecp256R1Signature        = "verifySecp256R1Signature"
+	managedEVMFunctionSelectorName  = "managedEVMFunctionSelector"
 )
 
 // Sha256 VMHooks implementation.
@@ -1988,3 +1991,41 @@ func (context *VMHooksImpl) ManagedVerifyBLSAggregatedSignature(
 	host := context.GetVMHost()
 	return ManagedVerifyBLSWithHost(host, keyHandle, messageHandle, sigHandle, verifyBLSAggregatedSignature)
 }
+
+// ManagedEVMFunctionSelector VMHooks implementation.
+// It writes the first 4 bytes of the Keccak-256 hash of the canonical form of a Solidity function signature.
+// @autogenerate(VMHooks)
+func (context *VMHooksImpl) ManagedEVMFunctionSelector(signatureHandle int32, resultHandle int32) int32 {
+	managedType := context.GetManagedTypesContext()
+	runtime := context.GetRuntimeContext()
+	crypto := context.GetCryptoContext()
+	metering := context.GetMeteringContext()
+
+	gasToUse := metering.GasSchedule().CryptoAPICost.ManagedEVMFunctionSelector
+	err := metering.UseGasBoundedAndAddTracedGas(managedEVMFunctionSelectorName, gasToUse)
+	if context.WithFault(err, runtime.CryptoAPIErrorShouldFailExecution()) {
+		return -1
+	}
+
+	signature, err := managedType.GetBytes(signatureHandle)
+	if context.WithFault(err, runtime.ManagedBufferAPIErrorShouldFailExecution()) {
+		return -1
+	}
+	err = managedType.ConsumeGasForBytes(signature)
+	if context.WithFault(err, runtime.CryptoAPIErrorShouldFailExecution()) {
+		return -1
+	}
+
+	canonicalSignature, err := evmabi.CanonicalSignature(string(signature))
+	if context.WithFault(err, runtime.CryptoAPIErrorShouldFailExecution()) {
+		return -1
+	}
+
+	hash, err := crypto.Keccak256([]byte(canonicalSignature))
+	if context.WithFault(err, runtime.CryptoAPIErrorShouldFailExecution()) {
+		return -1
+	}
+
+	managedType.SetBytes(resultHandle, hash[:evmFunctionSelectorLength])
+	return 0
+}
@@ -0,0 +1,401 @@
+package vmhooks
+
+import (
+	"math/big"
+
+	vmMath "github.com/multiversx/mx-chain-vm-go/math"
+)
+
+const (
+	decimalNewName        = "decimalNew"
+	decimalFromBigIntName = "decimalFromBigInt"
+	decimalToBigIntName   = "decimalToBigInt"
+	decimalRescaleName    = "decimalRescale"
+	decimalAddName        = "decimalAdd"
+	decimalSubName        = "decimalSub"
+	decimalMulName        = "decimalMul"
+	decimalDivName        = "decimalDiv"
+	decimalCmpName        = "decimalCmp"
+	decimalLnName         = "decimalLn"
+	decimalExpName        = "decimalExp"
+)
+
+func decimalScaleFromArgument(scale int32) (uint32, error) {
+	if scale < 0 || scale > vmMath.MaxDecimalScale {
+		return 0, vmMath.ErrInvalidDecimalScale
+	}
+	return uint32(scale), nil
+}
+
+// useGasForDecimalSeries charges the approximation of ln or exp for each digit of its working scale,
+// which grows with the scale of the operand and of the result
+func (context *VMHooksImpl) useGasForDecimalSeries(costPerScaleDigit uint64, op *vmMath.Decimal, scale uint32) error {
+	gasToUse := vmMath.MulUint64(costPerScaleDigit, uint64(vmMath.DecimalWorkScale(op, scale)))
+	return context.GetMeteringContext().UseGasBounded(gasToUse)
+}
+
+func (context *VMHooksImpl) setDecimalResult(destinationHandle int32, result *vmMath.Decimal) {
+	managedType := context.GetManagedTypesContext()
+	runtime := context.GetRuntimeContext()
+
+	err := managedType.ConsumeGasForDecimalCopy(result)
+	if context.WithFault(err, runtime.DecimalAPIErrorShouldFailExecution()) {
+		return
+	}
+
+	dest := managedType.GetDecimalOrCreate(destinationHandle)
+	dest.Set(result)
+}
+
+// DecimalNew VMHooks implementation.
+// @autogenerate(VMHooks)
+func (context *VMHooksImpl) DecimalNew(significand int64, scale int32) int32 {
+	managedType := context.GetManagedTypesContext()
+	runtime := context.GetRuntimeContext()
+	metering := context.GetMeteringContext()
+
+	gasToUse := metering.GasSchedule().DecimalAPICost.DecimalNew
+	err := metering.UseGasBoundedAndAddTracedGas(decimalNewName, gasToUse)
+	if context.WithFault(err, runtime.DecimalAPIErrorShouldFailExecution()) {
+		return -1
+	}
+
+	decimalScale, err := decimalScaleFromArgument(scale)
+	if context.WithFault(err, runtime.DecimalAPIErrorShouldFailExecution()) {
+		return -1
+	}
+
+	value, err := vmMath.NewDecimal(big.NewInt(significand), decimalScale)
+	if context.WithFault(err, runtime.DecimalAPIErrorShouldFailExecution()) {
+		return -1
+	}
+
+	return managedType.PutDecimal(value)
+}
+
+// DecimalFromBigInt VMHooks implementation.
+// @autogenerate(VMHooks)
+func (context *VMHooksImpl) DecimalFromBigInt(destinationHandle, bigIntHandle, scale int32) {
+	managedType := context.GetManagedTypesContext()
+	runtime := context.GetRuntimeContext()
+	metering := context.GetMeteringContext()
+
+	gasToUse := metering.GasSchedule().DecimalAPICost.DecimalFromBigInt
+	err := metering.UseGasBoundedAndAddTracedGas(decimalFromBigIntName, gasToUse)
+	if context.WithFault(err, runtime.DecimalAPIErrorShouldFailExecution()) {
+		return
+	}
+
+	decimalScale, err := decimalScaleFromArgument(scale)
+	if context.WithFault(err, runtime.DecimalAPIErrorShouldFailExecution()) {
+		return
+	}
+
+	unscaled, err := managedType.GetBigInt(bigIntHandle)
+	if context.WithFault(err, runtime.BigIntAPIErrorShouldFailExecution()) {
+		return
+	}
+
+	value, err := vmMath.NewDecimal(unscaled, decimalScale)
+	if context.WithFault(err, runtime.DecimalAPIErrorShouldFailExecution()) {
+		return
+	}
+
+	context.setDecimalResult(destinationHandle, value)
+}
+
+// DecimalToBigInt VMHooks implementation.
+// @autogenerate(VMHooks)
+func (context *VMHooksImpl) DecimalToBigInt(destBigIntHandle, opHandle int32) int32 {
+	managedType := context.GetManagedTypesContext()
+	runtime := context.GetRuntimeContext()
+	metering := context.GetMeteringContext()
+
+	gasToUse := metering.GasSchedule().DecimalAPICost.DecimalToBigInt
+	err := metering.UseGasBoundedAndAddTracedGas(decimalToBigIntName, gasToUse)
+	if context.WithFault(err, runtime.DecimalAPIErrorShouldFailExecution()) {
+		return -1
+	}
+
+	op, err := managedType.GetDecimal(opHandle)
+	if context.WithFault(err, runtime.DecimalAPIErrorShouldFailExecution()) {
+		return -1
+	}
+
+	err = managedType.ConsumeGasForDecimalCopy(op)
+	if context.WithFault(err, runtime.DecimalAPIErrorShouldFailExecution()) {
+		return -1
+	}
+
+	dest := managedType.GetBigIntOrCreate(destBigIntHandle)
+	dest.Set(op.Unscaled)
+	return int32(op.Scale)
+}
+
+// DecimalRescale VMHooks implementation.
+// @autogenerate(VMHooks)
+func (context *VMHooksImpl) DecimalRescale(destinationHandle, opHandle, scale, roundingMode int32) {
+	managedType := context.GetManagedTypesContext()
+	runtime := context.GetRuntimeContext()
+	metering := context.GetMeteringContext()
+
+	gasToUse := metering.GasSchedule().DecimalAPICost.DecimalRescale
+	err := metering.UseGasBoundedAndAddTracedGas(decimalRescaleName, gasToUse)
+	if context.WithFault(err, runtime.DecimalAPIErrorShouldFailExecution()) {
+		return
+	}
+
+	decimalScale, err := decimalScaleFromArgument(scale)
+	if context.WithFault(err, runtime.DecimalAPIErrorShouldFailExecution()) {
+		return
+	}
+
+	op, err := managedType.GetDecimal(opHandle)
+	if context.WithFault(err, runtime.DecimalAPIErrorShouldFailExecution()) {
+		return
+	}
+
+	err = managedType.ConsumeGasForDecimalCopy(op)
+	if context.WithFault(err, runtime.DecimalAPIErrorShouldFailExecution()) {
+		return
+	}
+
+	result, err := op.Rescale(decimalScale, vmMath.RoundingMode(roundingMode))
+	if context.WithFault(err, runtime.DecimalAPIErrorShouldFailExecution()) {
+		return
+	}
+
+	context.setDecimalResult(destinationHandle, result)
+}
+
+// DecimalAdd VMHooks implementation.
+// @autogenerate(VMHooks)
+func (context *VMHooksImpl) DecimalAdd(destinationHandle, op1Handle, op2Handle int32) {
+	managedType := context.GetManagedTypesContext()
+	runtime := context.GetRuntimeContext()
+	metering := context.GetMeteringContext()
+
+	gasToUse := metering.GasSchedule().DecimalAPICost.DecimalAdd
+	err := metering.UseGasBoundedAndAddTracedGas(decimalAddName, gasToUse)
+	if context.WithFault(err, runtime.DecimalAPIErrorShouldFailExecution()) {
+		return
+	}
+
+	op1, op2, err := managedType.GetTwoDecimals(op1Handle, op2Handle)
+	if context.WithFault(err, runtime.DecimalAPIErrorShouldFailExecution()) {
+		return
+	}
+
+	err = managedType.ConsumeGasForDecimalCopy(op1, op2)
+	if context.WithFault(err, runtime.DecimalAPIErrorShouldFailExecution()) {
+		return
+	}
+
+	context.setDecimalResult(destinationHandle, vmMath.AddDecimal(op1, op2))
+}
+
+// DecimalSub VMHooks implementation.
+// @autogenerate(VMHooks)
+func (context *VMHooksImpl) DecimalSub(destinationHandle, op1Handle, op2Handle int32) {
+	managedType := context.GetManagedTypesContext()
+	runtime := context.GetRuntimeContext()
+	metering := context.GetMeteringContext()
+
+	gasToUse := metering.GasSchedule().DecimalAPICost.DecimalSub
+	err := metering.UseGasBoundedAndAddTracedGas(decimalSubName, gasToUse)
+	if context.WithFault(err, runtime.DecimalAPIErrorShouldFailExecution()) {
+		return
+	}
+
+	op1, op2, err := managedType.GetTwoDecimals(op1Handle, op2Handle)
+	if context.WithFault(err, runtime.DecimalAPIErrorShouldFailExecution()) {
+		return
+	}
+
+	err = managedType.ConsumeGasForDecimalCopy(op1, op2)
+	if context.WithFault(err, runtime.DecimalAPIErrorShouldFailExecution()) {
+		return
+	}
+
+	context.setDecimalResult(destinationHandle, vmMath.SubDecimal(op1, op2))
+}
+
+// DecimalMul VMHooks implementation.
+// @autogenerate(VMHooks)
+func (context *VMHooksImpl) DecimalMul(destinationHandle, op1Handle, op2Handle, scale, roundingMode int32) {
+	managedType := context.GetManagedTypesContext()
+	runtime := context.GetRuntimeContext()
+	metering := context.GetMeteringContext()
+
+	gasToUse := metering.GasSchedule().DecimalAPICost.DecimalMul
+	err := metering.UseGasBoundedAndAddTracedGas(decimalMulName, gasToUse)
+	if context.WithFault(err, runtime.DecimalAPIErrorShouldFailExecution()) {
+		return
+	}
+
+	decimalScale, err := decimalScaleFromArgument(scale)
+	if context.WithFault(err, runtime.DecimalAPIErrorShouldFailExecution()) {
+		return
+	}
+
+	op1, op2, err := managedType.GetTwoDecimals(op1Handle, op2Handle)
+	if context.WithFault(err, runtime.DecimalAPIErrorShouldFailExecution()) {
+		return
+	}
+
+	err = managedType.ConsumeGasForDecimalCopy(op1, op2)
+	if context.WithFault(err, runtime.DecimalAPIErrorShouldFailExecution()) {
+		return
+	}
+
+	result, err := vmMath.MulDecimal(op1, op2, decimalScale, vmMath.RoundingMode(roundingMode))
+	if context.WithFault(err, runtime.DecimalAPIErrorShouldFailExecution()) {
+		return
+	}
+
+	context.setDecimalResult(destinationHandle, result)
+}
+
+// DecimalDiv VMHooks implementation.
+// @autogenerate(VMHooks)
+func (context *VMHooksImpl) DecimalDiv(destinationHandle, op1Handle, op2Handle, scale, roundingMode int32) {
+	managedType := context.GetManagedTypesContext()
+	runtime := context.GetRuntimeContext()
+	metering := context.GetMeteringContext()
+
+	gasToUse := metering.GasSchedule().DecimalAPICost.DecimalDiv
+	err := metering.UseGasBoundedAndAddTracedGas(decimalDivName, gasToUse)
+	if context.WithFault(err, runtime.DecimalAPIErrorShouldFailExecution()) {
+		return
+	}
+
+	decimalScale, err := decimalScaleFromArgument(scale)
+	if context.WithFault(err, runtime.DecimalAPIErrorShouldFailExecution()) {
+		return
+	}
+
+	op1, op2, err := managedType.GetTwoDecimals(op1Handle, op2Handle)
+	if context.WithFault(err, runtime.DecimalAPIErrorShouldFailExecution()) {
+		return
+	}
+
+	err = managedType.ConsumeGasForDecimalCopy(op1, op2)
+	if context.WithFault(err, runtime.DecimalAPIErrorShouldFailExecution()) {
+		return
+	}
+
+	result, err := vmMath.DivDecimal(op1, op2, decimalScale, vmMath.RoundingMode(roundingMode))
+	if context.WithFault(err, runtime.DecimalAPIErrorShouldFailExecution()) {
+		return
+	}
+
+	context.setDecimalResult(destinationHandle, result)
+}
+
+// DecimalCmp VMHooks implementation.
+// @autogenerate(VMHooks)
+func (context *VMHooksImpl) DecimalCmp(op1Handle, op2Handle int32) int32 {
+	managedType := context.GetManagedTypesContext()
+	runtime := context.GetRuntimeContext()
+	metering := context.GetMeteringContext()
+
+	gasToUse := metering.GasSchedule().DecimalAPICost.DecimalCmp
+	err := metering.UseGasBoundedAndAddTracedGas(decimalCmpName, gasToUse)
+	if context.WithFault(err, runtime.DecimalAPIErrorShouldFailExecution()) {
+		return -2
+	}
+
+	op1, op2, err := managedType.GetTwoDecimals(op1Handle, op2Handle)
+	if context.WithFault(err, runtime.DecimalAPIErrorShouldFailExecution()) {
+		return -2
+	}
+
+	err = managedType.ConsumeGasForDecimalCopy(op1, op2)
+	if context.WithFault(err, runtime.DecimalAPIErrorShouldFailExecution()) {
+		return -2
+	}
+
+	return int32(op1.Cmp(op2))
+}
+
+// DecimalLn VMHooks implementation.
+// @autogenerate(VMHooks)
+func (context *VMHooksImpl) DecimalLn(destinationHandle, opHandle, scale int32) {
+	managedType := context.GetManagedTypesContext()
+	runtime := context.GetRuntimeContext()
+	metering := context.GetMeteringContext()
+
+	gasToUse := metering.GasSchedule().DecimalAPICost.DecimalLn
+	err := metering.UseGasBoundedAndAddTracedGas(decimalLnName, gasToUse)
+	if context.WithFault(err, runtime.DecimalAPIErrorShouldFailExecution()) {
+		return
+	}
+
+	decimalScale, err := decimalScaleFromArgument(scale)
+	if context.WithFault(err, runtime.DecimalAPIErrorShouldFailExecution()) {
+		return
+	}
+
+	op, err := managedType.GetDecimal(opHandle)
+	if context.WithFault(err, runtime.DecimalAPIErrorShouldFailExecution()) {
+		return
+	}
+
+	err = managedType.ConsumeGasForDecimalCopy(op)
+	if context.WithFault(err, runtime.DecimalAPIErrorShouldFailExecution()) {
+		return
+	}
+
+	err = context.useGasForDecimalSeries(metering.GasSchedule().DecimalAPICost.DecimalLnPerScaleDigit, op, decimalScale)
+	if context.WithFault(err, runtime.DecimalAPIErrorShouldFailExecution()) {
+		return
+	}
+
+	result, err := vmMath.LnDecimal(op, decimalScale)
+	if context.WithFault(err, runtime.DecimalAPIErrorShouldFailExecution()) {
+		return
+	}
+
+	context.setDecimalResult(destinationHandle, result)
+}
+
+// DecimalExp VMHooks implementation.
+// @autogenerate(VMHooks)
+func (context *VMHooksImpl) DecimalExp(destinationHandle, opHandle, scale int32) {
+	managedType := context.GetManagedTypesContext()
+	runtime := context.GetRuntimeContext()
+	metering := context.GetMeteringContext()
+
+	gasToUse := metering.GasSchedule().DecimalAPICost.DecimalExp
+	err := metering.UseGasBoundedAndAddTracedGas(decimalExpName, gasToUse)
+	if context.WithFault(err, runtime.DecimalAPIErrorShouldFailExecution()) {
+		return
+	}
+
+	decimalScale, err := decimalScaleFromArgument(scale)
+	if context.WithFault(err, runtime.DecimalAPIErrorShouldFailExecution()) {
+		return
+	}
+
+	op, err := managedType.GetDecimal(opHandle)
+	if context.WithFault(err, runtime.DecimalAPIErrorShouldFailExecution()) {
+		return
+	}
+
+	err = managedType.ConsumeGasForDecimalCopy(op)
+	if context.WithFault(err, runtime.DecimalAPIErrorShouldFailExecution()) {
+		return
+	}
+
+	err = context.useGasForDecimalSeries(metering.GasSchedule().DecimalAPICost.DecimalExpPerScaleDigit, op, decimalScale)
+	if context.WithFault(err, runtime.DecimalAPIErrorShouldFailExecution()) {
+		return
+	}
+
+	result, err := vmMath.ExpDecimal(op, decimalScale)
+	if context.WithFault(err, runtime.DecimalAPIErrorShouldFailExecution()) {
+		return
+	}
+
+	context.setDecimalResult(destinationHandle, result)
+}
@@ -16,27 +16,11 @@ import (
 const pathToApiPackage = "./"
 const pathToRustRepoConfigFile = "wasm-vm-executor-rs-path.txt"
 
-func initEIMetadata() *eapigen.EIMetadata {
-	return &eapigen.EIMetadata{
-		Groups: []*eapigen.EIGroup{
-			{SourcePath: "baseOps.go", Name: "Main"},
-			{SourcePath: "managedei.go", Name: "Managed"},
-			{SourcePath: "bigFloatOps.go", Name: "BigFloat"},
-			{SourcePath: "bigIntOps.go", Name: "BigInt"},
-			{SourcePath: "manBufOps.go", Name: "ManagedBuffer"},
-			{SourcePath: "manMapOps.go", Name: "ManagedMap"},
-			{SourcePath: "smallIntOps.go", Name: "SmallInt"},
-			{SourcePath: "cryptoei.go", Name: "Crypto"},
-		},
-		AllFunctions: nil,
-	}
-}
-
 // Reads all .txt files in the current folder
 // and encodes them as strings literals in textfiles.go
 func main() {
 	fset := token.NewFileSet() // positions are relative to fset
-	eiMetadata := initEIMetadata()
+	eiMetadata := eapigen.NewEIMetadata()
 	err := eapigen.ReadAndParseEIMetadata(fset, pathToApiPackage, eiMetadata)
 	if err != nil {
 		panic(err)
@@ -45,18 +29,19 @@ func main() {
 	writeVMHooks(eiMetadata)
 	writeVMHooksWrapper(eiMetadata)
 	writeWasmer1ImportsCgo(eiMetadata)
-	writeWasmer2ImportsCgo(eiMetadata)
-	writeWasmer2Names(eiMetadata)
+	wasmer2Metadata := eiMetadata.Wasmer2Metadata()
+	writeWasmer2ImportsCgo(wasmer2Metadata)
+	writeWasmer2Names(wasmer2Metadata)
 
 	writeNamesForMockExecutor(eiMetadata)
 
 	tryCreateRustOutputDirectory()
 
-	writeRustVMHooksNames(eiMetadata)
-	writeRustVMHooksTrait(eiMetadata)
-	writeRustCapiVMHooks(eiMetadata)
-	writeRustCapiVMHooksPointers(eiMetadata)
-	writeRustWasmerImports(eiMetadata)
+	writeRustVMHooksNames(wasmer2Metadata)
+	writeRustVMHooksTrait(wasmer2Metadata)
+	writeRustCapiVMHooks(wasmer2Metadata)
+	writeRustCapiVMHooksPointers(wasmer2Metadata)
+	writeRustWasmerImports(wasmer2Metadata)
 
 	fmt.Printf("Generated code for %d executor callback methods.\n", len(eiMetadata.AllFunctions))
 
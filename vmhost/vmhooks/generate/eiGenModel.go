@@ -41,3 +41,90 @@ type EIMetadata struct {
 	Groups       []*EIGroup
 	AllFunctions []*EIFunction
 }
+
+// NewEIMetadata creates the EI metadata skeleton, listing the source file of each VM hooks group.
+// The functions are filled in by ReadAndParseEIMetadata.
+func NewEIMetadata() *EIMetadata {
+	return &EIMetadata{
+		Groups: []*EIGroup{
+			{SourcePath: "baseOps.go", Name: "Main"},
+			{SourcePath: "managedei.go", Name: "Managed"},
+			{SourcePath: "bigFloatOps.go", Name: "BigFloat"},
+			{SourcePath: "decimalOps.go", Name: "Decimal"},
+			{SourcePath: "bigIntOps.go", Name: "BigInt"},
+			{SourcePath: "manBufOps.go", Name: "ManagedBuffer"},
+			{SourcePath: "manMapOps.go", Name: "ManagedMap"},
+			{SourcePath: "smallIntOps.go", Name: "SmallInt"},
+			{SourcePath: "cryptoei.go", Name: "Crypto"},
+		},
+		AllFunctions: nil,
+	}
+}
+
+// functionsMissingFromWasmer2 lists the VM hooks which the prebuilt Wasmer 2 executor does not provide.
+// They are left out of the Wasmer 2 and Rust executor outputs, whose hook pointers must keep the layout
+// of the executor library; contracts importing them are rejected when running on Wasmer 2.
+var functionsMissingFromWasmer2 = map[string]struct{}{
+	"GetMemoryUsage":                          {},
+	"ChargeMemoryGrowth":                      {},
+	"CountInstructions":                       {},
+	"TransientStore":                          {},
+	"TransientLoad":                           {},
+	"GetStorageDeposit":                       {},
+	"ManagedDeployFromSourceContractWithSalt": {},
+	"ManagedCreateContractWithSalt":           {},
+	"ManagedDeployFromCodeHash":               {},
+	"ManagedRegisterCode":                     {},
+	"ManagedComputeContractAddress":           {},
+	"ManagedGetCodeHash":                      {},
+	"ManagedGetCodeDeployer":                  {},
+	"ManagedGetContractDeployInfo":            {},
+	"ManagedEVMAbiEncode":                     {},
+	"ManagedEVMAbiDecode":                     {},
+	"ManagedEVMFunctionSelector":              {},
+	"ManagedSetUpgradePolicy":                 {},
+	"ManagedProposeUpgrade":                   {},
+	"ManagedApproveUpgrade":                   {},
+	"ManagedCancelUpgrade":                    {},
+	"DecimalNew":                              {},
+	"DecimalFromBigInt":                       {},
+	"DecimalToBigInt":                         {},
+	"DecimalRescale":                          {},
+	"DecimalAdd":                              {},
+	"DecimalSub":                              {},
+	"DecimalMul":                              {},
+	"DecimalDiv":                              {},
+	"DecimalCmp":                              {},
+	"DecimalLn":                               {},
+	"DecimalExp":                              {},
+	"BigIntModPow":                            {},
+	"BigIntModInverse":                        {},
+	"BigIntMulMod":                            {},
+	"BigIntGCD":                               {},
+	"MBufferToDecimal":                        {},
+	"MBufferFromDecimal":                      {},
+}
+
+// Wasmer2Metadata returns the EI metadata restricted to the functions provided by the Wasmer 2 executor,
+// in the same order.
+func (eiMetadata *EIMetadata) Wasmer2Metadata() *EIMetadata {
+	wasmer2Metadata := &EIMetadata{}
+	for _, group := range eiMetadata.Groups {
+		wasmer2Group := &EIGroup{
+			SourcePath: group.SourcePath,
+			Name:       group.Name,
+		}
+		for _, function := range group.Functions {
+			if _, missing := functionsMissingFromWasmer2[function.Name]; missing {
+				continue
+			}
+			wasmer2Group.Functions = append(wasmer2Group.Functions, function)
+		}
+		if len(wasmer2Group.Functions) == 0 {
+			continue
+		}
+		wasmer2Metadata.Groups = append(wasmer2Metadata.Groups, wasmer2Group)
+		wasmer2Metadata.AllFunctions = append(wasmer2Metadata.AllFunctions, wasmer2Group.Functions...)
+	}
+	return wasmer2Metadata
+}
@@ -32,6 +32,8 @@ const (
 	mBufferSetRandomName          = "mBufferSetRandom"
 	mBufferToBigFloatName         = "mBufferToBigFloat"
 	mBufferFromBigFloatName       = "mBufferFromBigFloat"
+	mBufferToDecimalName          = "mBufferToDecimal"
+	mBufferFromDecimalName        = "mBufferFromDecimal"
 )
 
 // MBufferNew VMHooks implementation.
@@ -592,6 +594,67 @@ func (context *VMHooksImpl) MBufferFromBigFloat(mBufferHandle, bigFloatHandle in
 	return 0
 }
 
+// MBufferToDecimal VMHooks implementation.
+// @autogenerate(VMHooks)
+func (context *VMHooksImpl) MBufferToDecimal(mBufferHandle, decimalHandle int32) int32 {
+	managedType := context.GetManagedTypesContext()
+	runtime := context.GetRuntimeContext()
+	metering := context.GetMeteringContext()
+
+	gasToUse := metering.GasSchedule().ManagedBufferAPICost.MBufferToDecimal
+	err := metering.UseGasBoundedAndAddTracedGas(mBufferToDecimalName, gasToUse)
+	if context.WithFault(err, runtime.ManagedBufferAPIErrorShouldFailExecution()) {
+		return 1
+	}
+
+	managedBuffer, err := managedType.GetBytes(mBufferHandle)
+	if context.WithFault(err, runtime.ManagedBufferAPIErrorShouldFailExecution()) {
+		return 1
+	}
+
+	err = managedType.ConsumeGasForBytes(managedBuffer)
+	if context.WithFault(err, runtime.ManagedBufferAPIErrorShouldFailExecution()) {
+		return 1
+	}
+
+	decimal, err := math.DecodeDecimal(managedBuffer)
+	if context.WithFault(err, runtime.DecimalAPIErrorShouldFailExecution()) {
+		return 1
+	}
+
+	value := managedType.GetDecimalOrCreate(decimalHandle)
+	value.Set(decimal)
+	return 0
+}
+
+// MBufferFromDecimal VMHooks implementation.
+// @autogenerate(VMHooks)
+func (context *VMHooksImpl) MBufferFromDecimal(mBufferHandle, decimalHandle int32) int32 {
+	managedType := context.GetManagedTypesContext()
+	runtime := context.GetRuntimeContext()
+	metering := context.GetMeteringContext()
+
+	gasToUse := metering.GasSchedule().ManagedBufferAPICost.MBufferFromDecimal
+	err := metering.UseGasBoundedAndAddTracedGas(mBufferFromDecimalName, gasToUse)
+	if context.WithFault(err, runtime.ManagedBufferAPIErrorShouldFailExecution()) {
+		return 1
+	}
+
+	value, err := managedType.GetDecimal(decimalHandle)
+	if context.WithFault(err, runtime.DecimalAPIErrorShouldFailExecution()) {
+		return 1
+	}
+
+	encodedDecimal := value.Encode()
+	err = managedType.ConsumeGasForBytes(encodedDecimal)
+	if context.WithFault(err, runtime.ManagedBufferAPIErrorShouldFailExecution()) {
+		return 1
+	}
+
+	managedType.SetBytes(mBufferHandle, encodedDecimal)
+	return 0
+}
+
 // MBufferStorageStore VMHooks implementation.
 // @autogenerate(VMHooks)
 func (context *VMHooksImpl) MBufferStorageStore(keyHandle int32, sourceHandle int32) int32 {
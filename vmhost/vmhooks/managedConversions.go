@@ -7,6 +7,7 @@ import (
 
 	"github.com/multiversx/mx-chain-core-go/core"
 	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	"github.com/multiversx/mx-chain-vm-go/evmabi"
 	"github.com/multiversx/mx-chain-vm-go/math"
 	"github.com/multiversx/mx-chain-vm-go/vmhost"
 )
@@ -235,3 +236,118 @@ func readDestinationArguments(
 
 	return vmInput, nil
 }
+
+// Reads the types of an EVM ABI encoding, given as a comma-separated list such as "uint256,address[]".
+func readEVMAbiTypes(managedType vmhost.ManagedTypesContext, typesHandle int32) ([]*evmabi.Type, error) {
+	typeList, err := managedType.GetBytes(typesHandle)
+	if err != nil {
+		return nil, err
+	}
+	err = managedType.ConsumeGasForBytes(typeList)
+	if err != nil {
+		return nil, err
+	}
+
+	return evmabi.ParseTypes(string(typeList))
+}
+
+// Reads the values of the given EVM ABI types from a managed vec of managed buffer handles.
+// Elementary values are the contents of their managed buffer, while arrays and tuples
+// are themselves managed vecs holding the handles of their items.
+func readEVMAbiValues(
+	managedType vmhost.ManagedTypesContext,
+	types []*evmabi.Type,
+	managedVecHandle int32,
+) ([]*evmabi.Value, error) {
+	itemHandles, err := readEVMAbiItemHandles(managedType, managedVecHandle)
+	if err != nil {
+		return nil, err
+	}
+	if len(itemHandles) != len(types) {
+		return nil, evmabi.ErrValueCountMismatch
+	}
+
+	values := make([]*evmabi.Value, len(itemHandles))
+	for i, itemHandle := range itemHandles {
+		values[i], err = readEVMAbiValue(managedType, types[i], itemHandle)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return values, nil
+}
+
+func readEVMAbiValue(managedType vmhost.ManagedTypesContext, typ *evmabi.Type, handle int32) (*evmabi.Value, error) {
+	switch typ.Kind {
+	case evmabi.KindArray, evmabi.KindSlice:
+		itemHandles, err := readEVMAbiItemHandles(managedType, handle)
+		if err != nil {
+			return nil, err
+		}
+		items := make([]*evmabi.Value, len(itemHandles))
+		for i, itemHandle := range itemHandles {
+			items[i], err = readEVMAbiValue(managedType, typ.Elem, itemHandle)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return &evmabi.Value{Items: items}, nil
+	case evmabi.KindTuple:
+		items, err := readEVMAbiValues(managedType, typ.Components, handle)
+		if err != nil {
+			return nil, err
+		}
+		return &evmabi.Value{Items: items}, nil
+	default:
+		data, err := managedType.GetBytes(handle)
+		if err != nil {
+			return nil, err
+		}
+		err = managedType.ConsumeGasForBytes(data)
+		if err != nil {
+			return nil, err
+		}
+		return &evmabi.Value{Bytes: data}, nil
+	}
+}
+
+func readEVMAbiItemHandles(managedType vmhost.ManagedTypesContext, managedVecHandle int32) ([]int32, error) {
+	managedVecBytes, err := managedType.GetBytes(managedVecHandle)
+	if err != nil {
+		return nil, err
+	}
+	err = managedType.ConsumeGasForBytes(managedVecBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(managedVecBytes)%4 != 0 {
+		return nil, vmhost.ErrInvalidEVMAbiValueHandles
+	}
+
+	handles := make([]int32, 0, len(managedVecBytes)/4)
+	for i := 0; i < len(managedVecBytes); i += 4 {
+		handles = append(handles, int32(binary.BigEndian.Uint32(managedVecBytes[i:i+4])))
+	}
+	return handles, nil
+}
+
+// Writes decoded EVM ABI values as a managed vec of managed buffer handles, in the format read by readEVMAbiValues,
+// and returns the number of bytes written in all the created managed buffers.
+func writeEVMAbiValues(managedType vmhost.ManagedTypesContext, values []*evmabi.Value, destinationHandle int32) uint64 {
+	destinationBytes := make([]byte, 4*len(values))
+	writtenBytes := uint64(len(destinationBytes))
+	for i, value := range values {
+		itemHandle := managedType.NewManagedBufferFromBytes(value.Bytes)
+		if value.Items != nil {
+			writtenBytes += writeEVMAbiValues(managedType, value.Items, itemHandle)
+		} else {
+			writtenBytes += uint64(len(value.Bytes))
+		}
+		binary.BigEndian.PutUint32(destinationBytes[4*i:4*i+4], uint32(itemHandle))
+	}
+
+	managedType.SetBytes(destinationHandle, destinationBytes)
+	return writtenBytes
+}
@@ -4,9 +4,11 @@ import (
 	"encoding/hex"
 	"errors"
 
+	"github.com/multiversx/mx-chain-core-go/core"
 	"github.com/multiversx/mx-chain-core-go/core/check"
 	"github.com/multiversx/mx-chain-vm-common-go/builtInFunctions"
 
+	"github.com/multiversx/mx-chain-vm-go/evmabi"
 	"github.com/multiversx/mx-chain-vm-go/executor"
 	"github.com/multiversx/mx-chain-vm-go/math"
 	"github.com/multiversx/mx-chain-vm-go/vmhost"
@@ -46,6 +48,20 @@ const (
 	managedGetCodeMetadataName               = "managedGetCodeMetadata"
 	managedIsBuiltinFunction                 = "managedIsBuiltinFunction"
 	managedMultiTransferESDTNFTExecuteByUser = "managedMultiTransferESDTNFTExecuteByUser"
+	managedEVMAbiEncodeName                  = "managedEVMAbiEncode"
+	managedEVMAbiDecodeName                  = "managedEVMAbiDecode"
+	managedSetUpgradePolicyName              = "managedSetUpgradePolicy"
+	managedProposeUpgradeName                = "managedProposeUpgrade"
+	managedApproveUpgradeName                = "managedApproveUpgrade"
+	managedCancelUpgradeName                 = "managedCancelUpgrade"
+	managedCreateContractWithSaltName        = "managedCreateContractWithSalt"
+	managedDeployFromSourceWithSaltName      = "managedDeployFromSourceContractWithSalt"
+	managedComputeContractAddressName        = "managedComputeContractAddress"
+	managedGetCodeHashName                   = "managedGetCodeHash"
+	managedGetCodeDeployerName               = "managedGetCodeDeployer"
+	managedGetContractDeployInfoName         = "managedGetContractDeployInfo"
+	managedDeployFromCodeHashName            = "managedDeployFromCodeHash"
+	managedRegisterCodeName                  = "managedRegisterCode"
 )
 
 // ManagedSCAddress VMHooks implementation.
@@ -795,12 +811,66 @@ func (context *VMHooksImpl) ManagedDeployFromSourceContract(
 	argumentsHandle int32,
 	resultAddressHandle int32,
 	resultHandle int32,
+) int32 {
+	return context.managedDeployFromSourceContract(
+		managedDeployFromSourceContractName,
+		gas,
+		valueHandle,
+		addressHandle,
+		codeMetadataHandle,
+		argumentsHandle,
+		nil,
+		resultAddressHandle,
+		resultHandle,
+	)
+}
+
+// ManagedDeployFromSourceContractWithSalt VMHooks implementation.
+// @autogenerate(VMHooks)
+func (context *VMHooksImpl) ManagedDeployFromSourceContractWithSalt(
+	gas int64,
+	valueHandle int32,
+	addressHandle int32,
+	codeMetadataHandle int32,
+	argumentsHandle int32,
+	saltHandle int32,
+	resultAddressHandle int32,
+	resultHandle int32,
+) int32 {
+	salt, err := context.readContractAddressSalt(saltHandle)
+	if context.WithFault(err, context.GetRuntimeContext().BaseOpsErrorShouldFailExecution()) {
+		return 1
+	}
+
+	return context.managedDeployFromSourceContract(
+		managedDeployFromSourceWithSaltName,
+		gas,
+		valueHandle,
+		addressHandle,
+		codeMetadataHandle,
+		argumentsHandle,
+		salt,
+		resultAddressHandle,
+		resultHandle,
+	)
+}
+
+func (context *VMHooksImpl) managedDeployFromSourceContract(
+	tracedFunctionName string,
+	gas int64,
+	valueHandle int32,
+	addressHandle int32,
+	codeMetadataHandle int32,
+	argumentsHandle int32,
+	salt []byte,
+	resultAddressHandle int32,
+	resultHandle int32,
 ) int32 {
 	host := context.GetVMHost()
 	runtime := host.Runtime()
 	metering := host.Metering()
 	managedType := host.ManagedTypes()
-	metering.StartGasTracing(managedDeployFromSourceContractName)
+	metering.StartGasTracing(tracedFunctionName)
 
 	gasToUse := metering.GasSchedule().BaseOpsAPICost.CreateContract
 	err := metering.UseGasBounded(gasToUse)
@@ -820,13 +890,14 @@ func (context *VMHooksImpl) ManagedDeployFromSourceContract(
 
 	lenReturnData := len(host.Output().ReturnData())
 
-	newAddress, err := DeployFromSourceContractWithTypedArgs(
+	newAddress, err := deployFromSourceContract(
 		host,
 		vmInput.destination,
 		codeMetadata,
 		vmInput.value,
 		vmInput.arguments,
 		gas,
+		salt,
 	)
 	if WithFaultAndHost(host, err, runtime.BaseOpsErrorShouldFailExecution()) {
 		return 1
@@ -851,12 +922,66 @@ func (context *VMHooksImpl) ManagedCreateContract(
 	argumentsHandle int32,
 	resultAddressHandle int32,
 	resultHandle int32,
+) int32 {
+	return context.managedCreateContract(
+		managedCreateContractName,
+		gas,
+		valueHandle,
+		codeHandle,
+		codeMetadataHandle,
+		argumentsHandle,
+		nil,
+		resultAddressHandle,
+		resultHandle,
+	)
+}
+
+// ManagedCreateContractWithSalt VMHooks implementation.
+// @autogenerate(VMHooks)
+func (context *VMHooksImpl) ManagedCreateContractWithSalt(
+	gas int64,
+	valueHandle int32,
+	codeHandle int32,
+	codeMetadataHandle int32,
+	argumentsHandle int32,
+	saltHandle int32,
+	resultAddressHandle int32,
+	resultHandle int32,
+) int32 {
+	salt, err := context.readContractAddressSalt(saltHandle)
+	if context.WithFault(err, context.GetRuntimeContext().BaseOpsErrorShouldFailExecution()) {
+		return 1
+	}
+
+	return context.managedCreateContract(
+		managedCreateContractWithSaltName,
+		gas,
+		valueHandle,
+		codeHandle,
+		codeMetadataHandle,
+		argumentsHandle,
+		salt,
+		resultAddressHandle,
+		resultHandle,
+	)
+}
+
+func (context *VMHooksImpl) managedCreateContract(
+	tracedFunctionName string,
+	gas int64,
+	valueHandle int32,
+	codeHandle int32,
+	codeMetadataHandle int32,
+	argumentsHandle int32,
+	salt []byte,
+	resultAddressHandle int32,
+	resultHandle int32,
 ) int32 {
 	host := context.GetVMHost()
 	runtime := host.Runtime()
 	metering := host.Metering()
 	managedType := host.ManagedTypes()
-	metering.StartGasTracing(managedCreateContractName)
+	metering.StartGasTracing(tracedFunctionName)
 
 	gasToUse := metering.GasSchedule().BaseOpsAPICost.CreateContract
 	err := metering.UseGasBounded(gasToUse)
@@ -892,7 +1017,80 @@ func (context *VMHooksImpl) ManagedCreateContract(
 	}
 
 	lenReturnData := len(host.Output().ReturnData())
-	newAddress, err := createContract(sender, data, value, gas, code, codeMetadata, host, CreateContract)
+	newAddress, err := createContract(sender, data, value, gas, code, codeMetadata, salt, host, CreateContract)
+	if WithFaultAndHost(host, err, runtime.BaseOpsErrorShouldFailExecution()) {
+		return 1
+	}
+
+	managedType.SetBytes(resultAddressHandle, newAddress)
+	err = setReturnDataIfExists(host, lenReturnData, resultHandle)
+	if WithFaultAndHost(host, err, runtime.UseGasBoundedShouldFailExecution()) {
+		return 1
+	}
+
+	return 0
+}
+
+// ManagedDeployFromCodeHash VMHooks implementation.
+// @autogenerate(VMHooks)
+func (context *VMHooksImpl) ManagedDeployFromCodeHash(
+	gas int64,
+	valueHandle int32,
+	codeHashHandle int32,
+	codeMetadataHandle int32,
+	argumentsHandle int32,
+	resultAddressHandle int32,
+	resultHandle int32,
+) int32 {
+	host := context.GetVMHost()
+	runtime := host.Runtime()
+	metering := host.Metering()
+	managedType := host.ManagedTypes()
+	metering.StartGasTracing(managedDeployFromCodeHashName)
+
+	gasToUse := math.AddUint64(
+		metering.GasSchedule().BaseOpsAPICost.CreateContract,
+		metering.GasSchedule().BaseOperationCost.GetCode,
+	)
+	err := metering.UseGasBounded(gasToUse)
+	if context.WithFault(err, runtime.UseGasBoundedShouldFailExecution()) {
+		return -1
+	}
+
+	sender := runtime.GetContextAddress()
+	value, err := managedType.GetBigInt(valueHandle)
+	if WithFaultAndHost(host, err, runtime.BaseOpsErrorShouldFailExecution()) {
+		return 1
+	}
+
+	data, actualLen, err := managedType.ReadManagedVecOfManagedBuffers(argumentsHandle)
+	if WithFaultAndHost(host, err, runtime.BaseOpsErrorShouldFailExecution()) {
+		return 1
+	}
+
+	gasToUse = math.MulUint64(metering.GasSchedule().BaseOperationCost.DataCopyPerByte, actualLen)
+	err = metering.UseGasBounded(gasToUse)
+	if context.WithFault(err, runtime.UseGasBoundedShouldFailExecution()) {
+		return -1
+	}
+
+	codeMetadata, err := managedType.GetBytes(codeMetadataHandle)
+	if WithFaultAndHost(host, err, runtime.BaseOpsErrorShouldFailExecution()) {
+		return 1
+	}
+
+	codeHash, err := managedType.GetBytes(codeHashHandle)
+	if WithFaultAndHost(host, err, runtime.BaseOpsErrorShouldFailExecution()) {
+		return 1
+	}
+	if len(codeHash) != vmhost.HashLen {
+		WithFaultAndHost(host, vmhost.ErrCodeHashNotRegistered, runtime.BaseOpsErrorShouldFailExecution())
+		return 1
+	}
+
+	lenReturnData := len(host.Output().ReturnData())
+	code := vmhost.NewCodeReference(codeHash)
+	newAddress, err := createContract(sender, data, value, gas, code, codeMetadata, nil, host, DeployContract)
 	if WithFaultAndHost(host, err, runtime.BaseOpsErrorShouldFailExecution()) {
 		return 1
 	}
@@ -906,6 +1104,135 @@ func (context *VMHooksImpl) ManagedCreateContract(
 	return 0
 }
 
+// ManagedRegisterCode VMHooks implementation.
+// Adds the code of the given contract to the code registry, so that contracts can be deployed by reference to its hash.
+// The first registration of a code pays for storing it, per byte, along with the hash of the code under the hash of
+// its reference; registering it again only pays for reading it.
+// @autogenerate(VMHooks)
+func (context *VMHooksImpl) ManagedRegisterCode(sourceContractAddressHandle int32, resultCodeHashHandle int32) int32 {
+	host := context.GetVMHost()
+	runtime := host.Runtime()
+	metering := host.Metering()
+	storage := host.Storage()
+	managedType := host.ManagedTypes()
+	metering.StartGasTracing(managedRegisterCodeName)
+
+	gasToUse := metering.GasSchedule().BaseOperationCost.GetCode
+	err := metering.UseGasBounded(gasToUse)
+	if context.WithFault(err, runtime.UseGasBoundedShouldFailExecution()) {
+		return -1
+	}
+
+	if runtime.ReadOnly() {
+		context.WithFault(vmhost.ErrInvalidCallOnReadOnlyMode, runtime.BaseOpsErrorShouldFailExecution())
+		return -1
+	}
+
+	sourceContractAddress, err := managedType.GetBytes(sourceContractAddressHandle)
+	if context.WithFault(err, runtime.BaseOpsErrorShouldFailExecution()) {
+		return -1
+	}
+
+	code, err := host.Blockchain().GetCode(sourceContractAddress)
+	if context.WithFault(err, runtime.BaseOpsErrorShouldFailExecution()) {
+		return -1
+	}
+
+	codeHash := host.Hasher().Compute(string(code))
+	registeredCode, _, err := storage.GetRegisteredCode(codeHash)
+	if context.WithFault(err, runtime.BaseOpsErrorShouldFailExecution()) {
+		return -1
+	}
+
+	if len(registeredCode) == 0 {
+		gasToUse = math.MulUint64(metering.GasSchedule().BaseOperationCost.StorePerByte, uint64(len(code)))
+		err = metering.UseGasBounded(gasToUse)
+		if context.WithFault(err, runtime.UseGasBoundedShouldFailExecution()) {
+			return -1
+		}
+
+		key := append(storage.GetVmProtectedPrefix(vmhost.CodeRegistryKey), codeHash...)
+		_, err = storage.SetProtectedStorageToAddressUnmetered(core.SystemAccountAddress, key, code)
+		if context.WithFault(err, runtime.BaseOpsErrorShouldFailExecution()) {
+			return -1
+		}
+
+		gasToUse = math.MulUint64(metering.GasSchedule().BaseOperationCost.StorePerByte, uint64(len(codeHash)))
+		err = metering.UseGasBounded(gasToUse)
+		if context.WithFault(err, runtime.UseGasBoundedShouldFailExecution()) {
+			return -1
+		}
+
+		referenceCodeHash := host.Hasher().Compute(string(vmhost.NewCodeReference(codeHash)))
+		key = append(storage.GetVmProtectedPrefix(vmhost.CodeReferenceKey), referenceCodeHash...)
+		_, err = storage.SetProtectedStorageToAddressUnmetered(core.SystemAccountAddress, key, codeHash)
+		if context.WithFault(err, runtime.BaseOpsErrorShouldFailExecution()) {
+			return -1
+		}
+	}
+
+	managedType.SetBytes(resultCodeHashHandle, codeHash)
+	return 0
+}
+
+// ManagedComputeContractAddress VMHooks implementation.
+// @autogenerate(VMHooks)
+func (context *VMHooksImpl) ManagedComputeContractAddress(
+	creatorHandle int32,
+	saltHandle int32,
+	codeHashHandle int32,
+	resultHandle int32,
+) int32 {
+	host := context.GetVMHost()
+	runtime := host.Runtime()
+	metering := host.Metering()
+	managedType := host.ManagedTypes()
+
+	gasToUse := metering.GasSchedule().BaseOpsAPICost.ComputeContractAddress
+	err := metering.UseGasBoundedAndAddTracedGas(managedComputeContractAddressName, gasToUse)
+	if context.WithFault(err, runtime.UseGasBoundedShouldFailExecution()) {
+		return -1
+	}
+
+	creator, err := managedType.GetBytes(creatorHandle)
+	if context.WithFault(err, runtime.BaseOpsErrorShouldFailExecution()) {
+		return -1
+	}
+
+	salt, err := context.readContractAddressSalt(saltHandle)
+	if context.WithFault(err, runtime.BaseOpsErrorShouldFailExecution()) {
+		return -1
+	}
+
+	codeHash, err := managedType.GetBytes(codeHashHandle)
+	if context.WithFault(err, runtime.BaseOpsErrorShouldFailExecution()) {
+		return -1
+	}
+
+	address, err := host.Blockchain().NewSaltedAddress(creator, salt, codeHash)
+	if context.WithFault(err, runtime.BaseOpsErrorShouldFailExecution()) {
+		return -1
+	}
+
+	managedType.SetBytes(resultHandle, address)
+	return 0
+}
+
+// readContractAddressSalt reads a salt, which is never nil, so that the address is derived from it even when empty.
+func (context *VMHooksImpl) readContractAddressSalt(saltHandle int32) ([]byte, error) {
+	salt, err := context.GetManagedTypesContext().GetBytes(saltHandle)
+	if err != nil {
+		return nil, err
+	}
+	if len(salt) > vmhost.MaxContractAddressSaltLen {
+		return nil, vmhost.ErrInvalidContractAddressSalt
+	}
+	if salt == nil {
+		salt = make([]byte, 0)
+	}
+	return salt, nil
+}
+
 func setReturnDataIfExists(
 	host vmhost.VMHost,
 	oldLen int,
@@ -1319,6 +1646,108 @@ func ManagedGetCodeMetadataWithHost(host vmhost.VMHost, addressHandle int32, res
 	managedType.SetBytes(responseHandle, codeMetadata)
 }
 
+// ManagedGetCodeHash VMHooks implementation.
+// @autogenerate(VMHooks)
+func (context *VMHooksImpl) ManagedGetCodeHash(addressHandle int32, resultHandle int32) {
+	host := context.GetVMHost()
+	runtime := host.Runtime()
+	metering := host.Metering()
+	managedType := host.ManagedTypes()
+
+	gasToUse := metering.GasSchedule().BaseOpsAPICost.GetCodeHash
+	err := metering.UseGasBoundedAndAddTracedGas(managedGetCodeHashName, gasToUse)
+	if context.WithFault(err, runtime.BaseOpsErrorShouldFailExecution()) {
+		return
+	}
+
+	address, err := managedType.GetBytes(addressHandle)
+	if context.WithFault(err, runtime.BaseOpsErrorShouldFailExecution()) {
+		return
+	}
+
+	codeHash := host.Blockchain().GetCodeHash(address)
+	managedType.SetBytes(resultHandle, codeHash)
+}
+
+// ManagedGetCodeDeployer VMHooks implementation.
+// @autogenerate(VMHooks)
+func (context *VMHooksImpl) ManagedGetCodeDeployer(addressHandle int32, resultHandle int32) {
+	runtime := context.GetRuntimeContext()
+	metering := context.GetMeteringContext()
+	managedType := context.GetManagedTypesContext()
+
+	gasToUse := metering.GasSchedule().BaseOpsAPICost.GetCodeDeployer
+	err := metering.UseGasBoundedAndAddTracedGas(managedGetCodeDeployerName, gasToUse)
+	if context.WithFault(err, runtime.BaseOpsErrorShouldFailExecution()) {
+		return
+	}
+
+	deployInfo, err := context.loadDeployInfo(managedGetCodeDeployerName, addressHandle)
+	if context.WithFault(err, runtime.BaseOpsErrorShouldFailExecution()) {
+		return
+	}
+
+	var deployer []byte
+	if deployInfo != nil {
+		deployer = deployInfo.Deployer
+	}
+	managedType.SetBytes(resultHandle, deployer)
+}
+
+// ManagedGetContractDeployInfo VMHooks implementation.
+// @autogenerate(VMHooks)
+func (context *VMHooksImpl) ManagedGetContractDeployInfo(
+	addressHandle int32,
+	deployerHandle int32,
+	blockNonceHandle int32,
+	blockTimestampHandle int32,
+) int32 {
+	runtime := context.GetRuntimeContext()
+	metering := context.GetMeteringContext()
+	managedType := context.GetManagedTypesContext()
+
+	gasToUse := metering.GasSchedule().BaseOpsAPICost.GetContractDeployInfo
+	err := metering.UseGasBoundedAndAddTracedGas(managedGetContractDeployInfoName, gasToUse)
+	if context.WithFault(err, runtime.BaseOpsErrorShouldFailExecution()) {
+		return -1
+	}
+
+	deployInfo, err := context.loadDeployInfo(managedGetContractDeployInfoName, addressHandle)
+	if context.WithFault(err, runtime.BaseOpsErrorShouldFailExecution()) {
+		return -1
+	}
+	if deployInfo == nil {
+		managedType.SetBytes(deployerHandle, nil)
+		managedType.GetBigIntOrCreate(blockNonceHandle).SetUint64(0)
+		managedType.GetBigIntOrCreate(blockTimestampHandle).SetUint64(0)
+		return 0
+	}
+
+	managedType.SetBytes(deployerHandle, deployInfo.Deployer)
+	managedType.GetBigIntOrCreate(blockNonceHandle).SetUint64(deployInfo.BlockNonce)
+	managedType.GetBigIntOrCreate(blockTimestampHandle).SetUint64(deployInfo.BlockTimestamp)
+	return 1
+}
+
+// loadDeployInfo reads the deploy info of the contract at the given address, metered as a storage load.
+func (context *VMHooksImpl) loadDeployInfo(tracedFunctionName string, addressHandle int32) (*vmhost.DeployInfo, error) {
+	storage := context.GetStorageContext()
+	metering := context.GetMeteringContext()
+
+	address, err := context.GetManagedTypesContext().GetBytes(addressHandle)
+	if err != nil {
+		return nil, err
+	}
+
+	deployInfo, trieDepth, usedCache, err := storage.GetDeployInfo(address)
+	if err != nil {
+		return nil, err
+	}
+
+	err = storage.UseGasForStorageLoad(tracedFunctionName, int64(trieDepth), metering.GasSchedule().BaseOpsAPICost.StorageLoad, usedCache)
+	return deployInfo, err
+}
+
 // ManagedIsBuiltinFunction VMHooks implementation.
 // @autogenerate(VMHooks)
 func (context *VMHooksImpl) ManagedIsBuiltinFunction(functionNameHandle int32) int32 {
@@ -1350,3 +1779,318 @@ func ManagedIsBuiltinFunctionWithHost(host vmhost.VMHost, functionNameHandle int
 
 	return 0
 }
+
+// ManagedEVMAbiEncode VMHooks implementation.
+// The types are a comma-separated list of Solidity types and the values a managed vec of managed buffers,
+// arrays and tuples being themselves managed vecs of their items.
+// @autogenerate(VMHooks)
+func (context *VMHooksImpl) ManagedEVMAbiEncode(typesHandle int32, valuesHandle int32, resultHandle int32) int32 {
+	managedType := context.GetManagedTypesContext()
+	runtime := context.GetRuntimeContext()
+	metering := context.GetMeteringContext()
+
+	gasToUse := metering.GasSchedule().ManagedBufferAPICost.ManagedEVMAbiEncode
+	err := metering.UseGasBoundedAndAddTracedGas(managedEVMAbiEncodeName, gasToUse)
+	if context.WithFault(err, runtime.ManagedBufferAPIErrorShouldFailExecution()) {
+		return -1
+	}
+
+	types, err := readEVMAbiTypes(managedType, typesHandle)
+	if context.WithFault(err, runtime.ManagedBufferAPIErrorShouldFailExecution()) {
+		return -1
+	}
+
+	values, err := readEVMAbiValues(managedType, types, valuesHandle)
+	if context.WithFault(err, runtime.ManagedBufferAPIErrorShouldFailExecution()) {
+		return -1
+	}
+
+	encoded, err := evmabi.Encode(types, values)
+	if context.WithFault(err, runtime.ManagedBufferAPIErrorShouldFailExecution()) {
+		return -1
+	}
+
+	err = managedType.ConsumeGasForBytes(encoded)
+	if context.WithFault(err, runtime.ManagedBufferAPIErrorShouldFailExecution()) {
+		return -1
+	}
+
+	managedType.SetBytes(resultHandle, encoded)
+	return 0
+}
+
+// ManagedEVMAbiDecode VMHooks implementation.
+// The decoded values are written in the format expected by ManagedEVMAbiEncode: unsigned integers
+// without leading zeros, signed integers in the shortest two's complement, booleans as 0x01 or empty.
+// @autogenerate(VMHooks)
+func (context *VMHooksImpl) ManagedEVMAbiDecode(typesHandle int32, dataHandle int32, resultHandle int32) int32 {
+	managedType := context.GetManagedTypesContext()
+	runtime := context.GetRuntimeContext()
+	metering := context.GetMeteringContext()
+
+	gasToUse := metering.GasSchedule().ManagedBufferAPICost.ManagedEVMAbiDecode
+	err := metering.UseGasBoundedAndAddTracedGas(managedEVMAbiDecodeName, gasToUse)
+	if context.WithFault(err, runtime.ManagedBufferAPIErrorShouldFailExecution()) {
+		return -1
+	}
+
+	types, err := readEVMAbiTypes(managedType, typesHandle)
+	if context.WithFault(err, runtime.ManagedBufferAPIErrorShouldFailExecution()) {
+		return -1
+	}
+
+	data, err := managedType.GetBytes(dataHandle)
+	if context.WithFault(err, runtime.ManagedBufferAPIErrorShouldFailExecution()) {
+		return -1
+	}
+	err = managedType.ConsumeGasForBytes(data)
+	if context.WithFault(err, runtime.ManagedBufferAPIErrorShouldFailExecution()) {
+		return -1
+	}
+
+	values, err := evmabi.Decode(types, data)
+	if context.WithFault(err, runtime.ManagedBufferAPIErrorShouldFailExecution()) {
+		return -1
+	}
+
+	writtenBytes := writeEVMAbiValues(managedType, values, resultHandle)
+	gasToUse = math.MulUint64(writtenBytes, metering.GasSchedule().BaseOperationCost.DataCopyPerByte)
+	err = metering.UseGasBounded(gasToUse)
+	if context.WithFault(err, runtime.ManagedBufferAPIErrorShouldFailExecution()) {
+		return -1
+	}
+
+	return 0
+}
+
+// ManagedSetUpgradePolicy VMHooks implementation.
+// The approvers are given as the concatenation of their addresses. The policy
+// cannot change while an upgrade is pending.
+// @autogenerate(VMHooks)
+func (context *VMHooksImpl) ManagedSetUpgradePolicy(delayRounds int64, threshold int32, approversHandle int32) int32 {
+	managedType := context.GetManagedTypesContext()
+	runtime := context.GetRuntimeContext()
+	metering := context.GetMeteringContext()
+	storage := context.GetStorageContext()
+
+	gasToUse := metering.GasSchedule().BaseOpsAPICost.SetUpgradePolicy
+	err := metering.UseGasBoundedAndAddTracedGas(managedSetUpgradePolicyName, gasToUse)
+	if context.WithFault(err, runtime.BaseOpsErrorShouldFailExecution()) {
+		return -1
+	}
+
+	if delayRounds < 0 || threshold < 0 {
+		context.WithFault(vmhost.ErrInvalidUpgradePolicy, runtime.BaseOpsErrorShouldFailExecution())
+		return -1
+	}
+
+	approvers, err := managedType.GetBytes(approversHandle)
+	if context.WithFault(err, runtime.BaseOpsErrorShouldFailExecution()) {
+		return -1
+	}
+	err = managedType.ConsumeGasForBytes(approvers)
+	if context.WithFault(err, runtime.BaseOpsErrorShouldFailExecution()) {
+		return -1
+	}
+
+	policy, err := vmhost.NewUpgradePolicy(uint64(delayRounds), uint32(threshold), approvers)
+	if context.WithFault(err, runtime.BaseOpsErrorShouldFailExecution()) {
+		return -1
+	}
+
+	pending, err := context.loadPendingUpgrade(managedSetUpgradePolicyName)
+	if context.WithFault(err, runtime.BaseOpsErrorShouldFailExecution()) {
+		return -1
+	}
+	if pending != nil {
+		context.WithFault(vmhost.ErrPendingUpgradeExists, runtime.BaseOpsErrorShouldFailExecution())
+		return -1
+	}
+
+	_, err = storage.SetProtectedStorage(storage.GetVmProtectedPrefix(vmhost.UpgradePolicyKey), policy.Serialize())
+	if context.WithFault(err, runtime.BaseOpsErrorShouldFailExecution()) {
+		return -1
+	}
+
+	return 0
+}
+
+// ManagedProposeUpgrade VMHooks implementation.
+// The proposal replaces any pending upgrade, together with its approvals, and
+// can be applied once the delay of the upgrade policy has passed. Contracts
+// requiring timelocked upgrades cannot propose without a policy with a delay.
+// @autogenerate(VMHooks)
+func (context *VMHooksImpl) ManagedProposeUpgrade(codeHashHandle int32, codeMetadataHandle int32) int32 {
+	managedType := context.GetManagedTypesContext()
+	runtime := context.GetRuntimeContext()
+	metering := context.GetMeteringContext()
+	storage := context.GetStorageContext()
+	blockchain := context.GetBlockchainContext()
+
+	gasToUse := metering.GasSchedule().BaseOpsAPICost.ProposeUpgrade
+	err := metering.UseGasBoundedAndAddTracedGas(managedProposeUpgradeName, gasToUse)
+	if context.WithFault(err, runtime.BaseOpsErrorShouldFailExecution()) {
+		return -1
+	}
+
+	codeHash, err := managedType.GetBytes(codeHashHandle)
+	if context.WithFault(err, runtime.BaseOpsErrorShouldFailExecution()) {
+		return -1
+	}
+	codeMetadata, err := managedType.GetBytes(codeMetadataHandle)
+	if context.WithFault(err, runtime.BaseOpsErrorShouldFailExecution()) {
+		return -1
+	}
+	if len(codeHash) != vmhost.HashLen || len(codeMetadata) != vmhost.CodeMetadataLen {
+		context.WithFault(vmhost.ErrInvalidArgument, runtime.BaseOpsErrorShouldFailExecution())
+		return -1
+	}
+
+	policy, err := context.loadUpgradePolicy(managedProposeUpgradeName)
+	if context.WithFault(err, runtime.BaseOpsErrorShouldFailExecution()) {
+		return -1
+	}
+
+	conditions, err := context.loadUpgradeConditions(managedProposeUpgradeName)
+	if context.WithFault(err, runtime.BaseOpsErrorShouldFailExecution()) {
+		return -1
+	}
+	if conditions.Timelocked && policy.DelayRounds == 0 {
+		context.WithFault(vmhost.ErrUpgradeTimelockWithoutDelay, runtime.BaseOpsErrorShouldFailExecution())
+		return -1
+	}
+
+	pending := &vmhost.PendingUpgrade{
+		CodeHash:        codeHash,
+		CodeMetadata:    codeMetadata,
+		ActivationRound: math.AddUint64(blockchain.CurrentRound(), policy.DelayRounds),
+	}
+	_, err = storage.SetProtectedStorage(storage.GetVmProtectedPrefix(vmhost.PendingUpgradeKey), pending.Serialize())
+	if context.WithFault(err, runtime.BaseOpsErrorShouldFailExecution()) {
+		return -1
+	}
+
+	return 0
+}
+
+// ManagedApproveUpgrade VMHooks implementation.
+// The approval is given by the caller of the contract, which must be an approver
+// of the upgrade policy. Returns the number of approvals of the pending upgrade.
+// @autogenerate(VMHooks)
+func (context *VMHooksImpl) ManagedApproveUpgrade() int32 {
+	runtime := context.GetRuntimeContext()
+	metering := context.GetMeteringContext()
+	storage := context.GetStorageContext()
+
+	gasToUse := metering.GasSchedule().BaseOpsAPICost.ApproveUpgrade
+	err := metering.UseGasBoundedAndAddTracedGas(managedApproveUpgradeName, gasToUse)
+	if context.WithFault(err, runtime.BaseOpsErrorShouldFailExecution()) {
+		return -1
+	}
+
+	policy, err := context.loadUpgradePolicy(managedApproveUpgradeName)
+	if context.WithFault(err, runtime.BaseOpsErrorShouldFailExecution()) {
+		return -1
+	}
+	approver := runtime.GetVMInput().CallerAddr
+	if !policy.IsApprover(approver) {
+		context.WithFault(vmhost.ErrUpgradeApproverNotAllowed, runtime.BaseOpsErrorShouldFailExecution())
+		return -1
+	}
+
+	pending, err := context.loadPendingUpgrade(managedApproveUpgradeName)
+	if context.WithFault(err, runtime.BaseOpsErrorShouldFailExecution()) {
+		return -1
+	}
+	if pending == nil {
+		context.WithFault(vmhost.ErrNoPendingUpgrade, runtime.BaseOpsErrorShouldFailExecution())
+		return -1
+	}
+	if pending.IsApprovedBy(approver) {
+		return int32(len(pending.Approvals))
+	}
+
+	pending.Approvals = append(pending.Approvals, approver)
+	_, err = storage.SetProtectedStorage(storage.GetVmProtectedPrefix(vmhost.PendingUpgradeKey), pending.Serialize())
+	if context.WithFault(err, runtime.BaseOpsErrorShouldFailExecution()) {
+		return -1
+	}
+
+	return int32(len(pending.Approvals))
+}
+
+// ManagedCancelUpgrade VMHooks implementation.
+// @autogenerate(VMHooks)
+func (context *VMHooksImpl) ManagedCancelUpgrade() int32 {
+	runtime := context.GetRuntimeContext()
+	metering := context.GetMeteringContext()
+	storage := context.GetStorageContext()
+
+	gasToUse := metering.GasSchedule().BaseOpsAPICost.CancelUpgrade
+	err := metering.UseGasBoundedAndAddTracedGas(managedCancelUpgradeName, gasToUse)
+	if context.WithFault(err, runtime.BaseOpsErrorShouldFailExecution()) {
+		return -1
+	}
+
+	pending, err := context.loadPendingUpgrade(managedCancelUpgradeName)
+	if context.WithFault(err, runtime.BaseOpsErrorShouldFailExecution()) {
+		return -1
+	}
+	if pending == nil {
+		context.WithFault(vmhost.ErrNoPendingUpgrade, runtime.BaseOpsErrorShouldFailExecution())
+		return -1
+	}
+
+	_, err = storage.SetProtectedStorage(storage.GetVmProtectedPrefix(vmhost.PendingUpgradeKey), nil)
+	if context.WithFault(err, runtime.BaseOpsErrorShouldFailExecution()) {
+		return -1
+	}
+
+	return 0
+}
+
+func (context *VMHooksImpl) loadUpgradePolicy(tracedFunctionName string) (*vmhost.UpgradePolicy, error) {
+	storage := context.GetStorageContext()
+	metering := context.GetMeteringContext()
+
+	policy, trieDepth, usedCache, err := storage.GetUpgradePolicy(context.GetRuntimeContext().GetContextAddress())
+	if err != nil {
+		return nil, err
+	}
+
+	err = storage.UseGasForStorageLoad(tracedFunctionName, int64(trieDepth), metering.GasSchedule().BaseOpsAPICost.StorageLoad, usedCache)
+	return policy, err
+}
+
+func (context *VMHooksImpl) loadUpgradeConditions(tracedFunctionName string) (vmhost.UpgradeConditions, error) {
+	storage := context.GetStorageContext()
+	metering := context.GetMeteringContext()
+	address := context.GetRuntimeContext().GetContextAddress()
+
+	contract, err := context.GetBlockchainContext().GetUserAccount(address)
+	if err != nil {
+		return vmhost.UpgradeConditions{}, err
+	}
+
+	recordedConditions, trieDepth, usedCache, err := storage.GetUpgradeConditions(address)
+	if err != nil {
+		return vmhost.UpgradeConditions{}, err
+	}
+
+	err = storage.UseGasForStorageLoad(tracedFunctionName, int64(trieDepth), metering.GasSchedule().BaseOpsAPICost.StorageLoad, usedCache)
+	conditions := vmhost.UpgradeConditionsFromCodeMetadata(contract.GetCodeMetadata()).Union(recordedConditions)
+	return conditions, err
+}
+
+func (context *VMHooksImpl) loadPendingUpgrade(tracedFunctionName string) (*vmhost.PendingUpgrade, error) {
+	storage := context.GetStorageContext()
+	metering := context.GetMeteringContext()
+
+	pending, trieDepth, usedCache, err := storage.GetPendingUpgrade(context.GetRuntimeContext().GetContextAddress())
+	if err != nil {
+		return nil, err
+	}
+
+	err = storage.UseGasForStorageLoad(tracedFunctionName, int64(trieDepth), metering.GasSchedule().BaseOpsAPICost.StorageLoad, usedCache)
+	return pending, err
+}
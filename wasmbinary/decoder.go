@@ -0,0 +1,126 @@
+package wasmbinary
+
+import "fmt"
+
+const blockTypeEmpty = 0x40
+
+// Instruction is a decoded WASM instruction of a function body, with the immediates needed to follow its control flow.
+type Instruction struct {
+	Spec    *InstructionSpec
+	Offset  int
+	Index   uint32
+	Targets []uint32
+}
+
+// UnsupportedOpcodeError signals an opcode missing from Instructions and MiscInstructions, such as the SIMD ones.
+type UnsupportedOpcodeError struct {
+	Opcode uint32
+	Offset int
+}
+
+// Error returns the description of the unsupported opcode.
+func (err *UnsupportedOpcodeError) Error() string {
+	return fmt.Sprintf("unsupported opcode 0x%02x at offset 0x%x", err.Opcode, err.Offset)
+}
+
+// DecodeFunctionBody splits the code of a function into instructions.
+func DecodeFunctionBody(body *FunctionBody) ([]*Instruction, error) {
+	reader := &wasmReader{
+		data:       body.Code,
+		baseOffset: body.Offset,
+	}
+
+	decoded := make([]*Instruction, 0)
+	for !reader.isAtEnd() {
+		instruction := &Instruction{
+			Offset: reader.absoluteOffset(),
+		}
+		opcode, err := reader.readByte()
+		if err != nil {
+			return nil, err
+		}
+
+		spec, ok := Instructions[opcode]
+		unsupportedOpcode := uint32(opcode)
+		if opcode == PrefixMisc {
+			var miscOpcode uint32
+			miscOpcode, err = reader.readU32()
+			if err != nil {
+				return nil, err
+			}
+			spec, ok = MiscInstructions[byte(miscOpcode)]
+			ok = ok && miscOpcode <= 0xff
+			unsupportedOpcode = miscOpcode
+		}
+		if !ok {
+			return nil, &UnsupportedOpcodeError{Opcode: unsupportedOpcode, Offset: instruction.Offset}
+		}
+		instruction.Spec = spec
+
+		err = readImmediates(reader, instruction)
+		if err != nil {
+			return nil, fmt.Errorf("opcode %s at offset 0x%x: %w", spec.Name, instruction.Offset, err)
+		}
+		decoded = append(decoded, instruction)
+	}
+
+	return decoded, nil
+}
+
+func readImmediates(reader *wasmReader, instruction *Instruction) error {
+	var err error
+	switch instruction.Spec.Immediates {
+	case ImmIndex:
+		instruction.Index, err = reader.readU32()
+	case ImmTwoIndices, ImmMemArg:
+		instruction.Index, err = reader.readU32()
+		if err == nil {
+			_, err = reader.readU32()
+		}
+	case ImmBlockType:
+		err = skipBlockType(reader)
+	case ImmBrTable:
+		instruction.Targets, err = readBrTableTargets(reader)
+	case ImmSigned:
+		err = reader.skipSigned()
+	case ImmF32:
+		_, err = reader.readBytes(4)
+	case ImmF64:
+		_, err = reader.readBytes(8)
+	case ImmValueTypes:
+		_, err = reader.readValueTypes()
+	}
+	return err
+}
+
+// skipBlockType skips the empty block type, a value type, or a type index encoded as a signed LEB128.
+func skipBlockType(reader *wasmReader) error {
+	if reader.isAtEnd() {
+		return ErrUnexpectedEnd
+	}
+	if reader.data[reader.position] == blockTypeEmpty {
+		reader.position++
+		return nil
+	}
+	return reader.skipSigned()
+}
+
+// readBrTableTargets reads the label depths of a br_table, the default one being the last.
+func readBrTableTargets(reader *wasmReader) ([]uint32, error) {
+	numTargets, err := reader.readU32()
+	if err != nil {
+		return nil, err
+	}
+	if int(numTargets) > len(reader.data)-reader.position {
+		return nil, ErrUnexpectedEnd
+	}
+
+	targets := make([]uint32, numTargets+1)
+	for i := range targets {
+		targets[i], err = reader.readU32()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return targets, nil
+}
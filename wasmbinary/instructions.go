@@ -0,0 +1,246 @@
+package wasmbinary
+
+// ImmediateKind describes the immediate arguments encoded after an opcode.
+type ImmediateKind int
+
+const (
+	// ImmNone means that the opcode has no immediates
+	ImmNone ImmediateKind = iota
+	// ImmIndex is a function, local, global, label or type index
+	ImmIndex
+	// ImmTwoIndices is a pair of indices, such as the type and the table of call_indirect
+	ImmTwoIndices
+	// ImmBlockType is the type of a block, loop or if
+	ImmBlockType
+	// ImmBrTable is the list of labels of a br_table
+	ImmBrTable
+	// ImmMemArg is the alignment and the offset of a memory access
+	ImmMemArg
+	// ImmSigned is a signed LEB128 constant
+	ImmSigned
+	// ImmF32 is a 32 bits float constant
+	ImmF32
+	// ImmF64 is a 64 bits float constant
+	ImmF64
+	// ImmValueTypes is a list of value types, as for the typed select
+	ImmValueTypes
+)
+
+// PrefixMisc is the prefix of the saturating truncation, bulk memory and table opcodes
+const PrefixMisc = 0xfc
+
+// InstructionSpec describes a WASM instruction: its name in the gas schedule, which is also the
+// name of its field in executor.WASMOpcodeCost and in wasmer2.OpcodeCost.
+type InstructionSpec struct {
+	Name       string
+	Immediates ImmediateKind
+}
+
+// Instructions lists the single byte opcodes
+var Instructions = map[byte]*InstructionSpec{
+	0x00: {Name: "Unreachable", Immediates: ImmNone},
+	0x01: {Name: "Nop", Immediates: ImmNone},
+	0x02: {Name: "Block", Immediates: ImmBlockType},
+	0x03: {Name: "Loop", Immediates: ImmBlockType},
+	0x04: {Name: "If", Immediates: ImmBlockType},
+	0x05: {Name: "Else", Immediates: ImmNone},
+	0x0b: {Name: "End", Immediates: ImmNone},
+	0x0c: {Name: "Br", Immediates: ImmIndex},
+	0x0d: {Name: "BrIf", Immediates: ImmIndex},
+	0x0e: {Name: "BrTable", Immediates: ImmBrTable},
+	0x0f: {Name: "Return", Immediates: ImmNone},
+	0x10: {Name: "Call", Immediates: ImmIndex},
+	0x11: {Name: "CallIndirect", Immediates: ImmTwoIndices},
+	0x1a: {Name: "Drop", Immediates: ImmNone},
+	0x1b: {Name: "Select", Immediates: ImmNone},
+	0x1c: {Name: "TypedSelect", Immediates: ImmValueTypes},
+	0x20: {Name: "LocalGet", Immediates: ImmIndex},
+	0x21: {Name: "LocalSet", Immediates: ImmIndex},
+	0x22: {Name: "LocalTee", Immediates: ImmIndex},
+	0x23: {Name: "GlobalGet", Immediates: ImmIndex},
+	0x24: {Name: "GlobalSet", Immediates: ImmIndex},
+	0x25: {Name: "TableGet", Immediates: ImmIndex},
+	0x26: {Name: "TableSet", Immediates: ImmIndex},
+	0x28: {Name: "I32Load", Immediates: ImmMemArg},
+	0x29: {Name: "I64Load", Immediates: ImmMemArg},
+	0x2a: {Name: "F32Load", Immediates: ImmMemArg},
+	0x2b: {Name: "F64Load", Immediates: ImmMemArg},
+	0x2c: {Name: "I32Load8S", Immediates: ImmMemArg},
+	0x2d: {Name: "I32Load8U", Immediates: ImmMemArg},
+	0x2e: {Name: "I32Load16S", Immediates: ImmMemArg},
+	0x2f: {Name: "I32Load16U", Immediates: ImmMemArg},
+	0x30: {Name: "I64Load8S", Immediates: ImmMemArg},
+	0x31: {Name: "I64Load8U", Immediates: ImmMemArg},
+	0x32: {Name: "I64Load16S", Immediates: ImmMemArg},
+	0x33: {Name: "I64Load16U", Immediates: ImmMemArg},
+	0x34: {Name: "I64Load32S", Immediates: ImmMemArg},
+	0x35: {Name: "I64Load32U", Immediates: ImmMemArg},
+	0x36: {Name: "I32Store", Immediates: ImmMemArg},
+	0x37: {Name: "I64Store", Immediates: ImmMemArg},
+	0x38: {Name: "F32Store", Immediates: ImmMemArg},
+	0x39: {Name: "F64Store", Immediates: ImmMemArg},
+	0x3a: {Name: "I32Store8", Immediates: ImmMemArg},
+	0x3b: {Name: "I32Store16", Immediates: ImmMemArg},
+	0x3c: {Name: "I64Store8", Immediates: ImmMemArg},
+	0x3d: {Name: "I64Store16", Immediates: ImmMemArg},
+	0x3e: {Name: "I64Store32", Immediates: ImmMemArg},
+	0x3f: {Name: "MemorySize", Immediates: ImmIndex},
+	0x40: {Name: "MemoryGrow", Immediates: ImmIndex},
+	0x41: {Name: "I32Const", Immediates: ImmSigned},
+	0x42: {Name: "I64Const", Immediates: ImmSigned},
+	0x43: {Name: "F32Const", Immediates: ImmF32},
+	0x44: {Name: "F64Const", Immediates: ImmF64},
+	0x45: {Name: "I32Eqz", Immediates: ImmNone},
+	0x46: {Name: "I32Eq", Immediates: ImmNone},
+	0x47: {Name: "I32Ne", Immediates: ImmNone},
+	0x48: {Name: "I32LtS", Immediates: ImmNone},
+	0x49: {Name: "I32LtU", Immediates: ImmNone},
+	0x4a: {Name: "I32GtS", Immediates: ImmNone},
+	0x4b: {Name: "I32GtU", Immediates: ImmNone},
+	0x4c: {Name: "I32LeS", Immediates: ImmNone},
+	0x4d: {Name: "I32LeU", Immediates: ImmNone},
+	0x4e: {Name: "I32GeS", Immediates: ImmNone},
+	0x4f: {Name: "I32GeU", Immediates: ImmNone},
+	0x50: {Name: "I64Eqz", Immediates: ImmNone},
+	0x51: {Name: "I64Eq", Immediates: ImmNone},
+	0x52: {Name: "I64Ne", Immediates: ImmNone},
+	0x53: {Name: "I64LtS", Immediates: ImmNone},
+	0x54: {Name: "I64LtU", Immediates: ImmNone},
+	0x55: {Name: "I64GtS", Immediates: ImmNone},
+	0x56: {Name: "I64GtU", Immediates: ImmNone},
+	0x57: {Name: "I64LeS", Immediates: ImmNone},
+	0x58: {Name: "I64LeU", Immediates: ImmNone},
+	0x59: {Name: "I64GeS", Immediates: ImmNone},
+	0x5a: {Name: "I64GeU", Immediates: ImmNone},
+	0x5b: {Name: "F32Eq", Immediates: ImmNone},
+	0x5c: {Name: "F32Ne", Immediates: ImmNone},
+	0x5d: {Name: "F32Lt", Immediates: ImmNone},
+	0x5e: {Name: "F32Gt", Immediates: ImmNone},
+	0x5f: {Name: "F32Le", Immediates: ImmNone},
+	0x60: {Name: "F32Ge", Immediates: ImmNone},
+	0x61: {Name: "F64Eq", Immediates: ImmNone},
+	0x62: {Name: "F64Ne", Immediates: ImmNone},
+	0x63: {Name: "F64Lt", Immediates: ImmNone},
+	0x64: {Name: "F64Gt", Immediates: ImmNone},
+	0x65: {Name: "F64Le", Immediates: ImmNone},
+	0x66: {Name: "F64Ge", Immediates: ImmNone},
+	0x67: {Name: "I32Clz", Immediates: ImmNone},
+	0x68: {Name: "I32Ctz", Immediates: ImmNone},
+	0x69: {Name: "I32Popcnt", Immediates: ImmNone},
+	0x6a: {Name: "I32Add", Immediates: ImmNone},
+	0x6b: {Name: "I32Sub", Immediates: ImmNone},
+	0x6c: {Name: "I32Mul", Immediates: ImmNone},
+	0x6d: {Name: "I32DivS", Immediates: ImmNone},
+	0x6e: {Name: "I32DivU", Immediates: ImmNone},
+	0x6f: {Name: "I32RemS", Immediates: ImmNone},
+	0x70: {Name: "I32RemU", Immediates: ImmNone},
+	0x71: {Name: "I32And", Immediates: ImmNone},
+	0x72: {Name: "I32Or", Immediates: ImmNone},
+	0x73: {Name: "I32Xor", Immediates: ImmNone},
+	0x74: {Name: "I32Shl", Immediates: ImmNone},
+	0x75: {Name: "I32ShrS", Immediates: ImmNone},
+	0x76: {Name: "I32ShrU", Immediates: ImmNone},
+	0x77: {Name: "I32Rotl", Immediates: ImmNone},
+	0x78: {Name: "I32Rotr", Immediates: ImmNone},
+	0x79: {Name: "I64Clz", Immediates: ImmNone},
+	0x7a: {Name: "I64Ctz", Immediates: ImmNone},
+	0x7b: {Name: "I64Popcnt", Immediates: ImmNone},
+	0x7c: {Name: "I64Add", Immediates: ImmNone},
+	0x7d: {Name: "I64Sub", Immediates: ImmNone},
+	0x7e: {Name: "I64Mul", Immediates: ImmNone},
+	0x7f: {Name: "I64DivS", Immediates: ImmNone},
+	0x80: {Name: "I64DivU", Immediates: ImmNone},
+	0x81: {Name: "I64RemS", Immediates: ImmNone},
+	0x82: {Name: "I64RemU", Immediates: ImmNone},
+	0x83: {Name: "I64And", Immediates: ImmNone},
+	0x84: {Name: "I64Or", Immediates: ImmNone},
+	0x85: {Name: "I64Xor", Immediates: ImmNone},
+	0x86: {Name: "I64Shl", Immediates: ImmNone},
+	0x87: {Name: "I64ShrS", Immediates: ImmNone},
+	0x88: {Name: "I64ShrU", Immediates: ImmNone},
+	0x89: {Name: "I64Rotl", Immediates: ImmNone},
+	0x8a: {Name: "I64Rotr", Immediates: ImmNone},
+	0x8b: {Name: "F32Abs", Immediates: ImmNone},
+	0x8c: {Name: "F32Neg", Immediates: ImmNone},
+	0x8d: {Name: "F32Ceil", Immediates: ImmNone},
+	0x8e: {Name: "F32Floor", Immediates: ImmNone},
+	0x8f: {Name: "F32Trunc", Immediates: ImmNone},
+	0x90: {Name: "F32Nearest", Immediates: ImmNone},
+	0x91: {Name: "F32Sqrt", Immediates: ImmNone},
+	0x92: {Name: "F32Add", Immediates: ImmNone},
+	0x93: {Name: "F32Sub", Immediates: ImmNone},
+	0x94: {Name: "F32Mul", Immediates: ImmNone},
+	0x95: {Name: "F32Div", Immediates: ImmNone},
+	0x96: {Name: "F32Min", Immediates: ImmNone},
+	0x97: {Name: "F32Max", Immediates: ImmNone},
+	0x98: {Name: "F32Copysign", Immediates: ImmNone},
+	0x99: {Name: "F64Abs", Immediates: ImmNone},
+	0x9a: {Name: "F64Neg", Immediates: ImmNone},
+	0x9b: {Name: "F64Ceil", Immediates: ImmNone},
+	0x9c: {Name: "F64Floor", Immediates: ImmNone},
+	0x9d: {Name: "F64Trunc", Immediates: ImmNone},
+	0x9e: {Name: "F64Nearest", Immediates: ImmNone},
+	0x9f: {Name: "F64Sqrt", Immediates: ImmNone},
+	0xa0: {Name: "F64Add", Immediates: ImmNone},
+	0xa1: {Name: "F64Sub", Immediates: ImmNone},
+	0xa2: {Name: "F64Mul", Immediates: ImmNone},
+	0xa3: {Name: "F64Div", Immediates: ImmNone},
+	0xa4: {Name: "F64Min", Immediates: ImmNone},
+	0xa5: {Name: "F64Max", Immediates: ImmNone},
+	0xa6: {Name: "F64Copysign", Immediates: ImmNone},
+	0xa7: {Name: "I32WrapI64", Immediates: ImmNone},
+	0xa8: {Name: "I32TruncF32S", Immediates: ImmNone},
+	0xa9: {Name: "I32TruncF32U", Immediates: ImmNone},
+	0xaa: {Name: "I32TruncF64S", Immediates: ImmNone},
+	0xab: {Name: "I32TruncF64U", Immediates: ImmNone},
+	0xac: {Name: "I64ExtendI32S", Immediates: ImmNone},
+	0xad: {Name: "I64ExtendI32U", Immediates: ImmNone},
+	0xae: {Name: "I64TruncF32S", Immediates: ImmNone},
+	0xaf: {Name: "I64TruncF32U", Immediates: ImmNone},
+	0xb0: {Name: "I64TruncF64S", Immediates: ImmNone},
+	0xb1: {Name: "I64TruncF64U", Immediates: ImmNone},
+	0xb2: {Name: "F32ConvertI32S", Immediates: ImmNone},
+	0xb3: {Name: "F32ConvertI32U", Immediates: ImmNone},
+	0xb4: {Name: "F32ConvertI64S", Immediates: ImmNone},
+	0xb5: {Name: "F32ConvertI64U", Immediates: ImmNone},
+	0xb6: {Name: "F32DemoteF64", Immediates: ImmNone},
+	0xb7: {Name: "F64ConvertI32S", Immediates: ImmNone},
+	0xb8: {Name: "F64ConvertI32U", Immediates: ImmNone},
+	0xb9: {Name: "F64ConvertI64S", Immediates: ImmNone},
+	0xba: {Name: "F64ConvertI64U", Immediates: ImmNone},
+	0xbb: {Name: "F64PromoteF32", Immediates: ImmNone},
+	0xbc: {Name: "I32ReinterpretF32", Immediates: ImmNone},
+	0xbd: {Name: "I64ReinterpretF64", Immediates: ImmNone},
+	0xbe: {Name: "F32ReinterpretI32", Immediates: ImmNone},
+	0xbf: {Name: "F64ReinterpretI64", Immediates: ImmNone},
+	0xc0: {Name: "I32Extend8S", Immediates: ImmNone},
+	0xc1: {Name: "I32Extend16S", Immediates: ImmNone},
+	0xc2: {Name: "I64Extend8S", Immediates: ImmNone},
+	0xc3: {Name: "I64Extend16S", Immediates: ImmNone},
+	0xc4: {Name: "I64Extend32S", Immediates: ImmNone},
+	0xd0: {Name: "RefNull", Immediates: ImmIndex},
+	0xd1: {Name: "RefIsNull", Immediates: ImmNone},
+	0xd2: {Name: "RefFunc", Immediates: ImmIndex},
+}
+
+// MiscInstructions lists the opcodes prefixed by 0xfc: saturating truncations, bulk memory and table operations
+var MiscInstructions = map[byte]*InstructionSpec{
+	0x00: {Name: "I32TruncSatF32S", Immediates: ImmNone},
+	0x01: {Name: "I32TruncSatF32U", Immediates: ImmNone},
+	0x02: {Name: "I32TruncSatF64S", Immediates: ImmNone},
+	0x03: {Name: "I32TruncSatF64U", Immediates: ImmNone},
+	0x04: {Name: "I64TruncSatF32S", Immediates: ImmNone},
+	0x05: {Name: "I64TruncSatF32U", Immediates: ImmNone},
+	0x06: {Name: "I64TruncSatF64S", Immediates: ImmNone},
+	0x07: {Name: "I64TruncSatF64U", Immediates: ImmNone},
+	0x08: {Name: "MemoryInit", Immediates: ImmTwoIndices},
+	0x09: {Name: "DataDrop", Immediates: ImmIndex},
+	0x0a: {Name: "MemoryCopy", Immediates: ImmTwoIndices},
+	0x0b: {Name: "MemoryFill", Immediates: ImmIndex},
+	0x0c: {Name: "TableInit", Immediates: ImmTwoIndices},
+	0x0d: {Name: "ElemDrop", Immediates: ImmIndex},
+	0x0e: {Name: "TableCopy", Immediates: ImmTwoIndices},
+	0x0f: {Name: "TableGrow", Immediates: ImmIndex},
+	0x10: {Name: "TableSize", Immediates: ImmIndex},
+	0x11: {Name: "TableFill", Immediates: ImmIndex},
+}
@@ -0,0 +1,627 @@
+package wasmbinary
+
+import (
+	"errors"
+	"fmt"
+)
+
+const (
+	opIf         = 0x04
+	opEnd        = 0x0b
+	opCall       = 0x10
+	opLocalGet   = 0x20
+	opGlobalGet  = 0x23
+	opGlobalSet  = 0x24
+	opI32Const   = 0x41
+	opI64Const   = 0x42
+	opF32Const   = 0x43
+	opF64Const   = 0x44
+	opMemoryGrow = 0x40
+	opI64GeU     = 0x5a
+	opI64Add     = 0x7c
+	opRefNull    = 0xd0
+	opRefFunc    = 0xd2
+
+	globalMutable  = 0x01
+	maxElementKind = 7
+)
+
+// sectionOrder lists the non-custom sections in the order required by the binary format.
+var sectionOrder = []byte{
+	SectionType, SectionImport, SectionFunction, SectionTable, SectionMemory, 13, SectionGlobal,
+	SectionExport, SectionStart, SectionElement, SectionDataCount, SectionCode, SectionData,
+}
+
+// segmentEnds lists the instructions after which the execution may continue elsewhere than at the next instruction,
+// or at which it may arrive from elsewhere than the previous instruction; they end the segments counted at once.
+var segmentEnds = map[string]bool{
+	"Unreachable": true,
+	"Block":       true,
+	"Loop":        true,
+	"If":          true,
+	"Else":        true,
+	"End":         true,
+	"Br":          true,
+	"BrIf":        true,
+	"BrTable":     true,
+	"Return":      true,
+}
+
+// ErrUnsupportedConstantExpression signals an instruction which cannot be part of the initializer of a global, of
+// an element segment or of a data segment
+var ErrUnsupportedConstantExpression = errors.New("unsupported constant expression")
+
+// Instrumentation selects the code injected by InstrumentModule.
+type Instrumentation struct {
+	// CountInstructionsHook is the function imported from "env" to which the module reports the instructions it
+	// executes, as an i64, whenever it executed at least InstructionsChunk instructions since its last report.
+	// The instructions of each segment of code between two control instructions are counted when it starts.
+	CountInstructionsHook string
+	InstructionsChunk     uint64
+
+	// MemoryGrowthHook is the function imported from "env" which the module calls right after each memory.grow,
+	// before it uses the new pages; it receives the result of memory.grow, as an i32, and returns it.
+	// It is not injected into modules without memory.
+	MemoryGrowthHook string
+}
+
+// IsEmpty returns true if the instrumentation injects no code.
+func (instrumentation Instrumentation) IsEmpty() bool {
+	return !instrumentation.countsInstructions() && !instrumentation.chargesMemoryGrowth()
+}
+
+func (instrumentation Instrumentation) countsInstructions() bool {
+	return len(instrumentation.CountInstructionsHook) > 0
+}
+
+func (instrumentation Instrumentation) chargesMemoryGrowth() bool {
+	return len(instrumentation.MemoryGrowthHook) > 0
+}
+
+type wasmSection struct {
+	id      byte
+	payload []byte
+}
+
+// moduleInstrumenter rewrites a module to import the hooks of the instrumentation after its other imports, which
+// shifts the indices of the functions defined by the module, and to call them from the injected code. The function
+// which counts the instructions is defined after the functions of the module.
+type moduleInstrumenter struct {
+	module          *Module
+	instrumentation Instrumentation
+
+	numFunctionImports uint32
+	numHooks           uint32
+	countHook          uint32
+	memoryGrowthHook   uint32
+	numFunctions       uint32
+	countFunction      uint32
+	countType          uint32
+	growthHookType     uint32
+	numGlobals         uint32
+	counterGlobal      uint32
+}
+
+// InstrumentModule injects the code selected by the instrumentation into a module. The module is returned unchanged
+// if the instrumentation is empty.
+func InstrumentModule(data []byte, instrumentation Instrumentation) ([]byte, error) {
+	if instrumentation.IsEmpty() {
+		return data, nil
+	}
+
+	module, err := ParseModule(data)
+	if err != nil {
+		return nil, err
+	}
+	sections, err := splitSections(data)
+	if err != nil {
+		return nil, err
+	}
+
+	numGlobals := uint32(0)
+	for _, section := range sections {
+		if section.id == SectionGlobal {
+			numGlobals, err = newWasmReader(section.payload, 0).readU32()
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if len(module.MemoryPages) == 0 {
+		// memory.grow is not valid without memory, and neither would be the injected function which calls it
+		instrumentation.MemoryGrowthHook = ""
+	}
+	if instrumentation.IsEmpty() {
+		return data, nil
+	}
+
+	numTypes := uint32(len(module.Types))
+	instrumenter := &moduleInstrumenter{
+		module:             module,
+		instrumentation:    instrumentation,
+		numFunctionImports: uint32(len(module.Imports)),
+		countType:          numTypes,
+		growthHookType:     numTypes + 1,
+		counterGlobal:      module.NumGlobalImports + numGlobals,
+	}
+	instrumenter.assignFunctionIndices()
+	return instrumenter.rewrite(sections)
+}
+
+// assignFunctionIndices numbers the imported hooks and the injected function which the instrumentation requires.
+func (instrumenter *moduleInstrumenter) assignFunctionIndices() {
+	nextImport := instrumenter.numFunctionImports
+	if instrumenter.instrumentation.countsInstructions() {
+		instrumenter.countHook = nextImport
+		nextImport++
+	}
+	if instrumenter.instrumentation.chargesMemoryGrowth() {
+		instrumenter.memoryGrowthHook = nextImport
+		nextImport++
+	}
+	instrumenter.numHooks = nextImport - instrumenter.numFunctionImports
+
+	if instrumenter.instrumentation.countsInstructions() {
+		instrumenter.countFunction = nextImport + uint32(len(instrumenter.module.Functions))
+		instrumenter.numFunctions = 1
+		instrumenter.numGlobals = 1
+	}
+}
+
+func splitSections(data []byte) ([]*wasmSection, error) {
+	sections := make([]*wasmSection, 0)
+	reader := newWasmReader(data, len(MagicAndVersion))
+	for !reader.isAtEnd() {
+		sectionID, err := reader.readByte()
+		if err != nil {
+			return nil, err
+		}
+		sectionSize, err := reader.readU32()
+		if err != nil {
+			return nil, err
+		}
+		payload, err := reader.readBytes(int(sectionSize))
+		if err != nil {
+			return nil, err
+		}
+		sections = append(sections, &wasmSection{id: sectionID, payload: payload})
+	}
+	return sections, nil
+}
+
+func (instrumenter *moduleInstrumenter) rewrite(sections []*wasmSection) ([]byte, error) {
+	rewriters := map[byte]func([]byte) ([]byte, error){
+		SectionType:     instrumenter.rewriteTypes,
+		SectionImport:   instrumenter.rewriteImports,
+		SectionFunction: instrumenter.rewriteFunctions,
+		SectionGlobal:   instrumenter.rewriteGlobals,
+		SectionExport:   instrumenter.rewriteExports,
+		SectionStart:    instrumenter.rewriteStart,
+		SectionElement:  instrumenter.rewriteElements,
+		SectionCode:     instrumenter.rewriteCode,
+	}
+	requiredSections := []byte{SectionType, SectionImport, SectionFunction, SectionCode}
+	if instrumenter.instrumentation.countsInstructions() {
+		requiredSections = append(requiredSections, SectionGlobal)
+	}
+
+	present := make(map[byte]bool)
+	for _, section := range sections {
+		present[section.id] = true
+	}
+	for _, sectionID := range requiredSections {
+		if !present[sectionID] {
+			sections = insertSection(sections, &wasmSection{id: sectionID})
+		}
+	}
+
+	data := append([]byte{}, MagicAndVersion...)
+	for _, section := range sections {
+		payload := section.payload
+		rewriter, ok := rewriters[section.id]
+		if ok {
+			var err error
+			payload, err = rewriter(payload)
+			if err != nil {
+				return nil, fmt.Errorf("section %d: %w", section.id, err)
+			}
+		}
+		data = appendSection(data, section.id, payload)
+	}
+	return data, nil
+}
+
+// insertSection inserts a section before the first one which must follow it.
+func insertSection(sections []*wasmSection, newSection *wasmSection) []*wasmSection {
+	rank := sectionRank(newSection.id)
+	for i, section := range sections {
+		if section.id != SectionCustom && sectionRank(section.id) > rank {
+			result := append(make([]*wasmSection, 0, len(sections)+1), sections[:i]...)
+			result = append(result, newSection)
+			return append(result, sections[i:]...)
+		}
+	}
+	return append(sections, newSection)
+}
+
+func sectionRank(sectionID byte) int {
+	for rank, id := range sectionOrder {
+		if id == sectionID {
+			return rank
+		}
+	}
+	return len(sectionOrder)
+}
+
+// functionIndex returns the index of a function of the module once the hooks are imported after its other imports.
+func (instrumenter *moduleInstrumenter) functionIndex(funcIndex uint32) uint32 {
+	if funcIndex < instrumenter.numFunctionImports {
+		return funcIndex
+	}
+	return funcIndex + instrumenter.numHooks
+}
+
+// appendToVector appends entries to the vector which makes up a section payload; no payload is an empty vector.
+func appendToVector(payload []byte, numEntries uint32, entries []byte) ([]byte, error) {
+	count := uint32(0)
+	reader := newWasmReader(payload, 0)
+	if len(payload) > 0 {
+		var err error
+		count, err = reader.readU32()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	result := AppendU32(nil, count+numEntries)
+	result = append(result, reader.remaining()...)
+	return append(result, entries...), nil
+}
+
+// rewriteTypes appends the types (i64) -> () and (i32) -> (i32), whichever are used.
+func (instrumenter *moduleInstrumenter) rewriteTypes(payload []byte) ([]byte, error) {
+	types := []byte{functionTypeForm, 0x01, ValueTypeI64, 0x00}
+	types = append(types, functionTypeForm, 0x01, ValueTypeI32, 0x01, ValueTypeI32)
+	return appendToVector(payload, 2, types)
+}
+
+func (instrumenter *moduleInstrumenter) rewriteImports(payload []byte) ([]byte, error) {
+	hookImports := make([]byte, 0)
+	if instrumenter.instrumentation.countsInstructions() {
+		hookImports = appendHookImport(hookImports, instrumenter.instrumentation.CountInstructionsHook, instrumenter.countType)
+	}
+	if instrumenter.instrumentation.chargesMemoryGrowth() {
+		hookImports = appendHookImport(hookImports, instrumenter.instrumentation.MemoryGrowthHook, instrumenter.growthHookType)
+	}
+	return appendToVector(payload, instrumenter.numHooks, hookImports)
+}
+
+func appendHookImport(data []byte, hookName string, typeIndex uint32) []byte {
+	data = appendName(data, "env")
+	data = appendName(data, hookName)
+	data = append(data, ExternalKindFunction)
+	return AppendU32(data, typeIndex)
+}
+
+func (instrumenter *moduleInstrumenter) rewriteFunctions(payload []byte) ([]byte, error) {
+	functionTypes := make([]byte, 0)
+	if instrumenter.instrumentation.countsInstructions() {
+		functionTypes = AppendU32(functionTypes, instrumenter.countType)
+	}
+	return appendToVector(payload, instrumenter.numFunctions, functionTypes)
+}
+
+// rewriteGlobals shifts the functions referred by the initializers of the globals, and appends the counter of the
+// instructions not reported yet when they are counted.
+func (instrumenter *moduleInstrumenter) rewriteGlobals(payload []byte) ([]byte, error) {
+	reader := newWasmReader(payload, 0)
+	count := uint32(0)
+	var err error
+	if len(payload) > 0 {
+		count, err = reader.readU32()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	result := AppendU32(nil, count+instrumenter.numGlobals)
+	for i := uint32(0); i < count; i++ {
+		globalType, err := reader.readBytes(2)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, globalType...)
+		result, err = instrumenter.rewriteConstantExpression(reader, result)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if instrumenter.instrumentation.countsInstructions() {
+		result = append(result, ValueTypeI64, globalMutable, opI64Const, 0x00, opEnd)
+	}
+	return result, nil
+}
+
+func (instrumenter *moduleInstrumenter) rewriteExports(payload []byte) ([]byte, error) {
+	reader := newWasmReader(payload, 0)
+	count, err := reader.readU32()
+	if err != nil {
+		return nil, err
+	}
+
+	result := AppendU32(nil, count)
+	for i := uint32(0); i < count; i++ {
+		name, err := reader.readName()
+		if err != nil {
+			return nil, err
+		}
+		kind, err := reader.readByte()
+		if err != nil {
+			return nil, err
+		}
+		index, err := reader.readU32()
+		if err != nil {
+			return nil, err
+		}
+
+		if kind == ExternalKindFunction {
+			index = instrumenter.functionIndex(index)
+		}
+		result = appendName(result, name)
+		result = append(result, kind)
+		result = AppendU32(result, index)
+	}
+
+	return result, nil
+}
+
+func (instrumenter *moduleInstrumenter) rewriteStart(payload []byte) ([]byte, error) {
+	startFunction, err := newWasmReader(payload, 0).readU32()
+	if err != nil {
+		return nil, err
+	}
+	return AppendU32(nil, instrumenter.functionIndex(startFunction)), nil
+}
+
+// rewriteElements shifts the functions referred by the element segments, in all the encodings of the segments.
+func (instrumenter *moduleInstrumenter) rewriteElements(payload []byte) ([]byte, error) {
+	reader := newWasmReader(payload, 0)
+	count, err := reader.readU32()
+	if err != nil {
+		return nil, err
+	}
+
+	result := AppendU32(nil, count)
+	for i := uint32(0); i < count; i++ {
+		flags, err := reader.readU32()
+		if err != nil {
+			return nil, err
+		}
+		if flags > maxElementKind {
+			return nil, fmt.Errorf("unknown element segment kind %d", flags)
+		}
+		result = AppendU32(result, flags)
+
+		isActive := flags&0x01 == 0
+		if isActive && flags&0x02 != 0 {
+			tableIndex, err := reader.readU32()
+			if err != nil {
+				return nil, err
+			}
+			result = AppendU32(result, tableIndex)
+		}
+		if isActive {
+			result, err = instrumenter.rewriteConstantExpression(reader, result)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if flags&0x03 != 0 {
+			elementKind, err := reader.readByte()
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, elementKind)
+		}
+
+		numElements, err := reader.readU32()
+		if err != nil {
+			return nil, err
+		}
+		result = AppendU32(result, numElements)
+		for j := uint32(0); j < numElements; j++ {
+			if flags&0x04 != 0 {
+				result, err = instrumenter.rewriteConstantExpression(reader, result)
+			} else {
+				var funcIndex uint32
+				funcIndex, err = reader.readU32()
+				result = AppendU32(result, instrumenter.functionIndex(funcIndex))
+			}
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// rewriteConstantExpression copies a constant expression up to its end, shifting the functions it refers to.
+func (instrumenter *moduleInstrumenter) rewriteConstantExpression(reader *wasmReader, result []byte) ([]byte, error) {
+	for {
+		opcode, err := reader.readByte()
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, opcode)
+
+		start := reader.position
+		switch opcode {
+		case opEnd:
+			return result, nil
+		case opI32Const, opI64Const:
+			err = reader.skipSigned()
+		case opF32Const:
+			_, err = reader.readBytes(4)
+		case opF64Const:
+			_, err = reader.readBytes(8)
+		case opGlobalGet:
+			_, err = reader.readU32()
+		case opRefNull:
+			_, err = reader.readByte()
+		case opRefFunc:
+			var funcIndex uint32
+			funcIndex, err = reader.readU32()
+			if err != nil {
+				return nil, err
+			}
+			result = AppendU32(result, instrumenter.functionIndex(funcIndex))
+			continue
+		default:
+			return nil, fmt.Errorf("%w: opcode 0x%02x", ErrUnsupportedConstantExpression, opcode)
+		}
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, reader.data[start:reader.position]...)
+	}
+}
+
+func (instrumenter *moduleInstrumenter) rewriteCode(payload []byte) ([]byte, error) {
+	reader := newWasmReader(payload, 0)
+	count := uint32(0)
+	var err error
+	if len(payload) > 0 {
+		count, err = reader.readU32()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	result := AppendU32(nil, count+instrumenter.numFunctions)
+	for i := uint32(0); i < count; i++ {
+		bodySize, err := reader.readU32()
+		if err != nil {
+			return nil, err
+		}
+		body, err := reader.readBytes(int(bodySize))
+		if err != nil {
+			return nil, err
+		}
+
+		body, err = instrumenter.rewriteFunctionBody(body)
+		if err != nil {
+			return nil, fmt.Errorf("function %d: %w", i, err)
+		}
+		result = AppendU32(result, uint32(len(body)))
+		result = append(result, body...)
+	}
+
+	if instrumenter.instrumentation.countsInstructions() {
+		countBody := instrumenter.countFunctionBody()
+		result = AppendU32(result, uint32(len(countBody)))
+		result = append(result, countBody...)
+	}
+	return result, nil
+}
+
+// rewriteFunctionBody counts the instructions of each segment of the function when the segment starts,
+// calls the hook which charges the growth after each memory.grow, and shifts the functions which it calls.
+func (instrumenter *moduleInstrumenter) rewriteFunctionBody(body []byte) ([]byte, error) {
+	reader := newWasmReader(body, 0)
+	numLocalDeclarations, err := reader.readU32()
+	if err != nil {
+		return nil, err
+	}
+	for i := uint32(0); i < numLocalDeclarations; i++ {
+		_, err = reader.readU32()
+		if err != nil {
+			return nil, err
+		}
+		_, err = reader.readByte()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	code := reader.remaining()
+	instructions, err := DecodeFunctionBody(&FunctionBody{Code: code})
+	if err != nil {
+		return nil, err
+	}
+
+	result := append(make([]byte, 0, len(body)), body[:reader.position]...)
+	segmentStarts := instrumenter.instrumentation.countsInstructions()
+	for i, instruction := range instructions {
+		if segmentStarts {
+			result = instrumenter.appendCountInstructions(result, segmentLength(instructions[i:]))
+		}
+
+		end := len(code)
+		if i+1 < len(instructions) {
+			end = instructions[i+1].Offset
+		}
+		opcode := code[instruction.Offset]
+		switch opcode {
+		case opCall, opRefFunc:
+			result = append(result, opcode)
+			result = AppendU32(result, instrumenter.functionIndex(instruction.Index))
+		case opMemoryGrow:
+			result = append(result, code[instruction.Offset:end]...)
+			// only the default memory may be grown, other indices are left for the executor to reject
+			if instrumenter.instrumentation.chargesMemoryGrowth() && instruction.Index == 0 {
+				result = append(result, opCall)
+				result = AppendU32(result, instrumenter.memoryGrowthHook)
+			}
+		default:
+			result = append(result, code[instruction.Offset:end]...)
+		}
+
+		segmentStarts = instrumenter.instrumentation.countsInstructions() && segmentEnds[instruction.Spec.Name]
+	}
+
+	return result, nil
+}
+
+// segmentLength returns the number of instructions up to the first one which ends the segment, included.
+func segmentLength(instructions []*Instruction) int {
+	for i, instruction := range instructions {
+		if segmentEnds[instruction.Spec.Name] {
+			return i + 1
+		}
+	}
+	return len(instructions)
+}
+
+func (instrumenter *moduleInstrumenter) appendCountInstructions(code []byte, numInstructions int) []byte {
+	code = append(code, opI64Const)
+	code = AppendS64(code, int64(numInstructions))
+	code = append(code, opCall)
+	return AppendU32(code, instrumenter.countFunction)
+}
+
+// countFunctionBody adds its parameter to the counter and reports the counter to the hook once it reaches the chunk:
+//
+//	global.get $counter; local.get 0; i64.add; global.set $counter
+//	global.get $counter; i64.const chunk; i64.ge_u
+//	if; global.get $counter; call $hook; i64.const 0; global.set $counter; end
+func (instrumenter *moduleInstrumenter) countFunctionBody() []byte {
+	counter := AppendU32(nil, instrumenter.counterGlobal)
+
+	body := []byte{0x00}
+	body = append(append(body, opGlobalGet), counter...)
+	body = append(body, opLocalGet, 0x00, opI64Add)
+	body = append(append(body, opGlobalSet), counter...)
+	body = append(append(body, opGlobalGet), counter...)
+	body = append(body, opI64Const)
+	body = AppendS64(body, int64(instrumenter.instrumentation.InstructionsChunk))
+	body = append(body, opI64GeU, opIf, blockTypeEmpty)
+	body = append(append(body, opGlobalGet), counter...)
+	body = append(body, opCall)
+	body = AppendU32(body, instrumenter.countHook)
+	body = append(body, opI64Const, 0x00)
+	body = append(append(body, opGlobalSet), counter...)
+	return append(body, opEnd, opEnd)
+}
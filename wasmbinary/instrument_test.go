@@ -0,0 +1,145 @@
+package wasmbinary
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var testInstrumentation = Instrumentation{CountInstructionsHook: "countInstructions", InstructionsChunk: 1000}
+
+func TestInstrumentModule_EmptyInstrumentation(t *testing.T) {
+	data, err := InstrumentModule(testModuleData, Instrumentation{})
+	require.Nil(t, err)
+	require.Equal(t, testModuleData, data)
+}
+
+func TestInstrumentModule_ImportsHookAndShiftsFunctions(t *testing.T) {
+	data, err := InstrumentModule(testModuleData, testInstrumentation)
+	require.Nil(t, err)
+
+	module, err := ParseModule(data)
+	require.Nil(t, err)
+	require.Equal(t, []*FunctionImport{
+		{Module: "env", Name: "getGasLeft", TypeIndex: 0},
+		{Module: "env", Name: "countInstructions", TypeIndex: 3},
+	}, module.Imports)
+	require.Equal(t, &FunctionType{Params: []byte{0x7e}, Results: []byte{}}, module.Types[3])
+	require.Equal(t, map[string]uint32{"foo": 2, "bar": 3}, module.Exports)
+	require.Equal(t, []string{"mem"}, module.MemoryExports)
+	require.Equal(t, uint32(5), module.NumFunctions())
+
+	// foo counts its single instruction, then bar counts 2, and each calls $count, the last function
+	require.Equal(t, []byte{opI64Const, 0x01, opCall, 0x04, opEnd}, module.Function(2).Code)
+	require.Equal(t, []byte{opI64Const, 0x02, opCall, 0x04, 0x01, opEnd}, module.Function(3).Code)
+
+	countType, err := module.FunctionType(4)
+	require.Nil(t, err)
+	require.Equal(t, []byte{0x7e}, countType.Params)
+}
+
+func TestInstrumentModule_CountsEachSegment(t *testing.T) {
+	data := []byte{
+		0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00,
+		// types: () -> ()
+		0x01, 0x04, 0x01, 0x60, 0x00, 0x00,
+		// functions
+		0x03, 0x02, 0x01, 0x00,
+		// exports: loop
+		0x07, 0x08, 0x01, 0x04, 'l', 'o', 'o', 'p', 0x00, 0x00,
+		// code: loop; nop; br 0; end; end
+		0x0a, 0x0a, 0x01, 0x08, 0x00, 0x03, 0x40, 0x01, 0x0c, 0x00, 0x0b, 0x0b,
+	}
+
+	instrumented, err := InstrumentModule(data, testInstrumentation)
+	require.Nil(t, err)
+
+	module, err := ParseModule(instrumented)
+	require.Nil(t, err)
+	require.Equal(t, map[string]uint32{"loop": 1}, module.Exports)
+	require.Equal(t, []byte{
+		opI64Const, 0x01, opCall, 0x02, 0x03, 0x40,
+		opI64Const, 0x02, opCall, 0x02, 0x01, 0x0c, 0x00,
+		opI64Const, 0x01, opCall, 0x02, 0x0b,
+		opI64Const, 0x01, opCall, 0x02, 0x0b,
+	}, module.Function(1).Code)
+
+	// the counter is the only global, and the chunk is reported to the imported hook
+	require.Equal(t, []byte{
+		opGlobalGet, 0x00, opLocalGet, 0x00, opI64Add, opGlobalSet, 0x00,
+		opGlobalGet, 0x00, opI64Const, 0xe8, 0x07, opI64GeU, opIf, blockTypeEmpty,
+		opGlobalGet, 0x00, opCall, 0x00, opI64Const, 0x00, opGlobalSet, 0x00, opEnd, opEnd,
+	}, module.Function(2).Code)
+}
+
+func TestInstrumentModule_Errors(t *testing.T) {
+	_, err := InstrumentModule([]byte("\x00asm"), testInstrumentation)
+	require.Equal(t, ErrNotWasmModule, err)
+
+	_, err = InstrumentModule(testModuleData[:len(testModuleData)-3], testInstrumentation)
+	require.ErrorIs(t, err, ErrUnexpectedEnd)
+}
+
+func TestInstrumentModule_ChargesMemoryGrowth(t *testing.T) {
+	data := []byte{
+		0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00,
+		// types: () -> ()
+		0x01, 0x04, 0x01, 0x60, 0x00, 0x00,
+		// functions
+		0x03, 0x02, 0x01, 0x00,
+		// memories: a memory of 2 pages
+		0x05, 0x03, 0x01, 0x00, 0x02,
+		// exports: grow
+		0x07, 0x08, 0x01, 0x04, 'g', 'r', 'o', 'w', 0x00, 0x00,
+		// code: i32.const 1; memory.grow 0; drop; end
+		0x0a, 0x09, 0x01, 0x07, 0x00, 0x41, 0x01, 0x40, 0x00, 0x1a, 0x0b,
+	}
+
+	instrumented, err := InstrumentModule(data, Instrumentation{MemoryGrowthHook: "chargeMemoryGrowth"})
+	require.Nil(t, err)
+
+	module, err := ParseModule(instrumented)
+	require.Nil(t, err)
+	require.Equal(t, []*FunctionImport{{Module: "env", Name: "chargeMemoryGrowth", TypeIndex: 2}}, module.Imports)
+	require.Equal(t, &FunctionType{Params: []byte{0x7f}, Results: []byte{0x7f}}, module.Types[2])
+	require.Equal(t, map[string]uint32{"grow": 1}, module.Exports)
+	require.Equal(t, uint32(2), module.NumFunctions())
+
+	// the hook is called with the result of memory.grow, which it returns
+	require.Equal(t, []byte{opI32Const, 0x01, opMemoryGrow, 0x00, opCall, 0x00, 0x1a, opEnd}, module.Function(1).Code)
+}
+
+func TestInstrumentModule_MemoryGrowthWithoutMemory(t *testing.T) {
+	data, err := InstrumentModule(testModuleData, Instrumentation{MemoryGrowthHook: "chargeMemoryGrowth"})
+	require.Nil(t, err)
+	require.Equal(t, testModuleData, data)
+}
+
+func TestInstrumentModule_ShiftsFunctionsOfGlobals(t *testing.T) {
+	data := []byte{
+		0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00,
+		// types: () -> ()
+		0x01, 0x04, 0x01, 0x60, 0x00, 0x00,
+		// functions
+		0x03, 0x02, 0x01, 0x00,
+		// memories: a memory of 1 page
+		0x05, 0x03, 0x01, 0x00, 0x01,
+		// globals: an immutable funcref initialized with ref.func 0
+		0x06, 0x06, 0x01, 0x70, 0x00, opRefFunc, 0x00, opEnd,
+		// code: end
+		0x0a, 0x04, 0x01, 0x02, 0x00, 0x0b,
+	}
+
+	instrumented, err := InstrumentModule(data, Instrumentation{MemoryGrowthHook: "chargeMemoryGrowth"})
+	require.Nil(t, err)
+
+	sections, err := splitSections(instrumented)
+	require.Nil(t, err)
+	for _, section := range sections {
+		if section.id == SectionGlobal {
+			require.Equal(t, []byte{0x01, 0x70, 0x00, opRefFunc, 0x01, opEnd}, section.payload)
+			return
+		}
+	}
+	require.Fail(t, "global section not found")
+}
@@ -0,0 +1,425 @@
+package wasmbinary
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+)
+
+// The sections and the kinds of imports and exports of the binary format.
+const (
+	SectionCustom    = 0
+	SectionType      = 1
+	SectionImport    = 2
+	SectionFunction  = 3
+	SectionTable     = 4
+	SectionMemory    = 5
+	SectionGlobal    = 6
+	SectionExport    = 7
+	SectionStart     = 8
+	SectionElement   = 9
+	SectionCode      = 10
+	SectionData      = 11
+	SectionDataCount = 12
+
+	ExternalKindFunction = 0x00
+	ExternalKindTable    = 0x01
+	ExternalKindMemory   = 0x02
+	ExternalKindGlobal   = 0x03
+
+	limitsHasMaximum = 0x01
+	functionTypeForm = 0x60
+)
+
+// The value types of the binary format.
+const (
+	ValueTypeI32 byte = 0x7f
+	ValueTypeI64 byte = 0x7e
+	ValueTypeF32 byte = 0x7d
+	ValueTypeF64 byte = 0x7c
+)
+
+// MagicAndVersion is the header of all the WASM modules.
+var MagicAndVersion = []byte{0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00}
+
+// ErrNotWasmModule signals that the data does not start with the WASM header
+var ErrNotWasmModule = errors.New("not a WASM module")
+
+// ErrUnexpectedEnd signals that the module ends in the middle of a section, a function body or a value
+var ErrUnexpectedEnd = errors.New("unexpected end of WASM module")
+
+// ErrLEBTooLong signals that a LEB128 value has more bytes than its type allows
+var ErrLEBTooLong = errors.New("LEB128 value too long")
+
+// ErrFunctionCountMismatch signals that the function and code sections declare different numbers of functions
+var ErrFunctionCountMismatch = errors.New("function and code sections have different lengths")
+
+// ErrInvalidTypeIndex signals that a function refers to a type which is not declared
+var ErrInvalidTypeIndex = errors.New("invalid type index")
+
+// FunctionType holds the value types of the parameters and of the results of a function.
+type FunctionType struct {
+	Params  []byte
+	Results []byte
+}
+
+// FunctionImport is a function imported by the module, which is a VM hook when imported from "env".
+type FunctionImport struct {
+	Module    string
+	Name      string
+	TypeIndex uint32
+}
+
+// FunctionBody holds the code of a function defined by the module, without its locals declarations.
+type FunctionBody struct {
+	NumLocals uint64
+	Code      []byte
+	Offset    int
+}
+
+// Module holds the parts of a WASM module needed to analyze and validate its functions.
+// Functions are indexed as in WASM: the imported functions first, then the functions of the module.
+// NumImports counts the imports of all kinds and NumGlobalImports the imported globals, TableSizes holds the
+// initial sizes of the imported and defined tables and MemoryPages the initial numbers of pages of the imported
+// and defined memories.
+type Module struct {
+	Types            []*FunctionType
+	Imports          []*FunctionImport
+	FunctionTypes    []uint32
+	Functions        []*FunctionBody
+	Exports          map[string]uint32
+	MemoryExports    []string
+	NumImports       uint32
+	NumGlobalImports uint32
+	TableSizes       []uint32
+	MemoryPages      []uint32
+	NumDataSegments  uint32
+}
+
+// NumFunctions returns the number of imported and defined functions.
+func (module *Module) NumFunctions() uint32 {
+	return uint32(len(module.Imports) + len(module.Functions))
+}
+
+// IsImported returns true if the function with the given index is imported.
+func (module *Module) IsImported(funcIndex uint32) bool {
+	return funcIndex < uint32(len(module.Imports))
+}
+
+// Function returns the body of a function defined by the module.
+func (module *Module) Function(funcIndex uint32) *FunctionBody {
+	return module.Functions[funcIndex-uint32(len(module.Imports))]
+}
+
+// FunctionType returns the type of an imported or defined function.
+func (module *Module) FunctionType(funcIndex uint32) (*FunctionType, error) {
+	typeIndex := uint32(0)
+	if module.IsImported(funcIndex) {
+		typeIndex = module.Imports[funcIndex].TypeIndex
+	} else if funcIndex < module.NumFunctions() {
+		typeIndex = module.FunctionTypes[funcIndex-uint32(len(module.Imports))]
+	} else {
+		return nil, fmt.Errorf("function index %d out of range", funcIndex)
+	}
+
+	if typeIndex >= uint32(len(module.Types)) {
+		return nil, fmt.Errorf("%w: %d", ErrInvalidTypeIndex, typeIndex)
+	}
+	return module.Types[typeIndex], nil
+}
+
+// FunctionName returns the export name of a function, the name of an imported function, or its index.
+func (module *Module) FunctionName(funcIndex uint32) string {
+	if module.IsImported(funcIndex) {
+		return module.Imports[funcIndex].Name
+	}
+	exportName := ""
+	for name, index := range module.Exports {
+		if index == funcIndex && (len(exportName) == 0 || name < exportName) {
+			exportName = name
+		}
+	}
+	if len(exportName) > 0 {
+		return exportName
+	}
+	return fmt.Sprintf("#%d", funcIndex)
+}
+
+// ParseModule reads the types, imports, tables, memories, exports and function bodies of a WASM module, and counts
+// its data segments; all other sections are skipped.
+func ParseModule(data []byte) (*Module, error) {
+	if !bytes.HasPrefix(data, MagicAndVersion) {
+		return nil, ErrNotWasmModule
+	}
+
+	module := &Module{
+		Exports: make(map[string]uint32),
+	}
+
+	reader := newWasmReader(data, len(MagicAndVersion))
+	for !reader.isAtEnd() {
+		sectionID, err := reader.readByte()
+		if err != nil {
+			return nil, err
+		}
+		sectionSize, err := reader.readU32()
+		if err != nil {
+			return nil, err
+		}
+		sectionReader, err := reader.subReader(int(sectionSize))
+		if err != nil {
+			return nil, err
+		}
+
+		switch sectionID {
+		case SectionType:
+			err = module.parseTypes(sectionReader)
+		case SectionImport:
+			err = module.parseImports(sectionReader)
+		case SectionFunction:
+			err = module.parseFunctionTypes(sectionReader)
+		case SectionTable:
+			err = module.parseTables(sectionReader)
+		case SectionMemory:
+			err = module.parseMemories(sectionReader)
+		case SectionExport:
+			err = module.parseExports(sectionReader)
+		case SectionCode:
+			err = module.parseCode(sectionReader)
+		case SectionData:
+			module.NumDataSegments, err = sectionReader.readU32()
+		}
+		if err != nil {
+			return nil, fmt.Errorf("section %d: %w", sectionID, err)
+		}
+	}
+
+	if len(module.FunctionTypes) != len(module.Functions) {
+		return nil, ErrFunctionCountMismatch
+	}
+
+	return module, nil
+}
+
+func (module *Module) parseTypes(reader *wasmReader) error {
+	count, err := reader.readU32()
+	if err != nil {
+		return err
+	}
+
+	for i := uint32(0); i < count; i++ {
+		form, err := reader.readByte()
+		if err != nil {
+			return err
+		}
+		if form != functionTypeForm {
+			return fmt.Errorf("unknown type form 0x%02x", form)
+		}
+
+		params, err := reader.readValueTypes()
+		if err != nil {
+			return err
+		}
+		results, err := reader.readValueTypes()
+		if err != nil {
+			return err
+		}
+		module.Types = append(module.Types, &FunctionType{Params: params, Results: results})
+	}
+
+	return nil
+}
+
+func (module *Module) parseImports(reader *wasmReader) error {
+	count, err := reader.readU32()
+	if err != nil {
+		return err
+	}
+
+	module.NumImports = count
+	for i := uint32(0); i < count; i++ {
+		moduleName, err := reader.readName()
+		if err != nil {
+			return err
+		}
+		name, err := reader.readName()
+		if err != nil {
+			return err
+		}
+		kind, err := reader.readByte()
+		if err != nil {
+			return err
+		}
+
+		switch kind {
+		case ExternalKindFunction:
+			var typeIndex uint32
+			typeIndex, err = reader.readU32()
+			module.Imports = append(module.Imports, &FunctionImport{Module: moduleName, Name: name, TypeIndex: typeIndex})
+		case ExternalKindTable:
+			err = module.parseTable(reader)
+		case ExternalKindMemory:
+			err = module.parseMemory(reader)
+		case ExternalKindGlobal:
+			module.NumGlobalImports++
+			_, err = reader.readBytes(2)
+		default:
+			err = fmt.Errorf("unknown import kind %d", kind)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (module *Module) parseTables(reader *wasmReader) error {
+	count, err := reader.readU32()
+	if err != nil {
+		return err
+	}
+
+	for i := uint32(0); i < count; i++ {
+		err = module.parseTable(reader)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (module *Module) parseTable(reader *wasmReader) error {
+	_, err := reader.readByte()
+	if err != nil {
+		return err
+	}
+	size, err := reader.readLimits()
+	if err != nil {
+		return err
+	}
+
+	module.TableSizes = append(module.TableSizes, size)
+	return nil
+}
+
+func (module *Module) parseMemories(reader *wasmReader) error {
+	count, err := reader.readU32()
+	if err != nil {
+		return err
+	}
+
+	for i := uint32(0); i < count; i++ {
+		err = module.parseMemory(reader)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (module *Module) parseMemory(reader *wasmReader) error {
+	pages, err := reader.readLimits()
+	if err != nil {
+		return err
+	}
+
+	module.MemoryPages = append(module.MemoryPages, pages)
+	return nil
+}
+
+func (module *Module) parseFunctionTypes(reader *wasmReader) error {
+	count, err := reader.readU32()
+	if err != nil {
+		return err
+	}
+	if int(count) > len(reader.data)-reader.position {
+		return ErrUnexpectedEnd
+	}
+
+	module.FunctionTypes = make([]uint32, count)
+	for i := range module.FunctionTypes {
+		module.FunctionTypes[i], err = reader.readU32()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (module *Module) parseExports(reader *wasmReader) error {
+	count, err := reader.readU32()
+	if err != nil {
+		return err
+	}
+
+	for i := uint32(0); i < count; i++ {
+		name, err := reader.readName()
+		if err != nil {
+			return err
+		}
+		kind, err := reader.readByte()
+		if err != nil {
+			return err
+		}
+		index, err := reader.readU32()
+		if err != nil {
+			return err
+		}
+
+		switch kind {
+		case ExternalKindFunction:
+			module.Exports[name] = index
+		case ExternalKindMemory:
+			module.MemoryExports = append(module.MemoryExports, name)
+		}
+	}
+
+	return nil
+}
+
+func (module *Module) parseCode(reader *wasmReader) error {
+	count, err := reader.readU32()
+	if err != nil {
+		return err
+	}
+
+	for i := uint32(0); i < count; i++ {
+		bodySize, err := reader.readU32()
+		if err != nil {
+			return err
+		}
+		bodyReader, err := reader.subReader(int(bodySize))
+		if err != nil {
+			return err
+		}
+
+		numLocalDeclarations, err := bodyReader.readU32()
+		if err != nil {
+			return err
+		}
+		numLocals := uint64(0)
+		for j := uint32(0); j < numLocalDeclarations; j++ {
+			numLocalsOfType, err := bodyReader.readU32()
+			if err != nil {
+				return err
+			}
+			_, err = bodyReader.readByte()
+			if err != nil {
+				return err
+			}
+			numLocals += uint64(numLocalsOfType)
+		}
+
+		module.Functions = append(module.Functions, &FunctionBody{
+			NumLocals: numLocals,
+			Code:      bodyReader.remaining(),
+			Offset:    bodyReader.absoluteOffset(),
+		})
+	}
+
+	return nil
+}
@@ -0,0 +1,98 @@
+package wasmbinary
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// testModuleData imports env.getGasLeft () -> i64, defines foo () -> () and bar (i32) -> (), and exports foo, bar and the memory.
+var testModuleData = []byte{
+	0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00,
+	// types: () -> (i64), () -> (), (i32) -> ()
+	0x01, 0x0c, 0x03, 0x60, 0x00, 0x01, 0x7e, 0x60, 0x00, 0x00, 0x60, 0x01, 0x7f, 0x00,
+	// imports: env.getGasLeft
+	0x02, 0x12, 0x01, 0x03, 'e', 'n', 'v', 0x0a, 'g', 'e', 't', 'G', 'a', 's', 'L', 'e', 'f', 't', 0x00, 0x00,
+	// functions
+	0x03, 0x03, 0x02, 0x01, 0x02,
+	// exports: foo, bar, memory
+	0x07, 0x13, 0x03, 0x03, 'f', 'o', 'o', 0x00, 0x01, 0x03, 'b', 'a', 'r', 0x00, 0x02, 0x03, 'm', 'e', 'm', 0x02, 0x00,
+	// code: foo with 2 i32 locals, bar without locals
+	0x0a, 0x0a, 0x02, 0x04, 0x01, 0x02, 0x7f, 0x0b, 0x03, 0x00, 0x01, 0x0b,
+}
+
+func TestParseModule(t *testing.T) {
+	module, err := ParseModule(testModuleData)
+	require.Nil(t, err)
+
+	require.Equal(t, uint32(3), module.NumFunctions())
+	require.Equal(t, []*FunctionImport{{Module: "env", Name: "getGasLeft", TypeIndex: 0}}, module.Imports)
+	require.Equal(t, map[string]uint32{"foo": 1, "bar": 2}, module.Exports)
+	require.Equal(t, []string{"mem"}, module.MemoryExports)
+
+	require.True(t, module.IsImported(0))
+	require.False(t, module.IsImported(1))
+	require.Equal(t, "getGasLeft", module.FunctionName(0))
+	require.Equal(t, "bar", module.FunctionName(2))
+
+	require.Equal(t, uint64(2), module.Function(1).NumLocals)
+	require.Equal(t, []byte{0x0b}, module.Function(1).Code)
+	require.Equal(t, []byte{0x01, 0x0b}, module.Function(2).Code)
+
+	functionType, err := module.FunctionType(0)
+	require.Nil(t, err)
+	require.Equal(t, &FunctionType{Params: []byte{}, Results: []byte{0x7e}}, functionType)
+	functionType, err = module.FunctionType(2)
+	require.Nil(t, err)
+	require.Equal(t, []byte{0x7f}, functionType.Params)
+	_, err = module.FunctionType(3)
+	require.NotNil(t, err)
+}
+
+func TestParseModule_TablesAndData(t *testing.T) {
+	data := []byte{
+		0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00,
+		// imports: env.tbl, a table of 2 elements
+		0x02, 0x0d, 0x01, 0x03, 'e', 'n', 'v', 0x03, 't', 'b', 'l', 0x01, 0x70, 0x00, 0x02,
+		// tables: a table of 5 to 10 elements
+		0x04, 0x05, 0x01, 0x70, 0x01, 0x05, 0x0a,
+		// data: 2 segments of 1 byte
+		0x0b, 0x0d, 0x02, 0x00, 0x41, 0x00, 0x0b, 0x01, 0xaa, 0x00, 0x41, 0x01, 0x0b, 0x01, 0xbb,
+	}
+
+	module, err := ParseModule(data)
+	require.Nil(t, err)
+	require.Equal(t, uint32(1), module.NumImports)
+	require.Empty(t, module.Imports)
+	require.Equal(t, []uint32{2, 5}, module.TableSizes)
+	require.Equal(t, uint32(2), module.NumDataSegments)
+}
+
+func TestParseModule_Memories(t *testing.T) {
+	data := []byte{
+		0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00,
+		// imports: env.mem, a memory of 3 pages
+		0x02, 0x0c, 0x01, 0x03, 'e', 'n', 'v', 0x03, 'm', 'e', 'm', 0x02, 0x00, 0x03,
+		// memories: a memory of 2 to 16 pages
+		0x05, 0x04, 0x01, 0x01, 0x02, 0x10,
+	}
+
+	module, err := ParseModule(data)
+	require.Nil(t, err)
+	require.Equal(t, uint32(1), module.NumImports)
+	require.Equal(t, []uint32{3, 2}, module.MemoryPages)
+	require.Empty(t, module.TableSizes)
+}
+
+func TestParseModule_Errors(t *testing.T) {
+	_, err := ParseModule([]byte("\x00asm"))
+	require.Equal(t, ErrNotWasmModule, err)
+
+	_, err = ParseModule(testModuleData[:len(testModuleData)-3])
+	require.ErrorIs(t, err, ErrUnexpectedEnd)
+}
+
+func TestDecodeFunctionBody_UnsupportedOpcode(t *testing.T) {
+	_, err := DecodeFunctionBody(&FunctionBody{Code: []byte{0x01, 0xfd, 0x0c, 0x0b}, Offset: 0x20})
+	require.Equal(t, &UnsupportedOpcodeError{Opcode: 0xfd, Offset: 0x21}, err)
+}
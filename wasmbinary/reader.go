@@ -0,0 +1,127 @@
+package wasmbinary
+
+// wasmReader reads the binary encoding of a WASM module; offsets are kept relative to the whole module, for reporting.
+type wasmReader struct {
+	data       []byte
+	position   int
+	baseOffset int
+}
+
+func newWasmReader(data []byte, position int) *wasmReader {
+	return &wasmReader{
+		data:     data,
+		position: position,
+	}
+}
+
+func (reader *wasmReader) isAtEnd() bool {
+	return reader.position >= len(reader.data)
+}
+
+func (reader *wasmReader) absoluteOffset() int {
+	return reader.baseOffset + reader.position
+}
+
+func (reader *wasmReader) remaining() []byte {
+	return reader.data[reader.position:]
+}
+
+func (reader *wasmReader) subReader(length int) (*wasmReader, error) {
+	data, err := reader.readBytes(length)
+	if err != nil {
+		return nil, err
+	}
+
+	return &wasmReader{
+		data:       data,
+		baseOffset: reader.absoluteOffset() - length,
+	}, nil
+}
+
+func (reader *wasmReader) readByte() (byte, error) {
+	if reader.isAtEnd() {
+		return 0, ErrUnexpectedEnd
+	}
+	value := reader.data[reader.position]
+	reader.position++
+	return value, nil
+}
+
+func (reader *wasmReader) readBytes(length int) ([]byte, error) {
+	if length < 0 || reader.position+length > len(reader.data) {
+		return nil, ErrUnexpectedEnd
+	}
+	value := reader.data[reader.position : reader.position+length]
+	reader.position += length
+	return value, nil
+}
+
+// readU32 reads an unsigned LEB128 value of at most 32 bits.
+func (reader *wasmReader) readU32() (uint32, error) {
+	result := uint32(0)
+	for shift := uint(0); shift < 35; shift += 7 {
+		value, err := reader.readByte()
+		if err != nil {
+			return 0, err
+		}
+		result |= uint32(value&0x7f) << shift
+		if value&0x80 == 0 {
+			return result, nil
+		}
+	}
+	return 0, ErrLEBTooLong
+}
+
+// skipSigned skips a signed LEB128 value of at most 64 bits.
+func (reader *wasmReader) skipSigned() error {
+	for numBytes := 0; numBytes < 10; numBytes++ {
+		value, err := reader.readByte()
+		if err != nil {
+			return err
+		}
+		if value&0x80 == 0 {
+			return nil
+		}
+	}
+	return ErrLEBTooLong
+}
+
+func (reader *wasmReader) readName() (string, error) {
+	length, err := reader.readU32()
+	if err != nil {
+		return "", err
+	}
+	name, err := reader.readBytes(int(length))
+	if err != nil {
+		return "", err
+	}
+	return string(name), nil
+}
+
+func (reader *wasmReader) readValueTypes() ([]byte, error) {
+	count, err := reader.readU32()
+	if err != nil {
+		return nil, err
+	}
+	valueTypes, err := reader.readBytes(int(count))
+	if err != nil {
+		return nil, err
+	}
+	return valueTypes, nil
+}
+
+// readLimits reads the limits of a memory or of a table and returns the minimum.
+func (reader *wasmReader) readLimits() (uint32, error) {
+	flags, err := reader.readByte()
+	if err != nil {
+		return 0, err
+	}
+	minimum, err := reader.readU32()
+	if err != nil {
+		return 0, err
+	}
+	if flags&limitsHasMaximum != 0 {
+		_, err = reader.readU32()
+	}
+	return minimum, err
+}
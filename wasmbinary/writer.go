@@ -0,0 +1,186 @@
+package wasmbinary
+
+// AppendU32 appends an unsigned LEB128 value.
+func AppendU32(data []byte, value uint32) []byte {
+	for {
+		b := byte(value & 0x7f)
+		value >>= 7
+		if value == 0 {
+			return append(data, b)
+		}
+		data = append(data, b|0x80)
+	}
+}
+
+// AppendS64 appends a signed LEB128 value.
+func AppendS64(data []byte, value int64) []byte {
+	for {
+		b := byte(value & 0x7f)
+		value >>= 7
+		if (value == 0 && b&0x40 == 0) || (value == -1 && b&0x40 != 0) {
+			return append(data, b)
+		}
+		data = append(data, b|0x80)
+	}
+}
+
+func appendName(data []byte, name string) []byte {
+	data = AppendU32(data, uint32(len(name)))
+	return append(data, name...)
+}
+
+func appendSection(data []byte, sectionID byte, payload []byte) []byte {
+	data = append(data, sectionID)
+	data = AppendU32(data, uint32(len(payload)))
+	return append(data, payload...)
+}
+
+func appendValueTypes(data []byte, valueTypes []byte) []byte {
+	data = AppendU32(data, uint32(len(valueTypes)))
+	return append(data, valueTypes...)
+}
+
+// appendLocals appends the locals declarations of a function body, which group the consecutive locals of a type.
+func appendLocals(data []byte, locals []byte) []byte {
+	groups := make([]byte, 0)
+	numGroups := uint32(0)
+	for i := 0; i < len(locals); {
+		j := i
+		for j < len(locals) && locals[j] == locals[i] {
+			j++
+		}
+		groups = AppendU32(groups, uint32(j-i))
+		groups = append(groups, locals[i])
+		numGroups++
+		i = j
+	}
+	data = AppendU32(data, numGroups)
+	return append(data, groups...)
+}
+
+type builtFunction struct {
+	typeIndex uint32
+	locals    []byte
+	code      []byte
+}
+
+type builtExport struct {
+	name  string
+	kind  byte
+	index uint32
+}
+
+// ModuleBuilder encodes the small modules written by the tools and the tests: functions imported from "env",
+// functions defined by the module, at most one memory and exports.
+type ModuleBuilder struct {
+	types       []*FunctionType
+	imports     []*FunctionImport
+	functions   []*builtFunction
+	exports     []*builtExport
+	hasMemory   bool
+	memoryPages uint32
+}
+
+// NewModuleBuilder creates a builder of an empty module.
+func NewModuleBuilder() *ModuleBuilder {
+	return &ModuleBuilder{}
+}
+
+// AddType declares a function type, unless an identical one is already declared, and returns its index.
+func (builder *ModuleBuilder) AddType(params []byte, results []byte) uint32 {
+	for i, functionType := range builder.types {
+		if string(functionType.Params) == string(params) && string(functionType.Results) == string(results) {
+			return uint32(i)
+		}
+	}
+	builder.types = append(builder.types, &FunctionType{Params: params, Results: results})
+	return uint32(len(builder.types) - 1)
+}
+
+// AddImport imports a function from "env", unless it is already imported, and returns its index.
+// All the imports must be added before the functions of the module.
+func (builder *ModuleBuilder) AddImport(name string, params []byte, results []byte) uint32 {
+	for i, functionImport := range builder.imports {
+		if functionImport.Name == name {
+			return uint32(i)
+		}
+	}
+	typeIndex := builder.AddType(params, results)
+	builder.imports = append(builder.imports, &FunctionImport{Module: "env", Name: name, TypeIndex: typeIndex})
+	return uint32(len(builder.imports) - 1)
+}
+
+// AddFunction defines a function with the given locals and code, which includes its final end, and returns
+// its index.
+func (builder *ModuleBuilder) AddFunction(params []byte, results []byte, locals []byte, code []byte) uint32 {
+	typeIndex := builder.AddType(params, results)
+	builder.functions = append(builder.functions, &builtFunction{typeIndex: typeIndex, locals: locals, code: code})
+	return uint32(len(builder.imports) + len(builder.functions) - 1)
+}
+
+// AddMemory defines the memory of the module, with the given initial number of pages and no maximum.
+func (builder *ModuleBuilder) AddMemory(pages uint32) {
+	builder.hasMemory = true
+	builder.memoryPages = pages
+}
+
+// ExportFunction exports an imported or defined function.
+func (builder *ModuleBuilder) ExportFunction(name string, funcIndex uint32) {
+	builder.exports = append(builder.exports, &builtExport{name: name, kind: ExternalKindFunction, index: funcIndex})
+}
+
+// ExportMemory exports the memory of the module.
+func (builder *ModuleBuilder) ExportMemory(name string) {
+	builder.exports = append(builder.exports, &builtExport{name: name, kind: ExternalKindMemory})
+}
+
+// Build encodes the module.
+func (builder *ModuleBuilder) Build() []byte {
+	data := append([]byte{}, MagicAndVersion...)
+
+	types := AppendU32(nil, uint32(len(builder.types)))
+	for _, functionType := range builder.types {
+		types = append(types, functionTypeForm)
+		types = appendValueTypes(types, functionType.Params)
+		types = appendValueTypes(types, functionType.Results)
+	}
+	data = appendSection(data, SectionType, types)
+
+	imports := AppendU32(nil, uint32(len(builder.imports)))
+	for _, functionImport := range builder.imports {
+		imports = appendName(imports, functionImport.Module)
+		imports = appendName(imports, functionImport.Name)
+		imports = append(imports, ExternalKindFunction)
+		imports = AppendU32(imports, functionImport.TypeIndex)
+	}
+	data = appendSection(data, SectionImport, imports)
+
+	functions := AppendU32(nil, uint32(len(builder.functions)))
+	for _, function := range builder.functions {
+		functions = AppendU32(functions, function.typeIndex)
+	}
+	data = appendSection(data, SectionFunction, functions)
+
+	if builder.hasMemory {
+		memories := []byte{0x01, 0x00}
+		memories = AppendU32(memories, builder.memoryPages)
+		data = appendSection(data, SectionMemory, memories)
+	}
+
+	exports := AppendU32(nil, uint32(len(builder.exports)))
+	for _, export := range builder.exports {
+		exports = appendName(exports, export.name)
+		exports = append(exports, export.kind)
+		exports = AppendU32(exports, export.index)
+	}
+	data = appendSection(data, SectionExport, exports)
+
+	code := AppendU32(nil, uint32(len(builder.functions)))
+	for _, function := range builder.functions {
+		body := appendLocals(nil, function.locals)
+		body = append(body, function.code...)
+		code = AppendU32(code, uint32(len(body)))
+		code = append(code, body...)
+	}
+	return appendSection(data, SectionCode, code)
+}
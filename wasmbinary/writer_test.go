@@ -0,0 +1,45 @@
+package wasmbinary
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAppendLEB128(t *testing.T) {
+	require.Equal(t, []byte{0x00}, AppendU32(nil, 0))
+	require.Equal(t, []byte{0xe5, 0x8e, 0x26}, AppendU32(nil, 624485))
+	require.Equal(t, []byte{0x7f}, AppendS64(nil, -1))
+	require.Equal(t, []byte{0xc0, 0xbb, 0x78}, AppendS64(nil, -123456))
+	require.Equal(t, []byte{0x3f}, AppendS64(nil, 63))
+	require.Equal(t, []byte{0xc0, 0x00}, AppendS64(nil, 64))
+}
+
+func TestModuleBuilder(t *testing.T) {
+	builder := NewModuleBuilder()
+	first := builder.AddImport("bigIntAdd", []byte{ValueTypeI32, ValueTypeI32, ValueTypeI32}, nil)
+	second := builder.AddImport("bigIntSub", []byte{ValueTypeI32, ValueTypeI32, ValueTypeI32}, nil)
+	require.Equal(t, uint32(0), first)
+	require.Equal(t, uint32(1), second)
+	require.Equal(t, first, builder.AddImport("bigIntAdd", []byte{ValueTypeI32, ValueTypeI32, ValueTypeI32}, nil))
+
+	funcIndex := builder.AddFunction(nil, nil, []byte{ValueTypeI64, ValueTypeI64, ValueTypeF32}, []byte{opEnd})
+	require.Equal(t, uint32(2), funcIndex)
+	builder.ExportFunction("bench", funcIndex)
+	builder.AddMemory(3)
+	builder.ExportMemory("memory")
+
+	module, err := ParseModule(builder.Build())
+	require.Nil(t, err)
+	require.Len(t, module.Types, 2)
+	require.Equal(t, []*FunctionImport{
+		{Module: "env", Name: "bigIntAdd", TypeIndex: 0},
+		{Module: "env", Name: "bigIntSub", TypeIndex: 0},
+	}, module.Imports)
+	require.Equal(t, []uint32{1}, module.FunctionTypes)
+	require.Equal(t, uint64(3), module.Function(funcIndex).NumLocals)
+	require.Equal(t, []byte{opEnd}, module.Function(funcIndex).Code)
+	require.Equal(t, map[string]uint32{"bench": funcIndex}, module.Exports)
+	require.Equal(t, []string{"memory"}, module.MemoryExports)
+	require.Equal(t, []uint32{3}, module.MemoryPages)
+}
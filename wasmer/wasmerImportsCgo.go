@@ -12,6 +12,9 @@ package wasmer
 // typedef int int32_t;
 //
 // extern long long v1_5_getGasLeft(void* context);
+// extern long long v1_5_getMemoryUsage(void* context);
+// extern void      v1_5_countInstructions(void* context, long long count);
+// extern int32_t   v1_5_chargeMemoryGrowth(void* context, int32_t previousPages);
 // extern void      v1_5_getSCAddress(void* context, int32_t resultOffset);
 // extern void      v1_5_getOwnerAddress(void* context, int32_t resultOffset);
 // extern int32_t   v1_5_getShardOfAddress(void* context, int32_t addressOffset);
@@ -49,6 +52,9 @@ package wasmer
 // extern long long v1_5_getStorageLock(void* context, int32_t keyOffset, int32_t keyLength);
 // extern int32_t   v1_5_isStorageLocked(void* context, int32_t keyOffset, int32_t keyLength);
 // extern int32_t   v1_5_clearStorageLock(void* context, int32_t keyOffset, int32_t keyLength);
+// extern int32_t   v1_5_transientStore(void* context, int32_t keyHandle, int32_t valueHandle);
+// extern int32_t   v1_5_transientLoad(void* context, int32_t keyHandle, int32_t destinationHandle);
+// extern void      v1_5_getStorageDeposit(void* context, int32_t resultHandle);
 // extern void      v1_5_getCaller(void* context, int32_t resultOffset);
 // extern void      v1_5_checkNoPayment(void* context);
 // extern int32_t   v1_5_getCallValue(void* context, int32_t resultOffset);
@@ -115,7 +121,12 @@ package wasmer
 // extern void      v1_5_managedUpgradeContract(void* context, int32_t destHandle, long long gas, int32_t valueHandle, int32_t codeHandle, int32_t codeMetadataHandle, int32_t argumentsHandle, int32_t resultHandle);
 // extern void      v1_5_managedDeleteContract(void* context, int32_t destHandle, long long gasLimit, int32_t argumentsHandle);
 // extern int32_t   v1_5_managedDeployFromSourceContract(void* context, long long gas, int32_t valueHandle, int32_t addressHandle, int32_t codeMetadataHandle, int32_t argumentsHandle, int32_t resultAddressHandle, int32_t resultHandle);
+// extern int32_t   v1_5_managedDeployFromSourceContractWithSalt(void* context, long long gas, int32_t valueHandle, int32_t addressHandle, int32_t codeMetadataHandle, int32_t argumentsHandle, int32_t saltHandle, int32_t resultAddressHandle, int32_t resultHandle);
 // extern int32_t   v1_5_managedCreateContract(void* context, long long gas, int32_t valueHandle, int32_t codeHandle, int32_t codeMetadataHandle, int32_t argumentsHandle, int32_t resultAddressHandle, int32_t resultHandle);
+// extern int32_t   v1_5_managedCreateContractWithSalt(void* context, long long gas, int32_t valueHandle, int32_t codeHandle, int32_t codeMetadataHandle, int32_t argumentsHandle, int32_t saltHandle, int32_t resultAddressHandle, int32_t resultHandle);
+// extern int32_t   v1_5_managedDeployFromCodeHash(void* context, long long gas, int32_t valueHandle, int32_t codeHashHandle, int32_t codeMetadataHandle, int32_t argumentsHandle, int32_t resultAddressHandle, int32_t resultHandle);
+// extern int32_t   v1_5_managedRegisterCode(void* context, int32_t sourceContractAddressHandle, int32_t resultCodeHashHandle);
+// extern int32_t   v1_5_managedComputeContractAddress(void* context, int32_t creatorHandle, int32_t saltHandle, int32_t codeHashHandle, int32_t resultHandle);
 // extern int32_t   v1_5_managedExecuteReadOnly(void* context, long long gas, int32_t addressHandle, int32_t functionHandle, int32_t argumentsHandle, int32_t resultHandle);
 // extern int32_t   v1_5_managedExecuteOnSameContext(void* context, long long gas, int32_t addressHandle, int32_t valueHandle, int32_t functionHandle, int32_t argumentsHandle, int32_t resultHandle);
 // extern int32_t   v1_5_managedExecuteOnDestContext(void* context, long long gas, int32_t addressHandle, int32_t valueHandle, int32_t functionHandle, int32_t argumentsHandle, int32_t resultHandle);
@@ -127,7 +138,16 @@ package wasmer
 // extern int32_t   v1_5_managedIsESDTPaused(void* context, int32_t tokenIDHandle);
 // extern void      v1_5_managedBufferToHex(void* context, int32_t sourceHandle, int32_t destHandle);
 // extern void      v1_5_managedGetCodeMetadata(void* context, int32_t addressHandle, int32_t responseHandle);
+// extern void      v1_5_managedGetCodeHash(void* context, int32_t addressHandle, int32_t resultHandle);
+// extern void      v1_5_managedGetCodeDeployer(void* context, int32_t addressHandle, int32_t resultHandle);
+// extern int32_t   v1_5_managedGetContractDeployInfo(void* context, int32_t addressHandle, int32_t deployerHandle, int32_t blockNonceHandle, int32_t blockTimestampHandle);
 // extern int32_t   v1_5_managedIsBuiltinFunction(void* context, int32_t functionNameHandle);
+// extern int32_t   v1_5_managedEVMAbiEncode(void* context, int32_t typesHandle, int32_t valuesHandle, int32_t resultHandle);
+// extern int32_t   v1_5_managedEVMAbiDecode(void* context, int32_t typesHandle, int32_t dataHandle, int32_t resultHandle);
+// extern int32_t   v1_5_managedSetUpgradePolicy(void* context, long long delayRounds, int32_t threshold, int32_t approversHandle);
+// extern int32_t   v1_5_managedProposeUpgrade(void* context, int32_t codeHashHandle, int32_t codeMetadataHandle);
+// extern int32_t   v1_5_managedApproveUpgrade(void* context);
+// extern int32_t   v1_5_managedCancelUpgrade(void* context);
 // extern int32_t   v1_5_bigFloatNewFromParts(void* context, int32_t integralPart, int32_t fractionalPart, int32_t exponent);
 // extern int32_t   v1_5_bigFloatNewFromFrac(void* context, long long numerator, long long denominator);
 // extern int32_t   v1_5_bigFloatNewFromSci(void* context, long long significand, long long exponent);
@@ -150,6 +170,17 @@ package wasmer
 // extern void      v1_5_bigFloatSetBigInt(void* context, int32_t destinationHandle, int32_t bigIntHandle);
 // extern void      v1_5_bigFloatGetConstPi(void* context, int32_t destinationHandle);
 // extern void      v1_5_bigFloatGetConstE(void* context, int32_t destinationHandle);
+// extern int32_t   v1_5_decimalNew(void* context, long long significand, int32_t scale);
+// extern void      v1_5_decimalFromBigInt(void* context, int32_t destinationHandle, int32_t bigIntHandle, int32_t scale);
+// extern int32_t   v1_5_decimalToBigInt(void* context, int32_t destBigIntHandle, int32_t opHandle);
+// extern void      v1_5_decimalRescale(void* context, int32_t destinationHandle, int32_t opHandle, int32_t scale, int32_t roundingMode);
+// extern void      v1_5_decimalAdd(void* context, int32_t destinationHandle, int32_t op1Handle, int32_t op2Handle);
+// extern void      v1_5_decimalSub(void* context, int32_t destinationHandle, int32_t op1Handle, int32_t op2Handle);
+// extern void      v1_5_decimalMul(void* context, int32_t destinationHandle, int32_t op1Handle, int32_t op2Handle, int32_t scale, int32_t roundingMode);
+// extern void      v1_5_decimalDiv(void* context, int32_t destinationHandle, int32_t op1Handle, int32_t op2Handle, int32_t scale, int32_t roundingMode);
+// extern int32_t   v1_5_decimalCmp(void* context, int32_t op1Handle, int32_t op2Handle);
+// extern void      v1_5_decimalLn(void* context, int32_t destinationHandle, int32_t opHandle, int32_t scale);
+// extern void      v1_5_decimalExp(void* context, int32_t destinationHandle, int32_t opHandle, int32_t scale);
 // extern void      v1_5_bigIntGetUnsignedArgument(void* context, int32_t id, int32_t destinationHandle);
 // extern void      v1_5_bigIntGetSignedArgument(void* context, int32_t id, int32_t destinationHandle);
 // extern int32_t   v1_5_bigIntStorageStoreUnsigned(void* context, int32_t keyOffset, int32_t keyLength, int32_t sourceHandle);
@@ -178,6 +209,10 @@ package wasmer
 // extern void      v1_5_bigIntEMod(void* context, int32_t destinationHandle, int32_t op1Handle, int32_t op2Handle);
 // extern void      v1_5_bigIntSqrt(void* context, int32_t destinationHandle, int32_t opHandle);
 // extern void      v1_5_bigIntPow(void* context, int32_t destinationHandle, int32_t op1Handle, int32_t op2Handle);
+// extern void      v1_5_bigIntModPow(void* context, int32_t destinationHandle, int32_t baseHandle, int32_t exponentHandle, int32_t modulusHandle);
+// extern void      v1_5_bigIntModInverse(void* context, int32_t destinationHandle, int32_t opHandle, int32_t modulusHandle);
+// extern void      v1_5_bigIntMulMod(void* context, int32_t destinationHandle, int32_t op1Handle, int32_t op2Handle, int32_t modulusHandle);
+// extern void      v1_5_bigIntGCD(void* context, int32_t destinationHandle, int32_t op1Handle, int32_t op2Handle);
 // extern int32_t   v1_5_bigIntLog2(void* context, int32_t op1Handle);
 // extern void      v1_5_bigIntAbs(void* context, int32_t destinationHandle, int32_t opHandle);
 // extern void      v1_5_bigIntNeg(void* context, int32_t destinationHandle, int32_t opHandle);
@@ -209,6 +244,8 @@ package wasmer
 // extern int32_t   v1_5_mBufferFromBigIntSigned(void* context, int32_t mBufferHandle, int32_t bigIntHandle);
 // extern int32_t   v1_5_mBufferToBigFloat(void* context, int32_t mBufferHandle, int32_t bigFloatHandle);
 // extern int32_t   v1_5_mBufferFromBigFloat(void* context, int32_t mBufferHandle, int32_t bigFloatHandle);
+// extern int32_t   v1_5_mBufferToDecimal(void* context, int32_t mBufferHandle, int32_t decimalHandle);
+// extern int32_t   v1_5_mBufferFromDecimal(void* context, int32_t mBufferHandle, int32_t decimalHandle);
 // extern int32_t   v1_5_mBufferStorageStore(void* context, int32_t keyHandle, int32_t sourceHandle);
 // extern int32_t   v1_5_mBufferStorageLoad(void* context, int32_t keyHandle, int32_t destinationHandle);
 // extern void      v1_5_mBufferStorageLoadFromAddress(void* context, int32_t addressHandle, int32_t keyHandle, int32_t destinationHandle);
@@ -273,6 +310,7 @@ package wasmer
 // extern int32_t   v1_5_managedVerifySecp256r1(void* context, int32_t keyHandle, int32_t messageHandle, int32_t sigHandle);
 // extern int32_t   v1_5_managedVerifyBLSSignatureShare(void* context, int32_t keyHandle, int32_t messageHandle, int32_t sigHandle);
 // extern int32_t   v1_5_managedVerifyBLSAggregatedSignature(void* context, int32_t keyHandle, int32_t messageHandle, int32_t sigHandle);
+// extern int32_t   v1_5_managedEVMFunctionSelector(void* context, int32_t signatureHandle, int32_t resultHandle);
 import "C"
 
 import (
@@ -289,6 +327,21 @@ func populateWasmerImports(imports *wasmerImports) error {
 		return err
 	}
 
+	err = imports.append("getMemoryUsage", v1_5_getMemoryUsage, C.v1_5_getMemoryUsage)
+	if err != nil {
+		return err
+	}
+
+	err = imports.append("countInstructions", v1_5_countInstructions, C.v1_5_countInstructions)
+	if err != nil {
+		return err
+	}
+
+	err = imports.append("chargeMemoryGrowth", v1_5_chargeMemoryGrowth, C.v1_5_chargeMemoryGrowth)
+	if err != nil {
+		return err
+	}
+
 	err = imports.append("getSCAddress", v1_5_getSCAddress, C.v1_5_getSCAddress)
 	if err != nil {
 		return err
@@ -474,6 +527,21 @@ func populateWasmerImports(imports *wasmerImports) error {
 		return err
 	}
 
+	err = imports.append("transientStore", v1_5_transientStore, C.v1_5_transientStore)
+	if err != nil {
+		return err
+	}
+
+	err = imports.append("transientLoad", v1_5_transientLoad, C.v1_5_transientLoad)
+	if err != nil {
+		return err
+	}
+
+	err = imports.append("getStorageDeposit", v1_5_getStorageDeposit, C.v1_5_getStorageDeposit)
+	if err != nil {
+		return err
+	}
+
 	err = imports.append("getCaller", v1_5_getCaller, C.v1_5_getCaller)
 	if err != nil {
 		return err
@@ -804,11 +872,36 @@ func populateWasmerImports(imports *wasmerImports) error {
 		return err
 	}
 
+	err = imports.append("managedDeployFromSourceContractWithSalt", v1_5_managedDeployFromSourceContractWithSalt, C.v1_5_managedDeployFromSourceContractWithSalt)
+	if err != nil {
+		return err
+	}
+
 	err = imports.append("managedCreateContract", v1_5_managedCreateContract, C.v1_5_managedCreateContract)
 	if err != nil {
 		return err
 	}
 
+	err = imports.append("managedCreateContractWithSalt", v1_5_managedCreateContractWithSalt, C.v1_5_managedCreateContractWithSalt)
+	if err != nil {
+		return err
+	}
+
+	err = imports.append("managedDeployFromCodeHash", v1_5_managedDeployFromCodeHash, C.v1_5_managedDeployFromCodeHash)
+	if err != nil {
+		return err
+	}
+
+	err = imports.append("managedRegisterCode", v1_5_managedRegisterCode, C.v1_5_managedRegisterCode)
+	if err != nil {
+		return err
+	}
+
+	err = imports.append("managedComputeContractAddress", v1_5_managedComputeContractAddress, C.v1_5_managedComputeContractAddress)
+	if err != nil {
+		return err
+	}
+
 	err = imports.append("managedExecuteReadOnly", v1_5_managedExecuteReadOnly, C.v1_5_managedExecuteReadOnly)
 	if err != nil {
 		return err
@@ -864,11 +957,56 @@ func populateWasmerImports(imports *wasmerImports) error {
 		return err
 	}
 
+	err = imports.append("managedGetCodeHash", v1_5_managedGetCodeHash, C.v1_5_managedGetCodeHash)
+	if err != nil {
+		return err
+	}
+
+	err = imports.append("managedGetCodeDeployer", v1_5_managedGetCodeDeployer, C.v1_5_managedGetCodeDeployer)
+	if err != nil {
+		return err
+	}
+
+	err = imports.append("managedGetContractDeployInfo", v1_5_managedGetContractDeployInfo, C.v1_5_managedGetContractDeployInfo)
+	if err != nil {
+		return err
+	}
+
 	err = imports.append("managedIsBuiltinFunction", v1_5_managedIsBuiltinFunction, C.v1_5_managedIsBuiltinFunction)
 	if err != nil {
 		return err
 	}
 
+	err = imports.append("managedEVMAbiEncode", v1_5_managedEVMAbiEncode, C.v1_5_managedEVMAbiEncode)
+	if err != nil {
+		return err
+	}
+
+	err = imports.append("managedEVMAbiDecode", v1_5_managedEVMAbiDecode, C.v1_5_managedEVMAbiDecode)
+	if err != nil {
+		return err
+	}
+
+	err = imports.append("managedSetUpgradePolicy", v1_5_managedSetUpgradePolicy, C.v1_5_managedSetUpgradePolicy)
+	if err != nil {
+		return err
+	}
+
+	err = imports.append("managedProposeUpgrade", v1_5_managedProposeUpgrade, C.v1_5_managedProposeUpgrade)
+	if err != nil {
+		return err
+	}
+
+	err = imports.append("managedApproveUpgrade", v1_5_managedApproveUpgrade, C.v1_5_managedApproveUpgrade)
+	if err != nil {
+		return err
+	}
+
+	err = imports.append("managedCancelUpgrade", v1_5_managedCancelUpgrade, C.v1_5_managedCancelUpgrade)
+	if err != nil {
+		return err
+	}
+
 	err = imports.append("bigFloatNewFromParts", v1_5_bigFloatNewFromParts, C.v1_5_bigFloatNewFromParts)
 	if err != nil {
 		return err
@@ -979,6 +1117,61 @@ func populateWasmerImports(imports *wasmerImports) error {
 		return err
 	}
 
+	err = imports.append("decimalNew", v1_5_decimalNew, C.v1_5_decimalNew)
+	if err != nil {
+		return err
+	}
+
+	err = imports.append("decimalFromBigInt", v1_5_decimalFromBigInt, C.v1_5_decimalFromBigInt)
+	if err != nil {
+		return err
+	}
+
+	err = imports.append("decimalToBigInt", v1_5_decimalToBigInt, C.v1_5_decimalToBigInt)
+	if err != nil {
+		return err
+	}
+
+	err = imports.append("decimalRescale", v1_5_decimalRescale, C.v1_5_decimalRescale)
+	if err != nil {
+		return err
+	}
+
+	err = imports.append("decimalAdd", v1_5_decimalAdd, C.v1_5_decimalAdd)
+	if err != nil {
+		return err
+	}
+
+	err = imports.append("decimalSub", v1_5_decimalSub, C.v1_5_decimalSub)
+	if err != nil {
+		return err
+	}
+
+	err = imports.append("decimalMul", v1_5_decimalMul, C.v1_5_decimalMul)
+	if err != nil {
+		return err
+	}
+
+	err = imports.append("decimalDiv", v1_5_decimalDiv, C.v1_5_decimalDiv)
+	if err != nil {
+		return err
+	}
+
+	err = imports.append("decimalCmp", v1_5_decimalCmp, C.v1_5_decimalCmp)
+	if err != nil {
+		return err
+	}
+
+	err = imports.append("decimalLn", v1_5_decimalLn, C.v1_5_decimalLn)
+	if err != nil {
+		return err
+	}
+
+	err = imports.append("decimalExp", v1_5_decimalExp, C.v1_5_decimalExp)
+	if err != nil {
+		return err
+	}
+
 	err = imports.append("bigIntGetUnsignedArgument", v1_5_bigIntGetUnsignedArgument, C.v1_5_bigIntGetUnsignedArgument)
 	if err != nil {
 		return err
@@ -1119,6 +1312,26 @@ func populateWasmerImports(imports *wasmerImports) error {
 		return err
 	}
 
+	err = imports.append("bigIntModPow", v1_5_bigIntModPow, C.v1_5_bigIntModPow)
+	if err != nil {
+		return err
+	}
+
+	err = imports.append("bigIntModInverse", v1_5_bigIntModInverse, C.v1_5_bigIntModInverse)
+	if err != nil {
+		return err
+	}
+
+	err = imports.append("bigIntMulMod", v1_5_bigIntMulMod, C.v1_5_bigIntMulMod)
+	if err != nil {
+		return err
+	}
+
+	err = imports.append("bigIntGCD", v1_5_bigIntGCD, C.v1_5_bigIntGCD)
+	if err != nil {
+		return err
+	}
+
 	err = imports.append("bigIntLog2", v1_5_bigIntLog2, C.v1_5_bigIntLog2)
 	if err != nil {
 		return err
@@ -1274,6 +1487,16 @@ func populateWasmerImports(imports *wasmerImports) error {
 		return err
 	}
 
+	err = imports.append("mBufferToDecimal", v1_5_mBufferToDecimal, C.v1_5_mBufferToDecimal)
+	if err != nil {
+		return err
+	}
+
+	err = imports.append("mBufferFromDecimal", v1_5_mBufferFromDecimal, C.v1_5_mBufferFromDecimal)
+	if err != nil {
+		return err
+	}
+
 	err = imports.append("mBufferStorageStore", v1_5_mBufferStorageStore, C.v1_5_mBufferStorageStore)
 	if err != nil {
 		return err
@@ -1594,6 +1817,11 @@ func populateWasmerImports(imports *wasmerImports) error {
 		return err
 	}
 
+	err = imports.append("managedEVMFunctionSelector", v1_5_managedEVMFunctionSelector, C.v1_5_managedEVMFunctionSelector)
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -1603,6 +1831,24 @@ func v1_5_getGasLeft(context unsafe.Pointer) int64 {
 	return vmHooks.GetGasLeft()
 }
 
+//export v1_5_getMemoryUsage
+func v1_5_getMemoryUsage(context unsafe.Pointer) int64 {
+	vmHooks := getVMHooksFromContextRawPtr(context)
+	return vmHooks.GetMemoryUsage()
+}
+
+//export v1_5_countInstructions
+func v1_5_countInstructions(context unsafe.Pointer, count int64) {
+	vmHooks := getVMHooksFromContextRawPtr(context)
+	vmHooks.CountInstructions(count)
+}
+
+//export v1_5_chargeMemoryGrowth
+func v1_5_chargeMemoryGrowth(context unsafe.Pointer, previousPages int32) int32 {
+	vmHooks := getVMHooksFromContextRawPtr(context)
+	return vmHooks.ChargeMemoryGrowth(previousPages)
+}
+
 //export v1_5_getSCAddress
 func v1_5_getSCAddress(context unsafe.Pointer, resultOffset int32) {
 	vmHooks := getVMHooksFromContextRawPtr(context)
@@ -1825,6 +2071,24 @@ func v1_5_clearStorageLock(context unsafe.Pointer, keyOffset int32, keyLength in
 	return vmHooks.ClearStorageLock(executor.MemPtr(keyOffset), keyLength)
 }
 
+//export v1_5_transientStore
+func v1_5_transientStore(context unsafe.Pointer, keyHandle int32, valueHandle int32) int32 {
+	vmHooks := getVMHooksFromContextRawPtr(context)
+	return vmHooks.TransientStore(keyHandle, valueHandle)
+}
+
+//export v1_5_transientLoad
+func v1_5_transientLoad(context unsafe.Pointer, keyHandle int32, destinationHandle int32) int32 {
+	vmHooks := getVMHooksFromContextRawPtr(context)
+	return vmHooks.TransientLoad(keyHandle, destinationHandle)
+}
+
+//export v1_5_getStorageDeposit
+func v1_5_getStorageDeposit(context unsafe.Pointer, resultHandle int32) {
+	vmHooks := getVMHooksFromContextRawPtr(context)
+	vmHooks.GetStorageDeposit(resultHandle)
+}
+
 //export v1_5_getCaller
 func v1_5_getCaller(context unsafe.Pointer, resultOffset int32) {
 	vmHooks := getVMHooksFromContextRawPtr(context)
@@ -2221,12 +2485,42 @@ func v1_5_managedDeployFromSourceContract(context unsafe.Pointer, gas int64, val
 	return vmHooks.ManagedDeployFromSourceContract(gas, valueHandle, addressHandle, codeMetadataHandle, argumentsHandle, resultAddressHandle, resultHandle)
 }
 
+//export v1_5_managedDeployFromSourceContractWithSalt
+func v1_5_managedDeployFromSourceContractWithSalt(context unsafe.Pointer, gas int64, valueHandle int32, addressHandle int32, codeMetadataHandle int32, argumentsHandle int32, saltHandle int32, resultAddressHandle int32, resultHandle int32) int32 {
+	vmHooks := getVMHooksFromContextRawPtr(context)
+	return vmHooks.ManagedDeployFromSourceContractWithSalt(gas, valueHandle, addressHandle, codeMetadataHandle, argumentsHandle, saltHandle, resultAddressHandle, resultHandle)
+}
+
 //export v1_5_managedCreateContract
 func v1_5_managedCreateContract(context unsafe.Pointer, gas int64, valueHandle int32, codeHandle int32, codeMetadataHandle int32, argumentsHandle int32, resultAddressHandle int32, resultHandle int32) int32 {
 	vmHooks := getVMHooksFromContextRawPtr(context)
 	return vmHooks.ManagedCreateContract(gas, valueHandle, codeHandle, codeMetadataHandle, argumentsHandle, resultAddressHandle, resultHandle)
 }
 
+//export v1_5_managedCreateContractWithSalt
+func v1_5_managedCreateContractWithSalt(context unsafe.Pointer, gas int64, valueHandle int32, codeHandle int32, codeMetadataHandle int32, argumentsHandle int32, saltHandle int32, resultAddressHandle int32, resultHandle int32) int32 {
+	vmHooks := getVMHooksFromContextRawPtr(context)
+	return vmHooks.ManagedCreateContractWithSalt(gas, valueHandle, codeHandle, codeMetadataHandle, argumentsHandle, saltHandle, resultAddressHandle, resultHandle)
+}
+
+//export v1_5_managedDeployFromCodeHash
+func v1_5_managedDeployFromCodeHash(context unsafe.Pointer, gas int64, valueHandle int32, codeHashHandle int32, codeMetadataHandle int32, argumentsHandle int32, resultAddressHandle int32, resultHandle int32) int32 {
+	vmHooks := getVMHooksFromContextRawPtr(context)
+	return vmHooks.ManagedDeployFromCodeHash(gas, valueHandle, codeHashHandle, codeMetadataHandle, argumentsHandle, resultAddressHandle, resultHandle)
+}
+
+//export v1_5_managedRegisterCode
+func v1_5_managedRegisterCode(context unsafe.Pointer, sourceContractAddressHandle int32, resultCodeHashHandle int32) int32 {
+	vmHooks := getVMHooksFromContextRawPtr(context)
+	return vmHooks.ManagedRegisterCode(sourceContractAddressHandle, resultCodeHashHandle)
+}
+
+//export v1_5_managedComputeContractAddress
+func v1_5_managedComputeContractAddress(context unsafe.Pointer, creatorHandle int32, saltHandle int32, codeHashHandle int32, resultHandle int32) int32 {
+	vmHooks := getVMHooksFromContextRawPtr(context)
+	return vmHooks.ManagedComputeContractAddress(creatorHandle, saltHandle, codeHashHandle, resultHandle)
+}
+
 //export v1_5_managedExecuteReadOnly
 func v1_5_managedExecuteReadOnly(context unsafe.Pointer, gas int64, addressHandle int32, functionHandle int32, argumentsHandle int32, resultHandle int32) int32 {
 	vmHooks := getVMHooksFromContextRawPtr(context)
@@ -2293,12 +2587,66 @@ func v1_5_managedGetCodeMetadata(context unsafe.Pointer, addressHandle int32, re
 	vmHooks.ManagedGetCodeMetadata(addressHandle, responseHandle)
 }
 
+//export v1_5_managedGetCodeHash
+func v1_5_managedGetCodeHash(context unsafe.Pointer, addressHandle int32, resultHandle int32) {
+	vmHooks := getVMHooksFromContextRawPtr(context)
+	vmHooks.ManagedGetCodeHash(addressHandle, resultHandle)
+}
+
+//export v1_5_managedGetCodeDeployer
+func v1_5_managedGetCodeDeployer(context unsafe.Pointer, addressHandle int32, resultHandle int32) {
+	vmHooks := getVMHooksFromContextRawPtr(context)
+	vmHooks.ManagedGetCodeDeployer(addressHandle, resultHandle)
+}
+
+//export v1_5_managedGetContractDeployInfo
+func v1_5_managedGetContractDeployInfo(context unsafe.Pointer, addressHandle int32, deployerHandle int32, blockNonceHandle int32, blockTimestampHandle int32) int32 {
+	vmHooks := getVMHooksFromContextRawPtr(context)
+	return vmHooks.ManagedGetContractDeployInfo(addressHandle, deployerHandle, blockNonceHandle, blockTimestampHandle)
+}
+
 //export v1_5_managedIsBuiltinFunction
 func v1_5_managedIsBuiltinFunction(context unsafe.Pointer, functionNameHandle int32) int32 {
 	vmHooks := getVMHooksFromContextRawPtr(context)
 	return vmHooks.ManagedIsBuiltinFunction(functionNameHandle)
 }
 
+//export v1_5_managedEVMAbiEncode
+func v1_5_managedEVMAbiEncode(context unsafe.Pointer, typesHandle int32, valuesHandle int32, resultHandle int32) int32 {
+	vmHooks := getVMHooksFromContextRawPtr(context)
+	return vmHooks.ManagedEVMAbiEncode(typesHandle, valuesHandle, resultHandle)
+}
+
+//export v1_5_managedEVMAbiDecode
+func v1_5_managedEVMAbiDecode(context unsafe.Pointer, typesHandle int32, dataHandle int32, resultHandle int32) int32 {
+	vmHooks := getVMHooksFromContextRawPtr(context)
+	return vmHooks.ManagedEVMAbiDecode(typesHandle, dataHandle, resultHandle)
+}
+
+//export v1_5_managedSetUpgradePolicy
+func v1_5_managedSetUpgradePolicy(context unsafe.Pointer, delayRounds int64, threshold int32, approversHandle int32) int32 {
+	vmHooks := getVMHooksFromContextRawPtr(context)
+	return vmHooks.ManagedSetUpgradePolicy(delayRounds, threshold, approversHandle)
+}
+
+//export v1_5_managedProposeUpgrade
+func v1_5_managedProposeUpgrade(context unsafe.Pointer, codeHashHandle int32, codeMetadataHandle int32) int32 {
+	vmHooks := getVMHooksFromContextRawPtr(context)
+	return vmHooks.ManagedProposeUpgrade(codeHashHandle, codeMetadataHandle)
+}
+
+//export v1_5_managedApproveUpgrade
+func v1_5_managedApproveUpgrade(context unsafe.Pointer) int32 {
+	vmHooks := getVMHooksFromContextRawPtr(context)
+	return vmHooks.ManagedApproveUpgrade()
+}
+
+//export v1_5_managedCancelUpgrade
+func v1_5_managedCancelUpgrade(context unsafe.Pointer) int32 {
+	vmHooks := getVMHooksFromContextRawPtr(context)
+	return vmHooks.ManagedCancelUpgrade()
+}
+
 //export v1_5_bigFloatNewFromParts
 func v1_5_bigFloatNewFromParts(context unsafe.Pointer, integralPart int32, fractionalPart int32, exponent int32) int32 {
 	vmHooks := getVMHooksFromContextRawPtr(context)
@@ -2431,6 +2779,72 @@ func v1_5_bigFloatGetConstE(context unsafe.Pointer, destinationHandle int32) {
 	vmHooks.BigFloatGetConstE(destinationHandle)
 }
 
+//export v1_5_decimalNew
+func v1_5_decimalNew(context unsafe.Pointer, significand int64, scale int32) int32 {
+	vmHooks := getVMHooksFromContextRawPtr(context)
+	return vmHooks.DecimalNew(significand, scale)
+}
+
+//export v1_5_decimalFromBigInt
+func v1_5_decimalFromBigInt(context unsafe.Pointer, destinationHandle int32, bigIntHandle int32, scale int32) {
+	vmHooks := getVMHooksFromContextRawPtr(context)
+	vmHooks.DecimalFromBigInt(destinationHandle, bigIntHandle, scale)
+}
+
+//export v1_5_decimalToBigInt
+func v1_5_decimalToBigInt(context unsafe.Pointer, destBigIntHandle int32, opHandle int32) int32 {
+	vmHooks := getVMHooksFromContextRawPtr(context)
+	return vmHooks.DecimalToBigInt(destBigIntHandle, opHandle)
+}
+
+//export v1_5_decimalRescale
+func v1_5_decimalRescale(context unsafe.Pointer, destinationHandle int32, opHandle int32, scale int32, roundingMode int32) {
+	vmHooks := getVMHooksFromContextRawPtr(context)
+	vmHooks.DecimalRescale(destinationHandle, opHandle, scale, roundingMode)
+}
+
+//export v1_5_decimalAdd
+func v1_5_decimalAdd(context unsafe.Pointer, destinationHandle int32, op1Handle int32, op2Handle int32) {
+	vmHooks := getVMHooksFromContextRawPtr(context)
+	vmHooks.DecimalAdd(destinationHandle, op1Handle, op2Handle)
+}
+
+//export v1_5_decimalSub
+func v1_5_decimalSub(context unsafe.Pointer, destinationHandle int32, op1Handle int32, op2Handle int32) {
+	vmHooks := getVMHooksFromContextRawPtr(context)
+	vmHooks.DecimalSub(destinationHandle, op1Handle, op2Handle)
+}
+
+//export v1_5_decimalMul
+func v1_5_decimalMul(context unsafe.Pointer, destinationHandle int32, op1Handle int32, op2Handle int32, scale int32, roundingMode int32) {
+	vmHooks := getVMHooksFromContextRawPtr(context)
+	vmHooks.DecimalMul(destinationHandle, op1Handle, op2Handle, scale, roundingMode)
+}
+
+//export v1_5_decimalDiv
+func v1_5_decimalDiv(context unsafe.Pointer, destinationHandle int32, op1Handle int32, op2Handle int32, scale int32, roundingMode int32) {
+	vmHooks := getVMHooksFromContextRawPtr(context)
+	vmHooks.DecimalDiv(destinationHandle, op1Handle, op2Handle, scale, roundingMode)
+}
+
+//export v1_5_decimalCmp
+func v1_5_decimalCmp(context unsafe.Pointer, op1Handle int32, op2Handle int32) int32 {
+	vmHooks := getVMHooksFromContextRawPtr(context)
+	return vmHooks.DecimalCmp(op1Handle, op2Handle)
+}
+
+//export v1_5_decimalLn
+func v1_5_decimalLn(context unsafe.Pointer, destinationHandle int32, opHandle int32, scale int32) {
+	vmHooks := getVMHooksFromContextRawPtr(context)
+	vmHooks.DecimalLn(destinationHandle, opHandle, scale)
+}
+
+//export v1_5_decimalExp
+func v1_5_decimalExp(context unsafe.Pointer, destinationHandle int32, opHandle int32, scale int32) {
+	vmHooks := getVMHooksFromContextRawPtr(context)
+	vmHooks.DecimalExp(destinationHandle, opHandle, scale)
+}
+
 //export v1_5_bigIntGetUnsignedArgument
 func v1_5_bigIntGetUnsignedArgument(context unsafe.Pointer, id int32, destinationHandle int32) {
 	vmHooks := getVMHooksFromContextRawPtr(context)
@@ -2599,6 +3013,30 @@ func v1_5_bigIntPow(context unsafe.Pointer, destinationHandle int32, op1Handle i
 	vmHooks.BigIntPow(destinationHandle, op1Handle, op2Handle)
 }
 
+//export v1_5_bigIntModPow
+func v1_5_bigIntModPow(context unsafe.Pointer, destinationHandle int32, baseHandle int32, exponentHandle int32, modulusHandle int32) {
+	vmHooks := getVMHooksFromContextRawPtr(context)
+	vmHooks.BigIntModPow(destinationHandle, baseHandle, exponentHandle, modulusHandle)
+}
+
+//export v1_5_bigIntModInverse
+func v1_5_bigIntModInverse(context unsafe.Pointer, destinationHandle int32, opHandle int32, modulusHandle int32) {
+	vmHooks := getVMHooksFromContextRawPtr(context)
+	vmHooks.BigIntModInverse(destinationHandle, opHandle, modulusHandle)
+}
+
+//export v1_5_bigIntMulMod
+func v1_5_bigIntMulMod(context unsafe.Pointer, destinationHandle int32, op1Handle int32, op2Handle int32, modulusHandle int32) {
+	vmHooks := getVMHooksFromContextRawPtr(context)
+	vmHooks.BigIntMulMod(destinationHandle, op1Handle, op2Handle, modulusHandle)
+}
+
+//export v1_5_bigIntGCD
+func v1_5_bigIntGCD(context unsafe.Pointer, destinationHandle int32, op1Handle int32, op2Handle int32) {
+	vmHooks := getVMHooksFromContextRawPtr(context)
+	vmHooks.BigIntGCD(destinationHandle, op1Handle, op2Handle)
+}
+
 //export v1_5_bigIntLog2
 func v1_5_bigIntLog2(context unsafe.Pointer, op1Handle int32) int32 {
 	vmHooks := getVMHooksFromContextRawPtr(context)
@@ -2785,6 +3223,18 @@ func v1_5_mBufferFromBigFloat(context unsafe.Pointer, mBufferHandle int32, bigFl
 	return vmHooks.MBufferFromBigFloat(mBufferHandle, bigFloatHandle)
 }
 
+//export v1_5_mBufferToDecimal
+func v1_5_mBufferToDecimal(context unsafe.Pointer, mBufferHandle int32, decimalHandle int32) int32 {
+	vmHooks := getVMHooksFromContextRawPtr(context)
+	return vmHooks.MBufferToDecimal(mBufferHandle, decimalHandle)
+}
+
+//export v1_5_mBufferFromDecimal
+func v1_5_mBufferFromDecimal(context unsafe.Pointer, mBufferHandle int32, decimalHandle int32) int32 {
+	vmHooks := getVMHooksFromContextRawPtr(context)
+	return vmHooks.MBufferFromDecimal(mBufferHandle, decimalHandle)
+}
+
 //export v1_5_mBufferStorageStore
 func v1_5_mBufferStorageStore(context unsafe.Pointer, keyHandle int32, sourceHandle int32) int32 {
 	vmHooks := getVMHooksFromContextRawPtr(context)
@@ -3168,3 +3618,9 @@ func v1_5_managedVerifyBLSAggregatedSignature(context unsafe.Pointer, keyHandle
 	vmHooks := getVMHooksFromContextRawPtr(context)
 	return vmHooks.ManagedVerifyBLSAggregatedSignature(keyHandle, messageHandle, sigHandle)
 }
+
+//export v1_5_managedEVMFunctionSelector
+func v1_5_managedEVMFunctionSelector(context unsafe.Pointer, signatureHandle int32, resultHandle int32) int32 {
+	vmHooks := getVMHooksFromContextRawPtr(context)
+	return vmHooks.ManagedEVMFunctionSelector(signatureHandle, resultHandle)
+}
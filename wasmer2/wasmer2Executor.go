@@ -51,7 +51,7 @@ func CreateExecutor() (*Wasmer2Executor, error) {
 // SetOpcodeCosts sets gas costs globally inside the Wasmer executor.
 func (wasmerExecutor *Wasmer2Executor) SetOpcodeCosts(wasmOps *executor.WASMOpcodeCost) {
 	// extract only wasmer2 opcodes
-	wasmerExecutor.opcodeCost = wasmerExecutor.extractOpcodeCost(wasmOps)
+	wasmerExecutor.opcodeCost = extractOpcodeCost(wasmOps)
 	cWasmerExecutorSetOpcodeCost(
 		wasmerExecutor.cgoExecutor,
 		(*cWasmerOpcodeCostT)(unsafe.Pointer(wasmerExecutor.opcodeCost)),
@@ -70,6 +70,11 @@ func (wasmerExecutor *Wasmer2Executor) FunctionNames() vmcommon.FunctionNames {
 	return functionNames
 }
 
+// VMHookNames returns the names of the VM hooks which contracts can import, without creating an executor.
+func VMHookNames() vmcommon.FunctionNames {
+	return functionNames
+}
+
 // NewInstanceWithOptions creates a new Wasmer instance from WASM bytecode,
 // respecting the provided options
 func (wasmerExecutor *Wasmer2Executor) NewInstanceWithOptions(
@@ -140,7 +145,12 @@ func (wasmerExecutor *Wasmer2Executor) initVMHooks(vmHooks executor.VMHooks) {
 	cWasmerExecutorContextDataSet(wasmerExecutor.cgoExecutor, wasmerExecutor.vmHooksPtrPtr)
 }
 
-func (wasmerExecutor *Wasmer2Executor) extractOpcodeCost(wasmOps *executor.WASMOpcodeCost) *OpcodeCost {
+// NewOpcodeCost returns the opcode costs of the gas schedule, in the structure given to the wasmer2 executor.
+func NewOpcodeCost(wasmOps *executor.WASMOpcodeCost) *OpcodeCost {
+	return extractOpcodeCost(wasmOps)
+}
+
+func extractOpcodeCost(wasmOps *executor.WASMOpcodeCost) *OpcodeCost {
 	return &OpcodeCost{
 		Block:              wasmOps.Block,
 		Br:                 wasmOps.Br,